@@ -1,16 +1,25 @@
 package fortimanager
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/romanromanovv/forti-sdk-go/fortimanager2/auth"
+	"golang.org/x/net/http/httpproxy"
+
 	forticlient "github.com/romanromanovv/forti-sdk-go/fortimanager2/sdkcore"
 )
 
@@ -19,14 +28,38 @@ type Config struct {
 	Hostname      string
 	User          string
 	Passwd        string
+	Token         string
 	Insecure      *bool
 	CABundle      string
 	ScopeType     string
 	Adom          string
 	ImportOptions *schema.Set
 
-	LogSession bool
-	Session    string
+	CertFingerprint string
+	ClientCert      string
+	ClientKey       string
+	TLSServerName   string
+	MinTLSVersion   string
+
+	LogSession       bool
+	Session          string
+	SessionCacheFile string
+
+	HTTPProxy string
+	NoProxy   string
+
+	MaxRetries          int
+	RetryBackoffMin     int
+	RetryBackoffMax     int
+	RetryableErrorCodes []int
+	RetryableExecURLs   []string
+
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+
+	WorkspaceMode          string
+	WorkspaceCommitComment string
+	LockWaitTimeout        int
 }
 
 // FortiClient contains the basic FMG SDK connection information to FMG
@@ -34,6 +67,54 @@ type Config struct {
 type FortiClient struct {
 	Client *forticlient.FortiSDKClient
 	Cfg    *Config
+
+	// transport carries the requests of the SDK client and of the calls the
+	// provider makes on its own, see jsonrpc
+	transport *fmgTransport
+
+	// stopCtx is cancelled when Terraform stops the provider
+	stopCtx context.Context
+}
+
+// withStop returns a copy of ctx that is also cancelled when Terraform is
+// interrupted, for long running operations that have to react to it
+func (c *FortiClient) withStop(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if c.stopCtx == nil {
+		return ctx, cancel
+	}
+
+	go func() {
+		select {
+		case <-c.stopCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// sdk returns a copy of the SDK client for one call. Its requests are bound
+// to ctx: they are cancelled with ctx and end at its deadline, e.g. the timeout
+// of the resource operation. opts apply to the requests of this copy only.
+func (c *FortiClient) sdk(ctx context.Context, opts ...callOption) *forticlient.FortiSDKClient {
+	o := newCallOptions(opts)
+	o.sdkDecode = !o.raw
+
+	errors := &apiErrorRecorder{}
+	ctx = contextWithAPIErrorRecorder(contextWithCallOptions(ctx, o), errors)
+
+	fc := *c.Client
+	fc.Config.HTTPCon = &http.Client{
+		Transport: &contextTransport{
+			ctx:    ctx,
+			base:   c.transport,
+			errors: errors,
+		},
+	}
+
+	return &fc
 }
 
 // CreateClient creates a FortiClient Object with the authentication information.
@@ -52,7 +133,9 @@ func (c *Config) CreateClient() (interface{}, error) {
 func createFMGClient(fClient *FortiClient, c *Config) error {
 	config := &tls.Config{}
 
-	auth := auth.NewAuth(c.Hostname, c.User, c.Passwd, c.CABundle, c.Session, c.LogSession)
+	// Sessions are no longer written to presession.txt, logsession enables
+	// the session cache instead
+	auth := auth.NewAuth(c.Hostname, c.User, c.Passwd, c.CABundle, c.Session, false)
 
 	if auth.Hostname == "" {
 		_, err := auth.GetEnvHostname()
@@ -61,16 +144,26 @@ func createFMGClient(fClient *FortiClient, c *Config) error {
 		}
 	}
 
+	endpoint, err := parseEndpoint(auth.Hostname)
+	if err != nil {
+		return fmt.Errorf("Error reading Hostname: %v", err)
+	}
+
+	token := c.Token
+	if token == "" {
+		token = os.Getenv("FORTIMANAGER_ACCESS_TOKEN")
+	}
+
 	if auth.User == "" {
 		_, err := auth.GetEnvUsername()
-		if err != nil {
+		if err != nil && token == "" {
 			return fmt.Errorf("Error reading Username")
 		}
 	}
 
 	if auth.Passwd == "" {
 		_, err := auth.GetEnvPassword()
-		if err != nil {
+		if err != nil && token == "" {
 			return fmt.Errorf("Error reading Password")
 		}
 	}
@@ -106,23 +199,225 @@ func createFMGClient(fClient *FortiClient, c *Config) error {
 		config.InsecureSkipVerify = *c.Insecure
 	}
 
-	if config.InsecureSkipVerify == false && auth.CABundle == "" {
-		return fmt.Errorf("Error getting CA Bundle, CA Bundle should be set when insecure is false")
+	// Without a CA bundle, config.RootCAs stays nil and the certificate of
+	// FortiManager is verified against the system root pool
+	if err := configureTLS(config, c); err != nil {
+		return fmt.Errorf("Error TLS configuration: %v", err)
+	}
+
+	retry, err := newRetryPolicy(c.MaxRetries, c.RetryBackoffMin, c.RetryBackoffMax, c.RetryableErrorCodes, c.RetryableExecURLs)
+	if err != nil {
+		return fmt.Errorf("Error retry configuration: %v", err)
+	}
+
+	throttle, err := newThrottle(c.MaxConcurrentRequests, c.RequestsPerSecond)
+	if err != nil {
+		return fmt.Errorf("Error throttle configuration: %v", err)
 	}
 
-	tr := &http.Transport{
+	proxy, err := proxyFunc(c.HTTPProxy, c.NoProxy)
+	if err != nil {
+		return fmt.Errorf("Error proxy configuration: %v", err)
+	}
+
+	tr := newFmgTransport(&http.Transport{
 		TLSClientConfig: config,
+		Proxy:           proxy,
+	}, auth, token, endpoint)
+	tr.retry = retry
+	tr.throttle = throttle
+	tr.lockWaitTimeout = time.Duration(c.LockWaitTimeout) * time.Second
+
+	if c.WorkspaceMode == workspaceModeAuto {
+		tr.workspace = newWorkspace(tr, c.WorkspaceCommitComment)
 	}
 
+	// Requests are bounded by the context of each operation, see sdk
 	client := &http.Client{
 		Transport: tr,
-		Timeout:   time.Second * 250,
 	}
 
-	fc := forticlient.NewClient(auth, client)
+	cacheFile := c.SessionCacheFile
+	if cacheFile == "" && c.LogSession {
+		cacheFile = defaultSessionCacheFile()
+	}
+
+	var fc *forticlient.FortiSDKClient
+	if token != "" {
+		// API token admins do not log in, the token is sent with every request
+		fc = newSDKClient(auth, client, endpoint)
+	} else if auth.Session == "" && cacheFile != "" {
+		err := tr.openCachedSession(newSessionCache(cacheFile))
+		if err != nil {
+			return fmt.Errorf("Error opening FortiManager session: %v", err)
+		}
+		fc = newSDKClient(auth, client, endpoint)
+		fc.Session = tr.currentSession()
+	} else {
+		err := tr.openSession()
+		if err != nil {
+			return fmt.Errorf("Error opening FortiManager session: %v", err)
+		}
+		fc = newSDKClient(auth, client, endpoint)
+		fc.Session = tr.currentSession()
+	}
 
 	fClient.Cfg = c
 	fClient.Client = fc
+	fClient.transport = tr
+	registerTransport(tr)
 
 	return nil
 }
+
+// newSDKClient creates the SDK client, the login is handled by the provider
+// so that its errors are reported
+func newSDKClient(auth *auth.Auth, client *http.Client, endpoint *url.URL) *forticlient.FortiSDKClient {
+	fc := &forticlient.FortiSDKClient{}
+	fc.Config.Auth = auth
+	fc.Config.HTTPCon = client
+	fc.Config.FwTarget = endpoint.Host
+
+	return fc
+}
+
+// tlsVersions maps the values of min_tls_version to TLS versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// configureTLS applies the certificate pin, client certificate, server name
+// and minimum version of the provider configuration to config
+func configureTLS(config *tls.Config, c *Config) error {
+	if c.MinTLSVersion != "" {
+		v, ok := tlsVersions[c.MinTLSVersion]
+		if !ok {
+			return fmt.Errorf("unsupported min_tls_version %q", c.MinTLSVersion)
+		}
+		config.MinVersion = v
+	}
+
+	if c.TLSServerName != "" {
+		config.ServerName = c.TLSServerName
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return fmt.Errorf("client_cert and client_key must be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return fmt.Errorf("cannot load client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.CertFingerprint != "" {
+		pin, err := parseCertFingerprint(c.CertFingerprint)
+		if err != nil {
+			return err
+		}
+
+		// The pinned certificate is trusted on its own, which allows
+		// self-signed certificates without turning off verification
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("x509: FortiManager presented no certificate")
+			}
+
+			sum := sha256.Sum256(rawCerts[0])
+			if !bytes.Equal(sum[:], pin) {
+				return fmt.Errorf("x509: certificate fingerprint %s does not match cert_fingerprint_sha256", hex.EncodeToString(sum[:]))
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// parseCertFingerprint decodes a SHA-256 fingerprint given as hex digits,
+// optionally separated by colons as printed by openssl
+func parseCertFingerprint(fingerprint string) ([]byte, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", "")
+
+	pin, err := hex.DecodeString(s)
+	if err != nil || len(pin) != sha256.Size {
+		return nil, fmt.Errorf("cert_fingerprint_sha256 must be a SHA-256 fingerprint of 64 hex digits")
+	}
+
+	return pin, nil
+}
+
+// parseEndpoint parses the hostname argument, which is either a host with an
+// optional port (IPv6 addresses may be given without brackets) or a full URL
+// with scheme, port and a path prefix such as https://proxy.example.com/fmg/
+func parseEndpoint(hostname string) (*url.URL, error) {
+	hostname = strings.TrimSpace(hostname)
+	if hostname == "" {
+		return nil, fmt.Errorf("empty hostname")
+	}
+
+	if !strings.Contains(hostname, "://") {
+		if ip := net.ParseIP(strings.Trim(hostname, "[]")); ip != nil && strings.Contains(hostname, ":") {
+			hostname = "[" + ip.String() + "]"
+		}
+		hostname = "https://" + hostname
+	}
+
+	u, err := url.Parse(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported scheme %q in %s", u.Scheme, hostname)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("no host in %s", hostname)
+	}
+
+	// The SDK exits the process when it cannot parse the url of a request
+	if _, err := url.Parse("https://" + u.Host + "/jsonrpc?"); err != nil {
+		return nil, err
+	}
+
+	return &url.URL{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Path:   strings.TrimRight(u.Path, "/"),
+	}, nil
+}
+
+// proxyFunc returns the proxy selection of the HTTP transport. Without an
+// explicit proxy, HTTPS_PROXY, HTTP_PROXY and NO_PROXY from the environment
+// are used.
+func proxyFunc(proxy, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" && noProxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	cfg := httpproxy.FromEnvironment()
+	if proxy != "" {
+		if _, err := url.Parse(proxy); err != nil {
+			return nil, fmt.Errorf("invalid http_proxy: %v", err)
+		}
+		cfg.HTTPProxy = proxy
+		cfg.HTTPSProxy = proxy
+	}
+	if noProxy != "" {
+		cfg.NoProxy = noProxy
+	}
+
+	f := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return f(req.URL)
+	}, nil
+}
@@ -0,0 +1,311 @@
+package fortimanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/romanromanovv/forti-sdk-go/fortimanager2/auth"
+)
+
+// stubFortiManager answers the JSON-RPC requests of a client logged in with
+// a session, and expires the first session after expireAfter requests
+type stubFortiManager struct {
+	expireAfter int
+
+	mu       sync.Mutex
+	sessions int
+	valid    string
+	requests int
+	logins   int
+	logouts  int
+	adoms    map[string]map[string]interface{}
+}
+
+func (s *stubFortiManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := readRequestBody(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var rpc struct {
+		Method  string `json:"method"`
+		Session string `json:"session"`
+		Params  []struct {
+			URL  string          `json:"url"`
+			Data json.RawMessage `json:"data"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(body, &rpc); err != nil || len(rpc.Params) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	url := rpc.Params[0].URL
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := func(code int, message string, data interface{}) {
+		r := map[string]interface{}{
+			"status": map[string]interface{}{"code": code, "message": message},
+			"url":    url,
+		}
+		if data != nil {
+			r["data"] = data
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     1,
+			"result": []interface{}{r},
+		})
+	}
+
+	switch url {
+	case "sys/login/user":
+		s.logins++
+		s.sessions++
+		s.valid = fmt.Sprintf("session%d", s.sessions)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      1,
+			"result":  []interface{}{map[string]interface{}{"status": map[string]interface{}{"code": 0, "message": "OK"}, "url": url}},
+			"session": s.valid,
+		})
+		return
+
+	case "/sys/logout":
+		s.logouts++
+		result(0, "OK", nil)
+		return
+	}
+
+	if rpc.Session == "" || rpc.Session != s.valid {
+		result(-11, "Invalid session", nil)
+		return
+	}
+
+	if s.requests++; s.requests == s.expireAfter {
+		s.valid = ""
+	}
+
+	if url == "/sys/status" {
+		result(0, "OK", map[string]interface{}{"Version": "v7.2.2-build1334 230201 (GA)"})
+		return
+	}
+
+	name := strings.TrimPrefix(url, "/dvmdb/adom/")
+	switch rpc.Method {
+	case "add", "set", "update":
+		var data map[string]interface{}
+		json.Unmarshal(rpc.Params[0].Data, &data)
+		if n, ok := data["name"].(string); ok {
+			name = n
+		}
+		s.adoms[name] = data
+		result(0, "OK", map[string]interface{}{"name": name})
+
+	case "get":
+		if adom, ok := s.adoms[name]; ok {
+			result(0, "OK", adom)
+		} else {
+			result(-3, "Object does not exist", nil)
+		}
+
+	case "delete":
+		delete(s.adoms, name)
+		result(0, "OK", nil)
+
+	default:
+		result(-10, "Invalid method", nil)
+	}
+}
+
+func TestParallelOperations(t *testing.T) {
+	stub := &stubFortiManager{
+		expireAfter: 10,
+		adoms:       make(map[string]map[string]interface{}),
+	}
+	c := newTestClient(t, stub.ServeHTTP)
+
+	// Log in with a session instead of the API token of newTestClient
+	c.transport.token = ""
+	c.transport.auth = auth.NewAuth(c.transport.endpoint.Host, "admin", "password", "", "", false)
+
+	r := resourceDvmdbAdom()
+	trackOperations(r)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ctx := context.Background()
+			d := r.TestResourceData()
+			d.Set("name", fmt.Sprintf("adom%d", i))
+			d.Set("desc", "created")
+
+			if diags := r.CreateContext(ctx, d, c); diags.HasError() {
+				errs <- fmt.Errorf("create adom%d: %v", i, diags[0].Summary)
+				return
+			}
+
+			d.Set("desc", "updated")
+			if diags := r.UpdateContext(ctx, d, c); diags.HasError() {
+				errs <- fmt.Errorf("update adom%d: %v", i, diags[0].Summary)
+				return
+			}
+
+			if desc := d.Get("desc").(string); desc != "updated" {
+				errs <- fmt.Errorf("adom%d desc = %q, want updated", i, desc)
+				return
+			}
+
+			if diags := r.DeleteContext(ctx, d, c); diags.HasError() {
+				errs <- fmt.Errorf("delete adom%d: %v", i, diags[0].Summary)
+				return
+			}
+
+			if diags := r.ReadContext(ctx, d, c); diags.HasError() || d.Id() != "" {
+				errs <- fmt.Errorf("read deleted adom%d: %v, id %q", i, diags, d.Id())
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// The session is kept for the next operations, until the provider stops
+	if session := c.transport.currentSession(); session == "" {
+		t.Errorf("session released after the last operation, want it kept")
+	}
+	c.transport.releaseSession()
+
+	stub.mu.Lock()
+	defer stub.mu.Unlock()
+
+	if stub.logins < 2 {
+		t.Errorf("logins = %d, want the expired sessions to be renewed", stub.logins)
+	}
+	if stub.logouts != stub.logins {
+		t.Errorf("logouts = %d, want one per login (%d)", stub.logouts, stub.logins)
+	}
+	if len(stub.adoms) != 0 {
+		t.Errorf("adoms = %v, want all of them deleted", stub.adoms)
+	}
+	if session := c.transport.currentSession(); session != "" {
+		t.Errorf("session = %q after the provider stopped, want it released", session)
+	}
+}
+
+func TestWorkspaceKeptAcrossOperations(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	failCommit := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := readRequestBody(r)
+		rpc := decodeJSONRPC(body)
+		url := jsonrpcURL(rpc)
+
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, fmt.Sprintf("%v %s", rpc["method"], url))
+
+		data := `{}`
+		if url == "/cli/global/system/global" {
+			data = `{"workspace-mode":"normal"}`
+		}
+		if failCommit && strings.HasSuffix(url, "/workspace/commit") {
+			fmt.Fprintf(w, `{"id":1,"result":[{"status":{"code":-6,"message":"Invalid url"},"url":%q}]}`, url)
+			return
+		}
+		fmt.Fprintf(w, `{"id":1,"result":[{"data":%s,"status":{"code":0,"message":"OK"},"url":%q}]}`, data, url)
+	})
+	c.transport.workspace = newWorkspace(c.transport, "")
+	c.transport.workspace.idleTimeout = time.Hour
+
+	write := func() error {
+		c.transport.beginOperation()
+
+		if _, err := c.jsonrpc(context.Background(), "add", "/pm/config/adom/root/obj/firewall/address", nil); err != nil {
+			t.Fatalf("jsonrpc() error = %v", err)
+		}
+
+		return c.transport.endOperation()
+	}
+
+	requests := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		got := calls
+		if len(got) > 0 && got[0] == "get /cli/global/system/global" {
+			got = got[1:]
+		}
+		calls = nil
+
+		return strings.Join(got, "\n")
+	}
+
+	// The second operation reuses the lock of the first one
+	for run := 0; run < 2; run++ {
+		if err := write(); err != nil {
+			t.Fatalf("run %d endOperation() error = %v", run, err)
+		}
+	}
+	want := strings.Join([]string{
+		"add /pm/config/adom/root/obj/firewall/address",
+		"exec /dvmdb/adom/root/workspace/commit",
+		"add /pm/config/adom/root/obj/firewall/address",
+		"exec /dvmdb/adom/root/workspace/commit",
+	}, "\n")
+	if got := requests(); got != "exec /dvmdb/adom/root/workspace/lock\n"+want {
+		t.Errorf("requests:\n%s\nwant the lock kept:\nexec /dvmdb/adom/root/workspace/lock\n%s", got, want)
+	}
+
+	// The locks are released once the workspace is idle
+	c.transport.workspace.idleTimeout = 10 * time.Millisecond
+	if err := write(); err != nil {
+		t.Fatalf("endOperation() error = %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	want = strings.Join([]string{
+		"add /pm/config/adom/root/obj/firewall/address",
+		"exec /dvmdb/adom/root/workspace/commit",
+		"exec /dvmdb/adom/root/workspace/unlock",
+	}, "\n")
+	if got := requests(); got != want {
+		t.Errorf("requests:\n%s\nwant the idle lock released:\n%s", got, want)
+	}
+
+	// A failed commit fails the operation, and release still unlocks
+	c.transport.workspace.idleTimeout = time.Hour
+	mu.Lock()
+	failCommit = true
+	mu.Unlock()
+	if err := write(); err == nil || !strings.Contains(err.Error(), "cannot commit") {
+		t.Errorf("endOperation() error = %v, want the commit error", err)
+	}
+	c.transport.stopIdleTimer()
+	if err := c.transport.workspace.release(); err == nil || !strings.Contains(err.Error(), "discarded") {
+		t.Errorf("release() error = %v, want the discarded changes", err)
+	}
+	want = strings.Join([]string{
+		"exec /dvmdb/adom/root/workspace/lock",
+		"add /pm/config/adom/root/obj/firewall/address",
+		"exec /dvmdb/adom/root/workspace/commit",
+		"exec /dvmdb/adom/root/workspace/commit",
+		"exec /dvmdb/adom/root/workspace/unlock",
+	}, "\n")
+	if got := requests(); got != want {
+		t.Errorf("requests:\n%s\nwant:\n%s", got, want)
+	}
+}
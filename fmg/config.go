@@ -64,8 +64,8 @@ func flattenStringList(v interface{}) interface{} {
 		return result
 	}
 
-	l := v.([]interface{})
-	if len(l) == 0 || l[0] == nil {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
 		return nil
 	}
 
@@ -93,8 +93,8 @@ func flattenIntegerList(v interface{}) interface{} {
 		return nil
 	}
 
-	l := v.([]interface{})
-	if len(l) == 0 || l[0] == nil {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
 		return nil
 	}
 
@@ -177,10 +177,14 @@ func escapeFilter(filter string) string {
 }
 
 func adomChecking(c *Config, d *schema.ResourceData) (string, error) {
+	return adomValue(c, d.Get("scopetype").(string), d.Get("adom").(string))
+}
+
+// adomValue returns the adom of adomChecking for the scopetype st and adom
+// arguments, e.g. in a CustomizeDiff
+func adomValue(c *Config, st, adom string) (string, error) {
 	cst := c.ScopeType
 	cadom := c.Adom
-	st := d.Get("scopetype").(string)
-	adom := d.Get("adom").(string)
 
 	if st == "inherit" || st == "" {
 		if cst == "global" {
@@ -257,8 +261,8 @@ func convertV2Ipnetmaskstring(v interface{}) string {
 		return res
 	}
 
-	l := v.([]interface{})
-	if len(l) == 0 || l[0] == nil {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
 		return res
 	}
 
@@ -0,0 +1,81 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Get a managed device
+
+package fortimanager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDevice() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"scopetype": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "inherit",
+			ValidateFunc: validation.StringInSlice([]string{
+				"adom",
+				"global",
+				"inherit",
+			}, false),
+		},
+		"adom": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	for k, v := range dvmdbDeviceSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDeviceRead,
+
+		Schema: s,
+	}
+}
+
+func dataSourceDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error reading Device: %v", err)
+	}
+
+	name := d.Get("name").(string)
+	data, err := c.jsonrpc(ctx, "get", dvmdbDeviceURL(adomv)+"/"+name, nil)
+	if err != nil {
+		return diag.Errorf("Error reading Device %s: %v", name, err)
+	}
+
+	o, ok := data.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("Error reading Device %s: unexpected data %v", name, data)
+	}
+
+	for k, v := range flattenDvmdbDevice(o) {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("Error reading Device %s: %v", name, err)
+		}
+	}
+
+	d.SetId(strings.ReplaceAll("device"+adomv+name, "/", "."))
+
+	return nil
+}
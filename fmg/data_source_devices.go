@@ -0,0 +1,181 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: List managed devices
+
+package fortimanager
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDevices() *schema.Resource {
+	device := map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+
+	for k, v := range dvmdbDeviceSchema() {
+		device[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDevicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"scopetype": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "inherit",
+				ValidateFunc: validation.StringInSlice([]string{
+					"adom",
+					"global",
+					"inherit",
+				}, false),
+			},
+			"adom": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"group": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"platform": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"conn_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"unknown",
+					"up",
+					"down",
+				}, false),
+			},
+			"conf_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"unknown",
+					"insync",
+					"outofsync",
+				}, false),
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: device,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error reading Devices: %v", err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+
+	var members map[string]bool
+	if group := d.Get("group").(string); group != "" {
+		if adomv == "global" {
+			return diag.Errorf("Error reading Devices: group needs an ADOM scope")
+		}
+
+		names, err := dvmdbGroupMembers(ctx, c, strings.TrimPrefix(adomv, "adom/"), group)
+		if err != nil {
+			return diag.Errorf("Error reading Devices: %v", err)
+		}
+
+		members = make(map[string]bool)
+		for _, name := range names {
+			members[name] = true
+		}
+	}
+
+	data, err := c.jsonrpc(ctx, "get", dvmdbDeviceURL(adomv), nil)
+	if err != nil {
+		return diag.Errorf("Error reading Devices: %v", err)
+	}
+
+	l, _ := data.([]interface{})
+
+	platform := d.Get("platform").(string)
+	connStatus := d.Get("conn_status").(string)
+	confStatus := d.Get("conf_status").(string)
+
+	names := make([]string, 0, len(l))
+	devices := make([]map[string]interface{}, 0, len(l))
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		dev := flattenDvmdbDevice(o)
+		name := dev["name"].(string)
+
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		if members != nil && !members[name] {
+			continue
+		}
+		if platform != "" && dev["platform"] != platform {
+			continue
+		}
+		if connStatus != "" && dev["conn_status"] != connStatus {
+			continue
+		}
+		if confStatus != "" && dev["conf_status"] != confStatus {
+			continue
+		}
+
+		names = append(names, name)
+		devices = append(devices, dev)
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("Error reading Devices: %v", err)
+	}
+
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("Error reading Devices: %v", err)
+	}
+
+	d.SetId(strings.ReplaceAll("devices"+adomv, "/", "."))
+
+	return nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Read any FortiManager API with a JSON-RPC get request
+
+package fortimanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceJsonGenericAPI() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceJsonGenericAPIRead,
+
+		Schema: map[string]*schema.Schema{
+			"json_content": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"query": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := parseJSONQuery(v.(string)); err != nil {
+						return nil, []error{fmt.Errorf("invalid %s: %v", k, err)}
+					}
+					return nil, nil
+				},
+			},
+			"response": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"data": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"data_map": &schema.Schema{
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"query_result": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"query_value": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceJsonGenericAPIRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	content := d.Get("json_content").(string)
+
+	var request map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &request); err != nil {
+		return diag.Errorf("Error reading JsonGenericAPI: invalid json_content: %v", err)
+	}
+
+	// The data source is read during plan, it must not change anything
+	if method := fortiStringValue(request["method"]); method != "get" {
+		return diag.Errorf("Error reading JsonGenericAPI: method %q is not allowed, the data source only sends get requests", method)
+	}
+
+	c := m.(*FortiClient).sdk(ctx, withRawResponse())
+
+	res, err := c.JsonGenericAPI(content)
+	if err != nil {
+		return diag.Errorf("Error reading JsonGenericAPI: %v", err)
+	}
+
+	var response struct {
+		Result []struct {
+			Data interface{} `json:"data"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(res), &response); err != nil {
+		return diag.Errorf("Error reading JsonGenericAPI: cannot decode response: %v", err)
+	}
+
+	var data interface{}
+	if len(response.Result) > 0 {
+		data = response.Result[0].Data
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return diag.Errorf("Error reading JsonGenericAPI: %v", err)
+	}
+
+	dataMap := make(map[string]string)
+	if o, ok := data.(map[string]interface{}); ok {
+		for k, v := range o {
+			dataMap[k] = jsonScalarString(v)
+		}
+	}
+
+	var queryResult, queryValue string
+	if query := d.Get("query").(string); query != "" {
+		q, _ := parseJSONQuery(query)
+
+		v := q.eval(data)
+		b, err := json.Marshal(v)
+		if err != nil {
+			return diag.Errorf("Error reading JsonGenericAPI: %v", err)
+		}
+
+		queryResult = string(b)
+		if _, ok := v.([]interface{}); !ok {
+			if _, ok := v.(map[string]interface{}); !ok && v != nil {
+				queryValue = jsonScalarString(v)
+			}
+		}
+	}
+
+	d.Set("response", res)
+	d.Set("data", string(dataJSON))
+	if err := d.Set("data_map", dataMap); err != nil {
+		return diag.Errorf("Error reading JsonGenericAPI: %v", err)
+	}
+	d.Set("query_result", queryResult)
+	d.Set("query_value", queryValue)
+
+	d.SetId(fmt.Sprintf("JSONRPC-Get-%x", sha256.Sum256([]byte(content))))
+
+	return nil
+}
+
+// jsonScalarString returns strings as is and other values as JSON
+func jsonScalarString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jsonQuery is a path in decoded JSON, e.g. members[0].name or [*].name. A
+// step is an object key, a list index or the wildcard * that applies the rest
+// of the path to every element of a list.
+type jsonQuery []jsonQueryStep
+
+type jsonQueryStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONQuery parses a query written with dots and brackets, with an
+// optional leading $ as in JSONPath. Keys with dots or spaces are quoted in
+// brackets, e.g. ["scope member"].
+func parseJSONQuery(s string) (jsonQuery, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "$")
+
+	var q jsonQuery
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if s == "" || s[0] == '.' || s[0] == '[' {
+				return nil, fmt.Errorf("missing key after '.'")
+			}
+
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']'")
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+
+			switch {
+			case inner == "*":
+				q = append(q, jsonQueryStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0]:
+				q = append(q, jsonQueryStep{key: inner[1 : len(inner)-1]})
+			default:
+				i, err := strconv.Atoi(inner)
+				if err != nil || i < 0 {
+					return nil, fmt.Errorf("%q is not an index, * or a quoted key", inner)
+				}
+				q = append(q, jsonQueryStep{index: i, isIndex: true})
+			}
+
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			key := s[:end]
+			s = s[end:]
+
+			if key == "*" {
+				q = append(q, jsonQueryStep{wildcard: true})
+			} else if i, err := strconv.Atoi(key); err == nil && i >= 0 {
+				q = append(q, jsonQueryStep{index: i, isIndex: true})
+			} else {
+				q = append(q, jsonQueryStep{key: key})
+			}
+		}
+	}
+
+	return q, nil
+}
+
+// eval returns the value at the path q in v, nil when it does not exist
+func (q jsonQuery) eval(v interface{}) interface{} {
+	for i, step := range q {
+		switch {
+		case step.wildcard:
+			l, ok := v.([]interface{})
+			if !ok {
+				return nil
+			}
+
+			result := make([]interface{}, 0, len(l))
+			for _, e := range l {
+				if r := q[i+1:].eval(e); r != nil {
+					result = append(result, r)
+				}
+			}
+			return result
+
+		case step.isIndex:
+			l, ok := v.([]interface{})
+			if !ok || step.index >= len(l) {
+				return nil
+			}
+			v = l[step.index]
+
+		default:
+			o, ok := v.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			v = o[step.key]
+		}
+	}
+
+	return v
+}
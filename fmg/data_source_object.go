@@ -0,0 +1,340 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Data sources built from the object resources
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	forticlient "github.com/romanromanovv/forti-sdk-go/fortimanager2/sdkcore"
+)
+
+// objectDataSource describes the data sources of an object resource, they
+// read the object like the resource does
+type objectDataSource struct {
+	// name is the name of the resource in error messages, e.g.
+	// ObjectFirewallAddress
+	name string
+
+	// path is the url of the objects below /pm/config/{adom}, e.g.
+	// obj/firewall/address
+	path string
+
+	resource func() *schema.Resource
+	read     func(c *forticlient.FortiSDKClient, mkey string, paradict map[string]string) (map[string]interface{}, error)
+	refresh  func(d *schema.ResourceData, o map[string]interface{}) error
+
+	// tables are the sub-tables the resource only reads when they are in
+	// its configuration, the data sources always read them
+	tables []objectDataSourceTable
+}
+
+type objectDataSourceTable struct {
+	attr    string
+	key     string
+	flatten func(v interface{}, d *schema.ResourceData, pre string) []map[string]interface{}
+}
+
+// objectSchema returns the attributes of the object, all computed
+func (ds *objectDataSource) objectSchema() map[string]*schema.Schema {
+	s := computedSchemaMap(ds.resource().Schema)
+	delete(s, "scopetype")
+	delete(s, "adom")
+	delete(s, "dynamic_sort_subtable")
+
+	return s
+}
+
+// computedSchemaMap returns a copy of s where every attribute is computed
+func computedSchemaMap(s map[string]*schema.Schema) map[string]*schema.Schema {
+	r := make(map[string]*schema.Schema, len(s))
+	for k, v := range s {
+		r[k] = computedSchema(v)
+	}
+
+	return r
+}
+
+func computedSchema(v *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:      v.Type,
+		Computed:  true,
+		Sensitive: v.Sensitive,
+		Set:       v.Set,
+	}
+
+	switch e := v.Elem.(type) {
+	case *schema.Resource:
+		c.Elem = &schema.Resource{
+			Schema: computedSchemaMap(e.Schema),
+		}
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: e.Type}
+	}
+
+	return c
+}
+
+// scopeSchema adds the scopetype and adom arguments to s
+func scopeSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["scopetype"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "inherit",
+		ValidateFunc: validation.StringInSlice([]string{
+			"adom",
+			"global",
+			"inherit",
+		}, false),
+	}
+	s["adom"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+
+	return s
+}
+
+// refreshObject sets the attributes of d from the object o
+func (ds *objectDataSource) refreshObject(d *schema.ResourceData, o map[string]interface{}) error {
+	if err := ds.refresh(d, o); err != nil {
+		return err
+	}
+
+	for _, t := range ds.tables {
+		if err := d.Set(t.attr, t.flatten(o[t.key], d, t.attr)); err != nil {
+			return fmt.Errorf("Error reading %s: %v", t.attr, err)
+		}
+	}
+
+	return nil
+}
+
+// dataSourceObject returns the data source reading one object by name
+func dataSourceObject(ds *objectDataSource) *schema.Resource {
+	s := scopeSchema(ds.objectSchema())
+	s["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return ds.readObject(ctx, d, m)
+		},
+
+		Schema: s,
+	}
+}
+
+func (ds *objectDataSource) readObject(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
+
+	cfg := m.(*FortiClient).Cfg
+	adomv, err := adomChecking(cfg, d)
+	if err != nil {
+		return diag.Errorf("Error adom configuration: %v", err)
+	}
+
+	paradict := map[string]string{
+		"adom": adomv,
+	}
+
+	name := d.Get("name").(string)
+	o, err := ds.read(c, name, paradict)
+	if err != nil {
+		if IsNotFound(sdkError(c, err)) {
+			return diag.Errorf("Error reading %s data source: %s not found in %s", ds.name, name, adomv)
+		}
+		return diag.Errorf("Error reading %s data source: %v", ds.name, err)
+	}
+
+	if o == nil {
+		return diag.Errorf("Error reading %s data source: no data returned for %s", ds.name, name)
+	}
+
+	if err := ds.refreshObject(d, o); err != nil {
+		return diag.Errorf("Error reading %s data source from API: %v", ds.name, err)
+	}
+
+	d.SetId(strings.ReplaceAll(strings.ToLower(ds.name)+adomv+name, "/", "."))
+
+	return nil
+}
+
+// dataSourceObjects returns the data source listing the objects of an ADOM,
+// filtered by name and attribute values
+func dataSourceObjects(ds *objectDataSource) *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return ds.readObjects(ctx, d, m)
+		},
+
+		Schema: scopeSchema(map[string]*schema.Schema{
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"filter": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Required: true,
+						},
+					},
+				},
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"objects": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: ds.objectSchema(),
+				},
+			},
+		}),
+	}
+}
+
+// objectFilter matches the attribute name of an object against values
+type objectFilter struct {
+	name   string
+	values map[string]bool
+}
+
+// match reports whether the attribute v, or one of its elements when it is a
+// list, is one of the values
+func (f objectFilter) match(v interface{}) bool {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, e := range v {
+			if f.match(e) {
+				return true
+			}
+		}
+		return false
+	case *schema.Set:
+		return f.match(v.List())
+	}
+
+	return f.values[fmt.Sprintf("%v", v)]
+}
+
+func (ds *objectDataSource) readObjects(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error adom configuration: %v", err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+
+	element := &schema.Resource{
+		Schema: ds.objectSchema(),
+	}
+
+	var filters []objectFilter
+	for _, v := range d.Get("filter").([]interface{}) {
+		i, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		f := objectFilter{
+			name:   fortiStringValue(i["name"]),
+			values: make(map[string]bool),
+		}
+		if _, ok := element.Schema[f.name]; !ok {
+			return diag.Errorf("Error reading %s data source: unknown filter attribute %s", ds.name, f.name)
+		}
+		for _, value := range expandStringList(i["values"].([]interface{})) {
+			f.values[value] = true
+		}
+		filters = append(filters, f)
+	}
+
+	data, err := c.jsonrpc(ctx, "get", "/pm/config/"+adomv+"/"+ds.path, nil)
+	if err != nil {
+		return diag.Errorf("Error reading %s data source: %v", ds.name, err)
+	}
+
+	l, _ := data.([]interface{})
+
+	names := make([]string, 0, len(l))
+	objects := make([]map[string]interface{}, 0, len(l))
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := fortiStringValue(o["name"])
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+
+		od := element.Data(nil)
+		if err := ds.refreshObject(od, o); err != nil {
+			return diag.Errorf("Error reading %s data source from API: %s: %v", ds.name, name, err)
+		}
+
+		matched := true
+		for _, f := range filters {
+			if !f.match(od.Get(f.name)) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		object := make(map[string]interface{}, len(element.Schema))
+		for k := range element.Schema {
+			object[k] = od.Get(k)
+		}
+
+		names = append(names, name)
+		objects = append(objects, object)
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("Error reading %s data source: %v", ds.name, err)
+	}
+
+	if err := d.Set("objects", objects); err != nil {
+		return diag.Errorf("Error reading %s data source: %v", ds.name, err)
+	}
+
+	d.SetId(strings.ReplaceAll(strings.ToLower(ds.name)+"s"+adomv, "/", "."))
+
+	return nil
+}
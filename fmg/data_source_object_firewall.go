@@ -0,0 +1,125 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Data sources of firewall objects
+
+package fortimanager
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	forticlient "github.com/romanromanovv/forti-sdk-go/fortimanager2/sdkcore"
+)
+
+var objectFirewallAddressDataSource = &objectDataSource{
+	name:     "ObjectFirewallAddress",
+	path:     "obj/firewall/address",
+	resource: resourceObjectFirewallAddress,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallAddress,
+	refresh:  refreshObjectObjectFirewallAddress,
+	tables: []objectDataSourceTable{
+		{"dynamic_mapping", "dynamic_mapping", flattenObjectFirewallAddressDynamicMapping},
+		{"list", "list", flattenObjectFirewallAddressList},
+		{"tagging", "tagging", flattenObjectFirewallAddressTagging},
+	},
+}
+
+var objectFirewallAddrgrpDataSource = &objectDataSource{
+	name:     "ObjectFirewallAddrgrp",
+	path:     "obj/firewall/addrgrp",
+	resource: resourceObjectFirewallAddrgrp,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallAddrgrp,
+	refresh:  refreshObjectObjectFirewallAddrgrp,
+	tables: []objectDataSourceTable{
+		{"dynamic_mapping", "dynamic_mapping", flattenObjectFirewallAddrgrpDynamicMapping},
+		{"tagging", "tagging", flattenObjectFirewallAddrgrpTagging},
+	},
+}
+
+var objectFirewallServiceCustomDataSource = &objectDataSource{
+	name:     "ObjectFirewallServiceCustom",
+	path:     "obj/firewall/service/custom",
+	resource: resourceObjectFirewallServiceCustom,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallServiceCustom,
+	refresh:  refreshObjectObjectFirewallServiceCustom,
+}
+
+var objectFirewallServiceGroupDataSource = &objectDataSource{
+	name:     "ObjectFirewallServiceGroup",
+	path:     "obj/firewall/service/group",
+	resource: resourceObjectFirewallServiceGroup,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallServiceGroup,
+	refresh:  refreshObjectObjectFirewallServiceGroup,
+}
+
+var objectFirewallScheduleRecurringDataSource = &objectDataSource{
+	name:     "ObjectFirewallScheduleRecurring",
+	path:     "obj/firewall/schedule/recurring",
+	resource: resourceObjectFirewallScheduleRecurring,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallScheduleRecurring,
+	refresh:  refreshObjectObjectFirewallScheduleRecurring,
+}
+
+var objectFirewallVipDataSource = &objectDataSource{
+	name:     "ObjectFirewallVip",
+	path:     "obj/firewall/vip",
+	resource: resourceObjectFirewallVip,
+	read:     (*forticlient.FortiSDKClient).ReadObjectFirewallVip,
+	refresh:  refreshObjectObjectFirewallVip,
+	tables: []objectDataSourceTable{
+		{"dynamic_mapping", "dynamic_mapping", flattenObjectFirewallVipDynamicMapping},
+		{"realservers", "realservers", flattenObjectFirewallVipRealservers},
+		{"ssl_cipher_suites", "ssl-cipher-suites", flattenObjectFirewallVipSslCipherSuites},
+		{"ssl_server_cipher_suites", "ssl-server-cipher-suites", flattenObjectFirewallVipSslServerCipherSuites},
+	},
+}
+
+func dataSourceObjectFirewallAddress() *schema.Resource {
+	return dataSourceObject(objectFirewallAddressDataSource)
+}
+
+func dataSourceObjectFirewallAddresses() *schema.Resource {
+	return dataSourceObjects(objectFirewallAddressDataSource)
+}
+
+func dataSourceObjectFirewallAddrgrp() *schema.Resource {
+	return dataSourceObject(objectFirewallAddrgrpDataSource)
+}
+
+func dataSourceObjectFirewallAddrgrps() *schema.Resource {
+	return dataSourceObjects(objectFirewallAddrgrpDataSource)
+}
+
+func dataSourceObjectFirewallServiceCustom() *schema.Resource {
+	return dataSourceObject(objectFirewallServiceCustomDataSource)
+}
+
+func dataSourceObjectFirewallServiceCustoms() *schema.Resource {
+	return dataSourceObjects(objectFirewallServiceCustomDataSource)
+}
+
+func dataSourceObjectFirewallServiceGroup() *schema.Resource {
+	return dataSourceObject(objectFirewallServiceGroupDataSource)
+}
+
+func dataSourceObjectFirewallServiceGroups() *schema.Resource {
+	return dataSourceObjects(objectFirewallServiceGroupDataSource)
+}
+
+func dataSourceObjectFirewallScheduleRecurring() *schema.Resource {
+	return dataSourceObject(objectFirewallScheduleRecurringDataSource)
+}
+
+func dataSourceObjectFirewallScheduleRecurrings() *schema.Resource {
+	return dataSourceObjects(objectFirewallScheduleRecurringDataSource)
+}
+
+func dataSourceObjectFirewallVip() *schema.Resource {
+	return dataSourceObject(objectFirewallVipDataSource)
+}
+
+func dataSourceObjectFirewallVips() *schema.Resource {
+	return dataSourceObjects(objectFirewallVipDataSource)
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: List the firewall policies of a policy package
+
+package fortimanager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePackagePolicies() *schema.Resource {
+	str := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+	list := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcePackagePoliciesRead,
+
+		Schema: scopeSchema(map[string]*schema.Schema{
+			"pkg": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"policyids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
+			"policies": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policyid": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"uuid":       str(),
+						"name":       str(),
+						"srcintf":    list(),
+						"dstintf":    list(),
+						"srcaddr":    list(),
+						"dstaddr":    list(),
+						"service":    list(),
+						"schedule":   str(),
+						"action":     str(),
+						"status":     str(),
+						"nat":        str(),
+						"logtraffic": str(),
+						"comments":   str(),
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePackagePoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error adom configuration: %v", err)
+	}
+
+	pkg := d.Get("pkg").(string)
+
+	// Policies are returned in the order FortiManager evaluates them
+	data, err := c.jsonrpc(ctx, "get", "/pm/config/"+adomv+"/pkg/"+pkg+"/firewall/policy", nil)
+	if err != nil {
+		return diag.Errorf("Error reading PackagePolicies of %s: %v", pkg, err)
+	}
+
+	l, _ := data.([]interface{})
+
+	policyids := make([]int, 0, len(l))
+	policies := make([]map[string]interface{}, 0, len(l))
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		policyid := fortiIntValue(flattenPackagesFirewallPolicyPolicyid(o["policyid"], d, "policyid"))
+
+		policyids = append(policyids, policyid)
+		policies = append(policies, map[string]interface{}{
+			"policyid":   policyid,
+			"uuid":       packagePolicyString(flattenPackagesFirewallPolicyUuid(o["uuid"], d, "uuid"), "PackagesFirewallPolicy-Uuid"),
+			"name":       packagePolicyString(flattenPackagesFirewallPolicyName(o["name"], d, "name"), "PackagesFirewallPolicy-Name"),
+			"srcintf":    flattenPackagesFirewallPolicySrcintf(o["srcintf"], d, "srcintf"),
+			"dstintf":    flattenPackagesFirewallPolicyDstintf(o["dstintf"], d, "dstintf"),
+			"srcaddr":    flattenPackagesFirewallPolicySrcaddr(o["srcaddr"], d, "srcaddr"),
+			"dstaddr":    flattenPackagesFirewallPolicyDstaddr(o["dstaddr"], d, "dstaddr"),
+			"service":    flattenPackagesFirewallPolicyService(o["service"], d, "service"),
+			"schedule":   packagePolicyString(flattenPackagesFirewallPolicySchedule(o["schedule"], d, "schedule"), "PackagesFirewallPolicy-Schedule"),
+			"action":     packagePolicyString(flattenPackagesFirewallPolicyAction(o["action"], d, "action"), "PackagesFirewallPolicy-Action"),
+			"status":     packagePolicyString(flattenPackagesFirewallPolicyStatus(o["status"], d, "status"), "PackagesFirewallPolicy-Status"),
+			"nat":        packagePolicyString(flattenPackagesFirewallPolicyNat(o["nat"], d, "nat"), "PackagesFirewallPolicy-Nat"),
+			"logtraffic": packagePolicyString(flattenPackagesFirewallPolicyLogtraffic(o["logtraffic"], d, "logtraffic"), "PackagesFirewallPolicy-Logtraffic"),
+			"comments":   packagePolicyString(flattenPackagesFirewallPolicyComments(o["comments"], d, "comments"), "PackagesFirewallPolicy-Comments"),
+		})
+	}
+
+	if err := d.Set("policyids", policyids); err != nil {
+		return diag.Errorf("Error reading PackagePolicies: %v", err)
+	}
+
+	if err := d.Set("policies", policies); err != nil {
+		return diag.Errorf("Error reading PackagePolicies: %v", err)
+	}
+
+	d.SetId(strings.ReplaceAll("packagepolicies"+adomv+pkg, "/", "."))
+
+	return nil
+}
+
+// packagePolicyString returns a policy attribute as a string, enum values may
+// be returned as numbers and single references as lists
+func packagePolicyString(v interface{}, lgname string) string {
+	if s := dvmdbValue(v); s != "" {
+		return s
+	}
+
+	if vv, ok := fortiAPIPatch(v, lgname); ok {
+		return fortiStringValue(vv)
+	}
+
+	return ""
+}
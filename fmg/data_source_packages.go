@@ -0,0 +1,244 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: List the policy packages of an ADOM
+
+package fortimanager
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePackages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePackagesRead,
+
+		Schema: scopeSchema(map[string]*schema.Schema{
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "pkg",
+				ValidateFunc: validation.StringInSlice([]string{
+					"",
+					"pkg",
+					"folder",
+				}, false),
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"packages": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"folder": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"oid": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"scopemember": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"vdom": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"install_status": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"device": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"vdom": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"status": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourcePackagesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error adom configuration: %v", err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get("name_regex").(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+	pkgType := d.Get("type").(string)
+
+	data, err := c.jsonrpc(ctx, "get", "/pm/pkg/"+adomv, nil)
+	if err != nil {
+		return diag.Errorf("Error reading Packages: %v", err)
+	}
+
+	l, _ := data.([]interface{})
+
+	// Global policy packages are assigned to ADOMs, not installed on devices
+	status := make(map[string][]map[string]interface{})
+	if adomv != "global" {
+		status, err = packageInstallStatus(ctx, c, adomv)
+		if err != nil {
+			return diag.Errorf("Error reading Packages: %v", err)
+		}
+	}
+
+	names := make([]string, 0)
+	packages := make([]map[string]interface{}, 0)
+	for _, pkg := range flattenPackageTree(l, "", d) {
+		if nameRegex != nil && !nameRegex.MatchString(pkg["name"].(string)) {
+			continue
+		}
+		if pkgType != "" && pkg["type"] != pkgType {
+			continue
+		}
+
+		install := status[pkg["path"].(string)]
+		if install == nil {
+			install = make([]map[string]interface{}, 0)
+		}
+		pkg["install_status"] = install
+
+		names = append(names, pkg["path"].(string))
+		packages = append(packages, pkg)
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return diag.Errorf("Error reading Packages: %v", err)
+	}
+
+	if err := d.Set("packages", packages); err != nil {
+		return diag.Errorf("Error reading Packages: %v", err)
+	}
+
+	d.SetId(strings.ReplaceAll("packages"+adomv, "/", "."))
+
+	return nil
+}
+
+// flattenPackageTree returns the packages and folders of l and of its
+// folders, folder is the path of the folder holding l
+func flattenPackageTree(l []interface{}, folder string, d *schema.ResourceData) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := fortiStringValue(o["name"])
+		path := name
+		if folder != "" {
+			path = folder + "/" + name
+		}
+
+		members := flattenPackagesPkgScopeMember(o["scope member"], d, "scopemember")
+		if members == nil {
+			members = make([]map[string]interface{}, 0)
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":        name,
+			"path":        path,
+			"folder":      folder,
+			"type":        fortiStringValue(flattenPackagesPkgType(o["type"], d, "type")),
+			"oid":         fortiIntValue(flattenPackagesPkgOid(o["oid"], d, "oid")),
+			"scopemember": members,
+		})
+
+		if subobj, ok := o["subobj"].([]interface{}); ok {
+			result = append(result, flattenPackageTree(subobj, path, d)...)
+		}
+	}
+
+	return result
+}
+
+// packageInstallStatus returns the install status of the devices of the
+// ADOM by policy package path
+func packageInstallStatus(ctx context.Context, c *FortiClient, adomv string) (map[string][]map[string]interface{}, error) {
+	data, err := c.jsonrpc(ctx, "get", "/pm/config/"+adomv+"/_package/status", nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return map[string][]map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+
+	l, _ := data.([]interface{})
+
+	status := make(map[string][]map[string]interface{})
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pkg := fortiStringValue(o["pkg"])
+		status[pkg] = append(status[pkg], map[string]interface{}{
+			"device": fortiStringValue(o["dev"]),
+			"vdom":   fortiStringValue(o["vdom"]),
+			"status": fortiStringValue(o["status"]),
+		})
+	}
+
+	return status, nil
+}
@@ -0,0 +1,324 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Get the status of FortiManager
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// systemStatusVersion matches the version of /cli/global/system/status,
+// e.g. v7.2.2-build1334 230201 (GA)
+var systemStatusVersion = regexp.MustCompile(`v(\d+)\.(\d+)\.(\d+)-build(\d+)`)
+
+func dataSourceSystemStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSystemStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"min_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if _, err := parseVersion(v.(string)); err != nil {
+						return nil, []error{fmt.Errorf("invalid %s: %v", k, err)}
+					}
+					return nil, nil
+				},
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"major": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"minor": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"patch": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"build": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"branch_point": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"release_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"full_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"platform": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ha_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"adom_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"workspace_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"admin_user": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"admin_profile": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSystemStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	data, err := c.jsonrpc(ctx, "get", "/cli/global/system/status", nil)
+	if err != nil {
+		return diag.Errorf("Error reading SystemStatus: %v", err)
+	}
+
+	o, ok := data.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("Error reading SystemStatus: unexpected data %v", data)
+	}
+
+	v, err := parseSystemStatusVersion(o)
+	if err != nil {
+		return diag.Errorf("Error reading SystemStatus: %v", err)
+	}
+
+	data, err = c.jsonrpc(ctx, "get", "/cli/global/system/global", map[string]interface{}{
+		"fields": []string{"workspace-mode"},
+	})
+	if err != nil {
+		return diag.Errorf("Error reading SystemStatus: cannot read the workspace-mode: %v", err)
+	}
+	global, _ := data.(map[string]interface{})
+
+	var user, profile string
+	if c.transport.auth != nil {
+		user = c.transport.auth.User
+	}
+
+	if c.transport.token != "" {
+		// API token administrators do not log in with their name, username
+		// names the REST API admin when it is set
+		user, profile, err = systemStatusTokenAdmin(ctx, c, user)
+		if err != nil {
+			return diag.Errorf("Error reading SystemStatus: %v", err)
+		}
+	} else if user != "" {
+		// Best effort, remote administrators (e.g. RADIUS) have no entry and
+		// restricted profiles may not read the administrators
+		profile, err = systemStatusAdminProfile(ctx, c, user)
+		if err != nil {
+			log.Printf("[WARN] Cannot read the profile of administrator %s, admin_profile is left empty: %v", user, err)
+		}
+	}
+
+	attributes := map[string]interface{}{
+		"version":        v.String(),
+		"major":          v.major,
+		"minor":          v.minor,
+		"patch":          v.patch,
+		"build":          v.build,
+		"branch_point":   statusInt(o["Branch Point"]),
+		"release_type":   strings.Trim(fortiStringValue(o["Release Version Information"]), " ()"),
+		"full_version":   fortiStringValue(o["Version"]),
+		"serial":         fortiStringValue(o["Serial Number"]),
+		"hostname":       fortiStringValue(o["Hostname"]),
+		"platform":       fortiStringValue(o["Platform Full Name"]),
+		"ha_mode":        fortiStringValue(o["HA Mode"]),
+		"adom_enabled":   strings.EqualFold(fortiStringValue(o["Admin Domain Configuration"]), "enabled"),
+		"workspace_mode": workspaceModeName(global["workspace-mode"]),
+		"admin_user":     user,
+		"admin_profile":  profile,
+	}
+	for k, value := range attributes {
+		if err := d.Set(k, value); err != nil {
+			return diag.Errorf("Error reading SystemStatus: %v", err)
+		}
+	}
+
+	d.SetId(strings.ReplaceAll("systemstatus"+fortiStringValue(o["Serial Number"]), "/", "."))
+
+	if min := d.Get("min_version").(string); min != "" {
+		required, _ := parseVersion(min)
+		if v.less(required) {
+			return diag.Errorf("FortiManager %s (%s) runs version %s build %d, version %s or later is required",
+				attributes["hostname"], attributes["serial"], v, v.build, min)
+		}
+	}
+
+	return nil
+}
+
+// fmgVersion is a FortiManager firmware version
+type fmgVersion struct {
+	major, minor, patch, build int
+}
+
+func (v fmgVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// less compares the major, minor and patch numbers of v and w
+func (v fmgVersion) less(w fmgVersion) bool {
+	if v.major != w.major {
+		return v.major < w.major
+	}
+	if v.minor != w.minor {
+		return v.minor < w.minor
+	}
+
+	return v.patch < w.patch
+}
+
+// parseVersion parses a version such as 7.2 or 7.2.5
+func parseVersion(s string) (fmgVersion, error) {
+	var v fmgVersion
+
+	p := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(p) > 3 {
+		return v, fmt.Errorf("%q is not a version such as 7.2 or 7.2.5", s)
+	}
+
+	n := []*int{&v.major, &v.minor, &v.patch}
+	for i, part := range p {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return v, fmt.Errorf("%q is not a version such as 7.2 or 7.2.5", s)
+		}
+		*n[i] = value
+	}
+
+	return v, nil
+}
+
+// parseSystemStatusVersion returns the version of the system status o, from
+// the Major, Minor, Patch and Build numbers when returned or else from the
+// Version string
+// systemStatusAdminProfile returns the profile of administrator user
+func systemStatusAdminProfile(ctx context.Context, c *FortiClient, user string) (string, error) {
+	data, err := c.jsonrpc(ctx, "get", "/cli/global/system/admin/user/"+user, map[string]interface{}{
+		"fields": []string{"profileid"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	admin, _ := data.(map[string]interface{})
+	return systemStatusProfile(admin), nil
+}
+
+func systemStatusProfile(admin map[string]interface{}) string {
+	if vv, ok := fortiAPIPatch(admin["profileid"], "SystemStatus-Profileid"); ok {
+		return fortiStringValue(vv)
+	}
+
+	return fortiStringValue(admin["profileid"])
+}
+
+// systemStatusTokenAdmin returns the REST API administrator of the API token
+// and its profile. FortiManager does not tell which administrator a token
+// belongs to: it is user when set, otherwise the only REST API administrator.
+func systemStatusTokenAdmin(ctx context.Context, c *FortiClient, user string) (string, string, error) {
+	if user != "" {
+		profile, err := systemStatusAdminProfile(ctx, c, user)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot read the profile of REST API administrator %s: %v", user, err)
+		}
+
+		return user, profile, nil
+	}
+
+	data, err := c.jsonrpc(ctx, "get", "/cli/global/system/admin/user", map[string]interface{}{
+		"fields": []string{"userid", "profileid"},
+		"filter": []string{"user_type", "==", "api"},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("cannot read the REST API administrators to find the one of the API token, set username to its name: %v", err)
+	}
+
+	l, _ := data.([]interface{})
+	if len(l) != 1 {
+		return "", "", fmt.Errorf("cannot tell the REST API administrator of the API token among %d, set username to its name", len(l))
+	}
+
+	admin, _ := l[0].(map[string]interface{})
+	return fortiStringValue(admin["userid"]), systemStatusProfile(admin), nil
+}
+
+func parseSystemStatusVersion(o map[string]interface{}) (fmgVersion, error) {
+	if _, ok := o["Major"]; ok {
+		return fmgVersion{
+			major: statusInt(o["Major"]),
+			minor: statusInt(o["Minor"]),
+			patch: statusInt(o["Patch"]),
+			build: statusInt(o["Build"]),
+		}, nil
+	}
+
+	version := fortiStringValue(o["Version"])
+	match := systemStatusVersion.FindStringSubmatch(version)
+	if match == nil {
+		return fmgVersion{}, fmt.Errorf("cannot parse the version %q", version)
+	}
+
+	var v fmgVersion
+	v.major, _ = strconv.Atoi(match[1])
+	v.minor, _ = strconv.Atoi(match[2])
+	v.patch, _ = strconv.Atoi(match[3])
+	v.build, _ = strconv.Atoi(match[4])
+
+	return v, nil
+}
+
+// statusInt returns a number of the system status, returned as a number or
+// a string depending on the version
+func statusInt(v interface{}) int {
+	if s, ok := v.(string); ok {
+		i, _ := strconv.Atoi(strings.TrimSpace(s))
+		return i
+	}
+
+	return fortiIntValue(v)
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Get the workspace lock status of an ADOM, device or policy package
+
+package fortimanager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceWorkspaceLock() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"scopetype": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "inherit",
+			ValidateFunc: validation.StringInSlice([]string{
+				"adom",
+				"global",
+				"inherit",
+			}, false),
+		},
+		"adom": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"target": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "",
+			ValidateFunc: validation.StringInSlice([]string{
+				"",
+				"dev",
+				"pkg",
+			}, false),
+		},
+		"param": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "",
+		},
+	}
+
+	for k, v := range workspaceLockSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceLockRead,
+
+		Schema: s,
+	}
+}
+
+func dataSourceWorkspaceLockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cfg := m.(*FortiClient).Cfg
+	adomv, err := adomChecking(cfg, d)
+	if err != nil {
+		return diag.Errorf("Error reading WorkspaceLock: %v", err)
+	}
+
+	target, err := workspaceTargetFor(adomv, d.Get("target").(string), d.Get("param").(string))
+	if err != nil {
+		return diag.Errorf("Error reading WorkspaceLock: %v", err)
+	}
+
+	locks, err := m.(*FortiClient).workspaceLocks(ctx, target)
+	if err != nil {
+		return diag.Errorf("Error reading WorkspaceLock of %s: %v", target, err)
+	}
+
+	if err := setWorkspaceLockAttributes(d, locks); err != nil {
+		return diag.Errorf("Error reading WorkspaceLock: %v", err)
+	}
+
+	idstr := "workspacelock" + adomv + d.Get("target").(string) + d.Get("param").(string)
+	d.SetId(strings.ReplaceAll(idstr, "/", "."))
+
+	return nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Managed devices of the device manager database
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Device states returned as numbers without verbose
+var (
+	dvmdbConnStatus = map[int]string{0: "unknown", 1: "up", 2: "down"}
+	dvmdbConfStatus = map[int]string{0: "unknown", 1: "insync", 2: "outofsync"}
+	dvmdbDbStatus   = map[int]string{0: "unknown", 1: "nomod", 2: "mod"}
+)
+
+// dvmdbDeviceURL returns the url of the devices of adomv (as returned by
+// adomChecking), the global scope lists the devices of all ADOMs
+func dvmdbDeviceURL(adomv string) string {
+	if adomv == "global" {
+		return "/dvmdb/device"
+	}
+
+	return "/dvmdb/" + adomv + "/device"
+}
+
+// dvmdbGroupMembers returns the names of the devices of a device group,
+// devices with several vdoms in the group are returned once
+func dvmdbGroupMembers(ctx context.Context, c *FortiClient, adom, group string) ([]string, error) {
+	data, err := c.jsonrpc(ctx, "get", "/dvmdb/adom/"+adom+"/group/"+group, map[string]interface{}{
+		"option": []string{"object member"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read device group %s: %v", group, err)
+	}
+
+	o, _ := data.(map[string]interface{})
+	members, _ := o["object member"].([]interface{})
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, member := range members {
+		i, ok := member.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := fortiStringValue(i["name"])
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// dvmdbState returns the name of a device state, FortiManager returns its
+// number when the request is not verbose
+func dvmdbState(v interface{}, names map[int]string) string {
+	if s := fortiStringValue(v); s != "" {
+		return s
+	}
+
+	if s, ok := names[fortiIntValue(v)]; ok {
+		return s
+	}
+
+	return "unknown"
+}
+
+// dvmdbValue returns an enum value as a string, as the name given by
+// FortiManager when verbose or as its number
+func dvmdbValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.Itoa(int(v))
+	}
+
+	return ""
+}
+
+// dvmdbOSVersion returns the firmware version of a device, e.g. 7.2.5.
+// os_ver is the major version, 7.0 when verbose.
+func dvmdbOSVersion(o map[string]interface{}) string {
+	major := fortiStringValue(o["os_ver"])
+	if major == "" {
+		major = strconv.Itoa(fortiIntValue(o["os_ver"]))
+	}
+	major = strings.SplitN(major, ".", 2)[0]
+
+	return fmt.Sprintf("%s.%d.%d", major, fortiIntValue(o["mr"]), fortiIntValue(o["patch"]))
+}
+
+// flattenDvmdbDevice returns the attributes of dvmdbDeviceSchema for the
+// device o
+func flattenDvmdbDevice(o map[string]interface{}) map[string]interface{} {
+	members := make([]map[string]interface{}, 0)
+	if l, ok := o["ha_slave"].([]interface{}); ok {
+		for _, v := range l {
+			i, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			members = append(members, map[string]interface{}{
+				"name":     fortiStringValue(i["name"]),
+				"serial":   fortiStringValue(i["sn"]),
+				"role":     dvmdbValue(i["role"]),
+				"priority": fortiIntValue(i["prio"]),
+				"status":   dvmdbValue(i["status"]),
+			})
+		}
+	}
+
+	vdoms := make([]map[string]interface{}, 0)
+	if l, ok := o["vdom"].([]interface{}); ok {
+		for _, v := range l {
+			i, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			vdoms = append(vdoms, map[string]interface{}{
+				"name":   fortiStringValue(i["name"]),
+				"opmode": dvmdbValue(i["opmode"]),
+				"status": dvmdbValue(i["status"]),
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"name":        fortiStringValue(o["name"]),
+		"serial":      fortiStringValue(o["sn"]),
+		"platform":    fortiStringValue(o["platform_str"]),
+		"os_version":  dvmdbOSVersion(o),
+		"build":       fortiIntValue(o["build"]),
+		"ip":          fortiStringValue(o["ip"]),
+		"conn_status": dvmdbState(o["conn_status"], dvmdbConnStatus),
+		"conf_status": dvmdbState(o["conf_status"], dvmdbConfStatus),
+		"db_status":   dvmdbState(o["db_status"], dvmdbDbStatus),
+		"ha_mode":     dvmdbValue(o["ha_mode"]),
+		"ha_members":  members,
+		"vdoms":       vdoms,
+	}
+}
+
+// dvmdbDeviceSchema returns the computed attributes of a managed device
+func dvmdbDeviceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"serial": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"platform": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"os_version": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"build": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"ip": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"conn_status": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"conf_status": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"db_status": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"ha_mode": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"ha_members": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"serial": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"role": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"priority": &schema.Schema{
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"status": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"vdoms": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"opmode": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Structured FortiManager API errors
+
+package fortimanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	forticlient "github.com/romanromanovv/forti-sdk-go/fortimanager2/sdkcore"
+)
+
+// FortiManager status codes the provider reacts to
+const (
+	FortiAPIErrorNotFound   = -3
+	FortiAPIErrorInUse      = -10
+	FortiAPIErrorPermission = -11
+)
+
+// FortiAPIError is an error status returned by FortiManager for a JSON-RPC
+// request
+type FortiAPIError struct {
+	Code    int
+	Message string
+	URL     string
+	Method  string
+}
+
+func (e *FortiAPIError) Error() string {
+	if e.URL == "" {
+		return fmt.Sprintf("err %d: %s", e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("err %d: %s [%s %s]", e.Code, e.Message, e.Method, e.URL)
+}
+
+// AsFortiAPIError returns the FortiManager error carried by err. The errors
+// of SDK calls carry it once passed to sdkError.
+func AsFortiAPIError(err error) (*FortiAPIError, bool) {
+	var e *FortiAPIError
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// sdkError returns the error of a call made with the SDK client c: the
+// *FortiAPIError of the failed response, as recorded by the transport, or err
+// when the call failed otherwise. The SDK returns FortiManager errors as
+// plain strings.
+func sdkError(c *forticlient.FortiSDKClient, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if t, ok := c.Config.HTTPCon.Transport.(*contextTransport); ok {
+		if e := t.errors.last(); e != nil {
+			return e
+		}
+	}
+
+	return err
+}
+
+// apiErrorRecorder keeps the FortiAPIError of the last response to the
+// requests made with its context, or nil when it succeeded
+type apiErrorRecorder struct {
+	mu  sync.Mutex
+	err *FortiAPIError
+}
+
+func (r *apiErrorRecorder) last() *FortiAPIError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+type apiErrorRecorderKey struct{}
+
+// contextWithAPIErrorRecorder returns ctx carrying r
+func contextWithAPIErrorRecorder(ctx context.Context, r *apiErrorRecorder) context.Context {
+	return context.WithValue(ctx, apiErrorRecorderKey{}, r)
+}
+
+// recordAPIError records the outcome of the request made with ctx, e is nil
+// when it succeeded
+func recordAPIError(ctx context.Context, e *FortiAPIError) {
+	r, ok := ctx.Value(apiErrorRecorderKey{}).(*apiErrorRecorder)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	r.err = e
+	r.mu.Unlock()
+}
+
+// IsNotFound reports whether err means that the requested object does not exist
+func IsNotFound(err error) bool {
+	e, ok := AsFortiAPIError(err)
+	return ok && e.Code == FortiAPIErrorNotFound
+}
+
+// IsInUse reports whether err means that the object is still referenced by
+// other objects
+func IsInUse(err error) bool {
+	e, ok := AsFortiAPIError(err)
+	return ok && e.Code == FortiAPIErrorInUse
+}
+
+// IsPermissionDenied reports whether err means that the administrator has no
+// permission for the resource
+func IsPermissionDenied(err error) bool {
+	e, ok := AsFortiAPIError(err)
+	return ok && e.Code == FortiAPIErrorPermission
+}
+
+// IsLocked reports whether err means that the ADOM, device or package is
+// locked by another administrator
+func IsLocked(err error) bool {
+	e, ok := AsFortiAPIError(err)
+	if !ok {
+		return false
+	}
+
+	return isLockConflict(e.Code, e.Message)
+}
+
+// isLockConflict reports whether a FortiManager status means that the
+// request was rejected because another administrator holds the lock
+func isLockConflict(code int, message string) bool {
+	if code == 0 {
+		return false
+	}
+
+	message = strings.ToLower(message)
+	return strings.Contains(message, "locked by") || strings.Contains(message, "is locked")
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Install logs of failed installs
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// installLogPattern matches the install log lines worth showing in an error,
+// e.g. copy failures and CLI commands rejected by the device
+var installLogPattern = regexp.MustCompile(`(?i)error|fail|not found|invalid|denied|reject|abort|unable|cannot|conflict|timeout`)
+
+// Number of log lines of each device included in an error
+const (
+	installLogMaxLines  = 20
+	installLogTailLines = 5
+)
+
+// installLog is the install history of one device of a task
+type installLog struct {
+	line    TaskLine
+	history []string
+	err     error
+}
+
+// installLogs fetches the install history of the failed devices of task, of
+// all its devices when all is set. Errors are kept per device, a missing log
+// must not hide the install error.
+func installLogs(ctx context.Context, c *FortiClient, task *Task, all bool) []installLog {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	logs := make([]installLog, 0, len(task.Lines))
+	for _, l := range task.Lines {
+		if !all && !l.failed() {
+			continue
+		}
+
+		history, err := c.GetTaskLineHistory(ctx, task.ID, l.OID)
+		logs = append(logs, installLog{
+			line:    l,
+			history: history,
+			err:     err,
+		})
+	}
+
+	return logs
+}
+
+// relevantLines returns the lines of history explaining a failure, or the end
+// of the log if no line looks like an error
+func relevantLines(history []string) []string {
+	var lines []string
+	for _, h := range history {
+		if installLogPattern.MatchString(h) {
+			lines = append(lines, h)
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = history
+		if len(lines) > installLogTailLines {
+			lines = lines[len(lines)-installLogTailLines:]
+		}
+	}
+
+	if len(lines) > installLogMaxLines {
+		lines = lines[len(lines)-installLogMaxLines:]
+	}
+
+	return lines
+}
+
+// installLogError adds the relevant install log lines of the failed devices of
+// task to err, and writes the full log of all devices to path if set
+func installLogError(ctx context.Context, c *FortiClient, task *Task, err error, path string) error {
+	logs := installLogs(ctx, c, task, path != "")
+
+	var b strings.Builder
+	b.WriteString(err.Error())
+
+	for _, l := range logs {
+		if !l.line.failed() {
+			continue
+		}
+
+		if l.err != nil {
+			fmt.Fprintf(&b, "\ninstall log of %s is not available: %v", l.line.target(), l.err)
+			continue
+		}
+
+		lines := relevantLines(l.history)
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\ninstall log of %s:", l.line.target())
+		for _, line := range lines {
+			fmt.Fprintf(&b, "\n    %s", strings.TrimSpace(line))
+		}
+	}
+
+	if path != "" {
+		if werr := writeInstallLog(path, task, logs); werr != nil {
+			log.Printf("[WARN] Cannot write install log: %v", werr)
+			fmt.Fprintf(&b, "\ncannot write the install log to %s: %v", path, werr)
+		} else {
+			fmt.Fprintf(&b, "\nfull install log written to %s", path)
+		}
+	}
+
+	return fmt.Errorf("%s", b.String())
+}
+
+func writeInstallLog(path string, task *Task, logs []installLog) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "task %d (%s): %s, %d%%\n", task.ID, task.Title, task.State, task.Percent)
+
+	for _, l := range logs {
+		fmt.Fprintf(&b, "\n== %s (%s", l.line.target(), l.line.State)
+		if l.line.Err != 0 {
+			fmt.Fprintf(&b, ", err %d", l.line.Err)
+		}
+		b.WriteString(") ==\n")
+
+		if l.line.Detail != "" {
+			fmt.Fprintf(&b, "%s\n", l.line.Detail)
+		}
+
+		if l.err != nil {
+			fmt.Fprintf(&b, "log not available: %v\n", l.err)
+		}
+
+		for _, h := range l.history {
+			fmt.Fprintf(&b, "%s\n", strings.TrimRight(h, "\n"))
+		}
+	}
+
+	return ioutil.WriteFile(path, []byte(b.String()), 0600)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: JSON-RPC calls made by the provider outside of the SDK
+
+package fortimanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// jsonrpc sends a JSON-RPC request for url and returns the data of its first
+// result. params holds the other members of the request parameter, e.g. data,
+// fields, filter or option. The request shares the session, retries and
+// throttling of the SDK requests and can be cancelled with ctx.
+func (c *FortiClient) jsonrpc(ctx context.Context, method, url string, params map[string]interface{}, opts ...callOption) (interface{}, error) {
+	if c.transport == nil {
+		return nil, fmt.Errorf("FortiManager client is not configured")
+	}
+
+	return c.transport.jsonrpc(ctx, method, url, params, opts...)
+}
+
+// jsonrpc sends a JSON-RPC request through t, see FortiClient.jsonrpc
+func (t *fmgTransport) jsonrpc(ctx context.Context, method, url string, params map[string]interface{}, opts ...callOption) (interface{}, error) {
+	param := map[string]interface{}{
+		"url": url,
+	}
+	for k, v := range params {
+		param[k] = v
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"id":      1,
+		"method":  method,
+		"params":  []interface{}{param},
+		"session": "",
+		"verbose": 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode request: %v", err)
+	}
+
+	o := newCallOptions(opts)
+	errors := &apiErrorRecorder{}
+	ctx = contextWithAPIErrorRecorder(contextWithCallOptions(ctx, o), errors)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+t.endpoint.Host+"/jsonrpc", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot send request: %v", err)
+	}
+
+	rspBody, err := readResponseBody(rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if e := errors.last(); e != nil {
+		return nil, e
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(rspBody, &result); err != nil {
+		return nil, fmt.Errorf("cannot decode response: %v", err)
+	}
+
+	if problem := malformedResponse(rspBody, false); problem != "" {
+		return nil, fmt.Errorf("unexpected response from FortiManager, %s: %s", problem, bodySnippet(rspBody))
+	}
+
+	l, _ := result["result"].([]interface{})
+	v, _ := l[0].(map[string]interface{})
+	return v["data"], nil
+}
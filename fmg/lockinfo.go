@@ -0,0 +1,325 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Workspace lock information and lock conflicts
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// WorkspaceLock is a workspace lock held on an ADOM, device or policy package
+type WorkspaceLock struct {
+	User    string
+	Session int
+
+	// Time is the lock time in seconds since the epoch
+	Time int
+
+	// Object is the locked object
+	Object string
+}
+
+func (l WorkspaceLock) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "locked by %s", l.User)
+	if l.Session != 0 {
+		fmt.Fprintf(&b, " (session %d)", l.Session)
+	}
+	if l.Time != 0 {
+		fmt.Fprintf(&b, " since %s", time.Unix(int64(l.Time), 0).UTC().Format(time.RFC3339))
+	}
+	if l.Object != "" {
+		fmt.Fprintf(&b, " on %s", l.Object)
+	}
+
+	return b.String()
+}
+
+// workspaceLockList describes locks, e.g. locked by admin (session 12)
+func workspaceLockList(locks []WorkspaceLock) string {
+	l := make([]string, 0, len(locks))
+	for _, lock := range locks {
+		l = append(l, lock.String())
+	}
+
+	return strings.Join(l, ", ")
+}
+
+// lockInfoURL returns the url of the lock information of w
+func (w workspaceTarget) lockInfoURL() string {
+	path := "/dvmdb/adom/" + w.adom
+	if w.adom == "global" {
+		path = "/dvmdb/global"
+	}
+
+	path += "/workspace"
+	if w.pkg != "" {
+		path += "/pkg/" + w.pkg
+	} else if w.dev != "" {
+		path += "/dev/" + w.dev
+	}
+
+	return path + "/lockinfo"
+}
+
+// workspaceActionTarget returns the target of a workspace action url, e.g.
+// /dvmdb/adom/root/workspace/lock/pkg/default
+func workspaceActionTarget(url string) (workspaceTarget, bool) {
+	p := strings.Split(strings.Trim(url, "/"), "/")
+
+	var target workspaceTarget
+	switch {
+	case len(p) >= 4 && p[0] == "dvmdb" && p[1] == "adom" && p[3] == "workspace":
+		target.adom = p[2]
+		p = p[4:]
+	case len(p) >= 3 && p[0] == "dvmdb" && p[1] == "global" && p[2] == "workspace":
+		target.adom = "global"
+		p = p[3:]
+	default:
+		return target, false
+	}
+
+	if len(p) >= 3 && p[1] == "pkg" {
+		target.pkg = p[2]
+	} else if len(p) >= 3 && p[1] == "dev" {
+		target.dev = p[2]
+	}
+
+	return target, true
+}
+
+// workspaceLocks reads the locks held on target
+func (c *FortiClient) workspaceLocks(ctx context.Context, target workspaceTarget) ([]WorkspaceLock, error) {
+	if c.transport == nil {
+		return nil, fmt.Errorf("FortiManager client is not configured")
+	}
+
+	return c.transport.workspaceLocks(ctx, target)
+}
+
+// workspaceLocks reads the locks held on target, see FortiClient.workspaceLocks
+func (t *fmgTransport) workspaceLocks(ctx context.Context, target workspaceTarget) ([]WorkspaceLock, error) {
+	data, err := t.jsonrpc(ctx, "get", target.lockInfoURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	switch v := data.(type) {
+	case []interface{}:
+		items = v
+	case map[string]interface{}:
+		items = []interface{}{v}
+	}
+
+	locks := make([]WorkspaceLock, 0, len(items))
+	for _, item := range items {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		l := WorkspaceLock{
+			User:    fortiStringValue(v["lock_user"]),
+			Session: fortiIntValue(v["lock_sid"]),
+			Time:    fortiIntValue(v["lock_time"]),
+			Object:  lockObject(v, target),
+		}
+		if l.User == "" && l.Session == 0 {
+			continue
+		}
+
+		locks = append(locks, l)
+	}
+
+	return locks, nil
+}
+
+// lockObject describes the object of a lock information entry, the ADOM
+// lock information also lists the locks of its devices and packages
+func lockObject(v map[string]interface{}, target workspaceTarget) string {
+	if pkg := fortiStringValue(v["pkg"]); pkg != "" {
+		return workspaceTarget{adom: target.adom, pkg: pkg}.String()
+	}
+
+	if dev := fortiStringValue(v["dev"]); dev != "" {
+		return workspaceTarget{adom: target.adom, dev: dev}.String()
+	}
+
+	return target.String()
+}
+
+// lockOwners describes who holds the lock that made FortiManager reject rpc,
+// or returns an empty string when the locked object is not known
+func (t *fmgTransport) lockOwners(rpc map[string]interface{}) string {
+	url := jsonrpcURL(rpc)
+
+	target, ok := workspaceActionTarget(url)
+	if !ok {
+		target, ok = workspaceTargetOf(url)
+	}
+	if !ok {
+		return ""
+	}
+
+	// The request may have been cancelled already
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	locks, err := t.workspaceLocks(ctx, target)
+	if err != nil {
+		return fmt.Sprintf("cannot read the lock information of %s: %v", target, err)
+	}
+
+	if len(locks) == 0 {
+		return fmt.Sprintf("no lock information for %s", target)
+	}
+
+	return workspaceLockList(locks)
+}
+
+// sendRPCWaitingForLock sends rpc like sendRPC. While FortiManager rejects it
+// because another administrator holds the lock, it is sent again until
+// lock_wait_timeout expires. A rejected request has not been run, so exec
+// calls are sent again as well.
+func (t *fmgTransport) sendRPCWaitingForLock(req *http.Request, rpc map[string]interface{}) (*http.Response, []byte, error) {
+	deadline := time.Now().Add(t.lockWaitTimeout)
+
+	for attempt := 0; ; attempt++ {
+		rsp, rspBody, err := t.sendRPC(req, rpc)
+		if err != nil {
+			return rsp, rspBody, err
+		}
+
+		code, message := jsonrpcStatus(rspBody)
+		if !isLockConflict(code, message) {
+			return rsp, rspBody, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return t.lockConflictResponse(req, rpc, code, message)
+		}
+
+		wait := t.retry.backoff(attempt)
+		if wait > remaining {
+			wait = remaining
+		}
+		log.Printf("[INFO] FortiManager request rejected (err %d: %s), waiting %v for the lock to be released", code, message, wait)
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return t.lockConflictResponse(req, rpc, code, message)
+		}
+	}
+}
+
+// lockConflictResponse returns the lock conflict response of rpc completed
+// with the owner of the lock
+func (t *fmgTransport) lockConflictResponse(req *http.Request, rpc map[string]interface{}, code int, message string) (*http.Response, []byte, error) {
+	if owners := t.lockOwners(rpc); owners != "" {
+		message += "; " + owners
+	}
+
+	rsp := statusResponse(req, code, message)
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rsp, body, nil
+}
+
+// workspaceLockSchema returns the computed attributes describing the locks of
+// a workspace target
+func workspaceLockSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"locked": &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"lock_user": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"lock_session": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"lock_time": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"locks": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"user": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"session": &schema.Schema{
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"time": &schema.Schema{
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"object": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// setWorkspaceLockAttributes sets the attributes of workspaceLockSchema, the
+// lock_* attributes describe the first lock
+func setWorkspaceLockAttributes(d *schema.ResourceData, locks []WorkspaceLock) error {
+	var first WorkspaceLock
+	if len(locks) > 0 {
+		first = locks[0]
+	}
+
+	l := make([]map[string]interface{}, 0, len(locks))
+	for _, lock := range locks {
+		l = append(l, map[string]interface{}{
+			"user":    lock.User,
+			"session": lock.Session,
+			"time":    lock.Time,
+			"object":  lock.Object,
+		})
+	}
+
+	if err := d.Set("locked", len(locks) > 0); err != nil {
+		return err
+	}
+	if err := d.Set("lock_user", first.User); err != nil {
+		return err
+	}
+	if err := d.Set("lock_session", first.Session); err != nil {
+		return err
+	}
+	if err := d.Set("lock_time", first.Time); err != nil {
+		return err
+	}
+
+	return d.Set("locks", l)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Per-call options of FortiManager requests
+
+package fortimanager
+
+import (
+	"context"
+	"time"
+)
+
+// callOptions change how the requests of one call are sent. They are given
+// to sdk or jsonrpc and travel with the context of each request, so that the
+// shared client and transport are never modified.
+//
+// Retries are not a call option: the retry policy of the provider decides
+// which requests are safe to send again, see retryPolicy.allowed.
+type callOptions struct {
+	// timeout bounds each request instead of requestTimeout, the context
+	// deadline still applies
+	timeout time.Duration
+
+	// sdkDecode is set for the calls of an SDK client, whose responses are
+	// decoded by the SDK, see malformedResponse
+	sdkDecode bool
+
+	// raw is set for the calls that return the response as is
+	raw bool
+
+	// noWorkspace is set for the calls that must not take workspace locks,
+	// see withoutWorkspace
+	noWorkspace bool
+}
+
+type callOption func(*callOptions)
+
+// withRequestTimeout bounds each request of the call
+func withRequestTimeout(d time.Duration) callOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// withRawResponse accepts data of any type in the responses of the call,
+// for calls that do not decode them such as JsonGenericAPI
+func withRawResponse() callOption {
+	return func(o *callOptions) {
+		o.raw = true
+	}
+}
+
+// withoutWorkspace sends the requests of the call without the workspace
+// locks of workspace_mode auto, for the calls managing the locks themselves
+func withoutWorkspace() callOption {
+	return func(o *callOptions) {
+		o.noWorkspace = true
+	}
+}
+
+func newCallOptions(opts []callOption) *callOptions {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+type callOptionsKey struct{}
+
+// contextWithCallOptions returns ctx carrying o
+func contextWithCallOptions(ctx context.Context, o *callOptions) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// callOptionsFromContext returns the options of the call ctx belongs to
+func callOptionsFromContext(ctx context.Context) *callOptions {
+	if o, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		return o
+	}
+
+	return &callOptions{}
+}
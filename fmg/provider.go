@@ -9,19 +9,36 @@
 package fortimanager
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider creates and returns the FortiManager *schema.Provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"hostname": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "",
-				Description: "The hostname/IP address of the FORTIMANAGER to be connected",
+				Description: "The hostname/IP address of the FORTIMANAGER to be connected, or its full URL with scheme, port and path",
+			},
+
+			"http_proxy": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"}, ""),
+				Description: "URL of the proxy used to connect to the FORTIMANAGER",
+			},
+
+			"no_proxy": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"NO_PROXY", "no_proxy"}, ""),
+				Description: "Comma separated hosts, domains and networks connected to without proxy",
 			},
 
 			"username": &schema.Schema{
@@ -38,6 +55,14 @@ func Provider() *schema.Provider {
 				Description: "",
 			},
 
+			"token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Sensitive:   true,
+				Description: "The API token of a FortiManager REST API admin, used instead of username/password",
+			},
+
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -51,6 +76,42 @@ func Provider() *schema.Provider {
 				Description: "CA Bundle file",
 			},
 
+			"cert_fingerprint_sha256": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "SHA-256 fingerprint of the FORTIMANAGER certificate, trusted instead of a CA",
+			},
+
+			"client_cert": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Client certificate file for mutual TLS",
+			},
+
+			"client_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Private key file of the client certificate",
+			},
+
+			"tls_server_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Server name used to verify the FORTIMANAGER certificate",
+			},
+
+			"min_tls_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1.2",
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+				Description:  "Minimum TLS version",
+			},
+
 			"scopetype": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -75,22 +136,135 @@ func Provider() *schema.Provider {
 			},
 
 			"logsession": &schema.Schema{
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:       schema.TypeBool,
+				Optional:   true,
+				Default:    false,
+				Deprecated: "Use session_cache_file instead. Setting logsession to true enables the session cache in the default location.",
 			},
 
 			"presession": &schema.Schema{
+				Type:       schema.TypeString,
+				Optional:   true,
+				Default:    "",
+				Deprecated: "Use session_cache_file instead.",
+			},
+
+			"session_cache_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "File used to share the login session between provider instances",
+			},
+
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries of a failed request",
+			},
+
+			"retry_backoff_min": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minimum wait in seconds before retrying a request",
+			},
+
+			"retry_backoff_max": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum wait in seconds before retrying a request",
+			},
+
+			"retryable_error_codes": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "FortiManager status codes for which a request is retried",
+			},
+
+			"retryable_exec_urls": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "URL prefixes of exec calls that are safe to retry",
+			},
+
+			"max_concurrent_requests": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests sent to the FORTIMANAGER at the same time, 0 means no limit",
+			},
+
+			"requests_per_second": &schema.Schema{
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum rate of requests sent to the FORTIMANAGER, 0 means no limit",
+			},
+
+			"workspace_mode": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "",
+				Default:  workspaceModeDisabled,
+				ValidateFunc: validation.StringInSlice([]string{
+					workspaceModeDisabled,
+					workspaceModeAuto,
+				}, false),
+				Description: "Set to auto to lock, commit and unlock ADOMs and policy packages automatically when FORTIMANAGER runs in workspace mode",
+			},
+
+			"workspace_commit_comment": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Committed by Terraform",
+				Description: "Comment of the commits made by workspace_mode auto",
+			},
+
+			"lock_wait_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds to wait for a workspace lock held by another administrator to be released, 0 means fail at once",
 			},
 		},
 
+		DataSourcesMap: map[string]*schema.Resource{
+			"fortimanager_device":                              dataSourceDevice(),
+			"fortimanager_devices":                             dataSourceDevices(),
+			"fortimanager_json_generic_api":                    dataSourceJsonGenericAPI(),
+			"fortimanager_object_firewall_address":             dataSourceObjectFirewallAddress(),
+			"fortimanager_object_firewall_addresses":           dataSourceObjectFirewallAddresses(),
+			"fortimanager_object_firewall_addrgrp":             dataSourceObjectFirewallAddrgrp(),
+			"fortimanager_object_firewall_addrgrps":            dataSourceObjectFirewallAddrgrps(),
+			"fortimanager_object_firewall_schedule_recurring":  dataSourceObjectFirewallScheduleRecurring(),
+			"fortimanager_object_firewall_schedule_recurrings": dataSourceObjectFirewallScheduleRecurrings(),
+			"fortimanager_object_firewall_service_custom":      dataSourceObjectFirewallServiceCustom(),
+			"fortimanager_object_firewall_service_customs":     dataSourceObjectFirewallServiceCustoms(),
+			"fortimanager_object_firewall_service_group":       dataSourceObjectFirewallServiceGroup(),
+			"fortimanager_object_firewall_service_groups":      dataSourceObjectFirewallServiceGroups(),
+			"fortimanager_object_firewall_vip":                 dataSourceObjectFirewallVip(),
+			"fortimanager_object_firewall_vips":                dataSourceObjectFirewallVips(),
+			"fortimanager_package_policies":                    dataSourcePackagePolicies(),
+			"fortimanager_packages":                            dataSourcePackages(),
+			"fortimanager_system_status":                       dataSourceSystemStatus(),
+			"fortimanager_workspace_lock":                      dataSourceWorkspaceLock(),
+		},
+
 		ResourcesMap: map[string]*schema.Resource{
+			"fortimanager_generic_object":        resourceGenericObject(),
 			"fortimanager_json_generic_api":      resourceJsonGenericAPI(),
 			"fortimanager_exec_workspace_action": resourceExecWorkspaceAction(),
 
+			"fortimanager_device_firmware_upgrade":                                    resourceDeviceFirmwareUpgrade(),
 			"fortimanager_dvm_cmd_add_device":                                         resourceDvmCmdAddDevice(),
 			"fortimanager_dvm_cmd_del_device":                                         resourceDvmCmdDelDevice(),
 			"fortimanager_dvm_cmd_update_device":                                      resourceDvmCmdUpdateDevice(),
@@ -571,22 +745,58 @@ func Provider() *schema.Provider {
 			"fortimanager_wantemp_system_virtualwanlink_service_sla":                  resourceWantempSystemVirtualWanLinkServiceSla(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
+	}
+
+	for name, r := range p.ResourcesMap {
+		recoverResourcePanics(name, r)
+		trackOperations(r)
+	}
+
+	for name, r := range p.DataSourcesMap {
+		recoverResourcePanics(name, r)
+		trackOperations(r)
 	}
+
+	return p
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
 		Hostname:      d.Get("hostname").(string),
 		User:          d.Get("username").(string),
 		Passwd:        d.Get("password").(string),
+		Token:         d.Get("token").(string),
 		CABundle:      d.Get("cabundlefile").(string),
 		ScopeType:     d.Get("scopetype").(string),
 		Adom:          d.Get("adom").(string),
 		ImportOptions: d.Get("import_options").(*schema.Set),
 
-		LogSession: d.Get("logsession").(bool),
-		Session:    d.Get("presession").(string),
+		CertFingerprint: d.Get("cert_fingerprint_sha256").(string),
+		ClientCert:      d.Get("client_cert").(string),
+		ClientKey:       d.Get("client_key").(string),
+		TLSServerName:   d.Get("tls_server_name").(string),
+		MinTLSVersion:   d.Get("min_tls_version").(string),
+
+		HTTPProxy: d.Get("http_proxy").(string),
+		NoProxy:   d.Get("no_proxy").(string),
+
+		LogSession:       d.Get("logsession").(bool),
+		Session:          d.Get("presession").(string),
+		SessionCacheFile: d.Get("session_cache_file").(string),
+
+		MaxRetries:          d.Get("max_retries").(int),
+		RetryBackoffMin:     d.Get("retry_backoff_min").(int),
+		RetryBackoffMax:     d.Get("retry_backoff_max").(int),
+		RetryableErrorCodes: expandIntegerList(d.Get("retryable_error_codes").([]interface{})),
+		RetryableExecURLs:   expandStringList(d.Get("retryable_exec_urls").([]interface{})),
+
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:     d.Get("requests_per_second").(float64),
+
+		WorkspaceMode:          d.Get("workspace_mode").(string),
+		WorkspaceCommitComment: d.Get("workspace_commit_comment").(string),
+		LockWaitTimeout:        d.Get("lock_wait_timeout").(int),
 	}
 
 	v1, ok1 := d.GetOkExists("insecure")
@@ -596,5 +806,16 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	// Create Client for later connections
-	return config.CreateClient()
+	client, err := config.CreateClient()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// Terraform stops the provider when the run is interrupted, long running
+	// operations watch this context to cancel their FortiManager task
+	if stop, ok := ctx.Value(schema.StopContextKey).(context.Context); ok {
+		client.(*FortiClient).stopCtx = stop
+	}
+
+	return client, nil
 }
@@ -0,0 +1,59 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Keep the plugin running when a resource panics
+
+package fortimanager
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type crudFunc func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// recoverResourcePanics turns a panic in the CRUD functions of a resource or
+// data source, e.g. while the SDK decodes an unexpected FortiManager
+// response, into an error, so that one bad response does not terminate the
+// whole plugin. The SDK exits the process on the errors it cannot recover
+// from, they are prevented instead: the transport rejects the responses the
+// SDK cannot decode (see malformedResponse), parseEndpoint only accepts hosts
+// the SDK can build request urls for, and the request parameters only hold
+// values JSON can encode.
+func recoverResourcePanics(name string, r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = schema.CreateContextFunc(recoverCRUD(name, "creating", crudFunc(r.CreateContext)))
+	}
+
+	if r.ReadContext != nil {
+		r.ReadContext = schema.ReadContextFunc(recoverCRUD(name, "reading", crudFunc(r.ReadContext)))
+	}
+
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(recoverCRUD(name, "updating", crudFunc(r.UpdateContext)))
+	}
+
+	if r.DeleteContext != nil {
+		r.DeleteContext = schema.DeleteContextFunc(recoverCRUD(name, "deleting", crudFunc(r.DeleteContext)))
+	}
+}
+
+func recoverCRUD(name, action string, f crudFunc) crudFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("[ERROR] Recovered from panic while %s %s: %v\n%s", action, name, p, debug.Stack())
+				diags = diag.Errorf("Error %s %s: unexpected data in FortiManager response: %v", action, name, p)
+			}
+		}()
+
+		return f(ctx, d, m)
+	}
+}
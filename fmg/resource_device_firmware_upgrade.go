@@ -0,0 +1,376 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Upgrade the firmware of managed devices in batches
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDeviceFirmwareUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceFirmwareUpgradeUpdate,
+		ReadContext:   resourceDeviceFirmwareUpgradeRead,
+		UpdateContext: resourceDeviceFirmwareUpgradeUpdate,
+		DeleteContext: resourceDeviceFirmwareUpgradeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"force_recreate": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"fmgadom": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"device": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"vdom": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"device_group": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"image_release": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"image_build": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"flags": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"batch_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"stop_on_failure": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"skipped_devices": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"task_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Computed: true,
+			},
+			"task_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_percent": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_results": taskResultsSchema(),
+		},
+	}
+}
+
+// firmwareUpgradeDevice is a device to upgrade, vdom is only sent when set
+type firmwareUpgradeDevice struct {
+	name string
+	vdom string
+}
+
+func resourceDeviceFirmwareUpgradeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Terraform interrupts stop the wait, the running upgrade cannot be aborted
+	ctx, cancel := m.(*FortiClient).withStop(ctx)
+	defer cancel()
+
+	c := m.(*FortiClient)
+
+	adom := d.Get("fmgadom").(string)
+	if adom == "" {
+		adom = c.Cfg.Adom
+	}
+	if adom == "" {
+		adom = "root"
+	}
+	d.Set("fmgadom", adom)
+
+	devices, err := firmwareUpgradeDevices(ctx, c, d, adom)
+	if err != nil {
+		return diag.Errorf("Error updating DeviceFirmwareUpgrade resource: %v", err)
+	}
+
+	d.SetId("DeviceFirmwareUpgrade")
+
+	batches := firmwareUpgradeBatches(devices, d.Get("batch_size").(int))
+	stopOnFailure := d.Get("stop_on_failure").(bool)
+
+	// Results of all batches, reported as one task
+	summary := &Task{
+		Title: "firmware upgrade to " + d.Get("image_release").(string),
+		State: "done",
+	}
+
+	var failures []string
+	var skipped []string
+	var taskIDs []int
+	for i, batch := range batches {
+		if len(failures) > 0 && stopOnFailure {
+			for _, b := range batches[i:] {
+				for _, dev := range b {
+					skipped = append(skipped, dev.name)
+				}
+			}
+			break
+		}
+
+		log.Printf("[INFO] Upgrading firmware of batch %d/%d: %s", i+1, len(batches), firmwareUpgradeNames(batch))
+
+		id, task, err := upgradeFirmwareBatch(ctx, c, d, adom, batch)
+		if id != 0 {
+			summary.ID = id
+			taskIDs = append(taskIDs, id)
+		}
+		if task != nil {
+			summary.Lines = append(summary.Lines, task.Lines...)
+		}
+
+		if ctx.Err() == context.Canceled {
+			summary.State = "aborted"
+			setFirmwareUpgradeAttributes(d, summary, taskIDs, len(devices), firmwareUpgradeRemaining(batches[i+1:]))
+			return diag.Errorf("Error updating DeviceFirmwareUpgrade resource: interrupted during batch %d/%d, batches not started: %s\n%v",
+				i+1, len(batches), firmwareUpgradeRemainingNames(batches[i+1:]), err)
+		}
+
+		if err != nil {
+			summary.State = "error"
+			failures = append(failures, fmt.Sprintf("batch %d/%d: %v", i+1, len(batches), err))
+		}
+	}
+
+	setFirmwareUpgradeAttributes(d, summary, taskIDs, len(devices), skipped)
+
+	if len(failures) > 0 {
+		message := strings.Join(failures, "\n")
+		if len(skipped) > 0 {
+			message += "\nstopped on failure, devices not upgraded: " + strings.Join(skipped, ", ")
+		}
+		return diag.Errorf("Error updating DeviceFirmwareUpgrade resource: %s", message)
+	}
+
+	return resourceDeviceFirmwareUpgradeRead(ctx, d, m)
+}
+
+// upgradeFirmwareBatch starts the upgrade of batch and waits for its task. It
+// returns the task id as soon as the upgrade has started, the task itself
+// once it could be read.
+func upgradeFirmwareBatch(ctx context.Context, c *FortiClient, d *schema.ResourceData, adom string, batch []firmwareUpgradeDevice) (int, *Task, error) {
+	devices := make([]map[string]interface{}, 0, len(batch))
+	for _, dev := range batch {
+		v := map[string]interface{}{
+			"name": dev.name,
+		}
+		if dev.vdom != "" {
+			v["vdom"] = dev.vdom
+		}
+		devices = append(devices, v)
+	}
+
+	image := map[string]interface{}{
+		"release": d.Get("image_release").(string),
+	}
+	if build := d.Get("image_build").(string); build != "" {
+		image["build"] = build
+	}
+
+	data := map[string]interface{}{
+		"adom":        adom,
+		"create_task": "enable",
+		"device":      devices,
+		"image":       image,
+	}
+	if flags := expandStringList(d.Get("flags").(*schema.Set).List()); len(flags) > 0 {
+		data["flags"] = flags
+	}
+
+	o, err := c.jsonrpc(ctx, "exec", "/um/image/upgrade", map[string]interface{}{
+		"data": data,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Without its task the result of the upgrade is unknown
+	output, _ := o.(map[string]interface{})
+	id := taskIDFromOutput(output)
+	if id == 0 {
+		return 0, nil, fmt.Errorf("FortiManager returned no task for the upgrade of %s, its result is unknown", firmwareUpgradeNames(batch))
+	}
+
+	task, err := c.WaitTask(ctx, id, execTimeout(d))
+	if err != nil {
+		return id, task, err
+	}
+
+	if task.failed() {
+		return id, task, taskError(task)
+	}
+
+	return id, task, nil
+}
+
+// firmwareUpgradeDevices returns the devices given by device and the members
+// of device_group, each device once
+func firmwareUpgradeDevices(ctx context.Context, c *FortiClient, d *schema.ResourceData, adom string) ([]firmwareUpgradeDevice, error) {
+	var devices []firmwareUpgradeDevice
+	seen := make(map[string]bool)
+
+	add := func(name, vdom string) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		devices = append(devices, firmwareUpgradeDevice{name: name, vdom: vdom})
+	}
+
+	for _, v := range d.Get("device").([]interface{}) {
+		if i, ok := v.(map[string]interface{}); ok {
+			add(fortiStringValue(i["name"]), fortiStringValue(i["vdom"]))
+		}
+	}
+
+	if group := d.Get("device_group").(string); group != "" {
+		members, err := dvmdbGroupMembers(ctx, c, adom, group)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range members {
+			add(name, "")
+		}
+	}
+
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no device to upgrade, set device or device_group")
+	}
+
+	return devices, nil
+}
+
+// firmwareUpgradeBatches splits devices in batches of size, 0 means one batch
+func firmwareUpgradeBatches(devices []firmwareUpgradeDevice, size int) [][]firmwareUpgradeDevice {
+	if size <= 0 || size > len(devices) {
+		size = len(devices)
+	}
+
+	var batches [][]firmwareUpgradeDevice
+	for len(devices) > 0 {
+		n := size
+		if n > len(devices) {
+			n = len(devices)
+		}
+		batches = append(batches, devices[:n])
+		devices = devices[n:]
+	}
+
+	return batches
+}
+
+func firmwareUpgradeNames(batch []firmwareUpgradeDevice) string {
+	names := make([]string, 0, len(batch))
+	for _, dev := range batch {
+		names = append(names, dev.name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func firmwareUpgradeRemaining(batches [][]firmwareUpgradeDevice) []string {
+	var names []string
+	for _, batch := range batches {
+		for _, dev := range batch {
+			names = append(names, dev.name)
+		}
+	}
+
+	return names
+}
+
+func firmwareUpgradeRemainingNames(batches [][]firmwareUpgradeDevice) string {
+	if len(batches) == 0 {
+		return "none"
+	}
+
+	return strings.Join(firmwareUpgradeRemaining(batches), ", ")
+}
+
+// setFirmwareUpgradeAttributes sets the task attributes from the results of
+// all batches, task_percent is the share of the devices upgraded
+func setFirmwareUpgradeAttributes(d *schema.ResourceData, summary *Task, taskIDs []int, total int, skipped []string) {
+	done := 0
+	for _, l := range summary.Lines {
+		if !l.failed() && (l.State == "done" || l.State == "warning" || l.Percent >= 100) {
+			done++
+		}
+	}
+
+	if total > 0 {
+		summary.Percent = done * 100 / total
+	}
+
+	setTaskAttributes(d, summary)
+	d.Set("task_ids", taskIDs)
+	d.Set("skipped_devices", skipped)
+}
+
+func resourceDeviceFirmwareUpgradeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+func resourceDeviceFirmwareUpgradeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
@@ -9,23 +9,31 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmCmdAddDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmCmdAddDeviceUpdate,
-		Read:   resourceDvmCmdAddDeviceRead,
-		Update: resourceDvmCmdAddDeviceUpdate,
-		Delete: resourceDvmCmdAddDeviceDelete,
+		CreateContext: resourceDvmCmdAddDeviceUpdate,
+		ReadContext:   resourceDvmCmdAddDeviceRead,
+		UpdateContext: resourceDvmCmdAddDeviceUpdate,
+		DeleteContext: resourceDvmCmdAddDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -146,14 +154,30 @@ func resourceDvmCmdAddDevice() *schema.Resource {
 				Optional: true,
 				Default:  "false",
 			},
+			"task_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_percent": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_results": taskResultsSchema(),
 		},
 	}
 }
 
-func resourceDvmCmdAddDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdAddDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Cancelling the wait for the task aborts it when Terraform is interrupted
+	ctx, cancel := m.(*FortiClient).withStop(ctx)
+	defer cancel()
+
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	deviceVersion, err := c.GetDeviceVersion()
 	if err != nil {
@@ -166,28 +190,31 @@ func resourceDvmCmdAddDeviceUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectDvmCmdAddDevice(d, deviceVersion)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdAddDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmCmdAddDevice resource while getting object: %v", err)
 	}
 
-	_, err = c.UpdateDvmCmdAddDevice(obj, mkey, paradict)
+	o, err := c.UpdateDvmCmdAddDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdAddDevice resource: %v", err)
+		return diag.Errorf("Error updating DvmCmdAddDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("DvmCmdAddDevice")
 
-	return resourceDvmCmdAddDeviceRead(d, m)
+	_, err = waitExecTask(ctx, m.(*FortiClient), d, o, m.(*FortiClient).cancelTask)
+	if err != nil {
+		return diag.Errorf("Error updating DvmCmdAddDevice resource: %v", err)
+	}
+
+	return resourceDvmCmdAddDeviceRead(ctx, d, m)
 }
 
-func resourceDvmCmdAddDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdAddDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceDvmCmdAddDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdAddDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmCmdDelDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmCmdDelDeviceUpdate,
-		Read:   resourceDvmCmdDelDeviceRead,
-		Update: resourceDvmCmdDelDeviceUpdate,
-		Delete: resourceDvmCmdDelDeviceDelete,
+		CreateContext: resourceDvmCmdDelDeviceUpdate,
+		ReadContext:   resourceDvmCmdDelDeviceRead,
+		UpdateContext: resourceDvmCmdDelDeviceUpdate,
+		DeleteContext: resourceDvmCmdDelDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -54,10 +56,9 @@ func resourceDvmCmdDelDevice() *schema.Resource {
 	}
 }
 
-func resourceDvmCmdDelDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdDelDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -65,28 +66,26 @@ func resourceDvmCmdDelDeviceUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectDvmCmdDelDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdDelDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmCmdDelDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmCmdDelDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdDelDevice resource: %v", err)
+		return diag.Errorf("Error updating DvmCmdDelDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("DvmCmdDelDevice")
 
-	return resourceDvmCmdDelDeviceRead(d, m)
+	return resourceDvmCmdDelDeviceRead(ctx, d, m)
 }
 
-func resourceDvmCmdDelDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdDelDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceDvmCmdDelDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdDelDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
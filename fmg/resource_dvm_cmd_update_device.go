@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmCmdUpdateDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmCmdUpdateDeviceUpdate,
-		Read:   resourceDvmCmdUpdateDeviceRead,
-		Update: resourceDvmCmdUpdateDeviceUpdate,
-		Delete: resourceDvmCmdUpdateDeviceDelete,
+		CreateContext: resourceDvmCmdUpdateDeviceUpdate,
+		ReadContext:   resourceDvmCmdUpdateDeviceRead,
+		UpdateContext: resourceDvmCmdUpdateDeviceUpdate,
+		DeleteContext: resourceDvmCmdUpdateDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -54,10 +56,9 @@ func resourceDvmCmdUpdateDevice() *schema.Resource {
 	}
 }
 
-func resourceDvmCmdUpdateDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdUpdateDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -65,28 +66,26 @@ func resourceDvmCmdUpdateDeviceUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectDvmCmdUpdateDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdUpdateDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmCmdUpdateDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmCmdUpdateDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmCmdUpdateDevice resource: %v", err)
+		return diag.Errorf("Error updating DvmCmdUpdateDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("DvmCmdUpdateDevice")
 
-	return resourceDvmCmdUpdateDeviceRead(d, m)
+	return resourceDvmCmdUpdateDeviceRead(ctx, d, m)
 }
 
-func resourceDvmCmdUpdateDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdUpdateDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceDvmCmdUpdateDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmCmdUpdateDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
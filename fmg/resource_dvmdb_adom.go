@@ -9,23 +9,32 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmdbAdom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmdbAdomCreate,
-		Read:   resourceDvmdbAdomRead,
-		Update: resourceDvmdbAdomUpdate,
-		Delete: resourceDvmdbAdomDelete,
+		CreateContext: resourceDvmdbAdomCreate,
+		ReadContext:   resourceDvmdbAdomRead,
+		UpdateContext: resourceDvmdbAdomUpdate,
+		DeleteContext: resourceDvmdbAdomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -126,9 +135,9 @@ func resourceDvmdbAdom() *schema.Resource {
 	}
 }
 
-func resourceDvmdbAdomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceDvmdbAdomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// FortiManager answers once the ADOM database has been created
+	c := m.(*FortiClient).sdk(ctx, withRequestTimeout(d.Timeout(schema.TimeoutCreate)))
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -136,24 +145,25 @@ func resourceDvmdbAdomCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectDvmdbAdom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbAdom resource while getting object: %v", err)
+		return diag.Errorf("Error creating DvmdbAdom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateDvmdbAdom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbAdom resource: %v", err)
+		return diag.Errorf("Error creating DvmdbAdom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbAdomRead(d, m)
+	return resourceDvmdbAdomRead(ctx, d, m)
 }
 
-func resourceDvmdbAdomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbAdomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	// FortiManager answers once the ADOM has been updated, e.g. upgraded to a
+	// new version, which takes longer than requestTimeout
+	c := m.(*FortiClient).sdk(ctx, withRequestTimeout(d.Timeout(schema.TimeoutUpdate)))
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -161,26 +171,24 @@ func resourceDvmdbAdomUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectDvmdbAdom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbAdom resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmdbAdom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmdbAdom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbAdom resource: %v", err)
+		return diag.Errorf("Error updating DvmdbAdom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbAdomRead(d, m)
+	return resourceDvmdbAdomRead(ctx, d, m)
 }
 
-func resourceDvmdbAdomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbAdomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	// FortiManager answers once the ADOM database has been deleted
+	c := m.(*FortiClient).sdk(ctx, withRequestTimeout(d.Timeout(schema.TimeoutDelete)))
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -188,7 +196,7 @@ func resourceDvmdbAdomDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteDvmdbAdom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting DvmdbAdom resource: %v", err)
+		return diag.Errorf("Error deleting DvmdbAdom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -196,11 +204,10 @@ func resourceDvmdbAdomDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDvmdbAdomRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbAdomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -208,18 +215,21 @@ func resourceDvmdbAdomRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadDvmdbAdom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbAdom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading DvmdbAdom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading DvmdbAdom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectDvmdbAdom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbAdom resource from API: %v", err)
+		return diag.Errorf("Error reading DvmdbAdom resource from API: %v", err)
 	}
 	return nil
 }
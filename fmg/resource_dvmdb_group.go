@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmdbGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmdbGroupCreate,
-		Read:   resourceDvmdbGroupRead,
-		Update: resourceDvmdbGroupUpdate,
-		Delete: resourceDvmdbGroupDelete,
+		CreateContext: resourceDvmdbGroupCreate,
+		ReadContext:   resourceDvmdbGroupRead,
+		UpdateContext: resourceDvmdbGroupUpdate,
+		DeleteContext: resourceDvmdbGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -76,81 +78,76 @@ func resourceDvmdbGroup() *schema.Resource {
 	}
 }
 
-func resourceDvmdbGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceDvmdbGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating DvmdbGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateDvmdbGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbGroup resource: %v", err)
+		return diag.Errorf("Error creating DvmdbGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbGroupRead(d, m)
+	return resourceDvmdbGroupRead(ctx, d, m)
 }
 
-func resourceDvmdbGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmdbGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmdbGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbGroup resource: %v", err)
+		return diag.Errorf("Error updating DvmdbGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbGroupRead(d, m)
+	return resourceDvmdbGroupRead(ctx, d, m)
 }
 
-func resourceDvmdbGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteDvmdbGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting DvmdbGroup resource: %v", err)
+		return diag.Errorf("Error deleting DvmdbGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -158,34 +155,36 @@ func resourceDvmdbGroupDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDvmdbGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadDvmdbGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading DvmdbGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading DvmdbGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectDvmdbGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbGroup resource from API: %v", err)
+		return diag.Errorf("Error reading DvmdbGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmdbRevision() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmdbRevisionCreate,
-		Read:   resourceDvmdbRevisionRead,
-		Update: resourceDvmdbRevisionUpdate,
-		Delete: resourceDvmdbRevisionDelete,
+		CreateContext: resourceDvmdbRevisionCreate,
+		ReadContext:   resourceDvmdbRevisionRead,
+		UpdateContext: resourceDvmdbRevisionUpdate,
+		DeleteContext: resourceDvmdbRevisionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -74,81 +76,76 @@ func resourceDvmdbRevision() *schema.Resource {
 	}
 }
 
-func resourceDvmdbRevisionCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceDvmdbRevisionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbRevision(d)
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbRevision resource while getting object: %v", err)
+		return diag.Errorf("Error creating DvmdbRevision resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateDvmdbRevision(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbRevision resource: %v", err)
+		return diag.Errorf("Error creating DvmdbRevision resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "version")))
 
-	return resourceDvmdbRevisionRead(d, m)
+	return resourceDvmdbRevisionRead(ctx, d, m)
 }
 
-func resourceDvmdbRevisionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbRevisionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbRevision(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbRevision resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmdbRevision resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmdbRevision(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbRevision resource: %v", err)
+		return diag.Errorf("Error updating DvmdbRevision resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "version")))
 
-	return resourceDvmdbRevisionRead(d, m)
+	return resourceDvmdbRevisionRead(ctx, d, m)
 }
 
-func resourceDvmdbRevisionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbRevisionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteDvmdbRevision(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting DvmdbRevision resource: %v", err)
+		return diag.Errorf("Error deleting DvmdbRevision resource: %v", err)
 	}
 
 	d.SetId("")
@@ -156,34 +153,36 @@ func resourceDvmdbRevisionDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDvmdbRevisionRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbRevisionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadDvmdbRevision(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbRevision resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading DvmdbRevision resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading DvmdbRevision resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectDvmdbRevision(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbRevision resource from API: %v", err)
+		return diag.Errorf("Error reading DvmdbRevision resource from API: %v", err)
 	}
 	return nil
 }
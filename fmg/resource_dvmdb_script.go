@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmdbScript() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmdbScriptCreate,
-		Read:   resourceDvmdbScriptRead,
-		Update: resourceDvmdbScriptUpdate,
-		Delete: resourceDvmdbScriptDelete,
+		CreateContext: resourceDvmdbScriptCreate,
+		ReadContext:   resourceDvmdbScriptRead,
+		UpdateContext: resourceDvmdbScriptUpdate,
+		DeleteContext: resourceDvmdbScriptDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -146,81 +148,76 @@ func resourceDvmdbScript() *schema.Resource {
 	}
 }
 
-func resourceDvmdbScriptCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceDvmdbScriptCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbScript(d)
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbScript resource while getting object: %v", err)
+		return diag.Errorf("Error creating DvmdbScript resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateDvmdbScript(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating DvmdbScript resource: %v", err)
+		return diag.Errorf("Error creating DvmdbScript resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbScriptRead(d, m)
+	return resourceDvmdbScriptRead(ctx, d, m)
 }
 
-func resourceDvmdbScriptUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbScript(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbScript resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmdbScript resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateDvmdbScript(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbScript resource: %v", err)
+		return diag.Errorf("Error updating DvmdbScript resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceDvmdbScriptRead(d, m)
+	return resourceDvmdbScriptRead(ctx, d, m)
 }
 
-func resourceDvmdbScriptDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteDvmdbScript(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting DvmdbScript resource: %v", err)
+		return diag.Errorf("Error deleting DvmdbScript resource: %v", err)
 	}
 
 	d.SetId("")
@@ -228,34 +225,36 @@ func resourceDvmdbScriptDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDvmdbScriptRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadDvmdbScript(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbScript resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading DvmdbScript resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading DvmdbScript resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectDvmdbScript(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading DvmdbScript resource from API: %v", err)
+		return diag.Errorf("Error reading DvmdbScript resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,31 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDvmdbScriptExecute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDvmdbScriptExecuteUpdate,
-		Read:   resourceDvmdbScriptExecuteRead,
-		Update: resourceDvmdbScriptExecuteUpdate,
-		Delete: resourceDvmdbScriptExecuteDelete,
+		CreateContext: resourceDvmdbScriptExecuteUpdate,
+		ReadContext:   resourceDvmdbScriptExecuteRead,
+		UpdateContext: resourceDvmdbScriptExecuteUpdate,
+		DeleteContext: resourceDvmdbScriptExecuteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -85,47 +93,66 @@ func resourceDvmdbScriptExecute() *schema.Resource {
 				Optional: true,
 				Default:  "false",
 			},
+			"task_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_percent": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_results": taskResultsSchema(),
 		},
 	}
 }
 
-func resourceDvmdbScriptExecuteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptExecuteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Cancelling the wait for the task aborts it when Terraform is interrupted
+	ctx, cancel := m.(*FortiClient).withStop(ctx)
+	defer cancel()
+
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectDvmdbScriptExecute(d)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbScriptExecute resource while getting object: %v", err)
+		return diag.Errorf("Error updating DvmdbScriptExecute resource while getting object: %v", err)
 	}
 
-	_, err = c.UpdateDvmdbScriptExecute(obj, mkey, paradict)
+	o, err := c.UpdateDvmdbScriptExecute(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating DvmdbScriptExecute resource: %v", err)
+		return diag.Errorf("Error updating DvmdbScriptExecute resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("DvmdbScriptExecute")
 
-	return resourceDvmdbScriptExecuteRead(d, m)
+	_, err = waitExecTask(ctx, m.(*FortiClient), d, o, m.(*FortiClient).cancelTask)
+	if err != nil {
+		return diag.Errorf("Error updating DvmdbScriptExecute resource: %v", err)
+	}
+
+	return resourceDvmdbScriptExecuteRead(ctx, d, m)
 }
 
-func resourceDvmdbScriptExecuteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptExecuteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceDvmdbScriptExecuteRead(d *schema.ResourceData, m interface{}) error {
+func resourceDvmdbScriptExecuteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,66 +9,79 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceExecWorkspaceAction() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceExecWorkspaceActionCreateUpdate,
-		Read:   schema.Noop, //resourceExecWorkspaceActionRead,
-		Update: resourceExecWorkspaceActionCreateUpdate,
-		Delete: resourceExecWorkspaceActionDelete, //schema.Noop,
-
-		Schema: map[string]*schema.Schema{
-			"scopetype": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "inherit",
-				ValidateFunc: validation.StringInSlice([]string{
-					"adom",
-					"global",
-					"inherit",
-				}, false),
-			},
-			"adom": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"action": &schema.Schema{
-				Type:     schema.TypeString, //lockbegin, lockend
-				Required: true,
-			},
-			"target": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"param": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
-			},
-			"force_recreate": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
-			},
-			"comment": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
+	s := map[string]*schema.Schema{
+		"scopetype": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "inherit",
+			ValidateFunc: validation.StringInSlice([]string{
+				"adom",
+				"global",
+				"inherit",
+			}, false),
+		},
+		"adom": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"action": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				"lockbegin",
+				"lockend",
+				"force_unlock",
+			}, false),
+		},
+		"target": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"param": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "",
+		},
+		"force_recreate": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Computed: true,
 		},
+		"comment": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	for k, v := range workspaceLockSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceExecWorkspaceActionCreateUpdate,
+		ReadContext:   resourceExecWorkspaceActionRead,
+		UpdateContext: resourceExecWorkspaceActionCreateUpdate,
+		DeleteContext: resourceExecWorkspaceActionDelete,
+
+		Schema: s,
 	}
 }
 
-func execMain(d *schema.ResourceData, m interface{}, action string) (string, error) {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func execMain(ctx context.Context, d *schema.ResourceData, m interface{}, action string) (string, error) {
+	c := m.(*FortiClient).sdk(ctx)
 
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
@@ -86,57 +99,134 @@ func execMain(d *schema.ResourceData, m interface{}, action string) (string, err
 	return idstr, err
 }
 
-func resourceExecWorkspaceActionCreateUpdate(d *schema.ResourceData, m interface{}) error {
+// forceUnlock unlocks the target of d without commit, the changes of the lock
+// owner are discarded. FortiManager only breaks the lock of another session
+// for super_user administrators, a lock still held afterwards is reported
+// with its owner and session.
+func forceUnlock(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
+	adomv, err := adomChecking(m.(*FortiClient).Cfg, d)
+	if err != nil {
+		return "", err
+	}
+
+	// Objects have no lock information, they are unlocked as is
+	target, err := workspaceTargetFor(adomv, d.Get("target").(string), d.Get("param").(string))
+	if err != nil {
+		return execMain(ctx, d, m, "unlock")
+	}
+
+	locks, err := m.(*FortiClient).workspaceLocks(ctx, target)
+	if err != nil {
+		return "", fmt.Errorf("cannot read the lock information of %s: %v", target, err)
+	}
+
+	idstr, err := execMain(ctx, d, m, "unlock")
+	if err != nil {
+		if len(locks) == 0 {
+			return "", err
+		}
+		return "", fmt.Errorf("cannot unlock %s %s: %v", target, workspaceLockList(locks), err)
+	}
+
+	locks, err = m.(*FortiClient).workspaceLocks(ctx, target)
+	if err != nil {
+		return "", fmt.Errorf("cannot read the lock information of %s: %v", target, err)
+	}
+
+	if len(locks) > 0 {
+		return "", fmt.Errorf("%s is still %s: FortiManager only breaks the lock of another session for super_user administrators, "+
+			"use one or end that session on FortiManager", target, workspaceLockList(locks))
+	}
+
+	return idstr, nil
+}
+
+func resourceExecWorkspaceActionCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	action := d.Get("action").(string)
 
 	if action == "lockbegin" {
-		idstr, err := execMain(d, m, "lock")
+		idstr, err := execMain(ctx, d, m, "lock")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
 
 		d.SetId(idstr)
 	} else if action == "lockend" {
-		_, err := execMain(d, m, "commit")
+		_, err := execMain(ctx, d, m, "commit")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
-		idstr, err := execMain(d, m, "unlock")
+		idstr, err := execMain(ctx, d, m, "unlock")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
 
 		d.SetId(idstr)
+	} else if action == "force_unlock" {
+		idstr, err := forceUnlock(ctx, d, m)
+		if err != nil {
+			return diag.Errorf("Error exec workspace action: %v", err)
+		}
+
+		d.SetId(strings.Replace(idstr, "unlock", "force_unlock", 1))
 	} else {
-		return fmt.Errorf("Unknown action: %v", action)
+		return diag.Errorf("Unknown action: %v", action)
+	}
+
+	return resourceExecWorkspaceActionRead(ctx, d, m)
+}
+
+func resourceExecWorkspaceActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	cfg := m.(*FortiClient).Cfg
+	adomv, err := adomChecking(cfg, d)
+	if err != nil {
+		return diag.Errorf("Error reading ExecWorkspaceAction resource: %v", err)
+	}
+
+	target, err := workspaceTargetFor(adomv, d.Get("target").(string), d.Get("param").(string))
+	if err != nil {
+		// No lock information for objects
+		log.Printf("[WARN] Cannot read the lock status of ExecWorkspaceAction resource: %v", err)
+		return nil
+	}
+
+	locks, err := m.(*FortiClient).workspaceLocks(ctx, target)
+	if err != nil {
+		return diag.Errorf("Error reading ExecWorkspaceAction resource lock status of %s: %v", target, err)
+	}
+
+	if err := setWorkspaceLockAttributes(d, locks); err != nil {
+		return diag.Errorf("Error reading ExecWorkspaceAction resource: %v", err)
 	}
 
 	return nil
 }
 
-func resourceExecWorkspaceActionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceExecWorkspaceActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	action := d.Get("action").(string)
 
 	if action == "lockbegin" {
-		_, err := execMain(d, m, "commit")
+		_, err := execMain(ctx, d, m, "commit")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
-		_, err = execMain(d, m, "unlock")
+		_, err = execMain(ctx, d, m, "unlock")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
 
 		d.SetId("")
 	} else if action == "lockend" {
-		_, err := execMain(d, m, "lock")
+		_, err := execMain(ctx, d, m, "lock")
 		if err != nil {
-			return fmt.Errorf("Error exec workspace action: %v", err)
+			return diag.Errorf("Error exec workspace action: %v", err)
 		}
 
+		d.SetId("")
+	} else if action == "force_unlock" {
 		d.SetId("")
 	} else {
-		return fmt.Errorf("Unknown action: %v", action)
+		return diag.Errorf("Unknown action: %v", action)
 	}
 
 	return nil
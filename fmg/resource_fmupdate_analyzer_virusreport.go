@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateAnalyzerVirusreport() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateAnalyzerVirusreportUpdate,
-		Read:   resourceFmupdateAnalyzerVirusreportRead,
-		Update: resourceFmupdateAnalyzerVirusreportUpdate,
-		Delete: resourceFmupdateAnalyzerVirusreportDelete,
+		CreateContext: resourceFmupdateAnalyzerVirusreportUpdate,
+		ReadContext:   resourceFmupdateAnalyzerVirusreportRead,
+		UpdateContext: resourceFmupdateAnalyzerVirusreportUpdate,
+		DeleteContext: resourceFmupdateAnalyzerVirusreportDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceFmupdateAnalyzerVirusreport() *schema.Resource {
 	}
 }
 
-func resourceFmupdateAnalyzerVirusreportUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAnalyzerVirusreportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceFmupdateAnalyzerVirusreportUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectFmupdateAnalyzerVirusreport(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAnalyzerVirusreport resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateAnalyzerVirusreport resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateAnalyzerVirusreport(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAnalyzerVirusreport resource: %v", err)
+		return diag.Errorf("Error updating FmupdateAnalyzerVirusreport resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateAnalyzerVirusreport")
 
-	return resourceFmupdateAnalyzerVirusreportRead(d, m)
+	return resourceFmupdateAnalyzerVirusreportRead(ctx, d, m)
 }
 
-func resourceFmupdateAnalyzerVirusreportDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAnalyzerVirusreportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceFmupdateAnalyzerVirusreportDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteFmupdateAnalyzerVirusreport(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateAnalyzerVirusreport resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateAnalyzerVirusreport resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceFmupdateAnalyzerVirusreportDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceFmupdateAnalyzerVirusreportRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAnalyzerVirusreportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceFmupdateAnalyzerVirusreportRead(d *schema.ResourceData, m interface
 
 	o, err := c.ReadFmupdateAnalyzerVirusreport(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAnalyzerVirusreport resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateAnalyzerVirusreport resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateAnalyzerVirusreport resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateAnalyzerVirusreport(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAnalyzerVirusreport resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateAnalyzerVirusreport resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateAvIpsAdvancedLog() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateAvIpsAdvancedLogUpdate,
-		Read:   resourceFmupdateAvIpsAdvancedLogRead,
-		Update: resourceFmupdateAvIpsAdvancedLogUpdate,
-		Delete: resourceFmupdateAvIpsAdvancedLogDelete,
+		CreateContext: resourceFmupdateAvIpsAdvancedLogUpdate,
+		ReadContext:   resourceFmupdateAvIpsAdvancedLogRead,
+		UpdateContext: resourceFmupdateAvIpsAdvancedLogUpdate,
+		DeleteContext: resourceFmupdateAvIpsAdvancedLogDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,10 +45,9 @@ func resourceFmupdateAvIpsAdvancedLog() *schema.Resource {
 	}
 }
 
-func resourceFmupdateAvIpsAdvancedLogUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsAdvancedLogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -54,26 +55,23 @@ func resourceFmupdateAvIpsAdvancedLogUpdate(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectFmupdateAvIpsAdvancedLog(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAvIpsAdvancedLog resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateAvIpsAdvancedLog resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateAvIpsAdvancedLog(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAvIpsAdvancedLog resource: %v", err)
+		return diag.Errorf("Error updating FmupdateAvIpsAdvancedLog resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateAvIpsAdvancedLog")
 
-	return resourceFmupdateAvIpsAdvancedLogRead(d, m)
+	return resourceFmupdateAvIpsAdvancedLogRead(ctx, d, m)
 }
 
-func resourceFmupdateAvIpsAdvancedLogDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsAdvancedLogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,7 +79,7 @@ func resourceFmupdateAvIpsAdvancedLogDelete(d *schema.ResourceData, m interface{
 
 	err = c.DeleteFmupdateAvIpsAdvancedLog(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateAvIpsAdvancedLog resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateAvIpsAdvancedLog resource: %v", err)
 	}
 
 	d.SetId("")
@@ -89,11 +87,10 @@ func resourceFmupdateAvIpsAdvancedLogDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceFmupdateAvIpsAdvancedLogRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsAdvancedLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,18 +98,21 @@ func resourceFmupdateAvIpsAdvancedLogRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadFmupdateAvIpsAdvancedLog(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAvIpsAdvancedLog resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateAvIpsAdvancedLog resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateAvIpsAdvancedLog resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateAvIpsAdvancedLog(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAvIpsAdvancedLog resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateAvIpsAdvancedLog resource from API: %v", err)
 	}
 	return nil
 }
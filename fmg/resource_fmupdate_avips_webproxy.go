@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateAvIpsWebProxy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateAvIpsWebProxyUpdate,
-		Read:   resourceFmupdateAvIpsWebProxyRead,
-		Update: resourceFmupdateAvIpsWebProxyUpdate,
-		Delete: resourceFmupdateAvIpsWebProxyDelete,
+		CreateContext: resourceFmupdateAvIpsWebProxyUpdate,
+		ReadContext:   resourceFmupdateAvIpsWebProxyRead,
+		UpdateContext: resourceFmupdateAvIpsWebProxyUpdate,
+		DeleteContext: resourceFmupdateAvIpsWebProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,10 +66,9 @@ func resourceFmupdateAvIpsWebProxy() *schema.Resource {
 	}
 }
 
-func resourceFmupdateAvIpsWebProxyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsWebProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -75,26 +76,23 @@ func resourceFmupdateAvIpsWebProxyUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectFmupdateAvIpsWebProxy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAvIpsWebProxy resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateAvIpsWebProxy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateAvIpsWebProxy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateAvIpsWebProxy resource: %v", err)
+		return diag.Errorf("Error updating FmupdateAvIpsWebProxy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateAvIpsWebProxy")
 
-	return resourceFmupdateAvIpsWebProxyRead(d, m)
+	return resourceFmupdateAvIpsWebProxyRead(ctx, d, m)
 }
 
-func resourceFmupdateAvIpsWebProxyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsWebProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -102,7 +100,7 @@ func resourceFmupdateAvIpsWebProxyDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteFmupdateAvIpsWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateAvIpsWebProxy resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateAvIpsWebProxy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -110,11 +108,10 @@ func resourceFmupdateAvIpsWebProxyDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceFmupdateAvIpsWebProxyRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateAvIpsWebProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -122,18 +119,21 @@ func resourceFmupdateAvIpsWebProxyRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadFmupdateAvIpsWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAvIpsWebProxy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateAvIpsWebProxy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateAvIpsWebProxy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateAvIpsWebProxy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateAvIpsWebProxy resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateAvIpsWebProxy resource from API: %v", err)
 	}
 	return nil
 }
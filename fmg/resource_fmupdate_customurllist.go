@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateCustomUrlList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateCustomUrlListUpdate,
-		Read:   resourceFmupdateCustomUrlListRead,
-		Update: resourceFmupdateCustomUrlListUpdate,
-		Delete: resourceFmupdateCustomUrlListDelete,
+		CreateContext: resourceFmupdateCustomUrlListUpdate,
+		ReadContext:   resourceFmupdateCustomUrlListRead,
+		UpdateContext: resourceFmupdateCustomUrlListUpdate,
+		DeleteContext: resourceFmupdateCustomUrlListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -39,10 +41,9 @@ func resourceFmupdateCustomUrlList() *schema.Resource {
 	}
 }
 
-func resourceFmupdateCustomUrlListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateCustomUrlListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -50,26 +51,23 @@ func resourceFmupdateCustomUrlListUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectFmupdateCustomUrlList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateCustomUrlList resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateCustomUrlList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateCustomUrlList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateCustomUrlList resource: %v", err)
+		return diag.Errorf("Error updating FmupdateCustomUrlList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateCustomUrlList")
 
-	return resourceFmupdateCustomUrlListRead(d, m)
+	return resourceFmupdateCustomUrlListRead(ctx, d, m)
 }
 
-func resourceFmupdateCustomUrlListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateCustomUrlListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -77,7 +75,7 @@ func resourceFmupdateCustomUrlListDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteFmupdateCustomUrlList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateCustomUrlList resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateCustomUrlList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -85,11 +83,10 @@ func resourceFmupdateCustomUrlListDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceFmupdateCustomUrlListRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateCustomUrlListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -97,18 +94,21 @@ func resourceFmupdateCustomUrlListRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadFmupdateCustomUrlList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateCustomUrlList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateCustomUrlList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateCustomUrlList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateCustomUrlList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateCustomUrlList resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateCustomUrlList resource from API: %v", err)
 	}
 	return nil
 }
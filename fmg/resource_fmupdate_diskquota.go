@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateDiskQuota() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateDiskQuotaUpdate,
-		Read:   resourceFmupdateDiskQuotaRead,
-		Update: resourceFmupdateDiskQuotaUpdate,
-		Delete: resourceFmupdateDiskQuotaDelete,
+		CreateContext: resourceFmupdateDiskQuotaUpdate,
+		ReadContext:   resourceFmupdateDiskQuotaRead,
+		UpdateContext: resourceFmupdateDiskQuotaUpdate,
+		DeleteContext: resourceFmupdateDiskQuotaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceFmupdateDiskQuota() *schema.Resource {
 	}
 }
 
-func resourceFmupdateDiskQuotaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateDiskQuotaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceFmupdateDiskQuotaUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectFmupdateDiskQuota(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateDiskQuota resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateDiskQuota resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateDiskQuota(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateDiskQuota resource: %v", err)
+		return diag.Errorf("Error updating FmupdateDiskQuota resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateDiskQuota")
 
-	return resourceFmupdateDiskQuotaRead(d, m)
+	return resourceFmupdateDiskQuotaRead(ctx, d, m)
 }
 
-func resourceFmupdateDiskQuotaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateDiskQuotaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceFmupdateDiskQuotaDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteFmupdateDiskQuota(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateDiskQuota resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateDiskQuota resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceFmupdateDiskQuotaDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceFmupdateDiskQuotaRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateDiskQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceFmupdateDiskQuotaRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadFmupdateDiskQuota(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateDiskQuota resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateDiskQuota resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateDiskQuota resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateDiskQuota(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateDiskQuota resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateDiskQuota resource from API: %v", err)
 	}
 	return nil
 }
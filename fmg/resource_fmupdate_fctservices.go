@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFctServices() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFctServicesUpdate,
-		Read:   resourceFmupdateFctServicesRead,
-		Update: resourceFmupdateFctServicesUpdate,
-		Delete: resourceFmupdateFctServicesDelete,
+		CreateContext: resourceFmupdateFctServicesUpdate,
+		ReadContext:   resourceFmupdateFctServicesRead,
+		UpdateContext: resourceFmupdateFctServicesUpdate,
+		DeleteContext: resourceFmupdateFctServicesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,10 +45,9 @@ func resourceFmupdateFctServices() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFctServicesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFctServicesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -54,26 +55,23 @@ func resourceFmupdateFctServicesUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectFmupdateFctServices(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFctServices resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFctServices resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFctServices(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFctServices resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFctServices resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFctServices")
 
-	return resourceFmupdateFctServicesRead(d, m)
+	return resourceFmupdateFctServicesRead(ctx, d, m)
 }
 
-func resourceFmupdateFctServicesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFctServicesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,7 +79,7 @@ func resourceFmupdateFctServicesDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteFmupdateFctServices(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFctServices resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFctServices resource: %v", err)
 	}
 
 	d.SetId("")
@@ -89,11 +87,10 @@ func resourceFmupdateFctServicesDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceFmupdateFctServicesRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFctServicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,18 +98,21 @@ func resourceFmupdateFctServicesRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadFmupdateFctServices(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFctServices resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFctServices resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFctServices resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFctServices(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFctServices resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFctServices resource from API: %v", err)
 	}
 	return nil
 }
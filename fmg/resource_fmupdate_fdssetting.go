@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFdsSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFdsSettingUpdate,
-		Read:   resourceFmupdateFdsSettingRead,
-		Update: resourceFmupdateFdsSettingUpdate,
-		Delete: resourceFmupdateFdsSettingDelete,
+		CreateContext: resourceFmupdateFdsSettingUpdate,
+		ReadContext:   resourceFmupdateFdsSettingRead,
+		UpdateContext: resourceFmupdateFdsSettingUpdate,
+		DeleteContext: resourceFmupdateFdsSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -285,10 +287,9 @@ func resourceFmupdateFdsSetting() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFdsSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -296,26 +297,23 @@ func resourceFmupdateFdsSettingUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectFmupdateFdsSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFdsSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSetting resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFdsSetting")
 
-	return resourceFmupdateFdsSettingRead(d, m)
+	return resourceFmupdateFdsSettingRead(ctx, d, m)
 }
 
-func resourceFmupdateFdsSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -323,7 +321,7 @@ func resourceFmupdateFdsSettingDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteFmupdateFdsSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFdsSetting resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFdsSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -331,11 +329,10 @@ func resourceFmupdateFdsSettingDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceFmupdateFdsSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -343,18 +340,21 @@ func resourceFmupdateFdsSettingRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadFmupdateFdsSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFdsSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFdsSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFdsSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSetting resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFdsSetting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFdsSettingPushOverride() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFdsSettingPushOverrideUpdate,
-		Read:   resourceFmupdateFdsSettingPushOverrideRead,
-		Update: resourceFmupdateFdsSettingPushOverrideUpdate,
-		Delete: resourceFmupdateFdsSettingPushOverrideDelete,
+		CreateContext: resourceFmupdateFdsSettingPushOverrideUpdate,
+		ReadContext:   resourceFmupdateFdsSettingPushOverrideRead,
+		UpdateContext: resourceFmupdateFdsSettingPushOverrideUpdate,
+		DeleteContext: resourceFmupdateFdsSettingPushOverrideDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceFmupdateFdsSettingPushOverride() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFdsSettingPushOverrideUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceFmupdateFdsSettingPushOverrideUpdate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectFmupdateFdsSettingPushOverride(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingPushOverride resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingPushOverride resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFdsSettingPushOverride(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingPushOverride resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingPushOverride resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFdsSettingPushOverride")
 
-	return resourceFmupdateFdsSettingPushOverrideRead(d, m)
+	return resourceFmupdateFdsSettingPushOverrideRead(ctx, d, m)
 }
 
-func resourceFmupdateFdsSettingPushOverrideDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceFmupdateFdsSettingPushOverrideDelete(d *schema.ResourceData, m inte
 
 	err = c.DeleteFmupdateFdsSettingPushOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFdsSettingPushOverride resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFdsSettingPushOverride resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceFmupdateFdsSettingPushOverrideDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceFmupdateFdsSettingPushOverrideRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceFmupdateFdsSettingPushOverrideRead(d *schema.ResourceData, m interf
 
 	o, err := c.ReadFmupdateFdsSettingPushOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingPushOverride resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverride resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverride resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFdsSettingPushOverride(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingPushOverride resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverride resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFdsSettingPushOverrideToClient() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFdsSettingPushOverrideToClientUpdate,
-		Read:   resourceFmupdateFdsSettingPushOverrideToClientRead,
-		Update: resourceFmupdateFdsSettingPushOverrideToClientUpdate,
-		Delete: resourceFmupdateFdsSettingPushOverrideToClientDelete,
+		CreateContext: resourceFmupdateFdsSettingPushOverrideToClientUpdate,
+		ReadContext:   resourceFmupdateFdsSettingPushOverrideToClientRead,
+		UpdateContext: resourceFmupdateFdsSettingPushOverrideToClientUpdate,
+		DeleteContext: resourceFmupdateFdsSettingPushOverrideToClientDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -65,10 +67,9 @@ func resourceFmupdateFdsSettingPushOverrideToClient() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFdsSettingPushOverrideToClientUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideToClientUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,26 +77,23 @@ func resourceFmupdateFdsSettingPushOverrideToClientUpdate(d *schema.ResourceData
 
 	obj, err := getObjectFmupdateFdsSettingPushOverrideToClient(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingPushOverrideToClient resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingPushOverrideToClient resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFdsSettingPushOverrideToClient(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingPushOverrideToClient resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingPushOverrideToClient resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFdsSettingPushOverrideToClient")
 
-	return resourceFmupdateFdsSettingPushOverrideToClientRead(d, m)
+	return resourceFmupdateFdsSettingPushOverrideToClientRead(ctx, d, m)
 }
 
-func resourceFmupdateFdsSettingPushOverrideToClientDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideToClientDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -103,7 +101,7 @@ func resourceFmupdateFdsSettingPushOverrideToClientDelete(d *schema.ResourceData
 
 	err = c.DeleteFmupdateFdsSettingPushOverrideToClient(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFdsSettingPushOverrideToClient resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFdsSettingPushOverrideToClient resource: %v", err)
 	}
 
 	d.SetId("")
@@ -111,11 +109,10 @@ func resourceFmupdateFdsSettingPushOverrideToClientDelete(d *schema.ResourceData
 	return nil
 }
 
-func resourceFmupdateFdsSettingPushOverrideToClientRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingPushOverrideToClientRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -123,18 +120,21 @@ func resourceFmupdateFdsSettingPushOverrideToClientRead(d *schema.ResourceData,
 
 	o, err := c.ReadFmupdateFdsSettingPushOverrideToClient(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingPushOverrideToClient resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverrideToClient resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverrideToClient resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFdsSettingPushOverrideToClient(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingPushOverrideToClient resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFdsSettingPushOverrideToClient resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFdsSettingServerOverride() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFdsSettingServerOverrideUpdate,
-		Read:   resourceFmupdateFdsSettingServerOverrideRead,
-		Update: resourceFmupdateFdsSettingServerOverrideUpdate,
-		Delete: resourceFmupdateFdsSettingServerOverrideDelete,
+		CreateContext: resourceFmupdateFdsSettingServerOverrideUpdate,
+		ReadContext:   resourceFmupdateFdsSettingServerOverrideRead,
+		UpdateContext: resourceFmupdateFdsSettingServerOverrideUpdate,
+		DeleteContext: resourceFmupdateFdsSettingServerOverrideDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,10 +77,9 @@ func resourceFmupdateFdsSettingServerOverride() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFdsSettingServerOverrideUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingServerOverrideUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,26 +87,23 @@ func resourceFmupdateFdsSettingServerOverrideUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectFmupdateFdsSettingServerOverride(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingServerOverride resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingServerOverride resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFdsSettingServerOverride(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingServerOverride resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingServerOverride resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFdsSettingServerOverride")
 
-	return resourceFmupdateFdsSettingServerOverrideRead(d, m)
+	return resourceFmupdateFdsSettingServerOverrideRead(ctx, d, m)
 }
 
-func resourceFmupdateFdsSettingServerOverrideDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingServerOverrideDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -113,7 +111,7 @@ func resourceFmupdateFdsSettingServerOverrideDelete(d *schema.ResourceData, m in
 
 	err = c.DeleteFmupdateFdsSettingServerOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFdsSettingServerOverride resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFdsSettingServerOverride resource: %v", err)
 	}
 
 	d.SetId("")
@@ -121,11 +119,10 @@ func resourceFmupdateFdsSettingServerOverrideDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceFmupdateFdsSettingServerOverrideRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingServerOverrideRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -133,18 +130,21 @@ func resourceFmupdateFdsSettingServerOverrideRead(d *schema.ResourceData, m inte
 
 	o, err := c.ReadFmupdateFdsSettingServerOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingServerOverride resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFdsSettingServerOverride resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFdsSettingServerOverride resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFdsSettingServerOverride(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingServerOverride resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFdsSettingServerOverride resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFdsSettingUpdateSchedule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFdsSettingUpdateScheduleUpdate,
-		Read:   resourceFmupdateFdsSettingUpdateScheduleRead,
-		Update: resourceFmupdateFdsSettingUpdateScheduleUpdate,
-		Delete: resourceFmupdateFdsSettingUpdateScheduleDelete,
+		CreateContext: resourceFmupdateFdsSettingUpdateScheduleUpdate,
+		ReadContext:   resourceFmupdateFdsSettingUpdateScheduleRead,
+		UpdateContext: resourceFmupdateFdsSettingUpdateScheduleUpdate,
+		DeleteContext: resourceFmupdateFdsSettingUpdateScheduleDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -54,10 +56,9 @@ func resourceFmupdateFdsSettingUpdateSchedule() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFdsSettingUpdateScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingUpdateScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -65,26 +66,23 @@ func resourceFmupdateFdsSettingUpdateScheduleUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectFmupdateFdsSettingUpdateSchedule(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingUpdateSchedule resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingUpdateSchedule resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFdsSettingUpdateSchedule(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFdsSettingUpdateSchedule resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFdsSettingUpdateSchedule resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFdsSettingUpdateSchedule")
 
-	return resourceFmupdateFdsSettingUpdateScheduleRead(d, m)
+	return resourceFmupdateFdsSettingUpdateScheduleRead(ctx, d, m)
 }
 
-func resourceFmupdateFdsSettingUpdateScheduleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingUpdateScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -92,7 +90,7 @@ func resourceFmupdateFdsSettingUpdateScheduleDelete(d *schema.ResourceData, m in
 
 	err = c.DeleteFmupdateFdsSettingUpdateSchedule(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFdsSettingUpdateSchedule resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFdsSettingUpdateSchedule resource: %v", err)
 	}
 
 	d.SetId("")
@@ -100,11 +98,10 @@ func resourceFmupdateFdsSettingUpdateScheduleDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceFmupdateFdsSettingUpdateScheduleRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFdsSettingUpdateScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -112,18 +109,21 @@ func resourceFmupdateFdsSettingUpdateScheduleRead(d *schema.ResourceData, m inte
 
 	o, err := c.ReadFmupdateFdsSettingUpdateSchedule(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingUpdateSchedule resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFdsSettingUpdateSchedule resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFdsSettingUpdateSchedule resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFdsSettingUpdateSchedule(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFdsSettingUpdateSchedule resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFdsSettingUpdateSchedule resource from API: %v", err)
 	}
 	return nil
 }
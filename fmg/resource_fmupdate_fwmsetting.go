@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFwmSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFwmSettingUpdate,
-		Read:   resourceFmupdateFwmSettingRead,
-		Update: resourceFmupdateFwmSettingUpdate,
-		Delete: resourceFmupdateFwmSettingDelete,
+		CreateContext: resourceFmupdateFwmSettingUpdate,
+		ReadContext:   resourceFmupdateFwmSettingRead,
+		UpdateContext: resourceFmupdateFwmSettingUpdate,
+		DeleteContext: resourceFmupdateFwmSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -143,10 +145,9 @@ func resourceFmupdateFwmSetting() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFwmSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -154,26 +155,23 @@ func resourceFmupdateFwmSettingUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectFmupdateFwmSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFwmSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFwmSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFwmSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFwmSetting resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFwmSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFwmSetting")
 
-	return resourceFmupdateFwmSettingRead(d, m)
+	return resourceFmupdateFwmSettingRead(ctx, d, m)
 }
 
-func resourceFmupdateFwmSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -181,7 +179,7 @@ func resourceFmupdateFwmSettingDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteFmupdateFwmSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFwmSetting resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFwmSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,11 +187,10 @@ func resourceFmupdateFwmSettingDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceFmupdateFwmSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -201,18 +198,21 @@ func resourceFmupdateFwmSettingRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadFmupdateFwmSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFwmSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFwmSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFwmSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFwmSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFwmSetting resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFwmSetting resource from API: %v", err)
 	}
 	return nil
 }
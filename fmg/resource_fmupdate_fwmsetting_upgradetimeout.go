@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateFwmSettingUpgradeTimeout() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateFwmSettingUpgradeTimeoutUpdate,
-		Read:   resourceFmupdateFwmSettingUpgradeTimeoutRead,
-		Update: resourceFmupdateFwmSettingUpgradeTimeoutUpdate,
-		Delete: resourceFmupdateFwmSettingUpgradeTimeoutDelete,
+		CreateContext: resourceFmupdateFwmSettingUpgradeTimeoutUpdate,
+		ReadContext:   resourceFmupdateFwmSettingUpgradeTimeoutRead,
+		UpdateContext: resourceFmupdateFwmSettingUpgradeTimeoutUpdate,
+		DeleteContext: resourceFmupdateFwmSettingUpgradeTimeoutDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -98,10 +100,9 @@ func resourceFmupdateFwmSettingUpgradeTimeout() *schema.Resource {
 	}
 }
 
-func resourceFmupdateFwmSettingUpgradeTimeoutUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingUpgradeTimeoutUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -109,26 +110,23 @@ func resourceFmupdateFwmSettingUpgradeTimeoutUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectFmupdateFwmSettingUpgradeTimeout(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFwmSettingUpgradeTimeout resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateFwmSettingUpgradeTimeout resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateFwmSettingUpgradeTimeout(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateFwmSettingUpgradeTimeout resource: %v", err)
+		return diag.Errorf("Error updating FmupdateFwmSettingUpgradeTimeout resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateFwmSettingUpgradeTimeout")
 
-	return resourceFmupdateFwmSettingUpgradeTimeoutRead(d, m)
+	return resourceFmupdateFwmSettingUpgradeTimeoutRead(ctx, d, m)
 }
 
-func resourceFmupdateFwmSettingUpgradeTimeoutDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingUpgradeTimeoutDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -136,7 +134,7 @@ func resourceFmupdateFwmSettingUpgradeTimeoutDelete(d *schema.ResourceData, m in
 
 	err = c.DeleteFmupdateFwmSettingUpgradeTimeout(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateFwmSettingUpgradeTimeout resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateFwmSettingUpgradeTimeout resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,11 +142,10 @@ func resourceFmupdateFwmSettingUpgradeTimeoutDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceFmupdateFwmSettingUpgradeTimeoutRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateFwmSettingUpgradeTimeoutRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -156,18 +153,21 @@ func resourceFmupdateFwmSettingUpgradeTimeoutRead(d *schema.ResourceData, m inte
 
 	o, err := c.ReadFmupdateFwmSettingUpgradeTimeout(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFwmSettingUpgradeTimeout resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateFwmSettingUpgradeTimeout resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateFwmSettingUpgradeTimeout resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateFwmSettingUpgradeTimeout(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateFwmSettingUpgradeTimeout resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateFwmSettingUpgradeTimeout resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateMultilayer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateMultilayerUpdate,
-		Read:   resourceFmupdateMultilayerRead,
-		Update: resourceFmupdateMultilayerUpdate,
-		Delete: resourceFmupdateMultilayerDelete,
+		CreateContext: resourceFmupdateMultilayerUpdate,
+		ReadContext:   resourceFmupdateMultilayerRead,
+		UpdateContext: resourceFmupdateMultilayerUpdate,
+		DeleteContext: resourceFmupdateMultilayerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceFmupdateMultilayer() *schema.Resource {
 	}
 }
 
-func resourceFmupdateMultilayerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateMultilayerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceFmupdateMultilayerUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectFmupdateMultilayer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateMultilayer resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateMultilayer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateMultilayer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateMultilayer resource: %v", err)
+		return diag.Errorf("Error updating FmupdateMultilayer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateMultilayer")
 
-	return resourceFmupdateMultilayerRead(d, m)
+	return resourceFmupdateMultilayerRead(ctx, d, m)
 }
 
-func resourceFmupdateMultilayerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateMultilayerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceFmupdateMultilayerDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteFmupdateMultilayer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateMultilayer resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateMultilayer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceFmupdateMultilayerDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceFmupdateMultilayerRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateMultilayerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceFmupdateMultilayerRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadFmupdateMultilayer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateMultilayer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateMultilayer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateMultilayer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateMultilayer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateMultilayer resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateMultilayer resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdatePublicnetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdatePublicnetworkUpdate,
-		Read:   resourceFmupdatePublicnetworkRead,
-		Update: resourceFmupdatePublicnetworkUpdate,
-		Delete: resourceFmupdatePublicnetworkDelete,
+		CreateContext: resourceFmupdatePublicnetworkUpdate,
+		ReadContext:   resourceFmupdatePublicnetworkRead,
+		UpdateContext: resourceFmupdatePublicnetworkUpdate,
+		DeleteContext: resourceFmupdatePublicnetworkDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceFmupdatePublicnetwork() *schema.Resource {
 	}
 }
 
-func resourceFmupdatePublicnetworkUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdatePublicnetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceFmupdatePublicnetworkUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectFmupdatePublicnetwork(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdatePublicnetwork resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdatePublicnetwork resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdatePublicnetwork(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdatePublicnetwork resource: %v", err)
+		return diag.Errorf("Error updating FmupdatePublicnetwork resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdatePublicnetwork")
 
-	return resourceFmupdatePublicnetworkRead(d, m)
+	return resourceFmupdatePublicnetworkRead(ctx, d, m)
 }
 
-func resourceFmupdatePublicnetworkDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdatePublicnetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceFmupdatePublicnetworkDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteFmupdatePublicnetwork(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdatePublicnetwork resource: %v", err)
+		return diag.Errorf("Error deleting FmupdatePublicnetwork resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceFmupdatePublicnetworkDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceFmupdatePublicnetworkRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdatePublicnetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceFmupdatePublicnetworkRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadFmupdatePublicnetwork(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdatePublicnetwork resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdatePublicnetwork resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdatePublicnetwork resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdatePublicnetwork(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdatePublicnetwork resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdatePublicnetwork resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateServerAccessPriorities() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateServerAccessPrioritiesUpdate,
-		Read:   resourceFmupdateServerAccessPrioritiesRead,
-		Update: resourceFmupdateServerAccessPrioritiesUpdate,
-		Delete: resourceFmupdateServerAccessPrioritiesDelete,
+		CreateContext: resourceFmupdateServerAccessPrioritiesUpdate,
+		ReadContext:   resourceFmupdateServerAccessPrioritiesRead,
+		UpdateContext: resourceFmupdateServerAccessPrioritiesUpdate,
+		DeleteContext: resourceFmupdateServerAccessPrioritiesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,10 +82,9 @@ func resourceFmupdateServerAccessPriorities() *schema.Resource {
 	}
 }
 
-func resourceFmupdateServerAccessPrioritiesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerAccessPrioritiesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,26 +92,23 @@ func resourceFmupdateServerAccessPrioritiesUpdate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectFmupdateServerAccessPriorities(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateServerAccessPriorities resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateServerAccessPriorities resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateServerAccessPriorities(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateServerAccessPriorities resource: %v", err)
+		return diag.Errorf("Error updating FmupdateServerAccessPriorities resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateServerAccessPriorities")
 
-	return resourceFmupdateServerAccessPrioritiesRead(d, m)
+	return resourceFmupdateServerAccessPrioritiesRead(ctx, d, m)
 }
 
-func resourceFmupdateServerAccessPrioritiesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerAccessPrioritiesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -118,7 +116,7 @@ func resourceFmupdateServerAccessPrioritiesDelete(d *schema.ResourceData, m inte
 
 	err = c.DeleteFmupdateServerAccessPriorities(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateServerAccessPriorities resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateServerAccessPriorities resource: %v", err)
 	}
 
 	d.SetId("")
@@ -126,11 +124,10 @@ func resourceFmupdateServerAccessPrioritiesDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceFmupdateServerAccessPrioritiesRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerAccessPrioritiesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -138,18 +135,21 @@ func resourceFmupdateServerAccessPrioritiesRead(d *schema.ResourceData, m interf
 
 	o, err := c.ReadFmupdateServerAccessPriorities(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateServerAccessPriorities resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateServerAccessPriorities resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateServerAccessPriorities resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateServerAccessPriorities(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateServerAccessPriorities resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateServerAccessPriorities resource from API: %v", err)
 	}
 	return nil
 }
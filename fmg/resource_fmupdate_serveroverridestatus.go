@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateServerOverrideStatus() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateServerOverrideStatusUpdate,
-		Read:   resourceFmupdateServerOverrideStatusRead,
-		Update: resourceFmupdateServerOverrideStatusUpdate,
-		Delete: resourceFmupdateServerOverrideStatusDelete,
+		CreateContext: resourceFmupdateServerOverrideStatusUpdate,
+		ReadContext:   resourceFmupdateServerOverrideStatusRead,
+		UpdateContext: resourceFmupdateServerOverrideStatusUpdate,
+		DeleteContext: resourceFmupdateServerOverrideStatusDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceFmupdateServerOverrideStatus() *schema.Resource {
 	}
 }
 
-func resourceFmupdateServerOverrideStatusUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerOverrideStatusUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceFmupdateServerOverrideStatusUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectFmupdateServerOverrideStatus(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateServerOverrideStatus resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateServerOverrideStatus resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateServerOverrideStatus(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateServerOverrideStatus resource: %v", err)
+		return diag.Errorf("Error updating FmupdateServerOverrideStatus resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateServerOverrideStatus")
 
-	return resourceFmupdateServerOverrideStatusRead(d, m)
+	return resourceFmupdateServerOverrideStatusRead(ctx, d, m)
 }
 
-func resourceFmupdateServerOverrideStatusDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerOverrideStatusDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceFmupdateServerOverrideStatusDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteFmupdateServerOverrideStatus(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateServerOverrideStatus resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateServerOverrideStatus resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceFmupdateServerOverrideStatusDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceFmupdateServerOverrideStatusRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServerOverrideStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceFmupdateServerOverrideStatusRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadFmupdateServerOverrideStatus(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateServerOverrideStatus resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateServerOverrideStatus resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateServerOverrideStatus resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateServerOverrideStatus(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateServerOverrideStatus resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateServerOverrideStatus resource from API: %v", err)
 	}
 	return nil
 }
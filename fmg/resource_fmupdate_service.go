@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateServiceUpdate,
-		Read:   resourceFmupdateServiceRead,
-		Update: resourceFmupdateServiceUpdate,
-		Delete: resourceFmupdateServiceDelete,
+		CreateContext: resourceFmupdateServiceUpdate,
+		ReadContext:   resourceFmupdateServiceRead,
+		UpdateContext: resourceFmupdateServiceUpdate,
+		DeleteContext: resourceFmupdateServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,10 +75,9 @@ func resourceFmupdateService() *schema.Resource {
 	}
 }
 
-func resourceFmupdateServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -84,26 +85,23 @@ func resourceFmupdateServiceUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectFmupdateService(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateService resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateService resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateService(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateService resource: %v", err)
+		return diag.Errorf("Error updating FmupdateService resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateService")
 
-	return resourceFmupdateServiceRead(d, m)
+	return resourceFmupdateServiceRead(ctx, d, m)
 }
 
-func resourceFmupdateServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,7 +109,7 @@ func resourceFmupdateServiceDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteFmupdateService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateService resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateService resource: %v", err)
 	}
 
 	d.SetId("")
@@ -119,11 +117,10 @@ func resourceFmupdateServiceDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceFmupdateServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -131,18 +128,21 @@ func resourceFmupdateServiceRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadFmupdateService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateService resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateService resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateService resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateService(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateService resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateService resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateWebSpamFgdSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateWebSpamFgdSettingUpdate,
-		Read:   resourceFmupdateWebSpamFgdSettingRead,
-		Update: resourceFmupdateWebSpamFgdSettingUpdate,
-		Delete: resourceFmupdateWebSpamFgdSettingDelete,
+		CreateContext: resourceFmupdateWebSpamFgdSettingUpdate,
+		ReadContext:   resourceFmupdateWebSpamFgdSettingRead,
+		UpdateContext: resourceFmupdateWebSpamFgdSettingUpdate,
+		DeleteContext: resourceFmupdateWebSpamFgdSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -260,10 +262,9 @@ func resourceFmupdateWebSpamFgdSetting() *schema.Resource {
 	}
 }
 
-func resourceFmupdateWebSpamFgdSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamFgdSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -271,26 +272,23 @@ func resourceFmupdateWebSpamFgdSettingUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectFmupdateWebSpamFgdSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateWebSpamFgdSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateWebSpamFgdSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateWebSpamFgdSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateWebSpamFgdSetting resource: %v", err)
+		return diag.Errorf("Error updating FmupdateWebSpamFgdSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateWebSpamFgdSetting")
 
-	return resourceFmupdateWebSpamFgdSettingRead(d, m)
+	return resourceFmupdateWebSpamFgdSettingRead(ctx, d, m)
 }
 
-func resourceFmupdateWebSpamFgdSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamFgdSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -298,7 +296,7 @@ func resourceFmupdateWebSpamFgdSettingDelete(d *schema.ResourceData, m interface
 
 	err = c.DeleteFmupdateWebSpamFgdSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateWebSpamFgdSetting resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateWebSpamFgdSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -306,11 +304,10 @@ func resourceFmupdateWebSpamFgdSettingDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceFmupdateWebSpamFgdSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamFgdSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -318,18 +315,21 @@ func resourceFmupdateWebSpamFgdSettingRead(d *schema.ResourceData, m interface{}
 
 	o, err := c.ReadFmupdateWebSpamFgdSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateWebSpamFgdSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateWebSpamFgdSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateWebSpamFgdSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateWebSpamFgdSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateWebSpamFgdSetting resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateWebSpamFgdSetting resource from API: %v", err)
 	}
 	return nil
 }
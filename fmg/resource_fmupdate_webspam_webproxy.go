@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFmupdateWebSpamWebProxy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFmupdateWebSpamWebProxyUpdate,
-		Read:   resourceFmupdateWebSpamWebProxyRead,
-		Update: resourceFmupdateWebSpamWebProxyUpdate,
-		Delete: resourceFmupdateWebSpamWebProxyDelete,
+		CreateContext: resourceFmupdateWebSpamWebProxyUpdate,
+		ReadContext:   resourceFmupdateWebSpamWebProxyRead,
+		UpdateContext: resourceFmupdateWebSpamWebProxyUpdate,
+		DeleteContext: resourceFmupdateWebSpamWebProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,10 +65,9 @@ func resourceFmupdateWebSpamWebProxy() *schema.Resource {
 	}
 }
 
-func resourceFmupdateWebSpamWebProxyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamWebProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -74,26 +75,23 @@ func resourceFmupdateWebSpamWebProxyUpdate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectFmupdateWebSpamWebProxy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateWebSpamWebProxy resource while getting object: %v", err)
+		return diag.Errorf("Error updating FmupdateWebSpamWebProxy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateFmupdateWebSpamWebProxy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating FmupdateWebSpamWebProxy resource: %v", err)
+		return diag.Errorf("Error updating FmupdateWebSpamWebProxy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("FmupdateWebSpamWebProxy")
 
-	return resourceFmupdateWebSpamWebProxyRead(d, m)
+	return resourceFmupdateWebSpamWebProxyRead(ctx, d, m)
 }
 
-func resourceFmupdateWebSpamWebProxyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamWebProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,7 +99,7 @@ func resourceFmupdateWebSpamWebProxyDelete(d *schema.ResourceData, m interface{}
 
 	err = c.DeleteFmupdateWebSpamWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting FmupdateWebSpamWebProxy resource: %v", err)
+		return diag.Errorf("Error deleting FmupdateWebSpamWebProxy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -109,11 +107,10 @@ func resourceFmupdateWebSpamWebProxyDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceFmupdateWebSpamWebProxyRead(d *schema.ResourceData, m interface{}) error {
+func resourceFmupdateWebSpamWebProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -121,18 +118,21 @@ func resourceFmupdateWebSpamWebProxyRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadFmupdateWebSpamWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateWebSpamWebProxy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading FmupdateWebSpamWebProxy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading FmupdateWebSpamWebProxy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectFmupdateWebSpamWebProxy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading FmupdateWebSpamWebProxy resource from API: %v", err)
+		return diag.Errorf("Error reading FmupdateWebSpamWebProxy resource from API: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,427 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Manage any FortiManager object given by its URL and JSON body
+
+package fortimanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGenericObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGenericObjectCreate,
+		ReadContext:   resourceGenericObjectRead,
+		UpdateContext: resourceGenericObjectUpdate,
+		DeleteContext: resourceGenericObjectDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGenericObjectImport,
+		},
+
+		CustomizeDiff: resourceGenericObjectCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"scopetype": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "inherit",
+				ValidateFunc: validation.StringInSlice([]string{
+					"adom",
+					"global",
+					"inherit",
+				}, false),
+			},
+			"adom": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"url": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must start with /"),
+			},
+			"mkey": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"mkey_field": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "name",
+			},
+			"json_content": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateGenericObjectJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+			"ignore_fields": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"object_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// genericObjectIgnoredFields are never compared with the configuration:
+// FortiManager does not return secrets, or returns them encrypted
+var genericObjectIgnoredFields = []string{
+	"passwd",
+	"password",
+	"passphrase",
+	"psk",
+	"psksecret",
+	"secret",
+	"private-key",
+}
+
+// genericObjectIgnore returns the names of the fields ignored at any depth of
+// the object, the defaults and ignore_fields
+func genericObjectIgnore(d *schema.ResourceData) map[string]bool {
+	ignore := make(map[string]bool)
+	for _, k := range genericObjectIgnoredFields {
+		ignore[k] = true
+	}
+	for _, k := range d.Get("ignore_fields").([]interface{}) {
+		if k, ok := k.(string); ok {
+			ignore[k] = true
+		}
+	}
+
+	return ignore
+}
+
+// genericObjectVolatile reports whether the field k changes without any
+// configuration change, e.g. oid or _last-modified timestamp
+func genericObjectVolatile(k string) bool {
+	return k == "oid" || strings.HasPrefix(k, "_")
+}
+
+func validateGenericObjectJSON(v interface{}, k string) ([]string, []error) {
+	var o map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &o); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object: %v", k, err)}
+	}
+
+	return nil, nil
+}
+
+// genericObjectTableURL returns the url of the table holding the object. The
+// {adom} of template is replaced by the name of the ADOM, adom/{adom} by
+// global for the global scope.
+func genericObjectTableURL(template, adomv string) string {
+	if adomv == "global" {
+		template = strings.Replace(template, "adom/{adom}", "global", -1)
+	}
+
+	return strings.TrimSuffix(strings.Replace(template, "{adom}", strings.TrimPrefix(adomv, "adom/"), -1), "/")
+}
+
+// genericObjectURL returns the url of the object mkey of the table url, a /
+// in mkey is escaped as FortiManager expects
+func genericObjectURL(url, mkey string) string {
+	return url + "/" + strings.Replace(mkey, "/", "\\/", -1)
+}
+
+// genericObjectBody returns the JSON body of d with the mkey field
+func genericObjectBody(d *schema.ResourceData) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("json_content").(string)), &body); err != nil {
+		return nil, fmt.Errorf("invalid json_content: %v", err)
+	}
+
+	if field := d.Get("mkey_field").(string); field != "" {
+		if _, ok := body[field]; !ok {
+			body[field] = d.Get("mkey").(string)
+		}
+	}
+
+	return body, nil
+}
+
+func resourceGenericObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	adomv, err := adomChecking(c.Cfg, d)
+	if err != nil {
+		return diag.Errorf("Error adom configuration: %v", err)
+	}
+
+	body, err := genericObjectBody(d)
+	if err != nil {
+		return diag.Errorf("Error creating GenericObject resource: %v", err)
+	}
+
+	url := genericObjectTableURL(d.Get("url").(string), adomv)
+	_, err = c.jsonrpc(ctx, "add", url, map[string]interface{}{
+		"data": body,
+	})
+	if err != nil {
+		return diag.Errorf("Error creating GenericObject resource: %v", err)
+	}
+
+	d.SetId(genericObjectURL(url, d.Get("mkey").(string)))
+
+	return resourceGenericObjectRead(ctx, d, m)
+}
+
+func resourceGenericObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	body, err := genericObjectBody(d)
+	if err != nil {
+		return diag.Errorf("Error updating GenericObject resource: %v", err)
+	}
+
+	_, err = c.jsonrpc(ctx, "set", d.Id(), map[string]interface{}{
+		"data": body,
+	})
+	if err != nil {
+		return diag.Errorf("Error updating GenericObject resource: %v", err)
+	}
+
+	return resourceGenericObjectRead(ctx, d, m)
+}
+
+func resourceGenericObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	_, err := c.jsonrpc(ctx, "delete", d.Id(), nil)
+	if err != nil && !IsNotFound(err) {
+		return diag.Errorf("Error deleting GenericObject resource: %v", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceGenericObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient)
+
+	data, err := c.jsonrpc(ctx, "get", d.Id(), nil)
+	if err != nil {
+		if IsNotFound(err) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading GenericObject resource: %v", err)
+	}
+
+	o, ok := data.(map[string]interface{})
+	if !ok {
+		return diag.Errorf("Error reading GenericObject resource: no data returned for %s", d.Id())
+	}
+
+	object, err := json.Marshal(o)
+	if err != nil {
+		return diag.Errorf("Error reading GenericObject resource: %v", err)
+	}
+
+	// Only the keys of json_content are compared. An imported object has
+	// all its keys but the ignored and volatile ones.
+	ignore := genericObjectIgnore(d)
+	state := genericObjectTrim(o, ignore)
+	if content := d.Get("json_content").(string); content != "" {
+		var want map[string]interface{}
+		if err := json.Unmarshal([]byte(content), &want); err == nil {
+			state = genericObjectState(want, o, ignore)
+		}
+	}
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return diag.Errorf("Error reading GenericObject resource: %v", err)
+	}
+
+	d.Set("json_content", string(content))
+	d.Set("object", string(object))
+	d.Set("object_url", d.Id())
+
+	return nil
+}
+
+// genericObjectState returns got restricted to the keys of want. Values equal
+// to want keep the form of want, e.g. "any" for ["any"] or "1" for 1, so that
+// only real changes show as drift. The ignored fields keep the value of want.
+func genericObjectState(want, got interface{}, ignore map[string]bool) interface{} {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return got
+		}
+
+		r := make(map[string]interface{}, len(w))
+		for k, v := range w {
+			if ignore[k] {
+				r[k] = v
+				continue
+			}
+			r[k] = genericObjectState(v, g[k], ignore)
+		}
+		return r
+
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			if len(w) == 1 && genericObjectScalarEqual(w[0], got) {
+				return want
+			}
+			return got
+		}
+
+		if len(g) != len(w) {
+			return got
+		}
+
+		r := make([]interface{}, len(w))
+		for i := range w {
+			r[i] = genericObjectState(w[i], g[i], ignore)
+		}
+		return r
+	}
+
+	if genericObjectScalarEqual(want, got) {
+		return want
+	}
+	if g, ok := got.([]interface{}); ok && len(g) == 1 && genericObjectScalarEqual(want, g[0]) {
+		return want
+	}
+
+	return got
+}
+
+// genericObjectTrim returns v without its ignored and volatile fields, at any
+// depth
+func genericObjectTrim(v interface{}, ignore map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		r := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if ignore[k] || genericObjectVolatile(k) {
+				continue
+			}
+			r[k] = genericObjectTrim(e, ignore)
+		}
+		return r
+
+	case []interface{}:
+		r := make([]interface{}, len(v))
+		for i, e := range v {
+			r[i] = genericObjectTrim(e, ignore)
+		}
+		return r
+	}
+
+	return v
+}
+
+func genericObjectScalarEqual(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	switch b.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
+
+// resourceGenericObjectCustomizeDiff replaces the object when url, mkey,
+// scopetype or adom designate another object
+func resourceGenericObjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || m == nil {
+		return nil
+	}
+
+	for _, k := range []string{"url", "mkey", "scopetype", "adom"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	adomv, err := adomValue(m.(*FortiClient).Cfg, d.Get("scopetype").(string), d.Get("adom").(string))
+	if err != nil {
+		return err
+	}
+
+	url := genericObjectURL(genericObjectTableURL(d.Get("url").(string), adomv), d.Get("mkey").(string))
+	if url == d.Id() {
+		return nil
+	}
+
+	if err := d.SetNew("object_url", url); err != nil {
+		return err
+	}
+
+	return d.ForceNew("object_url")
+}
+
+// genericObjectImportURL matches the ADOM of an object url
+var genericObjectImportURL = regexp.MustCompile(`/adom/([^/]+)/`)
+
+// resourceGenericObjectImport imports an object by its url, e.g.
+// /pm/config/adom/root/obj/firewall/address/myaddr
+func resourceGenericObjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+
+	// The mkey follows the last / that is not escaped
+	i := len(id) - 1
+	for ; i > 0; i-- {
+		if id[i] == '/' && id[i-1] != '\\' {
+			break
+		}
+	}
+	if i <= 0 || i == len(id)-1 {
+		return nil, fmt.Errorf("invalid import id %q, expected the url of the object, e.g. /pm/config/adom/root/obj/firewall/address/myaddr", id)
+	}
+
+	url := id[:i]
+	mkey := strings.Replace(id[i+1:], "\\/", "/", -1)
+
+	if match := genericObjectImportURL.FindStringSubmatchIndex(url); match != nil {
+		d.Set("scopetype", "adom")
+		d.Set("adom", url[match[2]:match[3]])
+		url = url[:match[2]] + "{adom}" + url[match[3]:]
+	} else if strings.Contains(url+"/", "/global/") {
+		d.Set("scopetype", "global")
+	}
+
+	d.Set("url", url)
+	d.Set("mkey", mkey)
+	d.Set("mkey_field", "name")
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,95 @@
+package fortimanager
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Address object recorded from FortiManager, psksecret is returned encrypted
+const recordedGenericObject = `{
+	"name": "webserver",
+	"type": 0,
+	"subnet": ["10.1.1.10", "255.255.255.255"],
+	"comment": "Managed by Terraform",
+	"psksecret": ["ENC", "eTUkAkwJe1Q="],
+	"oid": 3421,
+	"_last-modified timestamp": 1698051600,
+	"dynamic_mapping": [{"_scope": [{"name": "fgt1", "vdom": "root"}], "subnet": ["10.1.1.11", "255.255.255.255"], "oid": 3422}],
+	"tagging": [{"name": "env", "tags": "prod"}]
+}`
+
+func TestGenericObjectState(t *testing.T) {
+	ignore := map[string]bool{"psksecret": true, "comment": true}
+
+	cases := []struct {
+		name  string
+		want  string
+		state string
+	}{
+		{"equal", `{"name":"webserver","subnet":["10.1.1.10","255.255.255.255"]}`, `{"name":"webserver","subnet":["10.1.1.10","255.255.255.255"]}`},
+		{"only the keys of want", `{"name":"webserver"}`, `{"name":"webserver"}`},
+		{"number as string", `{"type":"0"}`, `{"type":"0"}`},
+		{"single value as list", `{"tagging":[{"name":"env","tags":["prod"]}]}`, `{"tagging":[{"name":"env","tags":["prod"]}]}`},
+		{"drift", `{"subnet":["10.1.1.20","255.255.255.255"]}`, `{"subnet":["10.1.1.10","255.255.255.255"]}`},
+		{"encrypted secret", `{"psksecret":"my-secret"}`, `{"psksecret":"my-secret"}`},
+		{"ignored field", `{"comment":"old comment"}`, `{"comment":"old comment"}`},
+		{"missing key", `{"color":3}`, `{"color":null}`},
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(recordedGenericObject), &object); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var want interface{}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			var state interface{}
+			if err := json.Unmarshal([]byte(tc.state), &state); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := mustMarshal(t, genericObjectState(want, object, ignore)); got != mustMarshal(t, state) {
+				t.Errorf("genericObjectState() = %s, want %s", got, tc.state)
+			}
+		})
+	}
+}
+
+func TestGenericObjectTrim(t *testing.T) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(recordedGenericObject), &object); err != nil {
+		t.Fatal(err)
+	}
+
+	got := mustMarshal(t, genericObjectTrim(object, map[string]bool{"psksecret": true}))
+
+	var want interface{}
+	json.Unmarshal([]byte(`{
+		"name": "webserver",
+		"type": 0,
+		"subnet": ["10.1.1.10", "255.255.255.255"],
+		"comment": "Managed by Terraform",
+		"dynamic_mapping": [{"subnet": ["10.1.1.11", "255.255.255.255"]}],
+		"tagging": [{"name": "env", "tags": "prod"}]
+	}`), &want)
+
+	if got != mustMarshal(t, want) {
+		t.Errorf("genericObjectTrim() = %s, want %s", got, mustMarshal(t, want))
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
@@ -1,18 +1,19 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceJsonGenericAPI() *schema.Resource {
 	return &schema.Resource{
-		Create: createGeneric,
-		Update: updateGeneric,
-		Delete: deleteGeneric,
-		Read:   schema.Noop,
+		CreateContext: createGeneric,
+		UpdateContext: updateGeneric,
+		DeleteContext: deleteGeneric,
+		ReadContext:   schema.NoopContext,
 
 		Schema: map[string]*schema.Schema{
 			"json_content": &schema.Schema{
@@ -36,16 +37,15 @@ func resourceJsonGenericAPI() *schema.Resource {
 	}
 }
 
-func createGeneric(d *schema.ResourceData, m interface{}) error {
+func createGeneric(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	data := d.Get("json_content").(string)
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx, withRawResponse())
 
 	res, err := c.JsonGenericAPI(data)
 
 	if err != nil {
-		return fmt.Errorf("Error createGeneric: %v", err)
+		return diag.Errorf("Error createGeneric: %v", err)
 	}
 
 	d.Set("response", res)
@@ -55,17 +55,16 @@ func createGeneric(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func updateGeneric(d *schema.ResourceData, m interface{}) error {
+func updateGeneric(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 	data := d.Get("json_content").(string)
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx, withRawResponse())
 
 	res, err := c.JsonGenericAPI(data)
 
 	if err != nil {
-		return fmt.Errorf("Error updateGeneric: %v", err)
+		return diag.Errorf("Error updateGeneric: %v", err)
 	}
 
 	d.Set("response", res)
@@ -75,7 +74,7 @@ func updateGeneric(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func deleteGeneric(d *schema.ResourceData, m interface{}) error {
+func deleteGeneric(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
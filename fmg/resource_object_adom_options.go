@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectAdomOptions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectAdomOptionsUpdate,
-		Read:   resourceObjectAdomOptionsRead,
-		Update: resourceObjectAdomOptionsUpdate,
-		Delete: resourceObjectAdomOptionsDelete,
+		CreateContext: resourceObjectAdomOptionsUpdate,
+		ReadContext:   resourceObjectAdomOptionsRead,
+		UpdateContext: resourceObjectAdomOptionsUpdate,
+		DeleteContext: resourceObjectAdomOptionsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -61,53 +63,49 @@ func resourceObjectAdomOptions() *schema.Resource {
 	}
 }
 
-func resourceObjectAdomOptionsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAdomOptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAdomOptions(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAdomOptions resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectAdomOptions resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectAdomOptions(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAdomOptions resource: %v", err)
+		return diag.Errorf("Error updating ObjectAdomOptions resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectAdomOptions")
 
-	return resourceObjectAdomOptionsRead(d, m)
+	return resourceObjectAdomOptionsRead(ctx, d, m)
 }
 
-func resourceObjectAdomOptionsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAdomOptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectAdomOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectAdomOptions resource: %v", err)
+		return diag.Errorf("Error deleting ObjectAdomOptions resource: %v", err)
 	}
 
 	d.SetId("")
@@ -115,34 +113,36 @@ func resourceObjectAdomOptionsDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectAdomOptionsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAdomOptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectAdomOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAdomOptions resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectAdomOptions resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectAdomOptions resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectAdomOptions(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAdomOptions resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectAdomOptions resource from API: %v", err)
 	}
 	return nil
 }
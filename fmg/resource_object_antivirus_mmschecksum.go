@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectAntivirusMmsChecksum() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectAntivirusMmsChecksumCreate,
-		Read:   resourceObjectAntivirusMmsChecksumRead,
-		Update: resourceObjectAntivirusMmsChecksumUpdate,
-		Delete: resourceObjectAntivirusMmsChecksumDelete,
+		CreateContext: resourceObjectAntivirusMmsChecksumCreate,
+		ReadContext:   resourceObjectAntivirusMmsChecksumRead,
+		UpdateContext: resourceObjectAntivirusMmsChecksumUpdate,
+		DeleteContext: resourceObjectAntivirusMmsChecksumDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectAntivirusMmsChecksum() *schema.Resource {
 	}
 }
 
-func resourceObjectAntivirusMmsChecksumCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectAntivirusMmsChecksumCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusMmsChecksum(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusMmsChecksum resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusMmsChecksum resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectAntivirusMmsChecksum(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusMmsChecksum resource: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusMmsChecksum resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectAntivirusMmsChecksumRead(d, m)
+	return resourceObjectAntivirusMmsChecksumRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusMmsChecksumUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusMmsChecksumUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusMmsChecksum(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusMmsChecksum resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusMmsChecksum resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectAntivirusMmsChecksum(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusMmsChecksum resource: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusMmsChecksum resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectAntivirusMmsChecksumRead(d, m)
+	return resourceObjectAntivirusMmsChecksumRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusMmsChecksumDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusMmsChecksumDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectAntivirusMmsChecksum(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectAntivirusMmsChecksum resource: %v", err)
+		return diag.Errorf("Error deleting ObjectAntivirusMmsChecksum resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectAntivirusMmsChecksumDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectAntivirusMmsChecksumRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusMmsChecksumRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectAntivirusMmsChecksum(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusMmsChecksum resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectAntivirusMmsChecksum resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectAntivirusMmsChecksum resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectAntivirusMmsChecksum(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusMmsChecksum resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectAntivirusMmsChecksum resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectAntivirusNotification() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectAntivirusNotificationCreate,
-		Read:   resourceObjectAntivirusNotificationRead,
-		Update: resourceObjectAntivirusNotificationUpdate,
-		Delete: resourceObjectAntivirusNotificationDelete,
+		CreateContext: resourceObjectAntivirusNotificationCreate,
+		ReadContext:   resourceObjectAntivirusNotificationRead,
+		UpdateContext: resourceObjectAntivirusNotificationUpdate,
+		DeleteContext: resourceObjectAntivirusNotificationDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectAntivirusNotification() *schema.Resource {
 	}
 }
 
-func resourceObjectAntivirusNotificationCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectAntivirusNotificationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusNotification(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusNotification resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusNotification resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectAntivirusNotification(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusNotification resource: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusNotification resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectAntivirusNotificationRead(d, m)
+	return resourceObjectAntivirusNotificationRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusNotificationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusNotificationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusNotification(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusNotification resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusNotification resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectAntivirusNotification(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusNotification resource: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusNotification resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectAntivirusNotificationRead(d, m)
+	return resourceObjectAntivirusNotificationRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusNotificationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusNotificationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectAntivirusNotification(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectAntivirusNotification resource: %v", err)
+		return diag.Errorf("Error deleting ObjectAntivirusNotification resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectAntivirusNotificationDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectAntivirusNotificationRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusNotificationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectAntivirusNotification(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusNotification resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectAntivirusNotification resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectAntivirusNotification resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectAntivirusNotification(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusNotification resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectAntivirusNotification resource from API: %v", err)
 	}
 	return nil
 }
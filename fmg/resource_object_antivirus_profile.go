@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectAntivirusProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectAntivirusProfileCreate,
-		Read:   resourceObjectAntivirusProfileRead,
-		Update: resourceObjectAntivirusProfileUpdate,
-		Delete: resourceObjectAntivirusProfileDelete,
+		CreateContext: resourceObjectAntivirusProfileCreate,
+		ReadContext:   resourceObjectAntivirusProfileRead,
+		UpdateContext: resourceObjectAntivirusProfileUpdate,
+		DeleteContext: resourceObjectAntivirusProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1000,81 +1002,76 @@ func resourceObjectAntivirusProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectAntivirusProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectAntivirusProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectAntivirusProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAntivirusProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectAntivirusProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectAntivirusProfileRead(d, m)
+	return resourceObjectAntivirusProfileRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAntivirusProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectAntivirusProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAntivirusProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectAntivirusProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectAntivirusProfileRead(d, m)
+	return resourceObjectAntivirusProfileRead(ctx, d, m)
 }
 
-func resourceObjectAntivirusProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectAntivirusProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectAntivirusProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectAntivirusProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1082,34 +1079,36 @@ func resourceObjectAntivirusProfileDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectAntivirusProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAntivirusProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectAntivirusProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectAntivirusProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectAntivirusProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectAntivirusProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAntivirusProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectAntivirusProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectApplicationCategories() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectApplicationCategoriesCreate,
-		Read:   resourceObjectApplicationCategoriesRead,
-		Update: resourceObjectApplicationCategoriesUpdate,
-		Delete: resourceObjectApplicationCategoriesDelete,
+		CreateContext: resourceObjectApplicationCategoriesCreate,
+		ReadContext:   resourceObjectApplicationCategoriesRead,
+		UpdateContext: resourceObjectApplicationCategoriesUpdate,
+		DeleteContext: resourceObjectApplicationCategoriesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,81 +55,76 @@ func resourceObjectApplicationCategories() *schema.Resource {
 	}
 }
 
-func resourceObjectApplicationCategoriesCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectApplicationCategoriesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationCategories(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationCategories resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationCategories resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectApplicationCategories(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationCategories resource: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationCategories resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectApplicationCategoriesRead(d, m)
+	return resourceObjectApplicationCategoriesRead(ctx, d, m)
 }
 
-func resourceObjectApplicationCategoriesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCategoriesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationCategories(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationCategories resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationCategories resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectApplicationCategories(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationCategories resource: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationCategories resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectApplicationCategoriesRead(d, m)
+	return resourceObjectApplicationCategoriesRead(ctx, d, m)
 }
 
-func resourceObjectApplicationCategoriesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCategoriesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectApplicationCategories(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectApplicationCategories resource: %v", err)
+		return diag.Errorf("Error deleting ObjectApplicationCategories resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +132,36 @@ func resourceObjectApplicationCategoriesDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectApplicationCategoriesRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCategoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectApplicationCategories(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationCategories resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectApplicationCategories resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectApplicationCategories resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectApplicationCategories(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationCategories resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectApplicationCategories resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectApplicationCustom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectApplicationCustomCreate,
-		Read:   resourceObjectApplicationCustomRead,
-		Update: resourceObjectApplicationCustomUpdate,
-		Delete: resourceObjectApplicationCustomDelete,
+		CreateContext: resourceObjectApplicationCustomCreate,
+		ReadContext:   resourceObjectApplicationCustomRead,
+		UpdateContext: resourceObjectApplicationCustomUpdate,
+		DeleteContext: resourceObjectApplicationCustomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectApplicationCustom() *schema.Resource {
 	}
 }
 
-func resourceObjectApplicationCustomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectApplicationCustomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationCustom resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectApplicationCustom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationCustom resource: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationCustom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "tag"))
 
-	return resourceObjectApplicationCustomRead(d, m)
+	return resourceObjectApplicationCustomRead(ctx, d, m)
 }
 
-func resourceObjectApplicationCustomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCustomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationCustom resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectApplicationCustom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationCustom resource: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationCustom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "tag"))
 
-	return resourceObjectApplicationCustomRead(d, m)
+	return resourceObjectApplicationCustomRead(ctx, d, m)
 }
 
-func resourceObjectApplicationCustomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCustomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectApplicationCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectApplicationCustom resource: %v", err)
+		return diag.Errorf("Error deleting ObjectApplicationCustom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectApplicationCustomDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectApplicationCustomRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationCustomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectApplicationCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationCustom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectApplicationCustom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectApplicationCustom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectApplicationCustom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationCustom resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectApplicationCustom resource from API: %v", err)
 	}
 	return nil
 }
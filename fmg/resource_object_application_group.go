@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectApplicationGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectApplicationGroupCreate,
-		Read:   resourceObjectApplicationGroupRead,
-		Update: resourceObjectApplicationGroupUpdate,
-		Delete: resourceObjectApplicationGroupDelete,
+		CreateContext: resourceObjectApplicationGroupCreate,
+		ReadContext:   resourceObjectApplicationGroupRead,
+		UpdateContext: resourceObjectApplicationGroupUpdate,
+		DeleteContext: resourceObjectApplicationGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,81 +109,76 @@ func resourceObjectApplicationGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectApplicationGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectApplicationGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectApplicationGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectApplicationGroupRead(d, m)
+	return resourceObjectApplicationGroupRead(ctx, d, m)
 }
 
-func resourceObjectApplicationGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectApplicationGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectApplicationGroupRead(d, m)
+	return resourceObjectApplicationGroupRead(ctx, d, m)
 }
 
-func resourceObjectApplicationGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectApplicationGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectApplicationGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectApplicationGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,34 +186,36 @@ func resourceObjectApplicationGroupDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectApplicationGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectApplicationGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectApplicationGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectApplicationGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectApplicationGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectApplicationGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectApplicationList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectApplicationListCreate,
-		Read:   resourceObjectApplicationListRead,
-		Update: resourceObjectApplicationListUpdate,
-		Delete: resourceObjectApplicationListDelete,
+		CreateContext: resourceObjectApplicationListCreate,
+		ReadContext:   resourceObjectApplicationListRead,
+		UpdateContext: resourceObjectApplicationListUpdate,
+		DeleteContext: resourceObjectApplicationListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -333,81 +335,76 @@ func resourceObjectApplicationList() *schema.Resource {
 	}
 }
 
-func resourceObjectApplicationListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectApplicationListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectApplicationList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectApplicationList resource: %v", err)
+		return diag.Errorf("Error creating ObjectApplicationList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectApplicationListRead(d, m)
+	return resourceObjectApplicationListRead(ctx, d, m)
 }
 
-func resourceObjectApplicationListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectApplicationList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectApplicationList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectApplicationList resource: %v", err)
+		return diag.Errorf("Error updating ObjectApplicationList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectApplicationListRead(d, m)
+	return resourceObjectApplicationListRead(ctx, d, m)
 }
 
-func resourceObjectApplicationListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectApplicationList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectApplicationList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectApplicationList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -415,34 +412,36 @@ func resourceObjectApplicationListDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectApplicationListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectApplicationListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectApplicationList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectApplicationList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectApplicationList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectApplicationList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectApplicationList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectApplicationList resource from API: %v", err)
 	}
 	return nil
 }
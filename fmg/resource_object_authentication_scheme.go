@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectAuthenticationScheme() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectAuthenticationSchemeCreate,
-		Read:   resourceObjectAuthenticationSchemeRead,
-		Update: resourceObjectAuthenticationSchemeUpdate,
-		Delete: resourceObjectAuthenticationSchemeDelete,
+		CreateContext: resourceObjectAuthenticationSchemeCreate,
+		ReadContext:   resourceObjectAuthenticationSchemeRead,
+		UpdateContext: resourceObjectAuthenticationSchemeUpdate,
+		DeleteContext: resourceObjectAuthenticationSchemeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -113,81 +115,76 @@ func resourceObjectAuthenticationScheme() *schema.Resource {
 	}
 }
 
-func resourceObjectAuthenticationSchemeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectAuthenticationSchemeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAuthenticationScheme(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAuthenticationScheme resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectAuthenticationScheme resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectAuthenticationScheme(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectAuthenticationScheme resource: %v", err)
+		return diag.Errorf("Error creating ObjectAuthenticationScheme resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectAuthenticationSchemeRead(d, m)
+	return resourceObjectAuthenticationSchemeRead(ctx, d, m)
 }
 
-func resourceObjectAuthenticationSchemeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAuthenticationSchemeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectAuthenticationScheme(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAuthenticationScheme resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectAuthenticationScheme resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectAuthenticationScheme(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectAuthenticationScheme resource: %v", err)
+		return diag.Errorf("Error updating ObjectAuthenticationScheme resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectAuthenticationSchemeRead(d, m)
+	return resourceObjectAuthenticationSchemeRead(ctx, d, m)
 }
 
-func resourceObjectAuthenticationSchemeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAuthenticationSchemeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectAuthenticationScheme(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectAuthenticationScheme resource: %v", err)
+		return diag.Errorf("Error deleting ObjectAuthenticationScheme resource: %v", err)
 	}
 
 	d.SetId("")
@@ -195,34 +192,36 @@ func resourceObjectAuthenticationSchemeDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectAuthenticationSchemeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectAuthenticationSchemeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectAuthenticationScheme(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAuthenticationScheme resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectAuthenticationScheme resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectAuthenticationScheme resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectAuthenticationScheme(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectAuthenticationScheme resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectAuthenticationScheme resource from API: %v", err)
 	}
 	return nil
 }
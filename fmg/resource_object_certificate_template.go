@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCertificateTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCertificateTemplateCreate,
-		Read:   resourceObjectCertificateTemplateRead,
-		Update: resourceObjectCertificateTemplateUpdate,
-		Delete: resourceObjectCertificateTemplateDelete,
+		CreateContext: resourceObjectCertificateTemplateCreate,
+		ReadContext:   resourceObjectCertificateTemplateRead,
+		UpdateContext: resourceObjectCertificateTemplateUpdate,
+		DeleteContext: resourceObjectCertificateTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -129,81 +131,76 @@ func resourceObjectCertificateTemplate() *schema.Resource {
 	}
 }
 
-func resourceObjectCertificateTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCertificateTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCertificateTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCertificateTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCertificateTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCertificateTemplate(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCertificateTemplate resource: %v", err)
+		return diag.Errorf("Error creating ObjectCertificateTemplate resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCertificateTemplateRead(d, m)
+	return resourceObjectCertificateTemplateRead(ctx, d, m)
 }
 
-func resourceObjectCertificateTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCertificateTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCertificateTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCertificateTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCertificateTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCertificateTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCertificateTemplate resource: %v", err)
+		return diag.Errorf("Error updating ObjectCertificateTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCertificateTemplateRead(d, m)
+	return resourceObjectCertificateTemplateRead(ctx, d, m)
 }
 
-func resourceObjectCertificateTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCertificateTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCertificateTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCertificateTemplate resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCertificateTemplate resource: %v", err)
 	}
 
 	d.SetId("")
@@ -211,34 +208,36 @@ func resourceObjectCertificateTemplateDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectCertificateTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCertificateTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCertificateTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCertificateTemplate resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCertificateTemplate resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCertificateTemplate resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCertificateTemplate(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCertificateTemplate resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCertificateTemplate resource from API: %v", err)
 	}
 	return nil
 }
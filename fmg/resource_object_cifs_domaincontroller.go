@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCifsDomainController() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCifsDomainControllerCreate,
-		Read:   resourceObjectCifsDomainControllerRead,
-		Update: resourceObjectCifsDomainControllerUpdate,
-		Delete: resourceObjectCifsDomainControllerDelete,
+		CreateContext: resourceObjectCifsDomainControllerCreate,
+		ReadContext:   resourceObjectCifsDomainControllerRead,
+		UpdateContext: resourceObjectCifsDomainControllerUpdate,
+		DeleteContext: resourceObjectCifsDomainControllerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,81 +85,76 @@ func resourceObjectCifsDomainController() *schema.Resource {
 	}
 }
 
-func resourceObjectCifsDomainControllerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCifsDomainControllerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCifsDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCifsDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCifsDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCifsDomainController(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCifsDomainController resource: %v", err)
+		return diag.Errorf("Error creating ObjectCifsDomainController resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "server_name"))
 
-	return resourceObjectCifsDomainControllerRead(d, m)
+	return resourceObjectCifsDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectCifsDomainControllerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsDomainControllerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCifsDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCifsDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCifsDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCifsDomainController(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCifsDomainController resource: %v", err)
+		return diag.Errorf("Error updating ObjectCifsDomainController resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "server_name"))
 
-	return resourceObjectCifsDomainControllerRead(d, m)
+	return resourceObjectCifsDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectCifsDomainControllerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsDomainControllerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCifsDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCifsDomainController resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCifsDomainController resource: %v", err)
 	}
 
 	d.SetId("")
@@ -165,34 +162,36 @@ func resourceObjectCifsDomainControllerDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectCifsDomainControllerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsDomainControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCifsDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCifsDomainController resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCifsDomainController resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCifsDomainController resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCifsDomainController(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCifsDomainController resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCifsDomainController resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCifsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCifsProfileCreate,
-		Read:   resourceObjectCifsProfileRead,
-		Update: resourceObjectCifsProfileUpdate,
-		Delete: resourceObjectCifsProfileDelete,
+		CreateContext: resourceObjectCifsProfileCreate,
+		ReadContext:   resourceObjectCifsProfileRead,
+		UpdateContext: resourceObjectCifsProfileUpdate,
+		DeleteContext: resourceObjectCifsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -144,81 +146,76 @@ func resourceObjectCifsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectCifsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCifsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCifsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCifsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCifsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCifsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCifsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectCifsProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCifsProfileRead(d, m)
+	return resourceObjectCifsProfileRead(ctx, d, m)
 }
 
-func resourceObjectCifsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCifsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCifsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCifsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCifsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCifsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectCifsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCifsProfileRead(d, m)
+	return resourceObjectCifsProfileRead(ctx, d, m)
 }
 
-func resourceObjectCifsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCifsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCifsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCifsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -226,34 +223,36 @@ func resourceObjectCifsProfileDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectCifsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCifsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCifsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCifsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCifsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCifsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCifsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCifsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCifsProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCliTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCliTemplateCreate,
-		Read:   resourceObjectCliTemplateRead,
-		Update: resourceObjectCliTemplateUpdate,
-		Delete: resourceObjectCliTemplateDelete,
+		CreateContext: resourceObjectCliTemplateCreate,
+		ReadContext:   resourceObjectCliTemplateRead,
+		UpdateContext: resourceObjectCliTemplateUpdate,
+		DeleteContext: resourceObjectCliTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,81 +82,76 @@ func resourceObjectCliTemplate() *schema.Resource {
 	}
 }
 
-func resourceObjectCliTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCliTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCliTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCliTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCliTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCliTemplate(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCliTemplate resource: %v", err)
+		return diag.Errorf("Error creating ObjectCliTemplate resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCliTemplateRead(d, m)
+	return resourceObjectCliTemplateRead(ctx, d, m)
 }
 
-func resourceObjectCliTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCliTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCliTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCliTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCliTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCliTemplate resource: %v", err)
+		return diag.Errorf("Error updating ObjectCliTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCliTemplateRead(d, m)
+	return resourceObjectCliTemplateRead(ctx, d, m)
 }
 
-func resourceObjectCliTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCliTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCliTemplate resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCliTemplate resource: %v", err)
 	}
 
 	d.SetId("")
@@ -162,34 +159,36 @@ func resourceObjectCliTemplateDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectCliTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCliTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCliTemplate resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCliTemplate resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCliTemplate resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCliTemplate(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCliTemplate resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCliTemplate resource from API: %v", err)
 	}
 	return nil
 }
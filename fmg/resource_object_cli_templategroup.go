@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCliTemplateGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCliTemplateGroupCreate,
-		Read:   resourceObjectCliTemplateGroupRead,
-		Update: resourceObjectCliTemplateGroupUpdate,
-		Delete: resourceObjectCliTemplateGroupDelete,
+		CreateContext: resourceObjectCliTemplateGroupCreate,
+		ReadContext:   resourceObjectCliTemplateGroupRead,
+		UpdateContext: resourceObjectCliTemplateGroupUpdate,
+		DeleteContext: resourceObjectCliTemplateGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,81 +70,76 @@ func resourceObjectCliTemplateGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectCliTemplateGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCliTemplateGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCliTemplateGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCliTemplateGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCliTemplateGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCliTemplateGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCliTemplateGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectCliTemplateGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCliTemplateGroupRead(d, m)
+	return resourceObjectCliTemplateGroupRead(ctx, d, m)
 }
 
-func resourceObjectCliTemplateGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCliTemplateGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCliTemplateGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCliTemplateGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCliTemplateGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCliTemplateGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectCliTemplateGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectCliTemplateGroupRead(d, m)
+	return resourceObjectCliTemplateGroupRead(ctx, d, m)
 }
 
-func resourceObjectCliTemplateGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCliTemplateGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCliTemplateGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCliTemplateGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -150,34 +147,36 @@ func resourceObjectCliTemplateGroupDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectCliTemplateGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCliTemplateGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCliTemplateGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCliTemplateGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCliTemplateGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCliTemplateGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCliTemplateGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCliTemplateGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCliTemplateGroup resource from API: %v", err)
 	}
 	return nil
 }
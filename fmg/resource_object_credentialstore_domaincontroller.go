@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectCredentialStoreDomainController() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectCredentialStoreDomainControllerCreate,
-		Read:   resourceObjectCredentialStoreDomainControllerRead,
-		Update: resourceObjectCredentialStoreDomainControllerUpdate,
-		Delete: resourceObjectCredentialStoreDomainControllerDelete,
+		CreateContext: resourceObjectCredentialStoreDomainControllerCreate,
+		ReadContext:   resourceObjectCredentialStoreDomainControllerRead,
+		UpdateContext: resourceObjectCredentialStoreDomainControllerUpdate,
+		DeleteContext: resourceObjectCredentialStoreDomainControllerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectCredentialStoreDomainController() *schema.Resource {
 	}
 }
 
-func resourceObjectCredentialStoreDomainControllerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectCredentialStoreDomainControllerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCredentialStoreDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCredentialStoreDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectCredentialStoreDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectCredentialStoreDomainController(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectCredentialStoreDomainController resource: %v", err)
+		return diag.Errorf("Error creating ObjectCredentialStoreDomainController resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "server_name"))
 
-	return resourceObjectCredentialStoreDomainControllerRead(d, m)
+	return resourceObjectCredentialStoreDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectCredentialStoreDomainControllerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCredentialStoreDomainControllerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectCredentialStoreDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCredentialStoreDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectCredentialStoreDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectCredentialStoreDomainController(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectCredentialStoreDomainController resource: %v", err)
+		return diag.Errorf("Error updating ObjectCredentialStoreDomainController resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "server_name"))
 
-	return resourceObjectCredentialStoreDomainControllerRead(d, m)
+	return resourceObjectCredentialStoreDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectCredentialStoreDomainControllerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCredentialStoreDomainControllerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectCredentialStoreDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectCredentialStoreDomainController resource: %v", err)
+		return diag.Errorf("Error deleting ObjectCredentialStoreDomainController resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectCredentialStoreDomainControllerDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectCredentialStoreDomainControllerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectCredentialStoreDomainControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectCredentialStoreDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCredentialStoreDomainController resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectCredentialStoreDomainController resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectCredentialStoreDomainController resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectCredentialStoreDomainController(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectCredentialStoreDomainController resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectCredentialStoreDomainController resource from API: %v", err)
 	}
 	return nil
 }
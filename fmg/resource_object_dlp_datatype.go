@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpDataType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpDataTypeCreate,
-		Read:   resourceObjectDlpDataTypeRead,
-		Update: resourceObjectDlpDataTypeUpdate,
-		Delete: resourceObjectDlpDataTypeDelete,
+		CreateContext: resourceObjectDlpDataTypeCreate,
+		ReadContext:   resourceObjectDlpDataTypeRead,
+		UpdateContext: resourceObjectDlpDataTypeUpdate,
+		DeleteContext: resourceObjectDlpDataTypeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -85,81 +87,76 @@ func resourceObjectDlpDataType() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpDataTypeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpDataTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpDataType(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpDataType resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpDataType resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpDataType(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpDataType resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpDataType resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpDataTypeRead(d, m)
+	return resourceObjectDlpDataTypeRead(ctx, d, m)
 }
 
-func resourceObjectDlpDataTypeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDataTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpDataType(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpDataType resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpDataType resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpDataType(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpDataType resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpDataType resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpDataTypeRead(d, m)
+	return resourceObjectDlpDataTypeRead(ctx, d, m)
 }
 
-func resourceObjectDlpDataTypeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDataTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpDataType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpDataType resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpDataType resource: %v", err)
 	}
 
 	d.SetId("")
@@ -167,34 +164,36 @@ func resourceObjectDlpDataTypeDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectDlpDataTypeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDataTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpDataType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpDataType resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpDataType resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpDataType resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpDataType(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpDataType resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpDataType resource from API: %v", err)
 	}
 	return nil
 }
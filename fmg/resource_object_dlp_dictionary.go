@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpDictionary() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpDictionaryCreate,
-		Read:   resourceObjectDlpDictionaryRead,
-		Update: resourceObjectDlpDictionaryUpdate,
-		Delete: resourceObjectDlpDictionaryDelete,
+		CreateContext: resourceObjectDlpDictionaryCreate,
+		ReadContext:   resourceObjectDlpDictionaryRead,
+		UpdateContext: resourceObjectDlpDictionaryUpdate,
+		DeleteContext: resourceObjectDlpDictionaryDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -112,81 +114,76 @@ func resourceObjectDlpDictionary() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpDictionaryCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpDictionaryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpDictionary(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpDictionary resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpDictionary resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpDictionary(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpDictionary resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpDictionary resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpDictionaryRead(d, m)
+	return resourceObjectDlpDictionaryRead(ctx, d, m)
 }
 
-func resourceObjectDlpDictionaryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDictionaryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpDictionary(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpDictionary resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpDictionary resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpDictionary(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpDictionary resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpDictionary resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpDictionaryRead(d, m)
+	return resourceObjectDlpDictionaryRead(ctx, d, m)
 }
 
-func resourceObjectDlpDictionaryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDictionaryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpDictionary(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpDictionary resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpDictionary resource: %v", err)
 	}
 
 	d.SetId("")
@@ -194,34 +191,36 @@ func resourceObjectDlpDictionaryDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectDlpDictionaryRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpDictionaryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpDictionary(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpDictionary resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpDictionary resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpDictionary resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpDictionary(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpDictionary resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpDictionary resource from API: %v", err)
 	}
 	return nil
 }
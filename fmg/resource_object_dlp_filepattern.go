@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpFilepattern() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpFilepatternCreate,
-		Read:   resourceObjectDlpFilepatternRead,
-		Update: resourceObjectDlpFilepatternUpdate,
-		Delete: resourceObjectDlpFilepatternDelete,
+		CreateContext: resourceObjectDlpFilepatternCreate,
+		ReadContext:   resourceObjectDlpFilepatternRead,
+		UpdateContext: resourceObjectDlpFilepatternUpdate,
+		DeleteContext: resourceObjectDlpFilepatternDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectDlpFilepattern() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpFilepatternCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpFilepatternCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpFilepattern(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpFilepattern resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpFilepattern resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpFilepattern(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpFilepattern resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpFilepattern resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectDlpFilepatternRead(d, m)
+	return resourceObjectDlpFilepatternRead(ctx, d, m)
 }
 
-func resourceObjectDlpFilepatternUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFilepatternUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpFilepattern(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpFilepattern resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpFilepattern resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpFilepattern(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpFilepattern resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpFilepattern resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectDlpFilepatternRead(d, m)
+	return resourceObjectDlpFilepatternRead(ctx, d, m)
 }
 
-func resourceObjectDlpFilepatternDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFilepatternDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpFilepattern(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpFilepattern resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpFilepattern resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectDlpFilepatternDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectDlpFilepatternRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFilepatternRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpFilepattern(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpFilepattern resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpFilepattern resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpFilepattern resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpFilepattern(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpFilepattern resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpFilepattern resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpFpSensitivity() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpFpSensitivityCreate,
-		Read:   resourceObjectDlpFpSensitivityRead,
-		Update: resourceObjectDlpFpSensitivityUpdate,
-		Delete: resourceObjectDlpFpSensitivityDelete,
+		CreateContext: resourceObjectDlpFpSensitivityCreate,
+		ReadContext:   resourceObjectDlpFpSensitivityRead,
+		UpdateContext: resourceObjectDlpFpSensitivityUpdate,
+		DeleteContext: resourceObjectDlpFpSensitivityDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,81 +55,76 @@ func resourceObjectDlpFpSensitivity() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpFpSensitivityCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpFpSensitivityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpFpSensitivity(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpFpSensitivity resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpFpSensitivity resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpFpSensitivity(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpFpSensitivity resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpFpSensitivity resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpFpSensitivityRead(d, m)
+	return resourceObjectDlpFpSensitivityRead(ctx, d, m)
 }
 
-func resourceObjectDlpFpSensitivityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFpSensitivityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpFpSensitivity(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpFpSensitivity resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpFpSensitivity resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpFpSensitivity(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpFpSensitivity resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpFpSensitivity resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpFpSensitivityRead(d, m)
+	return resourceObjectDlpFpSensitivityRead(ctx, d, m)
 }
 
-func resourceObjectDlpFpSensitivityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFpSensitivityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpFpSensitivity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpFpSensitivity resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpFpSensitivity resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +132,36 @@ func resourceObjectDlpFpSensitivityDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectDlpFpSensitivityRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpFpSensitivityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpFpSensitivity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpFpSensitivity resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpFpSensitivity resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpFpSensitivity resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpFpSensitivity(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpFpSensitivity resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpFpSensitivity resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpProfileCreate,
-		Read:   resourceObjectDlpProfileRead,
-		Update: resourceObjectDlpProfileUpdate,
-		Delete: resourceObjectDlpProfileDelete,
+		CreateContext: resourceObjectDlpProfileCreate,
+		ReadContext:   resourceObjectDlpProfileRead,
+		UpdateContext: resourceObjectDlpProfileUpdate,
+		DeleteContext: resourceObjectDlpProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -178,81 +180,76 @@ func resourceObjectDlpProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpProfileRead(d, m)
+	return resourceObjectDlpProfileRead(ctx, d, m)
 }
 
-func resourceObjectDlpProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpProfileRead(d, m)
+	return resourceObjectDlpProfileRead(ctx, d, m)
 }
 
-func resourceObjectDlpProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -260,34 +257,36 @@ func resourceObjectDlpProfileDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectDlpProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpSensitivity() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpSensitivityCreate,
-		Read:   resourceObjectDlpSensitivityRead,
-		Update: resourceObjectDlpSensitivityUpdate,
-		Delete: resourceObjectDlpSensitivityDelete,
+		CreateContext: resourceObjectDlpSensitivityCreate,
+		ReadContext:   resourceObjectDlpSensitivityRead,
+		UpdateContext: resourceObjectDlpSensitivityUpdate,
+		DeleteContext: resourceObjectDlpSensitivityDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,81 +55,76 @@ func resourceObjectDlpSensitivity() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpSensitivityCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpSensitivityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpSensitivity(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpSensitivity resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpSensitivity resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpSensitivity(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpSensitivity resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpSensitivity resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpSensitivityRead(d, m)
+	return resourceObjectDlpSensitivityRead(ctx, d, m)
 }
 
-func resourceObjectDlpSensitivityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensitivityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpSensitivity(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpSensitivity resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpSensitivity resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpSensitivity(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpSensitivity resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpSensitivity resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpSensitivityRead(d, m)
+	return resourceObjectDlpSensitivityRead(ctx, d, m)
 }
 
-func resourceObjectDlpSensitivityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensitivityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpSensitivity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpSensitivity resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpSensitivity resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +132,36 @@ func resourceObjectDlpSensitivityDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectDlpSensitivityRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensitivityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpSensitivity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpSensitivity resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpSensitivity resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpSensitivity resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpSensitivity(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpSensitivity resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpSensitivity resource from API: %v", err)
 	}
 	return nil
 }
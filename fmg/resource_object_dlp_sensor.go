@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDlpSensor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDlpSensorCreate,
-		Read:   resourceObjectDlpSensorRead,
-		Update: resourceObjectDlpSensorUpdate,
-		Delete: resourceObjectDlpSensorDelete,
+		CreateContext: resourceObjectDlpSensorCreate,
+		ReadContext:   resourceObjectDlpSensorRead,
+		UpdateContext: resourceObjectDlpSensorUpdate,
+		DeleteContext: resourceObjectDlpSensorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -219,81 +221,76 @@ func resourceObjectDlpSensor() *schema.Resource {
 	}
 }
 
-func resourceObjectDlpSensorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDlpSensorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpSensor resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDlpSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDlpSensor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDlpSensor resource: %v", err)
+		return diag.Errorf("Error creating ObjectDlpSensor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpSensorRead(d, m)
+	return resourceObjectDlpSensorRead(ctx, d, m)
 }
 
-func resourceObjectDlpSensorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDlpSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpSensor resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDlpSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDlpSensor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDlpSensor resource: %v", err)
+		return diag.Errorf("Error updating ObjectDlpSensor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDlpSensorRead(d, m)
+	return resourceObjectDlpSensorRead(ctx, d, m)
 }
 
-func resourceObjectDlpSensorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDlpSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDlpSensor resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDlpSensor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -301,34 +298,36 @@ func resourceObjectDlpSensorDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectDlpSensorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDlpSensorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDlpSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpSensor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDlpSensor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDlpSensor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDlpSensor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDlpSensor resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDlpSensor resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDnsfilterDomainFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDnsfilterDomainFilterCreate,
-		Read:   resourceObjectDnsfilterDomainFilterRead,
-		Update: resourceObjectDnsfilterDomainFilterUpdate,
-		Delete: resourceObjectDnsfilterDomainFilterDelete,
+		CreateContext: resourceObjectDnsfilterDomainFilterCreate,
+		ReadContext:   resourceObjectDnsfilterDomainFilterRead,
+		UpdateContext: resourceObjectDnsfilterDomainFilterUpdate,
+		DeleteContext: resourceObjectDnsfilterDomainFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,81 +97,76 @@ func resourceObjectDnsfilterDomainFilter() *schema.Resource {
 	}
 }
 
-func resourceObjectDnsfilterDomainFilterCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDnsfilterDomainFilterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDnsfilterDomainFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDnsfilterDomainFilter resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDnsfilterDomainFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDnsfilterDomainFilter(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDnsfilterDomainFilter resource: %v", err)
+		return diag.Errorf("Error creating ObjectDnsfilterDomainFilter resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectDnsfilterDomainFilterRead(d, m)
+	return resourceObjectDnsfilterDomainFilterRead(ctx, d, m)
 }
 
-func resourceObjectDnsfilterDomainFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterDomainFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDnsfilterDomainFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDnsfilterDomainFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDnsfilterDomainFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDnsfilterDomainFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDnsfilterDomainFilter resource: %v", err)
+		return diag.Errorf("Error updating ObjectDnsfilterDomainFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectDnsfilterDomainFilterRead(d, m)
+	return resourceObjectDnsfilterDomainFilterRead(ctx, d, m)
 }
 
-func resourceObjectDnsfilterDomainFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterDomainFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDnsfilterDomainFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDnsfilterDomainFilter resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDnsfilterDomainFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,34 +174,36 @@ func resourceObjectDnsfilterDomainFilterDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectDnsfilterDomainFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterDomainFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDnsfilterDomainFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDnsfilterDomainFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDnsfilterDomainFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDnsfilterDomainFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDnsfilterDomainFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDnsfilterDomainFilter resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDnsfilterDomainFilter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDnsfilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDnsfilterProfileCreate,
-		Read:   resourceObjectDnsfilterProfileRead,
-		Update: resourceObjectDnsfilterProfileUpdate,
-		Delete: resourceObjectDnsfilterProfileDelete,
+		CreateContext: resourceObjectDnsfilterProfileCreate,
+		ReadContext:   resourceObjectDnsfilterProfileRead,
+		UpdateContext: resourceObjectDnsfilterProfileUpdate,
+		DeleteContext: resourceObjectDnsfilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -223,81 +225,76 @@ func resourceObjectDnsfilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectDnsfilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDnsfilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDnsfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDnsfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDnsfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDnsfilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDnsfilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectDnsfilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDnsfilterProfileRead(d, m)
+	return resourceObjectDnsfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectDnsfilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDnsfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDnsfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDnsfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDnsfilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDnsfilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectDnsfilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDnsfilterProfileRead(d, m)
+	return resourceObjectDnsfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectDnsfilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDnsfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDnsfilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDnsfilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -305,34 +302,36 @@ func resourceObjectDnsfilterProfileDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectDnsfilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDnsfilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDnsfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDnsfilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDnsfilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDnsfilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDnsfilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDnsfilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDnsfilterProfile resource from API: %v", err)
 	}
 	return nil
 }
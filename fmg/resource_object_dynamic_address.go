@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicAddressCreate,
-		Read:   resourceObjectDynamicAddressRead,
-		Update: resourceObjectDynamicAddressUpdate,
-		Delete: resourceObjectDynamicAddressDelete,
+		CreateContext: resourceObjectDynamicAddressCreate,
+		ReadContext:   resourceObjectDynamicAddressRead,
+		UpdateContext: resourceObjectDynamicAddressUpdate,
+		DeleteContext: resourceObjectDynamicAddressDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -85,81 +87,76 @@ func resourceObjectDynamicAddress() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicAddressCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicAddress resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicAddress(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicAddress resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicAddress resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicAddressRead(d, m)
+	return resourceObjectDynamicAddressRead(ctx, d, m)
 }
 
-func resourceObjectDynamicAddressUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicAddress resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicAddress(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicAddress resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicAddress resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicAddressRead(d, m)
+	return resourceObjectDynamicAddressRead(ctx, d, m)
 }
 
-func resourceObjectDynamicAddressDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicAddress resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicAddress resource: %v", err)
 	}
 
 	d.SetId("")
@@ -167,34 +164,36 @@ func resourceObjectDynamicAddressDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectDynamicAddressRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicAddress resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicAddress resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicAddress resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicAddress(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicAddress resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicAddress resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicCertificateLocal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicCertificateLocalCreate,
-		Read:   resourceObjectDynamicCertificateLocalRead,
-		Update: resourceObjectDynamicCertificateLocalUpdate,
-		Delete: resourceObjectDynamicCertificateLocalDelete,
+		CreateContext: resourceObjectDynamicCertificateLocalCreate,
+		ReadContext:   resourceObjectDynamicCertificateLocalRead,
+		UpdateContext: resourceObjectDynamicCertificateLocalUpdate,
+		DeleteContext: resourceObjectDynamicCertificateLocalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectDynamicCertificateLocal() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicCertificateLocalCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicCertificateLocalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicCertificateLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicCertificateLocal resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicCertificateLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicCertificateLocal(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicCertificateLocal resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicCertificateLocal resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicCertificateLocalRead(d, m)
+	return resourceObjectDynamicCertificateLocalRead(ctx, d, m)
 }
 
-func resourceObjectDynamicCertificateLocalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicCertificateLocalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicCertificateLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicCertificateLocal resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicCertificateLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicCertificateLocal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicCertificateLocal resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicCertificateLocal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicCertificateLocalRead(d, m)
+	return resourceObjectDynamicCertificateLocalRead(ctx, d, m)
 }
 
-func resourceObjectDynamicCertificateLocalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicCertificateLocalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicCertificateLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicCertificateLocal resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicCertificateLocal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectDynamicCertificateLocalDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectDynamicCertificateLocalRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicCertificateLocalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicCertificateLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicCertificateLocal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicCertificateLocal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicCertificateLocal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicCertificateLocal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicCertificateLocal resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicCertificateLocal resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicInterface() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicInterfaceCreate,
-		Read:   resourceObjectDynamicInterfaceRead,
-		Update: resourceObjectDynamicInterfaceUpdate,
-		Delete: resourceObjectDynamicInterfaceDelete,
+		CreateContext: resourceObjectDynamicInterfaceCreate,
+		ReadContext:   resourceObjectDynamicInterfaceRead,
+		UpdateContext: resourceObjectDynamicInterfaceUpdate,
+		DeleteContext: resourceObjectDynamicInterfaceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -182,81 +184,76 @@ func resourceObjectDynamicInterface() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicInterfaceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicInterface resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicInterface resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicInterface(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicInterface resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicInterface resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicInterfaceRead(d, m)
+	return resourceObjectDynamicInterfaceRead(ctx, d, m)
 }
 
-func resourceObjectDynamicInterfaceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicInterface resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicInterface resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicInterface(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicInterface resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicInterface resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicInterfaceRead(d, m)
+	return resourceObjectDynamicInterfaceRead(ctx, d, m)
 }
 
-func resourceObjectDynamicInterfaceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicInterface resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicInterface resource: %v", err)
 	}
 
 	d.SetId("")
@@ -264,34 +261,36 @@ func resourceObjectDynamicInterfaceDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectDynamicInterfaceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicInterface resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicInterface resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicInterface resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicInterface(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicInterface resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicInterface resource from API: %v", err)
 	}
 	return nil
 }
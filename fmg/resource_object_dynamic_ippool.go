@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicIppool() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicIppoolCreate,
-		Read:   resourceObjectDynamicIppoolRead,
-		Update: resourceObjectDynamicIppoolUpdate,
-		Delete: resourceObjectDynamicIppoolDelete,
+		CreateContext: resourceObjectDynamicIppoolCreate,
+		ReadContext:   resourceObjectDynamicIppoolRead,
+		UpdateContext: resourceObjectDynamicIppoolUpdate,
+		DeleteContext: resourceObjectDynamicIppoolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectDynamicIppool() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicIppoolCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicIppoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicIppool(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicIppool resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicIppool resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicIppool(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicIppool resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicIppool resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicIppoolRead(d, m)
+	return resourceObjectDynamicIppoolRead(ctx, d, m)
 }
 
-func resourceObjectDynamicIppoolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicIppoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicIppool(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicIppool resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicIppool resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicIppool(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicIppool resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicIppool resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicIppoolRead(d, m)
+	return resourceObjectDynamicIppoolRead(ctx, d, m)
 }
 
-func resourceObjectDynamicIppoolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicIppoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicIppool(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicIppool resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicIppool resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectDynamicIppoolDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectDynamicIppoolRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicIppoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicIppool(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicIppool resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicIppool resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicIppool resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicIppool(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicIppool resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicIppool resource from API: %v", err)
 	}
 	return nil
 }
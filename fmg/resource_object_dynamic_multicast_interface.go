@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicMulticastInterface() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicMulticastInterfaceCreate,
-		Read:   resourceObjectDynamicMulticastInterfaceRead,
-		Update: resourceObjectDynamicMulticastInterfaceUpdate,
-		Delete: resourceObjectDynamicMulticastInterfaceDelete,
+		CreateContext: resourceObjectDynamicMulticastInterfaceCreate,
+		ReadContext:   resourceObjectDynamicMulticastInterfaceRead,
+		UpdateContext: resourceObjectDynamicMulticastInterfaceUpdate,
+		DeleteContext: resourceObjectDynamicMulticastInterfaceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -104,81 +106,76 @@ func resourceObjectDynamicMulticastInterface() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicMulticastInterfaceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicMulticastInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicMulticastInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicMulticastInterface resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicMulticastInterface resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicMulticastInterface(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicMulticastInterface resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicMulticastInterface resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicMulticastInterfaceRead(d, m)
+	return resourceObjectDynamicMulticastInterfaceRead(ctx, d, m)
 }
 
-func resourceObjectDynamicMulticastInterfaceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicMulticastInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicMulticastInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicMulticastInterface resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicMulticastInterface resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicMulticastInterface(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicMulticastInterface resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicMulticastInterface resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicMulticastInterfaceRead(d, m)
+	return resourceObjectDynamicMulticastInterfaceRead(ctx, d, m)
 }
 
-func resourceObjectDynamicMulticastInterfaceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicMulticastInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicMulticastInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicMulticastInterface resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicMulticastInterface resource: %v", err)
 	}
 
 	d.SetId("")
@@ -186,34 +183,36 @@ func resourceObjectDynamicMulticastInterfaceDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectDynamicMulticastInterfaceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicMulticastInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicMulticastInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicMulticastInterface resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicMulticastInterface resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicMulticastInterface resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicMulticastInterface(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicMulticastInterface resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicMulticastInterface resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicVip() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicVipCreate,
-		Read:   resourceObjectDynamicVipRead,
-		Update: resourceObjectDynamicVipUpdate,
-		Delete: resourceObjectDynamicVipDelete,
+		CreateContext: resourceObjectDynamicVipCreate,
+		ReadContext:   resourceObjectDynamicVipRead,
+		UpdateContext: resourceObjectDynamicVipUpdate,
+		DeleteContext: resourceObjectDynamicVipDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectDynamicVip() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicVipCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicVipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicVip(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicVip resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicVip resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicVip(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicVip resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicVip resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicVipRead(d, m)
+	return resourceObjectDynamicVipRead(ctx, d, m)
 }
 
-func resourceObjectDynamicVipUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicVip(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicVip resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicVip resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicVip(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicVip resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicVip resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicVipRead(d, m)
+	return resourceObjectDynamicVipRead(ctx, d, m)
 }
 
-func resourceObjectDynamicVipDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicVip(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicVip resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicVip resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectDynamicVipDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectDynamicVipRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicVip(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicVip resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicVip resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicVip resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicVip(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicVip resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicVip resource from API: %v", err)
 	}
 	return nil
 }
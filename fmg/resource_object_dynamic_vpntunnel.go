@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectDynamicVpntunnel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectDynamicVpntunnelCreate,
-		Read:   resourceObjectDynamicVpntunnelRead,
-		Update: resourceObjectDynamicVpntunnelUpdate,
-		Delete: resourceObjectDynamicVpntunnelDelete,
+		CreateContext: resourceObjectDynamicVpntunnelCreate,
+		ReadContext:   resourceObjectDynamicVpntunnelRead,
+		UpdateContext: resourceObjectDynamicVpntunnelUpdate,
+		DeleteContext: resourceObjectDynamicVpntunnelDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectDynamicVpntunnel() *schema.Resource {
 	}
 }
 
-func resourceObjectDynamicVpntunnelCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectDynamicVpntunnelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicVpntunnel(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicVpntunnel resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicVpntunnel resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectDynamicVpntunnel(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectDynamicVpntunnel resource: %v", err)
+		return diag.Errorf("Error creating ObjectDynamicVpntunnel resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicVpntunnelRead(d, m)
+	return resourceObjectDynamicVpntunnelRead(ctx, d, m)
 }
 
-func resourceObjectDynamicVpntunnelUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVpntunnelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectDynamicVpntunnel(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicVpntunnel resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicVpntunnel resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectDynamicVpntunnel(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectDynamicVpntunnel resource: %v", err)
+		return diag.Errorf("Error updating ObjectDynamicVpntunnel resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectDynamicVpntunnelRead(d, m)
+	return resourceObjectDynamicVpntunnelRead(ctx, d, m)
 }
 
-func resourceObjectDynamicVpntunnelDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVpntunnelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectDynamicVpntunnel(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectDynamicVpntunnel resource: %v", err)
+		return diag.Errorf("Error deleting ObjectDynamicVpntunnel resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectDynamicVpntunnelDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectDynamicVpntunnelRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectDynamicVpntunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectDynamicVpntunnel(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicVpntunnel resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectDynamicVpntunnel resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectDynamicVpntunnel resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectDynamicVpntunnel(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectDynamicVpntunnel resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectDynamicVpntunnel resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterBlockAllowList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterBlockAllowListCreate,
-		Read:   resourceObjectEmailfilterBlockAllowListRead,
-		Update: resourceObjectEmailfilterBlockAllowListUpdate,
-		Delete: resourceObjectEmailfilterBlockAllowListDelete,
+		CreateContext: resourceObjectEmailfilterBlockAllowListCreate,
+		ReadContext:   resourceObjectEmailfilterBlockAllowListRead,
+		UpdateContext: resourceObjectEmailfilterBlockAllowListUpdate,
+		DeleteContext: resourceObjectEmailfilterBlockAllowListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -115,81 +117,76 @@ func resourceObjectEmailfilterBlockAllowList() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterBlockAllowListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterBlockAllowListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBlockAllowList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBlockAllowList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBlockAllowList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterBlockAllowList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBlockAllowList resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBlockAllowList resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBlockAllowListRead(d, m)
+	return resourceObjectEmailfilterBlockAllowListRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBlockAllowListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBlockAllowListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBlockAllowList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBlockAllowList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBlockAllowList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterBlockAllowList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBlockAllowList resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBlockAllowList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBlockAllowListRead(d, m)
+	return resourceObjectEmailfilterBlockAllowListRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBlockAllowListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBlockAllowListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterBlockAllowList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterBlockAllowList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterBlockAllowList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -197,34 +194,36 @@ func resourceObjectEmailfilterBlockAllowListDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectEmailfilterBlockAllowListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBlockAllowListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterBlockAllowList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBlockAllowList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterBlockAllowList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterBlockAllowList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterBlockAllowList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBlockAllowList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterBlockAllowList resource from API: %v", err)
 	}
 	return nil
 }
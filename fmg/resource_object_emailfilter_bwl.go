@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterBwl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterBwlCreate,
-		Read:   resourceObjectEmailfilterBwlRead,
-		Update: resourceObjectEmailfilterBwlUpdate,
-		Delete: resourceObjectEmailfilterBwlDelete,
+		CreateContext: resourceObjectEmailfilterBwlCreate,
+		ReadContext:   resourceObjectEmailfilterBwlRead,
+		UpdateContext: resourceObjectEmailfilterBwlUpdate,
+		DeleteContext: resourceObjectEmailfilterBwlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,81 +113,76 @@ func resourceObjectEmailfilterBwl() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterBwlCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterBwlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBwl resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterBwl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBwl resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBwl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBwlRead(d, m)
+	return resourceObjectEmailfilterBwlRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBwlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBwl resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterBwl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBwl resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBwl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBwlRead(d, m)
+	return resourceObjectEmailfilterBwlRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBwlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterBwl resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterBwl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -193,34 +190,36 @@ func resourceObjectEmailfilterBwlDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectEmailfilterBwlRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBwl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterBwl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterBwl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterBwl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBwl resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterBwl resource from API: %v", err)
 	}
 	return nil
 }
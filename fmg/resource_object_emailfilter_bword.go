@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterBword() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterBwordCreate,
-		Read:   resourceObjectEmailfilterBwordRead,
-		Update: resourceObjectEmailfilterBwordUpdate,
-		Delete: resourceObjectEmailfilterBwordDelete,
+		CreateContext: resourceObjectEmailfilterBwordCreate,
+		ReadContext:   resourceObjectEmailfilterBwordRead,
+		UpdateContext: resourceObjectEmailfilterBwordUpdate,
+		DeleteContext: resourceObjectEmailfilterBwordDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,81 +109,76 @@ func resourceObjectEmailfilterBword() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterBwordCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterBwordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBword(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBword resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBword resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterBword(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterBword resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterBword resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBwordRead(d, m)
+	return resourceObjectEmailfilterBwordRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBwordUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterBword(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBword resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBword resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterBword(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterBword resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterBword resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterBwordRead(d, m)
+	return resourceObjectEmailfilterBwordRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterBwordDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterBword(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterBword resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterBword resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,34 +186,36 @@ func resourceObjectEmailfilterBwordDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectEmailfilterBwordRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterBwordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterBword(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBword resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterBword resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterBword resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterBword(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterBword resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterBword resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterDnsbl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterDnsblCreate,
-		Read:   resourceObjectEmailfilterDnsblRead,
-		Update: resourceObjectEmailfilterDnsblUpdate,
-		Delete: resourceObjectEmailfilterDnsblDelete,
+		CreateContext: resourceObjectEmailfilterDnsblCreate,
+		ReadContext:   resourceObjectEmailfilterDnsblRead,
+		UpdateContext: resourceObjectEmailfilterDnsblUpdate,
+		DeleteContext: resourceObjectEmailfilterDnsblDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectEmailfilterDnsbl() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterDnsblCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterDnsblCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterDnsbl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterDnsbl resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterDnsbl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterDnsbl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterDnsbl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterDnsblRead(d, m)
+	return resourceObjectEmailfilterDnsblRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterDnsblUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterDnsblUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterDnsbl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterDnsbl resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterDnsbl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterDnsbl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterDnsbl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterDnsblRead(d, m)
+	return resourceObjectEmailfilterDnsblRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterDnsblDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterDnsblDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterDnsbl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterDnsbl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectEmailfilterDnsblDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectEmailfilterDnsblRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterDnsblRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterDnsbl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterDnsbl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterDnsbl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterDnsbl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterDnsbl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterDnsbl resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterDnsbl resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterFortishield() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterFortishieldUpdate,
-		Read:   resourceObjectEmailfilterFortishieldRead,
-		Update: resourceObjectEmailfilterFortishieldUpdate,
-		Delete: resourceObjectEmailfilterFortishieldDelete,
+		CreateContext: resourceObjectEmailfilterFortishieldUpdate,
+		ReadContext:   resourceObjectEmailfilterFortishieldRead,
+		UpdateContext: resourceObjectEmailfilterFortishieldUpdate,
+		DeleteContext: resourceObjectEmailfilterFortishieldDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,53 +66,49 @@ func resourceObjectEmailfilterFortishield() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterFortishieldUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterFortishieldUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterFortishield(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterFortishield resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterFortishield resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterFortishield(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterFortishield resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterFortishield resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectEmailfilterFortishield")
 
-	return resourceObjectEmailfilterFortishieldRead(d, m)
+	return resourceObjectEmailfilterFortishieldRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterFortishieldDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterFortishieldDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterFortishield(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterFortishield resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterFortishield resource: %v", err)
 	}
 
 	d.SetId("")
@@ -118,34 +116,36 @@ func resourceObjectEmailfilterFortishieldDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectEmailfilterFortishieldRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterFortishieldRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterFortishield(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterFortishield resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterFortishield resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterFortishield resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterFortishield(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterFortishield resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterFortishield resource from API: %v", err)
 	}
 	return nil
 }
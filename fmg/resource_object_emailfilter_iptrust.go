@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterIptrust() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterIptrustCreate,
-		Read:   resourceObjectEmailfilterIptrustRead,
-		Update: resourceObjectEmailfilterIptrustUpdate,
-		Delete: resourceObjectEmailfilterIptrustDelete,
+		CreateContext: resourceObjectEmailfilterIptrustCreate,
+		ReadContext:   resourceObjectEmailfilterIptrustRead,
+		UpdateContext: resourceObjectEmailfilterIptrustUpdate,
+		DeleteContext: resourceObjectEmailfilterIptrustDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,81 +97,76 @@ func resourceObjectEmailfilterIptrust() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterIptrustCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterIptrustCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterIptrust(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterIptrust resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterIptrust resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterIptrust(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterIptrust resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterIptrust resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterIptrustRead(d, m)
+	return resourceObjectEmailfilterIptrustRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterIptrustUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterIptrustUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterIptrust(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterIptrust resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterIptrust resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterIptrust(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterIptrust resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterIptrust resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterIptrustRead(d, m)
+	return resourceObjectEmailfilterIptrustRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterIptrustDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterIptrustDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterIptrust(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterIptrust resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterIptrust resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,34 +174,36 @@ func resourceObjectEmailfilterIptrustDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectEmailfilterIptrustRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterIptrustRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterIptrust(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterIptrust resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterIptrust resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterIptrust resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterIptrust(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterIptrust resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterIptrust resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterMheader() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterMheaderCreate,
-		Read:   resourceObjectEmailfilterMheaderRead,
-		Update: resourceObjectEmailfilterMheaderUpdate,
-		Delete: resourceObjectEmailfilterMheaderDelete,
+		CreateContext: resourceObjectEmailfilterMheaderCreate,
+		ReadContext:   resourceObjectEmailfilterMheaderRead,
+		UpdateContext: resourceObjectEmailfilterMheaderUpdate,
+		DeleteContext: resourceObjectEmailfilterMheaderDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,81 +101,76 @@ func resourceObjectEmailfilterMheader() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterMheaderCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterMheaderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterMheader(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterMheader resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterMheader resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterMheader(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterMheader resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterMheader resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterMheaderRead(d, m)
+	return resourceObjectEmailfilterMheaderRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterMheaderUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterMheaderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterMheader(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterMheader resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterMheader resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterMheader(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterMheader resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterMheader resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectEmailfilterMheaderRead(d, m)
+	return resourceObjectEmailfilterMheaderRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterMheaderDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterMheaderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterMheader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterMheader resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterMheader resource: %v", err)
 	}
 
 	d.SetId("")
@@ -181,34 +178,36 @@ func resourceObjectEmailfilterMheaderDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectEmailfilterMheaderRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterMheaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterMheader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterMheader resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterMheader resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterMheader resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterMheader(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterMheader resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterMheader resource from API: %v", err)
 	}
 	return nil
 }
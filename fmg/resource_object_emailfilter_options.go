@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterOptions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterOptionsUpdate,
-		Read:   resourceObjectEmailfilterOptionsRead,
-		Update: resourceObjectEmailfilterOptionsUpdate,
-		Delete: resourceObjectEmailfilterOptionsDelete,
+		CreateContext: resourceObjectEmailfilterOptionsUpdate,
+		ReadContext:   resourceObjectEmailfilterOptionsRead,
+		UpdateContext: resourceObjectEmailfilterOptionsUpdate,
+		DeleteContext: resourceObjectEmailfilterOptionsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,53 +55,49 @@ func resourceObjectEmailfilterOptions() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterOptionsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterOptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterOptions(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterOptions resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterOptions resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterOptions(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterOptions resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterOptions resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectEmailfilterOptions")
 
-	return resourceObjectEmailfilterOptionsRead(d, m)
+	return resourceObjectEmailfilterOptionsRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterOptionsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterOptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterOptions resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterOptions resource: %v", err)
 	}
 
 	d.SetId("")
@@ -107,34 +105,36 @@ func resourceObjectEmailfilterOptionsDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectEmailfilterOptionsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterOptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterOptions resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterOptions resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterOptions resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterOptions(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterOptions resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterOptions resource from API: %v", err)
 	}
 	return nil
 }
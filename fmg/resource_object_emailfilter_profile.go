@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEmailfilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEmailfilterProfileCreate,
-		Read:   resourceObjectEmailfilterProfileRead,
-		Update: resourceObjectEmailfilterProfileUpdate,
-		Delete: resourceObjectEmailfilterProfileDelete,
+		CreateContext: resourceObjectEmailfilterProfileCreate,
+		ReadContext:   resourceObjectEmailfilterProfileRead,
+		UpdateContext: resourceObjectEmailfilterProfileUpdate,
+		DeleteContext: resourceObjectEmailfilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -370,81 +372,76 @@ func resourceObjectEmailfilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectEmailfilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEmailfilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEmailfilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEmailfilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectEmailfilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectEmailfilterProfileRead(d, m)
+	return resourceObjectEmailfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEmailfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEmailfilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEmailfilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectEmailfilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectEmailfilterProfileRead(d, m)
+	return resourceObjectEmailfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectEmailfilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEmailfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEmailfilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEmailfilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -452,34 +449,36 @@ func resourceObjectEmailfilterProfileDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectEmailfilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEmailfilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEmailfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEmailfilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEmailfilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEmailfilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEmailfilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEmailfilterProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectEndpointControlFctems() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectEndpointControlFctemsCreate,
-		Read:   resourceObjectEndpointControlFctemsRead,
-		Update: resourceObjectEndpointControlFctemsUpdate,
-		Delete: resourceObjectEndpointControlFctemsDelete,
+		CreateContext: resourceObjectEndpointControlFctemsCreate,
+		ReadContext:   resourceObjectEndpointControlFctemsRead,
+		UpdateContext: resourceObjectEndpointControlFctemsUpdate,
+		DeleteContext: resourceObjectEndpointControlFctemsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -180,81 +182,76 @@ func resourceObjectEndpointControlFctems() *schema.Resource {
 	}
 }
 
-func resourceObjectEndpointControlFctemsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectEndpointControlFctemsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEndpointControlFctems(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEndpointControlFctems resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectEndpointControlFctems resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectEndpointControlFctems(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectEndpointControlFctems resource: %v", err)
+		return diag.Errorf("Error creating ObjectEndpointControlFctems resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectEndpointControlFctemsRead(d, m)
+	return resourceObjectEndpointControlFctemsRead(ctx, d, m)
 }
 
-func resourceObjectEndpointControlFctemsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEndpointControlFctemsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectEndpointControlFctems(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEndpointControlFctems resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectEndpointControlFctems resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectEndpointControlFctems(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectEndpointControlFctems resource: %v", err)
+		return diag.Errorf("Error updating ObjectEndpointControlFctems resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectEndpointControlFctemsRead(d, m)
+	return resourceObjectEndpointControlFctemsRead(ctx, d, m)
 }
 
-func resourceObjectEndpointControlFctemsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEndpointControlFctemsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectEndpointControlFctems(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectEndpointControlFctems resource: %v", err)
+		return diag.Errorf("Error deleting ObjectEndpointControlFctems resource: %v", err)
 	}
 
 	d.SetId("")
@@ -262,34 +259,36 @@ func resourceObjectEndpointControlFctemsDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectEndpointControlFctemsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectEndpointControlFctemsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectEndpointControlFctems(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEndpointControlFctems resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectEndpointControlFctems resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectEndpointControlFctems resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectEndpointControlFctems(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectEndpointControlFctems resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectEndpointControlFctems resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtenderControllerDataplan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtenderControllerDataplanCreate,
-		Read:   resourceObjectExtenderControllerDataplanRead,
-		Update: resourceObjectExtenderControllerDataplanUpdate,
-		Delete: resourceObjectExtenderControllerDataplanDelete,
+		CreateContext: resourceObjectExtenderControllerDataplanCreate,
+		ReadContext:   resourceObjectExtenderControllerDataplanRead,
+		UpdateContext: resourceObjectExtenderControllerDataplanUpdate,
+		DeleteContext: resourceObjectExtenderControllerDataplanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -144,81 +146,76 @@ func resourceObjectExtenderControllerDataplan() *schema.Resource {
 	}
 }
 
-func resourceObjectExtenderControllerDataplanCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtenderControllerDataplanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerDataplan(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerDataplan resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerDataplan resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtenderControllerDataplan(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerDataplan resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerDataplan resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerDataplanRead(d, m)
+	return resourceObjectExtenderControllerDataplanRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerDataplanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerDataplanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerDataplan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerDataplan resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerDataplan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtenderControllerDataplan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerDataplan resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerDataplan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerDataplanRead(d, m)
+	return resourceObjectExtenderControllerDataplanRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerDataplanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerDataplanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtenderControllerDataplan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtenderControllerDataplan resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtenderControllerDataplan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -226,34 +223,36 @@ func resourceObjectExtenderControllerDataplanDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectExtenderControllerDataplanRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerDataplanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtenderControllerDataplan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerDataplan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtenderControllerDataplan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtenderControllerDataplan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtenderControllerDataplan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerDataplan resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtenderControllerDataplan resource from API: %v", err)
 	}
 	return nil
 }
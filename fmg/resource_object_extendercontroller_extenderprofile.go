@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtenderControllerExtenderProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtenderControllerExtenderProfileCreate,
-		Read:   resourceObjectExtenderControllerExtenderProfileRead,
-		Update: resourceObjectExtenderControllerExtenderProfileUpdate,
-		Delete: resourceObjectExtenderControllerExtenderProfileDelete,
+		CreateContext: resourceObjectExtenderControllerExtenderProfileCreate,
+		ReadContext:   resourceObjectExtenderControllerExtenderProfileRead,
+		UpdateContext: resourceObjectExtenderControllerExtenderProfileUpdate,
+		DeleteContext: resourceObjectExtenderControllerExtenderProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -509,81 +511,76 @@ func resourceObjectExtenderControllerExtenderProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectExtenderControllerExtenderProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtenderControllerExtenderProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerExtenderProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerExtenderProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerExtenderProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtenderControllerExtenderProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerExtenderProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerExtenderProfileRead(d, m)
+	return resourceObjectExtenderControllerExtenderProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerExtenderProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerExtenderProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerExtenderProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerExtenderProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerExtenderProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtenderControllerExtenderProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerExtenderProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerExtenderProfileRead(d, m)
+	return resourceObjectExtenderControllerExtenderProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerExtenderProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerExtenderProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtenderControllerExtenderProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtenderControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtenderControllerExtenderProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -591,34 +588,36 @@ func resourceObjectExtenderControllerExtenderProfileDelete(d *schema.ResourceDat
 	return nil
 }
 
-func resourceObjectExtenderControllerExtenderProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerExtenderProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtenderControllerExtenderProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerExtenderProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtenderControllerExtenderProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtenderControllerExtenderProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtenderControllerExtenderProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerExtenderProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtenderControllerExtenderProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtenderControllerSimProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtenderControllerSimProfileCreate,
-		Read:   resourceObjectExtenderControllerSimProfileRead,
-		Update: resourceObjectExtenderControllerSimProfileUpdate,
-		Delete: resourceObjectExtenderControllerSimProfileDelete,
+		CreateContext: resourceObjectExtenderControllerSimProfileCreate,
+		ReadContext:   resourceObjectExtenderControllerSimProfileRead,
+		UpdateContext: resourceObjectExtenderControllerSimProfileUpdate,
+		DeleteContext: resourceObjectExtenderControllerSimProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -174,81 +176,76 @@ func resourceObjectExtenderControllerSimProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectExtenderControllerSimProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtenderControllerSimProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerSimProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerSimProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerSimProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtenderControllerSimProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerSimProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerSimProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerSimProfileRead(d, m)
+	return resourceObjectExtenderControllerSimProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerSimProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerSimProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerSimProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerSimProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerSimProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtenderControllerSimProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerSimProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerSimProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerSimProfileRead(d, m)
+	return resourceObjectExtenderControllerSimProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerSimProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerSimProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtenderControllerSimProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtenderControllerSimProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtenderControllerSimProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -256,34 +253,36 @@ func resourceObjectExtenderControllerSimProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectExtenderControllerSimProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerSimProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtenderControllerSimProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerSimProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtenderControllerSimProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtenderControllerSimProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtenderControllerSimProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerSimProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtenderControllerSimProfile resource from API: %v", err)
 	}
 	return nil
 }
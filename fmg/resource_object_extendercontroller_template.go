@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtenderControllerTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtenderControllerTemplateCreate,
-		Read:   resourceObjectExtenderControllerTemplateRead,
-		Update: resourceObjectExtenderControllerTemplateUpdate,
-		Delete: resourceObjectExtenderControllerTemplateDelete,
+		CreateContext: resourceObjectExtenderControllerTemplateCreate,
+		ReadContext:   resourceObjectExtenderControllerTemplateRead,
+		UpdateContext: resourceObjectExtenderControllerTemplateUpdate,
+		DeleteContext: resourceObjectExtenderControllerTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -78,81 +80,76 @@ func resourceObjectExtenderControllerTemplate() *schema.Resource {
 	}
 }
 
-func resourceObjectExtenderControllerTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtenderControllerTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtenderControllerTemplate(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtenderControllerTemplate resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtenderControllerTemplate resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerTemplateRead(d, m)
+	return resourceObjectExtenderControllerTemplateRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtenderControllerTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtenderControllerTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtenderControllerTemplate resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtenderControllerTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtenderControllerTemplateRead(d, m)
+	return resourceObjectExtenderControllerTemplateRead(ctx, d, m)
 }
 
-func resourceObjectExtenderControllerTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtenderControllerTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtenderControllerTemplate resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtenderControllerTemplate resource: %v", err)
 	}
 
 	d.SetId("")
@@ -160,34 +157,36 @@ func resourceObjectExtenderControllerTemplateDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectExtenderControllerTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtenderControllerTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtenderControllerTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerTemplate resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtenderControllerTemplate resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtenderControllerTemplate resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtenderControllerTemplate(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtenderControllerTemplate resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtenderControllerTemplate resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtensionControllerDataplan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtensionControllerDataplanCreate,
-		Read:   resourceObjectExtensionControllerDataplanRead,
-		Update: resourceObjectExtensionControllerDataplanUpdate,
-		Delete: resourceObjectExtensionControllerDataplanDelete,
+		CreateContext: resourceObjectExtensionControllerDataplanCreate,
+		ReadContext:   resourceObjectExtensionControllerDataplanRead,
+		UpdateContext: resourceObjectExtensionControllerDataplanUpdate,
+		DeleteContext: resourceObjectExtensionControllerDataplanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -137,81 +139,76 @@ func resourceObjectExtensionControllerDataplan() *schema.Resource {
 	}
 }
 
-func resourceObjectExtensionControllerDataplanCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtensionControllerDataplanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtensionControllerDataplan(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtensionControllerDataplan resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtensionControllerDataplan resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtensionControllerDataplan(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtensionControllerDataplan resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtensionControllerDataplan resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtensionControllerDataplanRead(d, m)
+	return resourceObjectExtensionControllerDataplanRead(ctx, d, m)
 }
 
-func resourceObjectExtensionControllerDataplanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerDataplanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtensionControllerDataplan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtensionControllerDataplan resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtensionControllerDataplan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtensionControllerDataplan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtensionControllerDataplan resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtensionControllerDataplan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtensionControllerDataplanRead(d, m)
+	return resourceObjectExtensionControllerDataplanRead(ctx, d, m)
 }
 
-func resourceObjectExtensionControllerDataplanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerDataplanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtensionControllerDataplan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtensionControllerDataplan resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtensionControllerDataplan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -219,34 +216,36 @@ func resourceObjectExtensionControllerDataplanDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectExtensionControllerDataplanRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerDataplanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtensionControllerDataplan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtensionControllerDataplan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtensionControllerDataplan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtensionControllerDataplan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtensionControllerDataplan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtensionControllerDataplan resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtensionControllerDataplan resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectExtensionControllerExtenderProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectExtensionControllerExtenderProfileCreate,
-		Read:   resourceObjectExtensionControllerExtenderProfileRead,
-		Update: resourceObjectExtensionControllerExtenderProfileUpdate,
-		Delete: resourceObjectExtensionControllerExtenderProfileDelete,
+		CreateContext: resourceObjectExtensionControllerExtenderProfileCreate,
+		ReadContext:   resourceObjectExtensionControllerExtenderProfileRead,
+		UpdateContext: resourceObjectExtensionControllerExtenderProfileUpdate,
+		DeleteContext: resourceObjectExtensionControllerExtenderProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -468,81 +470,76 @@ func resourceObjectExtensionControllerExtenderProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectExtensionControllerExtenderProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectExtensionControllerExtenderProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtensionControllerExtenderProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtensionControllerExtenderProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectExtensionControllerExtenderProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectExtensionControllerExtenderProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectExtensionControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectExtensionControllerExtenderProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtensionControllerExtenderProfileRead(d, m)
+	return resourceObjectExtensionControllerExtenderProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtensionControllerExtenderProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerExtenderProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectExtensionControllerExtenderProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtensionControllerExtenderProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectExtensionControllerExtenderProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectExtensionControllerExtenderProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectExtensionControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectExtensionControllerExtenderProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectExtensionControllerExtenderProfileRead(d, m)
+	return resourceObjectExtensionControllerExtenderProfileRead(ctx, d, m)
 }
 
-func resourceObjectExtensionControllerExtenderProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerExtenderProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectExtensionControllerExtenderProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectExtensionControllerExtenderProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectExtensionControllerExtenderProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -550,34 +547,36 @@ func resourceObjectExtensionControllerExtenderProfileDelete(d *schema.ResourceDa
 	return nil
 }
 
-func resourceObjectExtensionControllerExtenderProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectExtensionControllerExtenderProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectExtensionControllerExtenderProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtensionControllerExtenderProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectExtensionControllerExtenderProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectExtensionControllerExtenderProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectExtensionControllerExtenderProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectExtensionControllerExtenderProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectExtensionControllerExtenderProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFileFilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFileFilterProfileCreate,
-		Read:   resourceObjectFileFilterProfileRead,
-		Update: resourceObjectFileFilterProfileUpdate,
-		Delete: resourceObjectFileFilterProfileDelete,
+		CreateContext: resourceObjectFileFilterProfileCreate,
+		ReadContext:   resourceObjectFileFilterProfileRead,
+		UpdateContext: resourceObjectFileFilterProfileUpdate,
+		DeleteContext: resourceObjectFileFilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -130,81 +132,76 @@ func resourceObjectFileFilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectFileFilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFileFilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFileFilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFileFilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFileFilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFileFilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFileFilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectFileFilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFileFilterProfileRead(d, m)
+	return resourceObjectFileFilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectFileFilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFileFilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFileFilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFileFilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFileFilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFileFilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFileFilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectFileFilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFileFilterProfileRead(d, m)
+	return resourceObjectFileFilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectFileFilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFileFilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFileFilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFileFilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFileFilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -212,34 +209,36 @@ func resourceObjectFileFilterProfileDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectFileFilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFileFilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFileFilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFileFilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFileFilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFileFilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFileFilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFileFilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFileFilterProfile resource from API: %v", err)
 	}
 	return nil
 }
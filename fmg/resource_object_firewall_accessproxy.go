@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAccessProxy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAccessProxyCreate,
-		Read:   resourceObjectFirewallAccessProxyRead,
-		Update: resourceObjectFirewallAccessProxyUpdate,
-		Delete: resourceObjectFirewallAccessProxyDelete,
+		CreateContext: resourceObjectFirewallAccessProxyCreate,
+		ReadContext:   resourceObjectFirewallAccessProxyRead,
+		UpdateContext: resourceObjectFirewallAccessProxyUpdate,
+		DeleteContext: resourceObjectFirewallAccessProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -653,81 +655,76 @@ func resourceObjectFirewallAccessProxy() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAccessProxyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAccessProxyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAccessProxy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxy resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxyRead(d, m)
+	return resourceObjectFirewallAccessProxyRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAccessProxy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxyRead(d, m)
+	return resourceObjectFirewallAccessProxyRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAccessProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAccessProxy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAccessProxy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -735,34 +732,36 @@ func resourceObjectFirewallAccessProxyDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectFirewallAccessProxyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAccessProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAccessProxy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy resource from API: %v", err)
 	}
 	return nil
 }
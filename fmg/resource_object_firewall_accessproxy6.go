@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAccessProxy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAccessProxy6Create,
-		Read:   resourceObjectFirewallAccessProxy6Read,
-		Update: resourceObjectFirewallAccessProxy6Update,
-		Delete: resourceObjectFirewallAccessProxy6Delete,
+		CreateContext: resourceObjectFirewallAccessProxy6Create,
+		ReadContext:   resourceObjectFirewallAccessProxy6Read,
+		UpdateContext: resourceObjectFirewallAccessProxy6Update,
+		DeleteContext: resourceObjectFirewallAccessProxy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -540,81 +542,76 @@ func resourceObjectFirewallAccessProxy6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAccessProxy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAccessProxy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAccessProxy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxy6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxy6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxy6Read(d, m)
+	return resourceObjectFirewallAccessProxy6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxy6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAccessProxy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxy6Read(d, m)
+	return resourceObjectFirewallAccessProxy6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAccessProxy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAccessProxy6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAccessProxy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -622,34 +619,36 @@ func resourceObjectFirewallAccessProxy6Delete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallAccessProxy6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAccessProxy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAccessProxy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6 resource from API: %v", err)
 	}
 	return nil
 }
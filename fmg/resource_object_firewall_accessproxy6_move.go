@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAccessProxy6Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAccessProxy6MoveUpdate,
-		Read:   resourceObjectFirewallAccessProxy6MoveRead,
-		Update: resourceObjectFirewallAccessProxy6MoveUpdate,
-		Delete: resourceObjectFirewallAccessProxy6MoveDelete,
+		CreateContext: resourceObjectFirewallAccessProxy6MoveUpdate,
+		ReadContext:   resourceObjectFirewallAccessProxy6MoveRead,
+		UpdateContext: resourceObjectFirewallAccessProxy6MoveUpdate,
+		DeleteContext: resourceObjectFirewallAccessProxy6MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,16 +68,15 @@ func resourceObjectFirewallAccessProxy6Move() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAccessProxy6MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -86,54 +86,56 @@ func resourceObjectFirewallAccessProxy6MoveUpdate(d *schema.ResourceData, m inte
 	target := d.Get("target").(string)
 	obj, err := getObjectObjectFirewallAccessProxy6Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy6Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy6Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAccessProxy6Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxy6Move resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxy6Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectFirewallAccessProxy6Move" + "_" + access_proxy6 + "_" + target)
 
-	return resourceObjectFirewallAccessProxy6MoveRead(d, m)
+	return resourceObjectFirewallAccessProxy6MoveRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxy6MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceObjectFirewallAccessProxy6MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxy6MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("access_proxy6").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
 	o, err := c.ReadObjectFirewallAccessProxy6Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -150,12 +152,12 @@ func resourceObjectFirewallAccessProxy6MoveRead(d *schema.ResourceData, m interf
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["name"]; !ok {
-					return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: name doesn't exist.")
+					return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: name doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["name"].(float64); !ok {
-					return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: wrong name.")
+					return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: wrong name.")
 				} else {
 					idn = int(vidn)
 				}
@@ -168,7 +170,7 @@ func resourceObjectFirewallAccessProxy6MoveRead(d *schema.ResourceData, m interf
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: no valid map string.")
+				return diag.Errorf("Error reading ObjectFirewallAccessProxy6Move resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAccessProxyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAccessProxyMoveUpdate,
-		Read:   resourceObjectFirewallAccessProxyMoveRead,
-		Update: resourceObjectFirewallAccessProxyMoveUpdate,
-		Delete: resourceObjectFirewallAccessProxyMoveDelete,
+		CreateContext: resourceObjectFirewallAccessProxyMoveUpdate,
+		ReadContext:   resourceObjectFirewallAccessProxyMoveRead,
+		UpdateContext: resourceObjectFirewallAccessProxyMoveUpdate,
+		DeleteContext: resourceObjectFirewallAccessProxyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,16 +68,15 @@ func resourceObjectFirewallAccessProxyMove() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAccessProxyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -86,54 +86,56 @@ func resourceObjectFirewallAccessProxyMoveUpdate(d *schema.ResourceData, m inter
 	target := d.Get("target").(string)
 	obj, err := getObjectObjectFirewallAccessProxyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAccessProxyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxyMove resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectFirewallAccessProxyMove" + "_" + access_proxy + "_" + target)
 
-	return resourceObjectFirewallAccessProxyMoveRead(d, m)
+	return resourceObjectFirewallAccessProxyMoveRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceObjectFirewallAccessProxyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("access_proxy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
 	o, err := c.ReadObjectFirewallAccessProxyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -150,12 +152,12 @@ func resourceObjectFirewallAccessProxyMoveRead(d *schema.ResourceData, m interfa
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["name"]; !ok {
-					return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: name doesn't exist.")
+					return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: name doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["name"].(float64); !ok {
-					return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: wrong name.")
+					return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: wrong name.")
 				} else {
 					idn = int(vidn)
 				}
@@ -168,7 +170,7 @@ func resourceObjectFirewallAccessProxyMoveRead(d *schema.ResourceData, m interfa
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading ObjectFirewallAccessProxyMove resource: no valid map string.")
+				return diag.Errorf("Error reading ObjectFirewallAccessProxyMove resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAccessProxyVirtualHost() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAccessProxyVirtualHostCreate,
-		Read:   resourceObjectFirewallAccessProxyVirtualHostRead,
-		Update: resourceObjectFirewallAccessProxyVirtualHostUpdate,
-		Delete: resourceObjectFirewallAccessProxyVirtualHostDelete,
+		CreateContext: resourceObjectFirewallAccessProxyVirtualHostCreate,
+		ReadContext:   resourceObjectFirewallAccessProxyVirtualHostRead,
+		UpdateContext: resourceObjectFirewallAccessProxyVirtualHostUpdate,
+		DeleteContext: resourceObjectFirewallAccessProxyVirtualHostDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,81 +73,76 @@ func resourceObjectFirewallAccessProxyVirtualHost() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAccessProxyVirtualHostCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAccessProxyVirtualHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxyVirtualHost(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxyVirtualHost resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxyVirtualHost resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAccessProxyVirtualHost(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAccessProxyVirtualHost resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAccessProxyVirtualHost resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxyVirtualHostRead(d, m)
+	return resourceObjectFirewallAccessProxyVirtualHostRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxyVirtualHostUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyVirtualHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAccessProxyVirtualHost(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxyVirtualHost resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxyVirtualHost resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAccessProxyVirtualHost(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAccessProxyVirtualHost resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAccessProxyVirtualHost resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAccessProxyVirtualHostRead(d, m)
+	return resourceObjectFirewallAccessProxyVirtualHostRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAccessProxyVirtualHostDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyVirtualHostDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAccessProxyVirtualHost(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAccessProxyVirtualHost resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAccessProxyVirtualHost resource: %v", err)
 	}
 
 	d.SetId("")
@@ -153,34 +150,36 @@ func resourceObjectFirewallAccessProxyVirtualHostDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectFirewallAccessProxyVirtualHostRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAccessProxyVirtualHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAccessProxyVirtualHost(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxyVirtualHost resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyVirtualHost resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyVirtualHost resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAccessProxyVirtualHost(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAccessProxyVirtualHost resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAccessProxyVirtualHost resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAddressCreate,
-		Read:   resourceObjectFirewallAddressRead,
-		Update: resourceObjectFirewallAddressUpdate,
-		Delete: resourceObjectFirewallAddressDelete,
+		CreateContext: resourceObjectFirewallAddressCreate,
+		ReadContext:   resourceObjectFirewallAddressRead,
+		UpdateContext: resourceObjectFirewallAddressUpdate,
+		DeleteContext: resourceObjectFirewallAddressDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -499,81 +501,76 @@ func resourceObjectFirewallAddress() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAddressCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAddress(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddressRead(d, m)
+	return resourceObjectFirewallAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddressUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAddress(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddressRead(d, m)
+	return resourceObjectFirewallAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddressDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAddress resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAddress resource: %v", err)
 	}
 
 	d.SetId("")
@@ -581,34 +578,36 @@ func resourceObjectFirewallAddressDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallAddressRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAddress resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAddress resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAddress(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAddress resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAddress6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAddress6Create,
-		Read:   resourceObjectFirewallAddress6Read,
-		Update: resourceObjectFirewallAddress6Update,
-		Delete: resourceObjectFirewallAddress6Delete,
+		CreateContext: resourceObjectFirewallAddress6Create,
+		ReadContext:   resourceObjectFirewallAddress6Read,
+		UpdateContext: resourceObjectFirewallAddress6Update,
+		DeleteContext: resourceObjectFirewallAddress6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -391,81 +393,76 @@ func resourceObjectFirewallAddress6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAddress6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAddress6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAddress6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddress6Read(d, m)
+	return resourceObjectFirewallAddress6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAddress6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAddress6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddress6Read(d, m)
+	return resourceObjectFirewallAddress6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAddress6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAddress6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAddress6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAddress6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -473,34 +470,36 @@ func resourceObjectFirewallAddress6Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallAddress6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAddress6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAddress6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAddress6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAddress6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAddress6 resource from API: %v", err)
 	}
 	return nil
 }
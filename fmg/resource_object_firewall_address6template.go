@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAddress6Template() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAddress6TemplateCreate,
-		Read:   resourceObjectFirewallAddress6TemplateRead,
-		Update: resourceObjectFirewallAddress6TemplateUpdate,
-		Delete: resourceObjectFirewallAddress6TemplateDelete,
+		CreateContext: resourceObjectFirewallAddress6TemplateCreate,
+		ReadContext:   resourceObjectFirewallAddress6TemplateRead,
+		UpdateContext: resourceObjectFirewallAddress6TemplateUpdate,
+		DeleteContext: resourceObjectFirewallAddress6TemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -119,81 +121,76 @@ func resourceObjectFirewallAddress6Template() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAddress6TemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAddress6TemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress6Template(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress6Template resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress6Template resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAddress6Template(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddress6Template resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddress6Template resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddress6TemplateRead(d, m)
+	return resourceObjectFirewallAddress6TemplateRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddress6TemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6TemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddress6Template(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress6Template resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress6Template resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAddress6Template(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddress6Template resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddress6Template resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddress6TemplateRead(d, m)
+	return resourceObjectFirewallAddress6TemplateRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddress6TemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6TemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAddress6Template(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAddress6Template resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAddress6Template resource: %v", err)
 	}
 
 	d.SetId("")
@@ -201,34 +198,36 @@ func resourceObjectFirewallAddress6TemplateDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceObjectFirewallAddress6TemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddress6TemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAddress6Template(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress6Template resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAddress6Template resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAddress6Template resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAddress6Template(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddress6Template resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAddress6Template resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAddrgrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAddrgrpCreate,
-		Read:   resourceObjectFirewallAddrgrpRead,
-		Update: resourceObjectFirewallAddrgrpUpdate,
-		Delete: resourceObjectFirewallAddrgrpDelete,
+		CreateContext: resourceObjectFirewallAddrgrpCreate,
+		ReadContext:   resourceObjectFirewallAddrgrpRead,
+		UpdateContext: resourceObjectFirewallAddrgrpUpdate,
+		DeleteContext: resourceObjectFirewallAddrgrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -229,81 +231,76 @@ func resourceObjectFirewallAddrgrp() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAddrgrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAddrgrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAddrgrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddrgrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddrgrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddrgrpRead(d, m)
+	return resourceObjectFirewallAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddrgrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAddrgrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddrgrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddrgrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddrgrpRead(d, m)
+	return resourceObjectFirewallAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallAddrgrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAddrgrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAddrgrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -311,34 +308,36 @@ func resourceObjectFirewallAddrgrpDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallAddrgrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddrgrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAddrgrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddrgrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp resource from API: %v", err)
 	}
 	return nil
 }
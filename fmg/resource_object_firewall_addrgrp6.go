@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallAddrgrp6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallAddrgrp6Create,
-		Read:   resourceObjectFirewallAddrgrp6Read,
-		Update: resourceObjectFirewallAddrgrp6Update,
-		Delete: resourceObjectFirewallAddrgrp6Delete,
+		CreateContext: resourceObjectFirewallAddrgrp6Create,
+		ReadContext:   resourceObjectFirewallAddrgrp6Read,
+		UpdateContext: resourceObjectFirewallAddrgrp6Update,
+		DeleteContext: resourceObjectFirewallAddrgrp6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -179,81 +181,76 @@ func resourceObjectFirewallAddrgrp6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallAddrgrp6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallAddrgrp6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddrgrp6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddrgrp6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddrgrp6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallAddrgrp6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallAddrgrp6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallAddrgrp6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddrgrp6Read(d, m)
+	return resourceObjectFirewallAddrgrp6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAddrgrp6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrp6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallAddrgrp6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddrgrp6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddrgrp6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallAddrgrp6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallAddrgrp6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallAddrgrp6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallAddrgrp6Read(d, m)
+	return resourceObjectFirewallAddrgrp6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallAddrgrp6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrp6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallAddrgrp6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallAddrgrp6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallAddrgrp6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -261,34 +258,36 @@ func resourceObjectFirewallAddrgrp6Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallAddrgrp6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallAddrgrp6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallAddrgrp6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddrgrp6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallAddrgrp6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallAddrgrp6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallAddrgrp6 resource from API: %v", err)
 	}
 	return nil
 }
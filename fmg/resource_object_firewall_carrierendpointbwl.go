@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallCarrierEndpointBwl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallCarrierEndpointBwlCreate,
-		Read:   resourceObjectFirewallCarrierEndpointBwlRead,
-		Update: resourceObjectFirewallCarrierEndpointBwlUpdate,
-		Delete: resourceObjectFirewallCarrierEndpointBwlDelete,
+		CreateContext: resourceObjectFirewallCarrierEndpointBwlCreate,
+		ReadContext:   resourceObjectFirewallCarrierEndpointBwlRead,
+		UpdateContext: resourceObjectFirewallCarrierEndpointBwlUpdate,
+		DeleteContext: resourceObjectFirewallCarrierEndpointBwlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,81 +101,76 @@ func resourceObjectFirewallCarrierEndpointBwl() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallCarrierEndpointBwlCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallCarrierEndpointBwlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallCarrierEndpointBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallCarrierEndpointBwl resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallCarrierEndpointBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallCarrierEndpointBwl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallCarrierEndpointBwl resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallCarrierEndpointBwl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectFirewallCarrierEndpointBwlRead(d, m)
+	return resourceObjectFirewallCarrierEndpointBwlRead(ctx, d, m)
 }
 
-func resourceObjectFirewallCarrierEndpointBwlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallCarrierEndpointBwlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallCarrierEndpointBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallCarrierEndpointBwl resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallCarrierEndpointBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallCarrierEndpointBwl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallCarrierEndpointBwl resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallCarrierEndpointBwl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectFirewallCarrierEndpointBwlRead(d, m)
+	return resourceObjectFirewallCarrierEndpointBwlRead(ctx, d, m)
 }
 
-func resourceObjectFirewallCarrierEndpointBwlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallCarrierEndpointBwlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallCarrierEndpointBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallCarrierEndpointBwl resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallCarrierEndpointBwl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -181,34 +178,36 @@ func resourceObjectFirewallCarrierEndpointBwlDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectFirewallCarrierEndpointBwlRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallCarrierEndpointBwlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallCarrierEndpointBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallCarrierEndpointBwl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallCarrierEndpointBwl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallCarrierEndpointBwl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallCarrierEndpointBwl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallCarrierEndpointBwl resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallCarrierEndpointBwl resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallDecryptedTrafficMirror() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallDecryptedTrafficMirrorCreate,
-		Read:   resourceObjectFirewallDecryptedTrafficMirrorRead,
-		Update: resourceObjectFirewallDecryptedTrafficMirrorUpdate,
-		Delete: resourceObjectFirewallDecryptedTrafficMirrorDelete,
+		CreateContext: resourceObjectFirewallDecryptedTrafficMirrorCreate,
+		ReadContext:   resourceObjectFirewallDecryptedTrafficMirrorRead,
+		UpdateContext: resourceObjectFirewallDecryptedTrafficMirrorUpdate,
+		DeleteContext: resourceObjectFirewallDecryptedTrafficMirrorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -74,81 +76,76 @@ func resourceObjectFirewallDecryptedTrafficMirror() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallDecryptedTrafficMirrorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallDecryptedTrafficMirrorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallDecryptedTrafficMirror(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallDecryptedTrafficMirror resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallDecryptedTrafficMirror resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallDecryptedTrafficMirror(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallDecryptedTrafficMirror resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallDecryptedTrafficMirror resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallDecryptedTrafficMirrorRead(d, m)
+	return resourceObjectFirewallDecryptedTrafficMirrorRead(ctx, d, m)
 }
 
-func resourceObjectFirewallDecryptedTrafficMirrorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallDecryptedTrafficMirrorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallDecryptedTrafficMirror(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallDecryptedTrafficMirror resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallDecryptedTrafficMirror resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallDecryptedTrafficMirror(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallDecryptedTrafficMirror resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallDecryptedTrafficMirror resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallDecryptedTrafficMirrorRead(d, m)
+	return resourceObjectFirewallDecryptedTrafficMirrorRead(ctx, d, m)
 }
 
-func resourceObjectFirewallDecryptedTrafficMirrorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallDecryptedTrafficMirrorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallDecryptedTrafficMirror(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallDecryptedTrafficMirror resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallDecryptedTrafficMirror resource: %v", err)
 	}
 
 	d.SetId("")
@@ -156,34 +153,36 @@ func resourceObjectFirewallDecryptedTrafficMirrorDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectFirewallDecryptedTrafficMirrorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallDecryptedTrafficMirrorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallDecryptedTrafficMirror(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallDecryptedTrafficMirror resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallDecryptedTrafficMirror resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallDecryptedTrafficMirror resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallDecryptedTrafficMirror(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallDecryptedTrafficMirror resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallDecryptedTrafficMirror resource from API: %v", err)
 	}
 	return nil
 }
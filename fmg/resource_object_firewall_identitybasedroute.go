@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallIdentityBasedRoute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallIdentityBasedRouteCreate,
-		Read:   resourceObjectFirewallIdentityBasedRouteRead,
-		Update: resourceObjectFirewallIdentityBasedRouteUpdate,
-		Delete: resourceObjectFirewallIdentityBasedRouteDelete,
+		CreateContext: resourceObjectFirewallIdentityBasedRouteCreate,
+		ReadContext:   resourceObjectFirewallIdentityBasedRouteRead,
+		UpdateContext: resourceObjectFirewallIdentityBasedRouteUpdate,
+		DeleteContext: resourceObjectFirewallIdentityBasedRouteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,81 +90,76 @@ func resourceObjectFirewallIdentityBasedRoute() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallIdentityBasedRouteCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallIdentityBasedRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIdentityBasedRoute(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIdentityBasedRoute resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIdentityBasedRoute resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallIdentityBasedRoute(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIdentityBasedRoute resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIdentityBasedRoute resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIdentityBasedRouteRead(d, m)
+	return resourceObjectFirewallIdentityBasedRouteRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIdentityBasedRouteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIdentityBasedRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIdentityBasedRoute(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIdentityBasedRoute resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIdentityBasedRoute resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallIdentityBasedRoute(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIdentityBasedRoute resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIdentityBasedRoute resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIdentityBasedRouteRead(d, m)
+	return resourceObjectFirewallIdentityBasedRouteRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIdentityBasedRouteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIdentityBasedRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallIdentityBasedRoute(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallIdentityBasedRoute resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallIdentityBasedRoute resource: %v", err)
 	}
 
 	d.SetId("")
@@ -170,34 +167,36 @@ func resourceObjectFirewallIdentityBasedRouteDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectFirewallIdentityBasedRouteRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIdentityBasedRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallIdentityBasedRoute(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIdentityBasedRoute resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallIdentityBasedRoute resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallIdentityBasedRoute resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallIdentityBasedRoute(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIdentityBasedRoute resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallIdentityBasedRoute resource from API: %v", err)
 	}
 	return nil
 }
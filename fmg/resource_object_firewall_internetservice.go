@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceUpdate,
-		Read:   resourceObjectFirewallInternetServiceRead,
-		Update: resourceObjectFirewallInternetServiceUpdate,
-		Delete: resourceObjectFirewallInternetServiceDelete,
+		CreateContext: resourceObjectFirewallInternetServiceUpdate,
+		ReadContext:   resourceObjectFirewallInternetServiceRead,
+		UpdateContext: resourceObjectFirewallInternetServiceUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -194,53 +196,49 @@ func resourceObjectFirewallInternetService() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetService(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetService resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetService resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetService(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetService resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetService resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectFirewallInternetServiceRead(d, m)
+	return resourceObjectFirewallInternetServiceRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetService resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetService resource: %v", err)
 	}
 
 	d.SetId("")
@@ -248,34 +246,36 @@ func resourceObjectFirewallInternetServiceDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetService resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetService resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetService resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetService(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetService resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetService resource from API: %v", err)
 	}
 	return nil
 }
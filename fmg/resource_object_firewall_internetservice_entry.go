@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceEntry() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceEntryCreate,
-		Read:   resourceObjectFirewallInternetServiceEntryRead,
-		Update: resourceObjectFirewallInternetServiceEntryUpdate,
-		Delete: resourceObjectFirewallInternetServiceEntryDelete,
+		CreateContext: resourceObjectFirewallInternetServiceEntryCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceEntryRead,
+		UpdateContext: resourceObjectFirewallInternetServiceEntryUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceEntryDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,81 +74,76 @@ func resourceObjectFirewallInternetServiceEntry() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceEntryCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceEntryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceEntry(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceEntry resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceEntry resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceEntry(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceEntry resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceEntry resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectFirewallInternetServiceEntryRead(d, m)
+	return resourceObjectFirewallInternetServiceEntryRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceEntryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceEntryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceEntry(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceEntry resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceEntry resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceEntry(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceEntry resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceEntry resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectFirewallInternetServiceEntryRead(d, m)
+	return resourceObjectFirewallInternetServiceEntryRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceEntryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceEntryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceEntry(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceEntry resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceEntry resource: %v", err)
 	}
 
 	d.SetId("")
@@ -154,34 +151,36 @@ func resourceObjectFirewallInternetServiceEntryDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceEntryRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceEntryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceEntry(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceEntry resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceEntry resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceEntry resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceEntry(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceEntry resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceEntry resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceAddition() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceAdditionCreate,
-		Read:   resourceObjectFirewallInternetServiceAdditionRead,
-		Update: resourceObjectFirewallInternetServiceAdditionUpdate,
-		Delete: resourceObjectFirewallInternetServiceAdditionDelete,
+		CreateContext: resourceObjectFirewallInternetServiceAdditionCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceAdditionRead,
+		UpdateContext: resourceObjectFirewallInternetServiceAdditionUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceAdditionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -106,81 +108,76 @@ func resourceObjectFirewallInternetServiceAddition() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceAdditionCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceAdditionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceAddition(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceAddition resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceAddition resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceAddition(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceAddition resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceAddition resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectFirewallInternetServiceAdditionRead(d, m)
+	return resourceObjectFirewallInternetServiceAdditionRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceAdditionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceAdditionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceAddition(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceAddition resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceAddition resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceAddition(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceAddition resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceAddition resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectFirewallInternetServiceAdditionRead(d, m)
+	return resourceObjectFirewallInternetServiceAdditionRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceAdditionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceAdditionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceAddition(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceAddition resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceAddition resource: %v", err)
 	}
 
 	d.SetId("")
@@ -188,34 +185,36 @@ func resourceObjectFirewallInternetServiceAdditionDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceAdditionRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceAdditionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceAddition(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceAddition resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceAddition resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceAddition resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceAddition(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceAddition resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceAddition resource from API: %v", err)
 	}
 	return nil
 }
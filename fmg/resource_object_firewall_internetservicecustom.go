@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceCustom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceCustomCreate,
-		Read:   resourceObjectFirewallInternetServiceCustomRead,
-		Update: resourceObjectFirewallInternetServiceCustomUpdate,
-		Delete: resourceObjectFirewallInternetServiceCustomDelete,
+		CreateContext: resourceObjectFirewallInternetServiceCustomCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceCustomRead,
+		UpdateContext: resourceObjectFirewallInternetServiceCustomUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceCustomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -170,81 +172,76 @@ func resourceObjectFirewallInternetServiceCustom() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceCustomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceCustomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceCustom resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceCustom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceCustom resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceCustom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceCustomRead(d, m)
+	return resourceObjectFirewallInternetServiceCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceCustomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceCustom resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceCustom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceCustom resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceCustom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceCustomRead(d, m)
+	return resourceObjectFirewallInternetServiceCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceCustomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceCustom resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceCustom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -252,34 +249,36 @@ func resourceObjectFirewallInternetServiceCustomDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceCustomRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceCustom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceCustom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceCustom resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustom resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceCustomGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceCustomGroupCreate,
-		Read:   resourceObjectFirewallInternetServiceCustomGroupRead,
-		Update: resourceObjectFirewallInternetServiceCustomGroupUpdate,
-		Delete: resourceObjectFirewallInternetServiceCustomGroupDelete,
+		CreateContext: resourceObjectFirewallInternetServiceCustomGroupCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceCustomGroupRead,
+		UpdateContext: resourceObjectFirewallInternetServiceCustomGroupUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceCustomGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,81 +66,76 @@ func resourceObjectFirewallInternetServiceCustomGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceCustomGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceCustomGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceCustomGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceCustomGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceCustomGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceCustomGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceCustomGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceCustomGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceCustomGroupRead(d, m)
+	return resourceObjectFirewallInternetServiceCustomGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceCustomGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceCustomGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceCustomGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceCustomGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceCustomGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceCustomGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceCustomGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceCustomGroupRead(d, m)
+	return resourceObjectFirewallInternetServiceCustomGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceCustomGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceCustomGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceCustomGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceCustomGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -146,34 +143,36 @@ func resourceObjectFirewallInternetServiceCustomGroupDelete(d *schema.ResourceDa
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceCustomGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceCustomGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceCustomGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceCustomGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustomGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustomGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceCustomGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceCustomGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceCustomGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceGroupCreate,
-		Read:   resourceObjectFirewallInternetServiceGroupRead,
-		Update: resourceObjectFirewallInternetServiceGroupUpdate,
-		Delete: resourceObjectFirewallInternetServiceGroupDelete,
+		CreateContext: resourceObjectFirewallInternetServiceGroupCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceGroupRead,
+		UpdateContext: resourceObjectFirewallInternetServiceGroupUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,81 +71,76 @@ func resourceObjectFirewallInternetServiceGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceGroupRead(d, m)
+	return resourceObjectFirewallInternetServiceGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceGroupRead(d, m)
+	return resourceObjectFirewallInternetServiceGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -151,34 +148,36 @@ func resourceObjectFirewallInternetServiceGroupDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceGroup resource from API: %v", err)
 	}
 	return nil
 }
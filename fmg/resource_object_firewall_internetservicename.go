@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallInternetServiceName() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallInternetServiceNameCreate,
-		Read:   resourceObjectFirewallInternetServiceNameRead,
-		Update: resourceObjectFirewallInternetServiceNameUpdate,
-		Delete: resourceObjectFirewallInternetServiceNameDelete,
+		CreateContext: resourceObjectFirewallInternetServiceNameCreate,
+		ReadContext:   resourceObjectFirewallInternetServiceNameRead,
+		UpdateContext: resourceObjectFirewallInternetServiceNameUpdate,
+		DeleteContext: resourceObjectFirewallInternetServiceNameDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,81 +77,76 @@ func resourceObjectFirewallInternetServiceName() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallInternetServiceNameCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallInternetServiceNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceName(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceName resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceName resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallInternetServiceName(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallInternetServiceName resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallInternetServiceName resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceNameRead(d, m)
+	return resourceObjectFirewallInternetServiceNameRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceNameUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceNameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallInternetServiceName(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceName resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceName resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallInternetServiceName(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallInternetServiceName resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallInternetServiceName resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallInternetServiceNameRead(d, m)
+	return resourceObjectFirewallInternetServiceNameRead(ctx, d, m)
 }
 
-func resourceObjectFirewallInternetServiceNameDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallInternetServiceName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallInternetServiceName resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallInternetServiceName resource: %v", err)
 	}
 
 	d.SetId("")
@@ -157,34 +154,36 @@ func resourceObjectFirewallInternetServiceNameDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectFirewallInternetServiceNameRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallInternetServiceNameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallInternetServiceName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceName resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceName resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceName resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallInternetServiceName(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallInternetServiceName resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallInternetServiceName resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallIppool() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallIppoolCreate,
-		Read:   resourceObjectFirewallIppoolRead,
-		Update: resourceObjectFirewallIppoolUpdate,
-		Delete: resourceObjectFirewallIppoolDelete,
+		CreateContext: resourceObjectFirewallIppoolCreate,
+		ReadContext:   resourceObjectFirewallIppoolRead,
+		UpdateContext: resourceObjectFirewallIppoolUpdate,
+		DeleteContext: resourceObjectFirewallIppoolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -353,81 +355,76 @@ func resourceObjectFirewallIppool() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallIppoolCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallIppoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppool(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppool resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppool resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallIppool(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppool resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppool resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppoolRead(d, m)
+	return resourceObjectFirewallIppoolRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIppoolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppool(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppool resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppool resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallIppool(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppool resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppool resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppoolRead(d, m)
+	return resourceObjectFirewallIppoolRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIppoolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallIppool(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallIppool resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallIppool resource: %v", err)
 	}
 
 	d.SetId("")
@@ -435,34 +432,36 @@ func resourceObjectFirewallIppoolDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectFirewallIppoolRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallIppool(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppool resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallIppool resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallIppool resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallIppool(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppool resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallIppool resource from API: %v", err)
 	}
 	return nil
 }
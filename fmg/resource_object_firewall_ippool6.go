@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallIppool6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallIppool6Create,
-		Read:   resourceObjectFirewallIppool6Read,
-		Update: resourceObjectFirewallIppool6Update,
-		Delete: resourceObjectFirewallIppool6Delete,
+		CreateContext: resourceObjectFirewallIppool6Create,
+		ReadContext:   resourceObjectFirewallIppool6Read,
+		UpdateContext: resourceObjectFirewallIppool6Update,
+		DeleteContext: resourceObjectFirewallIppool6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -127,81 +129,76 @@ func resourceObjectFirewallIppool6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallIppool6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallIppool6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppool6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppool6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppool6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallIppool6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppool6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppool6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppool6Read(d, m)
+	return resourceObjectFirewallIppool6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallIppool6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppool6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppool6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppool6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppool6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallIppool6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppool6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppool6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppool6Read(d, m)
+	return resourceObjectFirewallIppool6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallIppool6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppool6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallIppool6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallIppool6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallIppool6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -209,34 +206,36 @@ func resourceObjectFirewallIppool6Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallIppool6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppool6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallIppool6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppool6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallIppool6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallIppool6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallIppool6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppool6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallIppool6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallIppoolGrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallIppoolGrpCreate,
-		Read:   resourceObjectFirewallIppoolGrpRead,
-		Update: resourceObjectFirewallIppoolGrpUpdate,
-		Delete: resourceObjectFirewallIppoolGrpDelete,
+		CreateContext: resourceObjectFirewallIppoolGrpCreate,
+		ReadContext:   resourceObjectFirewallIppoolGrpRead,
+		UpdateContext: resourceObjectFirewallIppoolGrpUpdate,
+		DeleteContext: resourceObjectFirewallIppoolGrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectFirewallIppoolGrp() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallIppoolGrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallIppoolGrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppoolGrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppoolGrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppoolGrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallIppoolGrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallIppoolGrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallIppoolGrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppoolGrpRead(d, m)
+	return resourceObjectFirewallIppoolGrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIppoolGrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolGrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallIppoolGrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppoolGrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppoolGrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallIppoolGrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallIppoolGrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallIppoolGrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallIppoolGrpRead(d, m)
+	return resourceObjectFirewallIppoolGrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallIppoolGrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolGrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallIppoolGrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallIppoolGrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallIppoolGrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectFirewallIppoolGrpDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectFirewallIppoolGrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallIppoolGrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallIppoolGrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppoolGrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallIppoolGrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallIppoolGrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallIppoolGrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallIppoolGrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallIppoolGrp resource from API: %v", err)
 	}
 	return nil
 }
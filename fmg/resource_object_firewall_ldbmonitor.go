@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallLdbMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallLdbMonitorCreate,
-		Read:   resourceObjectFirewallLdbMonitorRead,
-		Update: resourceObjectFirewallLdbMonitorUpdate,
-		Delete: resourceObjectFirewallLdbMonitorDelete,
+		CreateContext: resourceObjectFirewallLdbMonitorCreate,
+		ReadContext:   resourceObjectFirewallLdbMonitorRead,
+		UpdateContext: resourceObjectFirewallLdbMonitorUpdate,
+		DeleteContext: resourceObjectFirewallLdbMonitorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -108,81 +110,76 @@ func resourceObjectFirewallLdbMonitor() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallLdbMonitorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallLdbMonitorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallLdbMonitor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallLdbMonitor resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallLdbMonitor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallLdbMonitor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallLdbMonitor resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallLdbMonitor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallLdbMonitorRead(d, m)
+	return resourceObjectFirewallLdbMonitorRead(ctx, d, m)
 }
 
-func resourceObjectFirewallLdbMonitorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallLdbMonitorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallLdbMonitor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallLdbMonitor resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallLdbMonitor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallLdbMonitor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallLdbMonitor resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallLdbMonitor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallLdbMonitorRead(d, m)
+	return resourceObjectFirewallLdbMonitorRead(ctx, d, m)
 }
 
-func resourceObjectFirewallLdbMonitorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallLdbMonitorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallLdbMonitor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallLdbMonitor resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallLdbMonitor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -190,34 +187,36 @@ func resourceObjectFirewallLdbMonitorDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectFirewallLdbMonitorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallLdbMonitorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallLdbMonitor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallLdbMonitor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallLdbMonitor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallLdbMonitor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallLdbMonitor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallLdbMonitor resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallLdbMonitor resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallMmsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallMmsProfileCreate,
-		Read:   resourceObjectFirewallMmsProfileRead,
-		Update: resourceObjectFirewallMmsProfileUpdate,
-		Delete: resourceObjectFirewallMmsProfileDelete,
+		CreateContext: resourceObjectFirewallMmsProfileCreate,
+		ReadContext:   resourceObjectFirewallMmsProfileRead,
+		UpdateContext: resourceObjectFirewallMmsProfileUpdate,
+		DeleteContext: resourceObjectFirewallMmsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -629,81 +631,76 @@ func resourceObjectFirewallMmsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallMmsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallMmsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMmsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMmsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMmsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallMmsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMmsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMmsProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMmsProfileRead(d, m)
+	return resourceObjectFirewallMmsProfileRead(ctx, d, m)
 }
 
-func resourceObjectFirewallMmsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMmsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMmsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMmsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMmsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallMmsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMmsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMmsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMmsProfileRead(d, m)
+	return resourceObjectFirewallMmsProfileRead(ctx, d, m)
 }
 
-func resourceObjectFirewallMmsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMmsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallMmsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallMmsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallMmsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -711,34 +708,36 @@ func resourceObjectFirewallMmsProfileDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectFirewallMmsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMmsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallMmsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMmsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallMmsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallMmsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallMmsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMmsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallMmsProfile resource from API: %v", err)
 	}
 	return nil
 }
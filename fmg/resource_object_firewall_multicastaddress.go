@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallMulticastAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallMulticastAddressCreate,
-		Read:   resourceObjectFirewallMulticastAddressRead,
-		Update: resourceObjectFirewallMulticastAddressUpdate,
-		Delete: resourceObjectFirewallMulticastAddressDelete,
+		CreateContext: resourceObjectFirewallMulticastAddressCreate,
+		ReadContext:   resourceObjectFirewallMulticastAddressRead,
+		UpdateContext: resourceObjectFirewallMulticastAddressUpdate,
+		DeleteContext: resourceObjectFirewallMulticastAddressDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -116,81 +118,76 @@ func resourceObjectFirewallMulticastAddress() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallMulticastAddressCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallMulticastAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMulticastAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMulticastAddress resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMulticastAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallMulticastAddress(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMulticastAddress resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMulticastAddress resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMulticastAddressRead(d, m)
+	return resourceObjectFirewallMulticastAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallMulticastAddressUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMulticastAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMulticastAddress resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMulticastAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallMulticastAddress(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMulticastAddress resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMulticastAddress resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMulticastAddressRead(d, m)
+	return resourceObjectFirewallMulticastAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallMulticastAddressDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallMulticastAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallMulticastAddress resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallMulticastAddress resource: %v", err)
 	}
 
 	d.SetId("")
@@ -198,34 +195,36 @@ func resourceObjectFirewallMulticastAddressDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceObjectFirewallMulticastAddressRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallMulticastAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMulticastAddress resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallMulticastAddress(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMulticastAddress resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallMulticastAddress6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallMulticastAddress6Create,
-		Read:   resourceObjectFirewallMulticastAddress6Read,
-		Update: resourceObjectFirewallMulticastAddress6Update,
-		Delete: resourceObjectFirewallMulticastAddress6Delete,
+		CreateContext: resourceObjectFirewallMulticastAddress6Create,
+		ReadContext:   resourceObjectFirewallMulticastAddress6Read,
+		UpdateContext: resourceObjectFirewallMulticastAddress6Update,
+		DeleteContext: resourceObjectFirewallMulticastAddress6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -96,81 +98,76 @@ func resourceObjectFirewallMulticastAddress6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallMulticastAddress6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallMulticastAddress6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMulticastAddress6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMulticastAddress6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMulticastAddress6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallMulticastAddress6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallMulticastAddress6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallMulticastAddress6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMulticastAddress6Read(d, m)
+	return resourceObjectFirewallMulticastAddress6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallMulticastAddress6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddress6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallMulticastAddress6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMulticastAddress6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMulticastAddress6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallMulticastAddress6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallMulticastAddress6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallMulticastAddress6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallMulticastAddress6Read(d, m)
+	return resourceObjectFirewallMulticastAddress6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallMulticastAddress6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddress6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallMulticastAddress6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallMulticastAddress6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallMulticastAddress6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -178,34 +175,36 @@ func resourceObjectFirewallMulticastAddress6Delete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectFirewallMulticastAddress6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallMulticastAddress6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallMulticastAddress6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMulticastAddress6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallMulticastAddress6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallMulticastAddress6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallMulticastAddress6 resource from API: %v", err)
 	}
 	return nil
 }
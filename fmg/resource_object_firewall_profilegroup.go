@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallProfileGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallProfileGroupCreate,
-		Read:   resourceObjectFirewallProfileGroupRead,
-		Update: resourceObjectFirewallProfileGroupUpdate,
-		Delete: resourceObjectFirewallProfileGroupDelete,
+		CreateContext: resourceObjectFirewallProfileGroupCreate,
+		ReadContext:   resourceObjectFirewallProfileGroupRead,
+		UpdateContext: resourceObjectFirewallProfileGroupUpdate,
+		DeleteContext: resourceObjectFirewallProfileGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -136,81 +138,76 @@ func resourceObjectFirewallProfileGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallProfileGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallProfileGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProfileGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProfileGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProfileGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallProfileGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProfileGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProfileGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProfileGroupRead(d, m)
+	return resourceObjectFirewallProfileGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProfileGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProfileGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProfileGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProfileGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallProfileGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProfileGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProfileGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProfileGroupRead(d, m)
+	return resourceObjectFirewallProfileGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProfileGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallProfileGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallProfileGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallProfileGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -218,34 +215,36 @@ func resourceObjectFirewallProfileGroupDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallProfileGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallProfileGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProfileGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallProfileGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallProfileGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallProfileGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProfileGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallProfileGroup resource from API: %v", err)
 	}
 	return nil
 }
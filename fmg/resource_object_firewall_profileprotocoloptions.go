@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallProfileProtocolOptions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallProfileProtocolOptionsCreate,
-		Read:   resourceObjectFirewallProfileProtocolOptionsRead,
-		Update: resourceObjectFirewallProfileProtocolOptionsUpdate,
-		Delete: resourceObjectFirewallProfileProtocolOptionsDelete,
+		CreateContext: resourceObjectFirewallProfileProtocolOptionsCreate,
+		ReadContext:   resourceObjectFirewallProfileProtocolOptionsRead,
+		UpdateContext: resourceObjectFirewallProfileProtocolOptionsUpdate,
+		DeleteContext: resourceObjectFirewallProfileProtocolOptionsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -910,81 +912,76 @@ func resourceObjectFirewallProfileProtocolOptions() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallProfileProtocolOptionsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallProfileProtocolOptionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProfileProtocolOptions(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProfileProtocolOptions resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProfileProtocolOptions resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallProfileProtocolOptions(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProfileProtocolOptions resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProfileProtocolOptions resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProfileProtocolOptionsRead(d, m)
+	return resourceObjectFirewallProfileProtocolOptionsRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProfileProtocolOptionsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileProtocolOptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProfileProtocolOptions(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProfileProtocolOptions resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProfileProtocolOptions resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallProfileProtocolOptions(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProfileProtocolOptions resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProfileProtocolOptions resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProfileProtocolOptionsRead(d, m)
+	return resourceObjectFirewallProfileProtocolOptionsRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProfileProtocolOptionsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileProtocolOptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallProfileProtocolOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallProfileProtocolOptions resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallProfileProtocolOptions resource: %v", err)
 	}
 
 	d.SetId("")
@@ -992,34 +989,36 @@ func resourceObjectFirewallProfileProtocolOptionsDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectFirewallProfileProtocolOptionsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProfileProtocolOptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallProfileProtocolOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProfileProtocolOptions resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallProfileProtocolOptions resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallProfileProtocolOptions resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallProfileProtocolOptions(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProfileProtocolOptions resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallProfileProtocolOptions resource from API: %v", err)
 	}
 	return nil
 }
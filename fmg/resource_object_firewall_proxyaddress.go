@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallProxyAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallProxyAddressCreate,
-		Read:   resourceObjectFirewallProxyAddressRead,
-		Update: resourceObjectFirewallProxyAddressUpdate,
-		Delete: resourceObjectFirewallProxyAddressDelete,
+		CreateContext: resourceObjectFirewallProxyAddressCreate,
+		ReadContext:   resourceObjectFirewallProxyAddressRead,
+		UpdateContext: resourceObjectFirewallProxyAddressUpdate,
+		DeleteContext: resourceObjectFirewallProxyAddressDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -187,81 +189,76 @@ func resourceObjectFirewallProxyAddress() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallProxyAddressCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallProxyAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProxyAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProxyAddress resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProxyAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallProxyAddress(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProxyAddress resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProxyAddress resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProxyAddressRead(d, m)
+	return resourceObjectFirewallProxyAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProxyAddressUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProxyAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProxyAddress resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProxyAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallProxyAddress(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProxyAddress resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProxyAddress resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProxyAddressRead(d, m)
+	return resourceObjectFirewallProxyAddressRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProxyAddressDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallProxyAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallProxyAddress resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallProxyAddress resource: %v", err)
 	}
 
 	d.SetId("")
@@ -269,34 +266,36 @@ func resourceObjectFirewallProxyAddressDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallProxyAddressRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallProxyAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProxyAddress resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallProxyAddress resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallProxyAddress resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallProxyAddress(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProxyAddress resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallProxyAddress resource from API: %v", err)
 	}
 	return nil
 }
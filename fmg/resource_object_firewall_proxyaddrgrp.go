@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallProxyAddrgrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallProxyAddrgrpCreate,
-		Read:   resourceObjectFirewallProxyAddrgrpRead,
-		Update: resourceObjectFirewallProxyAddrgrpUpdate,
-		Delete: resourceObjectFirewallProxyAddrgrpDelete,
+		CreateContext: resourceObjectFirewallProxyAddrgrpCreate,
+		ReadContext:   resourceObjectFirewallProxyAddrgrpRead,
+		UpdateContext: resourceObjectFirewallProxyAddrgrpUpdate,
+		DeleteContext: resourceObjectFirewallProxyAddrgrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -113,81 +115,76 @@ func resourceObjectFirewallProxyAddrgrp() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallProxyAddrgrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallProxyAddrgrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProxyAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProxyAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProxyAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallProxyAddrgrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallProxyAddrgrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallProxyAddrgrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProxyAddrgrpRead(d, m)
+	return resourceObjectFirewallProxyAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProxyAddrgrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddrgrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallProxyAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProxyAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProxyAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallProxyAddrgrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallProxyAddrgrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallProxyAddrgrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallProxyAddrgrpRead(d, m)
+	return resourceObjectFirewallProxyAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallProxyAddrgrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddrgrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallProxyAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallProxyAddrgrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallProxyAddrgrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -195,34 +192,36 @@ func resourceObjectFirewallProxyAddrgrpDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallProxyAddrgrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallProxyAddrgrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallProxyAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProxyAddrgrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallProxyAddrgrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallProxyAddrgrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallProxyAddrgrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallProxyAddrgrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallProxyAddrgrp resource from API: %v", err)
 	}
 	return nil
 }
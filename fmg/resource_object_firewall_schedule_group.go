@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallScheduleGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallScheduleGroupCreate,
-		Read:   resourceObjectFirewallScheduleGroupRead,
-		Update: resourceObjectFirewallScheduleGroupUpdate,
-		Delete: resourceObjectFirewallScheduleGroupDelete,
+		CreateContext: resourceObjectFirewallScheduleGroupCreate,
+		ReadContext:   resourceObjectFirewallScheduleGroupRead,
+		UpdateContext: resourceObjectFirewallScheduleGroupUpdate,
+		DeleteContext: resourceObjectFirewallScheduleGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,81 +75,76 @@ func resourceObjectFirewallScheduleGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallScheduleGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallScheduleGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallScheduleGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleGroupRead(d, m)
+	return resourceObjectFirewallScheduleGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallScheduleGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleGroupRead(d, m)
+	return resourceObjectFirewallScheduleGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallScheduleGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallScheduleGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallScheduleGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -155,34 +152,36 @@ func resourceObjectFirewallScheduleGroupDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectFirewallScheduleGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallScheduleGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallScheduleGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallScheduleGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallScheduleGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallScheduleGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallScheduleOnetime() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallScheduleOnetimeCreate,
-		Read:   resourceObjectFirewallScheduleOnetimeRead,
-		Update: resourceObjectFirewallScheduleOnetimeUpdate,
-		Delete: resourceObjectFirewallScheduleOnetimeDelete,
+		CreateContext: resourceObjectFirewallScheduleOnetimeCreate,
+		ReadContext:   resourceObjectFirewallScheduleOnetimeRead,
+		UpdateContext: resourceObjectFirewallScheduleOnetimeUpdate,
+		DeleteContext: resourceObjectFirewallScheduleOnetimeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,81 +82,76 @@ func resourceObjectFirewallScheduleOnetime() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallScheduleOnetimeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallScheduleOnetimeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleOnetime(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleOnetime resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleOnetime resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallScheduleOnetime(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleOnetime resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleOnetime resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleOnetimeRead(d, m)
+	return resourceObjectFirewallScheduleOnetimeRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleOnetimeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleOnetimeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleOnetime(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleOnetime resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleOnetime resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallScheduleOnetime(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleOnetime resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleOnetime resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleOnetimeRead(d, m)
+	return resourceObjectFirewallScheduleOnetimeRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleOnetimeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleOnetimeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallScheduleOnetime(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallScheduleOnetime resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallScheduleOnetime resource: %v", err)
 	}
 
 	d.SetId("")
@@ -162,34 +159,36 @@ func resourceObjectFirewallScheduleOnetimeDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectFirewallScheduleOnetimeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleOnetimeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallScheduleOnetime(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleOnetime resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallScheduleOnetime resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallScheduleOnetime resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallScheduleOnetime(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleOnetime resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallScheduleOnetime resource from API: %v", err)
 	}
 	return nil
 }
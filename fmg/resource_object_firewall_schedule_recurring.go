@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallScheduleRecurring() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallScheduleRecurringCreate,
-		Read:   resourceObjectFirewallScheduleRecurringRead,
-		Update: resourceObjectFirewallScheduleRecurringUpdate,
-		Delete: resourceObjectFirewallScheduleRecurringDelete,
+		CreateContext: resourceObjectFirewallScheduleRecurringCreate,
+		ReadContext:   resourceObjectFirewallScheduleRecurringRead,
+		UpdateContext: resourceObjectFirewallScheduleRecurringUpdate,
+		DeleteContext: resourceObjectFirewallScheduleRecurringDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,81 +85,76 @@ func resourceObjectFirewallScheduleRecurring() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallScheduleRecurringCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallScheduleRecurringCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleRecurring(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleRecurring resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleRecurring resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallScheduleRecurring(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallScheduleRecurring resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallScheduleRecurring resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleRecurringRead(d, m)
+	return resourceObjectFirewallScheduleRecurringRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleRecurringUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleRecurringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallScheduleRecurring(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleRecurring resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleRecurring resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallScheduleRecurring(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallScheduleRecurring resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallScheduleRecurring resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallScheduleRecurringRead(d, m)
+	return resourceObjectFirewallScheduleRecurringRead(ctx, d, m)
 }
 
-func resourceObjectFirewallScheduleRecurringDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleRecurringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallScheduleRecurring(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallScheduleRecurring resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallScheduleRecurring resource: %v", err)
 	}
 
 	d.SetId("")
@@ -165,34 +162,36 @@ func resourceObjectFirewallScheduleRecurringDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectFirewallScheduleRecurringRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallScheduleRecurringRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallScheduleRecurring(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleRecurring resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallScheduleRecurring resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallScheduleRecurring resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallScheduleRecurring(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallScheduleRecurring resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallScheduleRecurring resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallServiceCategory() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallServiceCategoryCreate,
-		Read:   resourceObjectFirewallServiceCategoryRead,
-		Update: resourceObjectFirewallServiceCategoryUpdate,
-		Delete: resourceObjectFirewallServiceCategoryDelete,
+		CreateContext: resourceObjectFirewallServiceCategoryCreate,
+		ReadContext:   resourceObjectFirewallServiceCategoryRead,
+		UpdateContext: resourceObjectFirewallServiceCategoryUpdate,
+		DeleteContext: resourceObjectFirewallServiceCategoryDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectFirewallServiceCategory() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallServiceCategoryCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallServiceCategoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceCategory(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceCategory resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceCategory resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallServiceCategory(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceCategory resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceCategory resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceCategoryRead(d, m)
+	return resourceObjectFirewallServiceCategoryRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceCategoryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCategoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceCategory(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceCategory resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceCategory resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallServiceCategory(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceCategory resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceCategory resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceCategoryRead(d, m)
+	return resourceObjectFirewallServiceCategoryRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceCategoryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCategoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallServiceCategory(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallServiceCategory resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallServiceCategory resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectFirewallServiceCategoryDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectFirewallServiceCategoryRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCategoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallServiceCategory(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceCategory resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallServiceCategory resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallServiceCategory resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallServiceCategory(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceCategory resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallServiceCategory resource from API: %v", err)
 	}
 	return nil
 }
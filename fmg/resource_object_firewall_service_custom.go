@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallServiceCustom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallServiceCustomCreate,
-		Read:   resourceObjectFirewallServiceCustomRead,
-		Update: resourceObjectFirewallServiceCustomUpdate,
-		Delete: resourceObjectFirewallServiceCustomDelete,
+		CreateContext: resourceObjectFirewallServiceCustomCreate,
+		ReadContext:   resourceObjectFirewallServiceCustomRead,
+		UpdateContext: resourceObjectFirewallServiceCustomUpdate,
+		DeleteContext: resourceObjectFirewallServiceCustomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -175,81 +177,76 @@ func resourceObjectFirewallServiceCustom() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallServiceCustomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallServiceCustomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceCustom resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallServiceCustom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceCustom resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceCustom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceCustomRead(d, m)
+	return resourceObjectFirewallServiceCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceCustomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCustomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceCustom resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallServiceCustom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceCustom resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceCustom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceCustomRead(d, m)
+	return resourceObjectFirewallServiceCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceCustomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCustomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallServiceCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallServiceCustom resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallServiceCustom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -257,34 +254,36 @@ func resourceObjectFirewallServiceCustomDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectFirewallServiceCustomRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceCustomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallServiceCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceCustom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallServiceCustom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallServiceCustom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallServiceCustom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceCustom resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallServiceCustom resource from API: %v", err)
 	}
 	return nil
 }
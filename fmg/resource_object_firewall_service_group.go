@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallServiceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallServiceGroupCreate,
-		Read:   resourceObjectFirewallServiceGroupRead,
-		Update: resourceObjectFirewallServiceGroupUpdate,
-		Delete: resourceObjectFirewallServiceGroupDelete,
+		CreateContext: resourceObjectFirewallServiceGroupCreate,
+		ReadContext:   resourceObjectFirewallServiceGroupRead,
+		UpdateContext: resourceObjectFirewallServiceGroupUpdate,
+		DeleteContext: resourceObjectFirewallServiceGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -82,81 +84,76 @@ func resourceObjectFirewallServiceGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallServiceGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallServiceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallServiceGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallServiceGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallServiceGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceGroupRead(d, m)
+	return resourceObjectFirewallServiceGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallServiceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallServiceGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallServiceGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallServiceGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallServiceGroupRead(d, m)
+	return resourceObjectFirewallServiceGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallServiceGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallServiceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallServiceGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallServiceGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -164,34 +161,36 @@ func resourceObjectFirewallServiceGroupDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallServiceGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallServiceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallServiceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallServiceGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallServiceGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallServiceGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallServiceGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallServiceGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallShaperPerIpShaper() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallShaperPerIpShaperCreate,
-		Read:   resourceObjectFirewallShaperPerIpShaperRead,
-		Update: resourceObjectFirewallShaperPerIpShaperUpdate,
-		Delete: resourceObjectFirewallShaperPerIpShaperDelete,
+		CreateContext: resourceObjectFirewallShaperPerIpShaperCreate,
+		ReadContext:   resourceObjectFirewallShaperPerIpShaperRead,
+		UpdateContext: resourceObjectFirewallShaperPerIpShaperUpdate,
+		DeleteContext: resourceObjectFirewallShaperPerIpShaperDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -94,81 +96,76 @@ func resourceObjectFirewallShaperPerIpShaper() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallShaperPerIpShaperCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallShaperPerIpShaperCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallShaperPerIpShaper(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallShaperPerIpShaper resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallShaperPerIpShaper resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallShaperPerIpShaper(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallShaperPerIpShaper resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallShaperPerIpShaper resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallShaperPerIpShaperRead(d, m)
+	return resourceObjectFirewallShaperPerIpShaperRead(ctx, d, m)
 }
 
-func resourceObjectFirewallShaperPerIpShaperUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperPerIpShaperUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallShaperPerIpShaper(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallShaperPerIpShaper resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallShaperPerIpShaper resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallShaperPerIpShaper(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallShaperPerIpShaper resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallShaperPerIpShaper resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallShaperPerIpShaperRead(d, m)
+	return resourceObjectFirewallShaperPerIpShaperRead(ctx, d, m)
 }
 
-func resourceObjectFirewallShaperPerIpShaperDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperPerIpShaperDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallShaperPerIpShaper(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallShaperPerIpShaper resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallShaperPerIpShaper resource: %v", err)
 	}
 
 	d.SetId("")
@@ -176,34 +173,36 @@ func resourceObjectFirewallShaperPerIpShaperDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectFirewallShaperPerIpShaperRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperPerIpShaperRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallShaperPerIpShaper(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallShaperPerIpShaper resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallShaperPerIpShaper resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallShaperPerIpShaper resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallShaperPerIpShaper(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallShaperPerIpShaper resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallShaperPerIpShaper resource from API: %v", err)
 	}
 	return nil
 }
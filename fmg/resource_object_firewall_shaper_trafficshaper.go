@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallShaperTrafficShaper() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallShaperTrafficShaperCreate,
-		Read:   resourceObjectFirewallShaperTrafficShaperRead,
-		Update: resourceObjectFirewallShaperTrafficShaperUpdate,
-		Delete: resourceObjectFirewallShaperTrafficShaperDelete,
+		CreateContext: resourceObjectFirewallShaperTrafficShaperCreate,
+		ReadContext:   resourceObjectFirewallShaperTrafficShaperRead,
+		UpdateContext: resourceObjectFirewallShaperTrafficShaperUpdate,
+		DeleteContext: resourceObjectFirewallShaperTrafficShaperDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,81 +116,76 @@ func resourceObjectFirewallShaperTrafficShaper() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallShaperTrafficShaperCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallShaperTrafficShaperCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallShaperTrafficShaper(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallShaperTrafficShaper resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallShaperTrafficShaper resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallShaperTrafficShaper(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallShaperTrafficShaper resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallShaperTrafficShaper resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallShaperTrafficShaperRead(d, m)
+	return resourceObjectFirewallShaperTrafficShaperRead(ctx, d, m)
 }
 
-func resourceObjectFirewallShaperTrafficShaperUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperTrafficShaperUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallShaperTrafficShaper(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallShaperTrafficShaper resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallShaperTrafficShaper resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallShaperTrafficShaper(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallShaperTrafficShaper resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallShaperTrafficShaper resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallShaperTrafficShaperRead(d, m)
+	return resourceObjectFirewallShaperTrafficShaperRead(ctx, d, m)
 }
 
-func resourceObjectFirewallShaperTrafficShaperDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperTrafficShaperDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallShaperTrafficShaper(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallShaperTrafficShaper resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallShaperTrafficShaper resource: %v", err)
 	}
 
 	d.SetId("")
@@ -196,34 +193,36 @@ func resourceObjectFirewallShaperTrafficShaperDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectFirewallShaperTrafficShaperRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallShaperTrafficShaperRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallShaperTrafficShaper(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallShaperTrafficShaper resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallShaperTrafficShaper resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallShaperTrafficShaper resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallShaperTrafficShaper(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallShaperTrafficShaper resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallShaperTrafficShaper resource from API: %v", err)
 	}
 	return nil
 }
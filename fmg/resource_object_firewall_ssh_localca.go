@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallSshLocalCa() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallSshLocalCaCreate,
-		Read:   resourceObjectFirewallSshLocalCaRead,
-		Update: resourceObjectFirewallSshLocalCaUpdate,
-		Delete: resourceObjectFirewallSshLocalCaDelete,
+		CreateContext: resourceObjectFirewallSshLocalCaCreate,
+		ReadContext:   resourceObjectFirewallSshLocalCaRead,
+		UpdateContext: resourceObjectFirewallSshLocalCaUpdate,
+		DeleteContext: resourceObjectFirewallSshLocalCaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -74,81 +76,76 @@ func resourceObjectFirewallSshLocalCa() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallSshLocalCaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallSshLocalCaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallSshLocalCa(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallSshLocalCa resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallSshLocalCa resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallSshLocalCa(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallSshLocalCa resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallSshLocalCa resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallSshLocalCaRead(d, m)
+	return resourceObjectFirewallSshLocalCaRead(ctx, d, m)
 }
 
-func resourceObjectFirewallSshLocalCaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSshLocalCaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallSshLocalCa(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallSshLocalCa resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallSshLocalCa resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallSshLocalCa(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallSshLocalCa resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallSshLocalCa resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallSshLocalCaRead(d, m)
+	return resourceObjectFirewallSshLocalCaRead(ctx, d, m)
 }
 
-func resourceObjectFirewallSshLocalCaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSshLocalCaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallSshLocalCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallSshLocalCa resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallSshLocalCa resource: %v", err)
 	}
 
 	d.SetId("")
@@ -156,34 +153,36 @@ func resourceObjectFirewallSshLocalCaDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectFirewallSshLocalCaRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSshLocalCaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallSshLocalCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallSshLocalCa resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallSshLocalCa resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallSshLocalCa resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallSshLocalCa(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallSshLocalCa resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallSshLocalCa resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallSslSshProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallSslSshProfileCreate,
-		Read:   resourceObjectFirewallSslSshProfileRead,
-		Update: resourceObjectFirewallSslSshProfileUpdate,
-		Delete: resourceObjectFirewallSslSshProfileDelete,
+		CreateContext: resourceObjectFirewallSslSshProfileCreate,
+		ReadContext:   resourceObjectFirewallSslSshProfileRead,
+		UpdateContext: resourceObjectFirewallSslSshProfileUpdate,
+		DeleteContext: resourceObjectFirewallSslSshProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -992,81 +994,76 @@ func resourceObjectFirewallSslSshProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallSslSshProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallSslSshProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallSslSshProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallSslSshProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallSslSshProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallSslSshProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallSslSshProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallSslSshProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallSslSshProfileRead(d, m)
+	return resourceObjectFirewallSslSshProfileRead(ctx, d, m)
 }
 
-func resourceObjectFirewallSslSshProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSslSshProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallSslSshProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallSslSshProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallSslSshProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallSslSshProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallSslSshProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallSslSshProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallSslSshProfileRead(d, m)
+	return resourceObjectFirewallSslSshProfileRead(ctx, d, m)
 }
 
-func resourceObjectFirewallSslSshProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSslSshProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallSslSshProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallSslSshProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallSslSshProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1074,34 +1071,36 @@ func resourceObjectFirewallSslSshProfileDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectFirewallSslSshProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallSslSshProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallSslSshProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallSslSshProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallSslSshProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallSslSshProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallSslSshProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallSslSshProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallSslSshProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallTrafficClass() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallTrafficClassCreate,
-		Read:   resourceObjectFirewallTrafficClassRead,
-		Update: resourceObjectFirewallTrafficClassUpdate,
-		Delete: resourceObjectFirewallTrafficClassDelete,
+		CreateContext: resourceObjectFirewallTrafficClassCreate,
+		ReadContext:   resourceObjectFirewallTrafficClassRead,
+		UpdateContext: resourceObjectFirewallTrafficClassUpdate,
+		DeleteContext: resourceObjectFirewallTrafficClassDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectFirewallTrafficClass() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallTrafficClassCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallTrafficClassCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallTrafficClass(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallTrafficClass resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallTrafficClass resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallTrafficClass(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallTrafficClass resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallTrafficClass resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "class_id")))
 
-	return resourceObjectFirewallTrafficClassRead(d, m)
+	return resourceObjectFirewallTrafficClassRead(ctx, d, m)
 }
 
-func resourceObjectFirewallTrafficClassUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallTrafficClassUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallTrafficClass(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallTrafficClass resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallTrafficClass resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallTrafficClass(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallTrafficClass resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallTrafficClass resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "class_id")))
 
-	return resourceObjectFirewallTrafficClassRead(d, m)
+	return resourceObjectFirewallTrafficClassRead(ctx, d, m)
 }
 
-func resourceObjectFirewallTrafficClassDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallTrafficClassDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallTrafficClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallTrafficClass resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallTrafficClass resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectFirewallTrafficClassDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectFirewallTrafficClassRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallTrafficClassRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallTrafficClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallTrafficClass resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallTrafficClass resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallTrafficClass resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallTrafficClass(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallTrafficClass resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallTrafficClass resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVip() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVipCreate,
-		Read:   resourceObjectFirewallVipRead,
-		Update: resourceObjectFirewallVipUpdate,
-		Delete: resourceObjectFirewallVipDelete,
+		CreateContext: resourceObjectFirewallVipCreate,
+		ReadContext:   resourceObjectFirewallVipRead,
+		UpdateContext: resourceObjectFirewallVipUpdate,
+		DeleteContext: resourceObjectFirewallVipDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -969,81 +971,76 @@ func resourceObjectFirewallVip() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVipCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVip(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipRead(d, m)
+	return resourceObjectFirewallVipRead(ctx, d, m)
 }
 
-func resourceObjectFirewallVipUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVip(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipRead(d, m)
+	return resourceObjectFirewallVipRead(ctx, d, m)
 }
 
-func resourceObjectFirewallVipDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVip(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVip resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVip resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1051,34 +1048,36 @@ func resourceObjectFirewallVipDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectFirewallVipRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVip(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVip resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVip resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVip(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVip resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVip46() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVip46Create,
-		Read:   resourceObjectFirewallVip46Read,
-		Update: resourceObjectFirewallVip46Update,
-		Delete: resourceObjectFirewallVip46Delete,
+		CreateContext: resourceObjectFirewallVip46Create,
+		ReadContext:   resourceObjectFirewallVip46Read,
+		UpdateContext: resourceObjectFirewallVip46Update,
+		DeleteContext: resourceObjectFirewallVip46Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -287,81 +289,76 @@ func resourceObjectFirewallVip46() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVip46Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVip46Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip46(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip46 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip46 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVip46(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip46 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip46 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip46Read(d, m)
+	return resourceObjectFirewallVip46Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip46Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip46Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip46(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip46 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip46 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVip46(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip46 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip46 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip46Read(d, m)
+	return resourceObjectFirewallVip46Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip46Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip46Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVip46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVip46 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVip46 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -369,34 +366,36 @@ func resourceObjectFirewallVip46Delete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectFirewallVip46Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip46Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVip46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip46 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVip46 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVip46 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVip46(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip46 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVip46 resource from API: %v", err)
 	}
 	return nil
 }
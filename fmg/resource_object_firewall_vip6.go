@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVip6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVip6Create,
-		Read:   resourceObjectFirewallVip6Read,
-		Update: resourceObjectFirewallVip6Update,
-		Delete: resourceObjectFirewallVip6Delete,
+		CreateContext: resourceObjectFirewallVip6Create,
+		ReadContext:   resourceObjectFirewallVip6Read,
+		UpdateContext: resourceObjectFirewallVip6Update,
+		DeleteContext: resourceObjectFirewallVip6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -919,81 +921,76 @@ func resourceObjectFirewallVip6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVip6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVip6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVip6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip6Read(d, m)
+	return resourceObjectFirewallVip6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVip6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip6Read(d, m)
+	return resourceObjectFirewallVip6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVip6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVip6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVip6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1001,34 +998,36 @@ func resourceObjectFirewallVip6Delete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectFirewallVip6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVip6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVip6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVip6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVip6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVip6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVip64() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVip64Create,
-		Read:   resourceObjectFirewallVip64Read,
-		Update: resourceObjectFirewallVip64Update,
-		Delete: resourceObjectFirewallVip64Delete,
+		CreateContext: resourceObjectFirewallVip64Create,
+		ReadContext:   resourceObjectFirewallVip64Read,
+		UpdateContext: resourceObjectFirewallVip64Update,
+		DeleteContext: resourceObjectFirewallVip64Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -279,81 +281,76 @@ func resourceObjectFirewallVip64() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVip64Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVip64Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip64(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip64 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip64 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVip64(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVip64 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVip64 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip64Read(d, m)
+	return resourceObjectFirewallVip64Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip64Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip64Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVip64(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip64 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip64 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVip64(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVip64 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVip64 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVip64Read(d, m)
+	return resourceObjectFirewallVip64Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVip64Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip64Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVip64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVip64 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVip64 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -361,34 +358,36 @@ func resourceObjectFirewallVip64Delete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectFirewallVip64Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVip64Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVip64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip64 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVip64 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVip64 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVip64(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVip64 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVip64 resource from API: %v", err)
 	}
 	return nil
 }
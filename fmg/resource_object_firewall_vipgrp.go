@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVipgrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVipgrpCreate,
-		Read:   resourceObjectFirewallVipgrpRead,
-		Update: resourceObjectFirewallVipgrpUpdate,
-		Delete: resourceObjectFirewallVipgrpDelete,
+		CreateContext: resourceObjectFirewallVipgrpCreate,
+		ReadContext:   resourceObjectFirewallVipgrpRead,
+		UpdateContext: resourceObjectFirewallVipgrpUpdate,
+		DeleteContext: resourceObjectFirewallVipgrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -127,81 +129,76 @@ func resourceObjectFirewallVipgrp() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVipgrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVipgrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVipgrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrpRead(d, m)
+	return resourceObjectFirewallVipgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVipgrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrpRead(d, m)
+	return resourceObjectFirewallVipgrpRead(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVipgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVipgrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVipgrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -209,34 +206,36 @@ func resourceObjectFirewallVipgrpDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectFirewallVipgrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVipgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVipgrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVipgrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVipgrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVipgrp resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVipgrp46() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVipgrp46Create,
-		Read:   resourceObjectFirewallVipgrp46Read,
-		Update: resourceObjectFirewallVipgrp46Update,
-		Delete: resourceObjectFirewallVipgrp46Delete,
+		CreateContext: resourceObjectFirewallVipgrp46Create,
+		ReadContext:   resourceObjectFirewallVipgrp46Read,
+		UpdateContext: resourceObjectFirewallVipgrp46Update,
+		DeleteContext: resourceObjectFirewallVipgrp46Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,81 +75,76 @@ func resourceObjectFirewallVipgrp46() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVipgrp46Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVipgrp46Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp46(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp46 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp46 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVipgrp46(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp46 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp46 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp46Read(d, m)
+	return resourceObjectFirewallVipgrp46Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp46Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp46Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp46(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp46 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp46 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVipgrp46(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp46 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp46 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp46Read(d, m)
+	return resourceObjectFirewallVipgrp46Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp46Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp46Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVipgrp46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVipgrp46 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVipgrp46 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -155,34 +152,36 @@ func resourceObjectFirewallVipgrp46Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallVipgrp46Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp46Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVipgrp46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp46 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVipgrp46 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVipgrp46 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVipgrp46(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp46 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVipgrp46 resource from API: %v", err)
 	}
 	return nil
 }
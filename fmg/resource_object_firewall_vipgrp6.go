@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVipgrp6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVipgrp6Create,
-		Read:   resourceObjectFirewallVipgrp6Read,
-		Update: resourceObjectFirewallVipgrp6Update,
-		Delete: resourceObjectFirewallVipgrp6Delete,
+		CreateContext: resourceObjectFirewallVipgrp6Create,
+		ReadContext:   resourceObjectFirewallVipgrp6Read,
+		UpdateContext: resourceObjectFirewallVipgrp6Update,
+		DeleteContext: resourceObjectFirewallVipgrp6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,81 +75,76 @@ func resourceObjectFirewallVipgrp6() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVipgrp6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVipgrp6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVipgrp6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp6Read(d, m)
+	return resourceObjectFirewallVipgrp6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVipgrp6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp6Read(d, m)
+	return resourceObjectFirewallVipgrp6Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVipgrp6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVipgrp6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVipgrp6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -155,34 +152,36 @@ func resourceObjectFirewallVipgrp6Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallVipgrp6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVipgrp6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVipgrp6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVipgrp6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVipgrp6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVipgrp6 resource from API: %v", err)
 	}
 	return nil
 }
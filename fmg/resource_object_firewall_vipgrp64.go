@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallVipgrp64() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallVipgrp64Create,
-		Read:   resourceObjectFirewallVipgrp64Read,
-		Update: resourceObjectFirewallVipgrp64Update,
-		Delete: resourceObjectFirewallVipgrp64Delete,
+		CreateContext: resourceObjectFirewallVipgrp64Create,
+		ReadContext:   resourceObjectFirewallVipgrp64Read,
+		UpdateContext: resourceObjectFirewallVipgrp64Update,
+		DeleteContext: resourceObjectFirewallVipgrp64Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,81 +75,76 @@ func resourceObjectFirewallVipgrp64() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallVipgrp64Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallVipgrp64Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp64(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp64 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp64 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallVipgrp64(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallVipgrp64 resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallVipgrp64 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp64Read(d, m)
+	return resourceObjectFirewallVipgrp64Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp64Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp64Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallVipgrp64(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp64 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp64 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallVipgrp64(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallVipgrp64 resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallVipgrp64 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallVipgrp64Read(d, m)
+	return resourceObjectFirewallVipgrp64Read(ctx, d, m)
 }
 
-func resourceObjectFirewallVipgrp64Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp64Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallVipgrp64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallVipgrp64 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallVipgrp64 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -155,34 +152,36 @@ func resourceObjectFirewallVipgrp64Delete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectFirewallVipgrp64Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallVipgrp64Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallVipgrp64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp64 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallVipgrp64 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallVipgrp64 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallVipgrp64(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallVipgrp64 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallVipgrp64 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallWildcardFqdnCustom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallWildcardFqdnCustomCreate,
-		Read:   resourceObjectFirewallWildcardFqdnCustomRead,
-		Update: resourceObjectFirewallWildcardFqdnCustomUpdate,
-		Delete: resourceObjectFirewallWildcardFqdnCustomDelete,
+		CreateContext: resourceObjectFirewallWildcardFqdnCustomCreate,
+		ReadContext:   resourceObjectFirewallWildcardFqdnCustomRead,
+		UpdateContext: resourceObjectFirewallWildcardFqdnCustomUpdate,
+		DeleteContext: resourceObjectFirewallWildcardFqdnCustomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,81 +77,76 @@ func resourceObjectFirewallWildcardFqdnCustom() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallWildcardFqdnCustomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallWildcardFqdnCustomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallWildcardFqdnCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallWildcardFqdnCustom resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallWildcardFqdnCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallWildcardFqdnCustom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallWildcardFqdnCustom resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallWildcardFqdnCustom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallWildcardFqdnCustomRead(d, m)
+	return resourceObjectFirewallWildcardFqdnCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallWildcardFqdnCustomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnCustomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallWildcardFqdnCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallWildcardFqdnCustom resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallWildcardFqdnCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallWildcardFqdnCustom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallWildcardFqdnCustom resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallWildcardFqdnCustom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallWildcardFqdnCustomRead(d, m)
+	return resourceObjectFirewallWildcardFqdnCustomRead(ctx, d, m)
 }
 
-func resourceObjectFirewallWildcardFqdnCustomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnCustomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallWildcardFqdnCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallWildcardFqdnCustom resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallWildcardFqdnCustom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -157,34 +154,36 @@ func resourceObjectFirewallWildcardFqdnCustomDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectFirewallWildcardFqdnCustomRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnCustomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallWildcardFqdnCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallWildcardFqdnCustom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnCustom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnCustom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallWildcardFqdnCustom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallWildcardFqdnCustom resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnCustom resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFirewallWildcardFqdnGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFirewallWildcardFqdnGroupCreate,
-		Read:   resourceObjectFirewallWildcardFqdnGroupRead,
-		Update: resourceObjectFirewallWildcardFqdnGroupUpdate,
-		Delete: resourceObjectFirewallWildcardFqdnGroupDelete,
+		CreateContext: resourceObjectFirewallWildcardFqdnGroupCreate,
+		ReadContext:   resourceObjectFirewallWildcardFqdnGroupRead,
+		UpdateContext: resourceObjectFirewallWildcardFqdnGroupUpdate,
+		DeleteContext: resourceObjectFirewallWildcardFqdnGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,81 +79,76 @@ func resourceObjectFirewallWildcardFqdnGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectFirewallWildcardFqdnGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFirewallWildcardFqdnGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallWildcardFqdnGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallWildcardFqdnGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallWildcardFqdnGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFirewallWildcardFqdnGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFirewallWildcardFqdnGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectFirewallWildcardFqdnGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallWildcardFqdnGroupRead(d, m)
+	return resourceObjectFirewallWildcardFqdnGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallWildcardFqdnGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFirewallWildcardFqdnGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallWildcardFqdnGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallWildcardFqdnGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFirewallWildcardFqdnGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFirewallWildcardFqdnGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectFirewallWildcardFqdnGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFirewallWildcardFqdnGroupRead(d, m)
+	return resourceObjectFirewallWildcardFqdnGroupRead(ctx, d, m)
 }
 
-func resourceObjectFirewallWildcardFqdnGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFirewallWildcardFqdnGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFirewallWildcardFqdnGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFirewallWildcardFqdnGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -159,34 +156,36 @@ func resourceObjectFirewallWildcardFqdnGroupDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectFirewallWildcardFqdnGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFirewallWildcardFqdnGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFirewallWildcardFqdnGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallWildcardFqdnGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFirewallWildcardFqdnGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFirewallWildcardFqdnGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFirewallWildcardFqdnGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFmgDeviceBlueprint() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFmgDeviceBlueprintCreate,
-		Read:   resourceObjectFmgDeviceBlueprintRead,
-		Update: resourceObjectFmgDeviceBlueprintUpdate,
-		Delete: resourceObjectFmgDeviceBlueprintDelete,
+		CreateContext: resourceObjectFmgDeviceBlueprintCreate,
+		ReadContext:   resourceObjectFmgDeviceBlueprintRead,
+		UpdateContext: resourceObjectFmgDeviceBlueprintUpdate,
+		DeleteContext: resourceObjectFmgDeviceBlueprintDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -106,81 +108,76 @@ func resourceObjectFmgDeviceBlueprint() *schema.Resource {
 	}
 }
 
-func resourceObjectFmgDeviceBlueprintCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFmgDeviceBlueprintCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgDeviceBlueprint(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgDeviceBlueprint resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFmgDeviceBlueprint resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFmgDeviceBlueprint(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgDeviceBlueprint resource: %v", err)
+		return diag.Errorf("Error creating ObjectFmgDeviceBlueprint resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgDeviceBlueprintRead(d, m)
+	return resourceObjectFmgDeviceBlueprintRead(ctx, d, m)
 }
 
-func resourceObjectFmgDeviceBlueprintUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgDeviceBlueprintUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgDeviceBlueprint(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgDeviceBlueprint resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFmgDeviceBlueprint resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFmgDeviceBlueprint(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgDeviceBlueprint resource: %v", err)
+		return diag.Errorf("Error updating ObjectFmgDeviceBlueprint resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgDeviceBlueprintRead(d, m)
+	return resourceObjectFmgDeviceBlueprintRead(ctx, d, m)
 }
 
-func resourceObjectFmgDeviceBlueprintDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgDeviceBlueprintDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFmgDeviceBlueprint(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFmgDeviceBlueprint resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFmgDeviceBlueprint resource: %v", err)
 	}
 
 	d.SetId("")
@@ -188,34 +185,36 @@ func resourceObjectFmgDeviceBlueprintDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectFmgDeviceBlueprintRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgDeviceBlueprintRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFmgDeviceBlueprint(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgDeviceBlueprint resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFmgDeviceBlueprint resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFmgDeviceBlueprint resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFmgDeviceBlueprint(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgDeviceBlueprint resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFmgDeviceBlueprint resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFmgFabricAuthorizationTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFmgFabricAuthorizationTemplateCreate,
-		Read:   resourceObjectFmgFabricAuthorizationTemplateRead,
-		Update: resourceObjectFmgFabricAuthorizationTemplateUpdate,
-		Delete: resourceObjectFmgFabricAuthorizationTemplateDelete,
+		CreateContext: resourceObjectFmgFabricAuthorizationTemplateCreate,
+		ReadContext:   resourceObjectFmgFabricAuthorizationTemplateRead,
+		UpdateContext: resourceObjectFmgFabricAuthorizationTemplateUpdate,
+		DeleteContext: resourceObjectFmgFabricAuthorizationTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -104,81 +106,76 @@ func resourceObjectFmgFabricAuthorizationTemplate() *schema.Resource {
 	}
 }
 
-func resourceObjectFmgFabricAuthorizationTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFmgFabricAuthorizationTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgFabricAuthorizationTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgFabricAuthorizationTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFmgFabricAuthorizationTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFmgFabricAuthorizationTemplate(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgFabricAuthorizationTemplate resource: %v", err)
+		return diag.Errorf("Error creating ObjectFmgFabricAuthorizationTemplate resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgFabricAuthorizationTemplateRead(d, m)
+	return resourceObjectFmgFabricAuthorizationTemplateRead(ctx, d, m)
 }
 
-func resourceObjectFmgFabricAuthorizationTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgFabricAuthorizationTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgFabricAuthorizationTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgFabricAuthorizationTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFmgFabricAuthorizationTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFmgFabricAuthorizationTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgFabricAuthorizationTemplate resource: %v", err)
+		return diag.Errorf("Error updating ObjectFmgFabricAuthorizationTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgFabricAuthorizationTemplateRead(d, m)
+	return resourceObjectFmgFabricAuthorizationTemplateRead(ctx, d, m)
 }
 
-func resourceObjectFmgFabricAuthorizationTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgFabricAuthorizationTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFmgFabricAuthorizationTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFmgFabricAuthorizationTemplate resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFmgFabricAuthorizationTemplate resource: %v", err)
 	}
 
 	d.SetId("")
@@ -186,34 +183,36 @@ func resourceObjectFmgFabricAuthorizationTemplateDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectFmgFabricAuthorizationTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgFabricAuthorizationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFmgFabricAuthorizationTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgFabricAuthorizationTemplate resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFmgFabricAuthorizationTemplate resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFmgFabricAuthorizationTemplate resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFmgFabricAuthorizationTemplate(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgFabricAuthorizationTemplate resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFmgFabricAuthorizationTemplate resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFmgVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFmgVariableCreate,
-		Read:   resourceObjectFmgVariableRead,
-		Update: resourceObjectFmgVariableUpdate,
-		Delete: resourceObjectFmgVariableDelete,
+		CreateContext: resourceObjectFmgVariableCreate,
+		ReadContext:   resourceObjectFmgVariableRead,
+		UpdateContext: resourceObjectFmgVariableUpdate,
+		DeleteContext: resourceObjectFmgVariableDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,81 +97,76 @@ func resourceObjectFmgVariable() *schema.Resource {
 	}
 }
 
-func resourceObjectFmgVariableCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFmgVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgVariable(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgVariable resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFmgVariable resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFmgVariable(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFmgVariable resource: %v", err)
+		return diag.Errorf("Error creating ObjectFmgVariable resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgVariableRead(d, m)
+	return resourceObjectFmgVariableRead(ctx, d, m)
 }
 
-func resourceObjectFmgVariableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFmgVariable(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgVariable resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFmgVariable resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFmgVariable(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFmgVariable resource: %v", err)
+		return diag.Errorf("Error updating ObjectFmgVariable resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFmgVariableRead(d, m)
+	return resourceObjectFmgVariableRead(ctx, d, m)
 }
 
-func resourceObjectFmgVariableDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFmgVariable(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFmgVariable resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFmgVariable resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,34 +174,36 @@ func resourceObjectFmgVariableDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectFmgVariableRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFmgVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFmgVariable(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgVariable resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFmgVariable resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFmgVariable resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFmgVariable(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFmgVariable resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFmgVariable resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectFspVlan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectFspVlanCreate,
-		Read:   resourceObjectFspVlanRead,
-		Update: resourceObjectFspVlanUpdate,
-		Delete: resourceObjectFspVlanDelete,
+		CreateContext: resourceObjectFspVlanCreate,
+		ReadContext:   resourceObjectFspVlanRead,
+		UpdateContext: resourceObjectFspVlanUpdate,
+		DeleteContext: resourceObjectFspVlanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -3113,81 +3115,76 @@ func resourceObjectFspVlan() *schema.Resource {
 	}
 }
 
-func resourceObjectFspVlanCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectFspVlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFspVlan(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFspVlan resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectFspVlan resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectFspVlan(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectFspVlan resource: %v", err)
+		return diag.Errorf("Error creating ObjectFspVlan resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFspVlanRead(d, m)
+	return resourceObjectFspVlanRead(ctx, d, m)
 }
 
-func resourceObjectFspVlanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFspVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectFspVlan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFspVlan resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectFspVlan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectFspVlan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectFspVlan resource: %v", err)
+		return diag.Errorf("Error updating ObjectFspVlan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectFspVlanRead(d, m)
+	return resourceObjectFspVlanRead(ctx, d, m)
 }
 
-func resourceObjectFspVlanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFspVlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectFspVlan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectFspVlan resource: %v", err)
+		return diag.Errorf("Error deleting ObjectFspVlan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -3195,34 +3192,36 @@ func resourceObjectFspVlanDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectFspVlanRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectFspVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectFspVlan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFspVlan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectFspVlan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectFspVlan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectFspVlan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectFspVlan resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectFspVlan resource from API: %v", err)
 	}
 	return nil
 }
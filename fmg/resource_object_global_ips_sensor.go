@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectGlobalIpsSensor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectGlobalIpsSensorCreate,
-		Read:   resourceObjectGlobalIpsSensorRead,
-		Update: resourceObjectGlobalIpsSensorUpdate,
-		Delete: resourceObjectGlobalIpsSensorDelete,
+		CreateContext: resourceObjectGlobalIpsSensorCreate,
+		ReadContext:   resourceObjectGlobalIpsSensorRead,
+		UpdateContext: resourceObjectGlobalIpsSensorUpdate,
+		DeleteContext: resourceObjectGlobalIpsSensorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -386,81 +388,76 @@ func resourceObjectGlobalIpsSensor() *schema.Resource {
 	}
 }
 
-func resourceObjectGlobalIpsSensorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectGlobalIpsSensorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectGlobalIpsSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectGlobalIpsSensor resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectGlobalIpsSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectGlobalIpsSensor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectGlobalIpsSensor resource: %v", err)
+		return diag.Errorf("Error creating ObjectGlobalIpsSensor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectGlobalIpsSensorRead(d, m)
+	return resourceObjectGlobalIpsSensorRead(ctx, d, m)
 }
 
-func resourceObjectGlobalIpsSensorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectGlobalIpsSensorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectGlobalIpsSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectGlobalIpsSensor resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectGlobalIpsSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectGlobalIpsSensor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectGlobalIpsSensor resource: %v", err)
+		return diag.Errorf("Error updating ObjectGlobalIpsSensor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectGlobalIpsSensorRead(d, m)
+	return resourceObjectGlobalIpsSensorRead(ctx, d, m)
 }
 
-func resourceObjectGlobalIpsSensorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectGlobalIpsSensorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectGlobalIpsSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectGlobalIpsSensor resource: %v", err)
+		return diag.Errorf("Error deleting ObjectGlobalIpsSensor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -468,34 +465,36 @@ func resourceObjectGlobalIpsSensorDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectGlobalIpsSensorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectGlobalIpsSensorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectGlobalIpsSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectGlobalIpsSensor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectGlobalIpsSensor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectGlobalIpsSensor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectGlobalIpsSensor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectGlobalIpsSensor resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectGlobalIpsSensor resource from API: %v", err)
 	}
 	return nil
 }
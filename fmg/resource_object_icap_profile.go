@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectIcapProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectIcapProfileCreate,
-		Read:   resourceObjectIcapProfileRead,
-		Update: resourceObjectIcapProfileUpdate,
-		Delete: resourceObjectIcapProfileDelete,
+		CreateContext: resourceObjectIcapProfileCreate,
+		ReadContext:   resourceObjectIcapProfileRead,
+		UpdateContext: resourceObjectIcapProfileUpdate,
+		DeleteContext: resourceObjectIcapProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -258,81 +260,76 @@ func resourceObjectIcapProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectIcapProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectIcapProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIcapProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIcapProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectIcapProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectIcapProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIcapProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectIcapProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIcapProfileRead(d, m)
+	return resourceObjectIcapProfileRead(ctx, d, m)
 }
 
-func resourceObjectIcapProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIcapProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIcapProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectIcapProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectIcapProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIcapProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectIcapProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIcapProfileRead(d, m)
+	return resourceObjectIcapProfileRead(ctx, d, m)
 }
 
-func resourceObjectIcapProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectIcapProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectIcapProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectIcapProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -340,34 +337,36 @@ func resourceObjectIcapProfileDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectIcapProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectIcapProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIcapProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectIcapProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectIcapProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectIcapProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIcapProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectIcapProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectIcapServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectIcapServerCreate,
-		Read:   resourceObjectIcapServerRead,
-		Update: resourceObjectIcapServerUpdate,
-		Delete: resourceObjectIcapServerDelete,
+		CreateContext: resourceObjectIcapServerCreate,
+		ReadContext:   resourceObjectIcapServerRead,
+		UpdateContext: resourceObjectIcapServerUpdate,
+		DeleteContext: resourceObjectIcapServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -105,81 +107,76 @@ func resourceObjectIcapServer() *schema.Resource {
 	}
 }
 
-func resourceObjectIcapServerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectIcapServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIcapServer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIcapServer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectIcapServer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectIcapServer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIcapServer resource: %v", err)
+		return diag.Errorf("Error creating ObjectIcapServer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIcapServerRead(d, m)
+	return resourceObjectIcapServerRead(ctx, d, m)
 }
 
-func resourceObjectIcapServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIcapServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIcapServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectIcapServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectIcapServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIcapServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectIcapServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIcapServerRead(d, m)
+	return resourceObjectIcapServerRead(ctx, d, m)
 }
 
-func resourceObjectIcapServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectIcapServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectIcapServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectIcapServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -187,34 +184,36 @@ func resourceObjectIcapServerDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectIcapServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIcapServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectIcapServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIcapServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectIcapServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectIcapServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectIcapServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIcapServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectIcapServer resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectIpsCustom() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectIpsCustomCreate,
-		Read:   resourceObjectIpsCustomRead,
-		Update: resourceObjectIpsCustomUpdate,
-		Delete: resourceObjectIpsCustomDelete,
+		CreateContext: resourceObjectIpsCustomCreate,
+		ReadContext:   resourceObjectIpsCustomRead,
+		UpdateContext: resourceObjectIpsCustomUpdate,
+		DeleteContext: resourceObjectIpsCustomDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -118,81 +120,76 @@ func resourceObjectIpsCustom() *schema.Resource {
 	}
 }
 
-func resourceObjectIpsCustomCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectIpsCustomCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIpsCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIpsCustom resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectIpsCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectIpsCustom(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIpsCustom resource: %v", err)
+		return diag.Errorf("Error creating ObjectIpsCustom resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "tag"))
 
-	return resourceObjectIpsCustomRead(d, m)
+	return resourceObjectIpsCustomRead(ctx, d, m)
 }
 
-func resourceObjectIpsCustomUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsCustomUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIpsCustom(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIpsCustom resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectIpsCustom resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectIpsCustom(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIpsCustom resource: %v", err)
+		return diag.Errorf("Error updating ObjectIpsCustom resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "tag"))
 
-	return resourceObjectIpsCustomRead(d, m)
+	return resourceObjectIpsCustomRead(ctx, d, m)
 }
 
-func resourceObjectIpsCustomDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsCustomDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectIpsCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectIpsCustom resource: %v", err)
+		return diag.Errorf("Error deleting ObjectIpsCustom resource: %v", err)
 	}
 
 	d.SetId("")
@@ -200,34 +197,36 @@ func resourceObjectIpsCustomDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectIpsCustomRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsCustomRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectIpsCustom(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIpsCustom resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectIpsCustom resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectIpsCustom resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectIpsCustom(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIpsCustom resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectIpsCustom resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectIpsSensor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectIpsSensorCreate,
-		Read:   resourceObjectIpsSensorRead,
-		Update: resourceObjectIpsSensorUpdate,
-		Delete: resourceObjectIpsSensorDelete,
+		CreateContext: resourceObjectIpsSensorCreate,
+		ReadContext:   resourceObjectIpsSensorRead,
+		UpdateContext: resourceObjectIpsSensorUpdate,
+		DeleteContext: resourceObjectIpsSensorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -233,81 +235,76 @@ func resourceObjectIpsSensor() *schema.Resource {
 	}
 }
 
-func resourceObjectIpsSensorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectIpsSensorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIpsSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIpsSensor resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectIpsSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectIpsSensor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectIpsSensor resource: %v", err)
+		return diag.Errorf("Error creating ObjectIpsSensor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIpsSensorRead(d, m)
+	return resourceObjectIpsSensorRead(ctx, d, m)
 }
 
-func resourceObjectIpsSensorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsSensorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectIpsSensor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIpsSensor resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectIpsSensor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectIpsSensor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectIpsSensor resource: %v", err)
+		return diag.Errorf("Error updating ObjectIpsSensor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectIpsSensorRead(d, m)
+	return resourceObjectIpsSensorRead(ctx, d, m)
 }
 
-func resourceObjectIpsSensorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsSensorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectIpsSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectIpsSensor resource: %v", err)
+		return diag.Errorf("Error deleting ObjectIpsSensor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -315,34 +312,36 @@ func resourceObjectIpsSensorDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectIpsSensorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectIpsSensorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectIpsSensor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIpsSensor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectIpsSensor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectIpsSensor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectIpsSensor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectIpsSensor resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectIpsSensor resource from API: %v", err)
 	}
 	return nil
 }
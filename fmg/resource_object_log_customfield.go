@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectLogCustomField() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectLogCustomFieldCreate,
-		Read:   resourceObjectLogCustomFieldRead,
-		Update: resourceObjectLogCustomFieldUpdate,
-		Delete: resourceObjectLogCustomFieldDelete,
+		CreateContext: resourceObjectLogCustomFieldCreate,
+		ReadContext:   resourceObjectLogCustomFieldRead,
+		UpdateContext: resourceObjectLogCustomFieldUpdate,
+		DeleteContext: resourceObjectLogCustomFieldDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectLogCustomField() *schema.Resource {
 	}
 }
 
-func resourceObjectLogCustomFieldCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectLogCustomFieldCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogCustomField(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogCustomField resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectLogCustomField resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectLogCustomField(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogCustomField resource: %v", err)
+		return diag.Errorf("Error creating ObjectLogCustomField resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectLogCustomFieldRead(d, m)
+	return resourceObjectLogCustomFieldRead(ctx, d, m)
 }
 
-func resourceObjectLogCustomFieldUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogCustomFieldUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogCustomField(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogCustomField resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectLogCustomField resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectLogCustomField(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogCustomField resource: %v", err)
+		return diag.Errorf("Error updating ObjectLogCustomField resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectLogCustomFieldRead(d, m)
+	return resourceObjectLogCustomFieldRead(ctx, d, m)
 }
 
-func resourceObjectLogCustomFieldDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogCustomFieldDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectLogCustomField(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectLogCustomField resource: %v", err)
+		return diag.Errorf("Error deleting ObjectLogCustomField resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectLogCustomFieldDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectLogCustomFieldRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogCustomFieldRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectLogCustomField(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogCustomField resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectLogCustomField resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectLogCustomField resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectLogCustomField(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogCustomField resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectLogCustomField resource from API: %v", err)
 	}
 	return nil
 }
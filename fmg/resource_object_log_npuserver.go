@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectLogNpuServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectLogNpuServerUpdate,
-		Read:   resourceObjectLogNpuServerRead,
-		Update: resourceObjectLogNpuServerUpdate,
-		Delete: resourceObjectLogNpuServerDelete,
+		CreateContext: resourceObjectLogNpuServerUpdate,
+		ReadContext:   resourceObjectLogNpuServerRead,
+		UpdateContext: resourceObjectLogNpuServerUpdate,
+		DeleteContext: resourceObjectLogNpuServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -170,53 +172,49 @@ func resourceObjectLogNpuServer() *schema.Resource {
 	}
 }
 
-func resourceObjectLogNpuServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogNpuServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectLogNpuServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectLogNpuServer")
 
-	return resourceObjectLogNpuServerRead(d, m)
+	return resourceObjectLogNpuServerRead(ctx, d, m)
 }
 
-func resourceObjectLogNpuServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectLogNpuServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectLogNpuServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectLogNpuServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -224,34 +222,36 @@ func resourceObjectLogNpuServerDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectLogNpuServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectLogNpuServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectLogNpuServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectLogNpuServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectLogNpuServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectLogNpuServer resource from API: %v", err)
 	}
 	return nil
 }
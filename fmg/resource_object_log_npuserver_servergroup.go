@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectLogNpuServerServerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectLogNpuServerServerGroupCreate,
-		Read:   resourceObjectLogNpuServerServerGroupRead,
-		Update: resourceObjectLogNpuServerServerGroupUpdate,
-		Delete: resourceObjectLogNpuServerServerGroupDelete,
+		CreateContext: resourceObjectLogNpuServerServerGroupCreate,
+		ReadContext:   resourceObjectLogNpuServerServerGroupRead,
+		UpdateContext: resourceObjectLogNpuServerServerGroupUpdate,
+		DeleteContext: resourceObjectLogNpuServerServerGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -89,81 +91,76 @@ func resourceObjectLogNpuServerServerGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectLogNpuServerServerGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectLogNpuServerServerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogNpuServerServerGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogNpuServerServerGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectLogNpuServerServerGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectLogNpuServerServerGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogNpuServerServerGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectLogNpuServerServerGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "group_name"))
 
-	return resourceObjectLogNpuServerServerGroupRead(d, m)
+	return resourceObjectLogNpuServerServerGroupRead(ctx, d, m)
 }
 
-func resourceObjectLogNpuServerServerGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogNpuServerServerGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServerServerGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServerServerGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectLogNpuServerServerGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServerServerGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServerServerGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "group_name"))
 
-	return resourceObjectLogNpuServerServerGroupRead(d, m)
+	return resourceObjectLogNpuServerServerGroupRead(ctx, d, m)
 }
 
-func resourceObjectLogNpuServerServerGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectLogNpuServerServerGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectLogNpuServerServerGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectLogNpuServerServerGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -171,34 +168,36 @@ func resourceObjectLogNpuServerServerGroupDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectLogNpuServerServerGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectLogNpuServerServerGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServerServerGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectLogNpuServerServerGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectLogNpuServerServerGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectLogNpuServerServerGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServerServerGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectLogNpuServerServerGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectLogNpuServerServerInfo() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectLogNpuServerServerInfoCreate,
-		Read:   resourceObjectLogNpuServerServerInfoRead,
-		Update: resourceObjectLogNpuServerServerInfoUpdate,
-		Delete: resourceObjectLogNpuServerServerInfoDelete,
+		CreateContext: resourceObjectLogNpuServerServerInfoCreate,
+		ReadContext:   resourceObjectLogNpuServerServerInfoRead,
+		UpdateContext: resourceObjectLogNpuServerServerInfoUpdate,
+		DeleteContext: resourceObjectLogNpuServerServerInfoDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -86,81 +88,76 @@ func resourceObjectLogNpuServerServerInfo() *schema.Resource {
 	}
 }
 
-func resourceObjectLogNpuServerServerInfoCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectLogNpuServerServerInfoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogNpuServerServerInfo(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogNpuServerServerInfo resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectLogNpuServerServerInfo resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectLogNpuServerServerInfo(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectLogNpuServerServerInfo resource: %v", err)
+		return diag.Errorf("Error creating ObjectLogNpuServerServerInfo resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectLogNpuServerServerInfoRead(d, m)
+	return resourceObjectLogNpuServerServerInfoRead(ctx, d, m)
 }
 
-func resourceObjectLogNpuServerServerInfoUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerInfoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectLogNpuServerServerInfo(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServerServerInfo resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServerServerInfo resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectLogNpuServerServerInfo(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectLogNpuServerServerInfo resource: %v", err)
+		return diag.Errorf("Error updating ObjectLogNpuServerServerInfo resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectLogNpuServerServerInfoRead(d, m)
+	return resourceObjectLogNpuServerServerInfoRead(ctx, d, m)
 }
 
-func resourceObjectLogNpuServerServerInfoDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerInfoDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectLogNpuServerServerInfo(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectLogNpuServerServerInfo resource: %v", err)
+		return diag.Errorf("Error deleting ObjectLogNpuServerServerInfo resource: %v", err)
 	}
 
 	d.SetId("")
@@ -168,34 +165,36 @@ func resourceObjectLogNpuServerServerInfoDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectLogNpuServerServerInfoRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectLogNpuServerServerInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectLogNpuServerServerInfo(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServerServerInfo resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectLogNpuServerServerInfo resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectLogNpuServerServerInfo resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectLogNpuServerServerInfo(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectLogNpuServerServerInfo resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectLogNpuServerServerInfo resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterAccessList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterAccessListCreate,
-		Read:   resourceObjectRouterAccessListRead,
-		Update: resourceObjectRouterAccessListUpdate,
-		Delete: resourceObjectRouterAccessListDelete,
+		CreateContext: resourceObjectRouterAccessListCreate,
+		ReadContext:   resourceObjectRouterAccessListRead,
+		UpdateContext: resourceObjectRouterAccessListUpdate,
+		DeleteContext: resourceObjectRouterAccessListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -97,81 +99,76 @@ func resourceObjectRouterAccessList() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterAccessListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterAccessListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAccessList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAccessList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAccessList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterAccessList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAccessList resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAccessList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAccessListRead(d, m)
+	return resourceObjectRouterAccessListRead(ctx, d, m)
 }
 
-func resourceObjectRouterAccessListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAccessList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAccessList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAccessList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterAccessList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAccessList resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAccessList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAccessListRead(d, m)
+	return resourceObjectRouterAccessListRead(ctx, d, m)
 }
 
-func resourceObjectRouterAccessListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterAccessList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterAccessList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterAccessList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -179,34 +176,36 @@ func resourceObjectRouterAccessListDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectRouterAccessListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterAccessList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAccessList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterAccessList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterAccessList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterAccessList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAccessList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterAccessList resource from API: %v", err)
 	}
 	return nil
 }
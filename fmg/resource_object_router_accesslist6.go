@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterAccessList6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterAccessList6Create,
-		Read:   resourceObjectRouterAccessList6Read,
-		Update: resourceObjectRouterAccessList6Update,
-		Delete: resourceObjectRouterAccessList6Delete,
+		CreateContext: resourceObjectRouterAccessList6Create,
+		ReadContext:   resourceObjectRouterAccessList6Read,
+		UpdateContext: resourceObjectRouterAccessList6Update,
+		DeleteContext: resourceObjectRouterAccessList6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -93,81 +95,76 @@ func resourceObjectRouterAccessList6() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterAccessList6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterAccessList6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAccessList6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAccessList6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAccessList6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterAccessList6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAccessList6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAccessList6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAccessList6Read(d, m)
+	return resourceObjectRouterAccessList6Read(ctx, d, m)
 }
 
-func resourceObjectRouterAccessList6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessList6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAccessList6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAccessList6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAccessList6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterAccessList6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAccessList6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAccessList6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAccessList6Read(d, m)
+	return resourceObjectRouterAccessList6Read(ctx, d, m)
 }
 
-func resourceObjectRouterAccessList6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessList6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterAccessList6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterAccessList6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterAccessList6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -175,34 +172,36 @@ func resourceObjectRouterAccessList6Delete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectRouterAccessList6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAccessList6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterAccessList6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAccessList6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterAccessList6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterAccessList6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterAccessList6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAccessList6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterAccessList6 resource from API: %v", err)
 	}
 	return nil
 }
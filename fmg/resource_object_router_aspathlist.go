@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterAspathList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterAspathListCreate,
-		Read:   resourceObjectRouterAspathListRead,
-		Update: resourceObjectRouterAspathListUpdate,
-		Delete: resourceObjectRouterAspathListDelete,
+		CreateContext: resourceObjectRouterAspathListCreate,
+		ReadContext:   resourceObjectRouterAspathListRead,
+		UpdateContext: resourceObjectRouterAspathListUpdate,
+		DeleteContext: resourceObjectRouterAspathListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectRouterAspathList() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterAspathListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterAspathListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAspathList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAspathList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAspathList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterAspathList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterAspathList resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterAspathList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAspathListRead(d, m)
+	return resourceObjectRouterAspathListRead(ctx, d, m)
 }
 
-func resourceObjectRouterAspathListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAspathListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterAspathList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAspathList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAspathList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterAspathList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterAspathList resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterAspathList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterAspathListRead(d, m)
+	return resourceObjectRouterAspathListRead(ctx, d, m)
 }
 
-func resourceObjectRouterAspathListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAspathListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterAspathList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterAspathList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterAspathList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectRouterAspathListDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectRouterAspathListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterAspathListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterAspathList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAspathList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterAspathList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterAspathList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterAspathList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterAspathList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterAspathList resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterCommunityList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterCommunityListCreate,
-		Read:   resourceObjectRouterCommunityListRead,
-		Update: resourceObjectRouterCommunityListUpdate,
-		Delete: resourceObjectRouterCommunityListDelete,
+		CreateContext: resourceObjectRouterCommunityListCreate,
+		ReadContext:   resourceObjectRouterCommunityListRead,
+		UpdateContext: resourceObjectRouterCommunityListUpdate,
+		DeleteContext: resourceObjectRouterCommunityListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,81 +90,76 @@ func resourceObjectRouterCommunityList() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterCommunityListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterCommunityListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterCommunityList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterCommunityList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterCommunityList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterCommunityList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterCommunityList resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterCommunityList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterCommunityListRead(d, m)
+	return resourceObjectRouterCommunityListRead(ctx, d, m)
 }
 
-func resourceObjectRouterCommunityListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterCommunityListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterCommunityList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterCommunityList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterCommunityList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterCommunityList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterCommunityList resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterCommunityList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterCommunityListRead(d, m)
+	return resourceObjectRouterCommunityListRead(ctx, d, m)
 }
 
-func resourceObjectRouterCommunityListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterCommunityListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterCommunityList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterCommunityList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterCommunityList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -170,34 +167,36 @@ func resourceObjectRouterCommunityListDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectRouterCommunityListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterCommunityListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterCommunityList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterCommunityList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterCommunityList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterCommunityList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterCommunityList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterCommunityList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterCommunityList resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterPrefixList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterPrefixListCreate,
-		Read:   resourceObjectRouterPrefixListRead,
-		Update: resourceObjectRouterPrefixListUpdate,
-		Delete: resourceObjectRouterPrefixListDelete,
+		CreateContext: resourceObjectRouterPrefixListCreate,
+		ReadContext:   resourceObjectRouterPrefixListRead,
+		UpdateContext: resourceObjectRouterPrefixListUpdate,
+		DeleteContext: resourceObjectRouterPrefixListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -98,81 +100,76 @@ func resourceObjectRouterPrefixList() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterPrefixListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterPrefixListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterPrefixList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterPrefixList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterPrefixList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterPrefixList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterPrefixList resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterPrefixList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterPrefixListRead(d, m)
+	return resourceObjectRouterPrefixListRead(ctx, d, m)
 }
 
-func resourceObjectRouterPrefixListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterPrefixList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterPrefixList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterPrefixList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterPrefixList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterPrefixList resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterPrefixList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterPrefixListRead(d, m)
+	return resourceObjectRouterPrefixListRead(ctx, d, m)
 }
 
-func resourceObjectRouterPrefixListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterPrefixList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterPrefixList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterPrefixList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -180,34 +177,36 @@ func resourceObjectRouterPrefixListDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectRouterPrefixListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterPrefixList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterPrefixList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterPrefixList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterPrefixList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterPrefixList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterPrefixList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterPrefixList resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterPrefixList6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterPrefixList6Create,
-		Read:   resourceObjectRouterPrefixList6Read,
-		Update: resourceObjectRouterPrefixList6Update,
-		Delete: resourceObjectRouterPrefixList6Delete,
+		CreateContext: resourceObjectRouterPrefixList6Create,
+		ReadContext:   resourceObjectRouterPrefixList6Read,
+		UpdateContext: resourceObjectRouterPrefixList6Update,
+		DeleteContext: resourceObjectRouterPrefixList6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -96,81 +98,76 @@ func resourceObjectRouterPrefixList6() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterPrefixList6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterPrefixList6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterPrefixList6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterPrefixList6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterPrefixList6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterPrefixList6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterPrefixList6 resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterPrefixList6 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterPrefixList6Read(d, m)
+	return resourceObjectRouterPrefixList6Read(ctx, d, m)
 }
 
-func resourceObjectRouterPrefixList6Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixList6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterPrefixList6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterPrefixList6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterPrefixList6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterPrefixList6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterPrefixList6 resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterPrefixList6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterPrefixList6Read(d, m)
+	return resourceObjectRouterPrefixList6Read(ctx, d, m)
 }
 
-func resourceObjectRouterPrefixList6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixList6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterPrefixList6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterPrefixList6 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterPrefixList6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -178,34 +175,36 @@ func resourceObjectRouterPrefixList6Delete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectRouterPrefixList6Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterPrefixList6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterPrefixList6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterPrefixList6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterPrefixList6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterPrefixList6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterPrefixList6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterPrefixList6 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterPrefixList6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectRouterRouteMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectRouterRouteMapCreate,
-		Read:   resourceObjectRouterRouteMapRead,
-		Update: resourceObjectRouterRouteMapUpdate,
-		Delete: resourceObjectRouterRouteMapDelete,
+		CreateContext: resourceObjectRouterRouteMapCreate,
+		ReadContext:   resourceObjectRouterRouteMapRead,
+		UpdateContext: resourceObjectRouterRouteMapUpdate,
+		DeleteContext: resourceObjectRouterRouteMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -267,81 +269,76 @@ func resourceObjectRouterRouteMap() *schema.Resource {
 	}
 }
 
-func resourceObjectRouterRouteMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectRouterRouteMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterRouteMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterRouteMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectRouterRouteMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectRouterRouteMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectRouterRouteMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectRouterRouteMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterRouteMapRead(d, m)
+	return resourceObjectRouterRouteMapRead(ctx, d, m)
 }
 
-func resourceObjectRouterRouteMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterRouteMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectRouterRouteMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterRouteMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectRouterRouteMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectRouterRouteMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectRouterRouteMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectRouterRouteMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectRouterRouteMapRead(d, m)
+	return resourceObjectRouterRouteMapRead(ctx, d, m)
 }
 
-func resourceObjectRouterRouteMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterRouteMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectRouterRouteMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectRouterRouteMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectRouterRouteMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -349,34 +346,36 @@ func resourceObjectRouterRouteMapDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectRouterRouteMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectRouterRouteMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectRouterRouteMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterRouteMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectRouterRouteMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectRouterRouteMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectRouterRouteMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectRouterRouteMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectRouterRouteMap resource from API: %v", err)
 	}
 	return nil
 }
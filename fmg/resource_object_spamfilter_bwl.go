@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterBwl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterBwlCreate,
-		Read:   resourceObjectSpamfilterBwlRead,
-		Update: resourceObjectSpamfilterBwlUpdate,
-		Delete: resourceObjectSpamfilterBwlDelete,
+		CreateContext: resourceObjectSpamfilterBwlCreate,
+		ReadContext:   resourceObjectSpamfilterBwlRead,
+		UpdateContext: resourceObjectSpamfilterBwlUpdate,
+		DeleteContext: resourceObjectSpamfilterBwlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,81 +113,76 @@ func resourceObjectSpamfilterBwl() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterBwlCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterBwlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterBwl resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterBwl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterBwl resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterBwl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterBwlRead(d, m)
+	return resourceObjectSpamfilterBwlRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterBwlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterBwl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterBwl resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterBwl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterBwl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterBwl resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterBwl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterBwlRead(d, m)
+	return resourceObjectSpamfilterBwlRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterBwlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterBwl resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterBwl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -193,34 +190,36 @@ func resourceObjectSpamfilterBwlDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectSpamfilterBwlRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterBwl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterBwl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterBwl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterBwl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterBwl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterBwl resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterBwl resource from API: %v", err)
 	}
 	return nil
 }
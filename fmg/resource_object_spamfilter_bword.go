@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterBword() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterBwordCreate,
-		Read:   resourceObjectSpamfilterBwordRead,
-		Update: resourceObjectSpamfilterBwordUpdate,
-		Delete: resourceObjectSpamfilterBwordDelete,
+		CreateContext: resourceObjectSpamfilterBwordCreate,
+		ReadContext:   resourceObjectSpamfilterBwordRead,
+		UpdateContext: resourceObjectSpamfilterBwordUpdate,
+		DeleteContext: resourceObjectSpamfilterBwordDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,81 +109,76 @@ func resourceObjectSpamfilterBword() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterBwordCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterBwordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterBword(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterBword resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterBword resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterBword(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterBword resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterBword resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterBwordRead(d, m)
+	return resourceObjectSpamfilterBwordRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterBwordUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterBword(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterBword resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterBword resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterBword(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterBword resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterBword resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterBwordRead(d, m)
+	return resourceObjectSpamfilterBwordRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterBwordDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterBword(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterBword resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterBword resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,34 +186,36 @@ func resourceObjectSpamfilterBwordDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSpamfilterBwordRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterBwordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterBword(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterBword resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterBword resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterBword resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterBword(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterBword resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterBword resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterDnsbl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterDnsblCreate,
-		Read:   resourceObjectSpamfilterDnsblRead,
-		Update: resourceObjectSpamfilterDnsblUpdate,
-		Delete: resourceObjectSpamfilterDnsblDelete,
+		CreateContext: resourceObjectSpamfilterDnsblCreate,
+		ReadContext:   resourceObjectSpamfilterDnsblRead,
+		UpdateContext: resourceObjectSpamfilterDnsblUpdate,
+		DeleteContext: resourceObjectSpamfilterDnsblDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectSpamfilterDnsbl() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterDnsblCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterDnsblCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterDnsbl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterDnsbl resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterDnsbl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterDnsbl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterDnsbl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterDnsblRead(d, m)
+	return resourceObjectSpamfilterDnsblRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterDnsblUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterDnsblUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterDnsbl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterDnsbl resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterDnsbl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterDnsbl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterDnsbl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterDnsblRead(d, m)
+	return resourceObjectSpamfilterDnsblRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterDnsblDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterDnsblDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterDnsbl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterDnsbl resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterDnsbl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectSpamfilterDnsblDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSpamfilterDnsblRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterDnsblRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterDnsbl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterDnsbl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterDnsbl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterDnsbl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterDnsbl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterDnsbl resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterDnsbl resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterIptrust() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterIptrustCreate,
-		Read:   resourceObjectSpamfilterIptrustRead,
-		Update: resourceObjectSpamfilterIptrustUpdate,
-		Delete: resourceObjectSpamfilterIptrustDelete,
+		CreateContext: resourceObjectSpamfilterIptrustCreate,
+		ReadContext:   resourceObjectSpamfilterIptrustRead,
+		UpdateContext: resourceObjectSpamfilterIptrustUpdate,
+		DeleteContext: resourceObjectSpamfilterIptrustDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,81 +97,76 @@ func resourceObjectSpamfilterIptrust() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterIptrustCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterIptrustCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterIptrust(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterIptrust resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterIptrust resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterIptrust(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterIptrust resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterIptrust resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterIptrustRead(d, m)
+	return resourceObjectSpamfilterIptrustRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterIptrustUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterIptrustUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterIptrust(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterIptrust resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterIptrust resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterIptrust(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterIptrust resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterIptrust resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterIptrustRead(d, m)
+	return resourceObjectSpamfilterIptrustRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterIptrustDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterIptrustDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterIptrust(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterIptrust resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterIptrust resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,34 +174,36 @@ func resourceObjectSpamfilterIptrustDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectSpamfilterIptrustRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterIptrustRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterIptrust(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterIptrust resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterIptrust resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterIptrust resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterIptrust(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterIptrust resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterIptrust resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterMheader() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterMheaderCreate,
-		Read:   resourceObjectSpamfilterMheaderRead,
-		Update: resourceObjectSpamfilterMheaderUpdate,
-		Delete: resourceObjectSpamfilterMheaderDelete,
+		CreateContext: resourceObjectSpamfilterMheaderCreate,
+		ReadContext:   resourceObjectSpamfilterMheaderRead,
+		UpdateContext: resourceObjectSpamfilterMheaderUpdate,
+		DeleteContext: resourceObjectSpamfilterMheaderDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,81 +101,76 @@ func resourceObjectSpamfilterMheader() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterMheaderCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterMheaderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterMheader(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterMheader resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterMheader resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterMheader(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterMheader resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterMheader resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterMheaderRead(d, m)
+	return resourceObjectSpamfilterMheaderRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterMheaderUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterMheaderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterMheader(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterMheader resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterMheader resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterMheader(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterMheader resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterMheader resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSpamfilterMheaderRead(d, m)
+	return resourceObjectSpamfilterMheaderRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterMheaderDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterMheaderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterMheader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterMheader resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterMheader resource: %v", err)
 	}
 
 	d.SetId("")
@@ -181,34 +178,36 @@ func resourceObjectSpamfilterMheaderDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectSpamfilterMheaderRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterMheaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterMheader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterMheader resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterMheader resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterMheader resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterMheader(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterMheader resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterMheader resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSpamfilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSpamfilterProfileCreate,
-		Read:   resourceObjectSpamfilterProfileRead,
-		Update: resourceObjectSpamfilterProfileUpdate,
-		Delete: resourceObjectSpamfilterProfileDelete,
+		CreateContext: resourceObjectSpamfilterProfileCreate,
+		ReadContext:   resourceObjectSpamfilterProfileRead,
+		UpdateContext: resourceObjectSpamfilterProfileUpdate,
+		DeleteContext: resourceObjectSpamfilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -250,81 +252,76 @@ func resourceObjectSpamfilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSpamfilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSpamfilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSpamfilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSpamfilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSpamfilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSpamfilterProfileRead(d, m)
+	return resourceObjectSpamfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSpamfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSpamfilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSpamfilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSpamfilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSpamfilterProfileRead(d, m)
+	return resourceObjectSpamfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectSpamfilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSpamfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSpamfilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSpamfilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -332,34 +329,36 @@ func resourceObjectSpamfilterProfileDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectSpamfilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSpamfilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSpamfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSpamfilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSpamfilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSpamfilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSpamfilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSpamfilterProfile resource from API: %v", err)
 	}
 	return nil
 }
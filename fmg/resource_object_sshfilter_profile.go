@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSshFilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSshFilterProfileCreate,
-		Read:   resourceObjectSshFilterProfileRead,
-		Update: resourceObjectSshFilterProfileUpdate,
-		Delete: resourceObjectSshFilterProfileDelete,
+		CreateContext: resourceObjectSshFilterProfileCreate,
+		ReadContext:   resourceObjectSshFilterProfileRead,
+		UpdateContext: resourceObjectSshFilterProfileUpdate,
+		DeleteContext: resourceObjectSshFilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -179,81 +181,76 @@ func resourceObjectSshFilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSshFilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSshFilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSshFilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSshFilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSshFilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSshFilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSshFilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSshFilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSshFilterProfileRead(d, m)
+	return resourceObjectSshFilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectSshFilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSshFilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSshFilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSshFilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSshFilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSshFilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSshFilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSshFilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSshFilterProfileRead(d, m)
+	return resourceObjectSshFilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectSshFilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSshFilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSshFilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSshFilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSshFilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -261,34 +258,36 @@ func resourceObjectSshFilterProfileDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSshFilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSshFilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSshFilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSshFilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSshFilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSshFilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSshFilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSshFilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSshFilterProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerCustomCommand() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerCustomCommandCreate,
-		Read:   resourceObjectSwitchControllerCustomCommandRead,
-		Update: resourceObjectSwitchControllerCustomCommandUpdate,
-		Delete: resourceObjectSwitchControllerCustomCommandDelete,
+		CreateContext: resourceObjectSwitchControllerCustomCommandCreate,
+		ReadContext:   resourceObjectSwitchControllerCustomCommandRead,
+		UpdateContext: resourceObjectSwitchControllerCustomCommandUpdate,
+		DeleteContext: resourceObjectSwitchControllerCustomCommandDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectSwitchControllerCustomCommand() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerCustomCommandCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerCustomCommandCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerCustomCommand(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerCustomCommand resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerCustomCommand resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerCustomCommand(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerCustomCommand resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerCustomCommand resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "command_name"))
 
-	return resourceObjectSwitchControllerCustomCommandRead(d, m)
+	return resourceObjectSwitchControllerCustomCommandRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerCustomCommandUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerCustomCommandUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerCustomCommand(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerCustomCommand resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerCustomCommand resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerCustomCommand(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerCustomCommand resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerCustomCommand resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "command_name"))
 
-	return resourceObjectSwitchControllerCustomCommandRead(d, m)
+	return resourceObjectSwitchControllerCustomCommandRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerCustomCommandDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerCustomCommandDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerCustomCommand(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerCustomCommand resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerCustomCommand resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectSwitchControllerCustomCommandDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectSwitchControllerCustomCommandRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerCustomCommandRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerCustomCommand(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerCustomCommand resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerCustomCommand resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerCustomCommand resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerCustomCommand(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerCustomCommand resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerCustomCommand resource from API: %v", err)
 	}
 	return nil
 }
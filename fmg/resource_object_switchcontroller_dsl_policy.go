@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerDslPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerDslPolicyCreate,
-		Read:   resourceObjectSwitchControllerDslPolicyRead,
-		Update: resourceObjectSwitchControllerDslPolicyUpdate,
-		Delete: resourceObjectSwitchControllerDslPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerDslPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerDslPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerDslPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerDslPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -100,81 +102,76 @@ func resourceObjectSwitchControllerDslPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerDslPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerDslPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerDslPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerDslPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerDslPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerDslPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerDslPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerDslPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerDslPolicyRead(d, m)
+	return resourceObjectSwitchControllerDslPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerDslPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDslPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerDslPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerDslPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerDslPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerDslPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerDslPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerDslPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerDslPolicyRead(d, m)
+	return resourceObjectSwitchControllerDslPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerDslPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDslPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerDslPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerDslPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerDslPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -182,34 +179,36 @@ func resourceObjectSwitchControllerDslPolicyDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectSwitchControllerDslPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDslPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerDslPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerDslPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerDslPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerDslPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerDslPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerDslPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerDslPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerDynamicPortPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerDynamicPortPolicyCreate,
-		Read:   resourceObjectSwitchControllerDynamicPortPolicyRead,
-		Update: resourceObjectSwitchControllerDynamicPortPolicyUpdate,
-		Delete: resourceObjectSwitchControllerDynamicPortPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerDynamicPortPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerDynamicPortPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerDynamicPortPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerDynamicPortPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -136,81 +138,76 @@ func resourceObjectSwitchControllerDynamicPortPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerDynamicPortPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerDynamicPortPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerDynamicPortPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerDynamicPortPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerDynamicPortPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerDynamicPortPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerDynamicPortPolicyRead(d, m)
+	return resourceObjectSwitchControllerDynamicPortPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerDynamicPortPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDynamicPortPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerDynamicPortPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerDynamicPortPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerDynamicPortPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerDynamicPortPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerDynamicPortPolicyRead(d, m)
+	return resourceObjectSwitchControllerDynamicPortPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerDynamicPortPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDynamicPortPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerDynamicPortPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -218,34 +215,36 @@ func resourceObjectSwitchControllerDynamicPortPolicyDelete(d *schema.ResourceDat
 	return nil
 }
 
-func resourceObjectSwitchControllerDynamicPortPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerDynamicPortPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerDynamicPortPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerDynamicPortPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerDynamicPortPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerDynamicPortPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerDynamicPortPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerDynamicPortPolicy resource from API: %v", err)
 	}
 	return nil
 }
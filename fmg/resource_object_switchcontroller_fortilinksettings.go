@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerFortilinkSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerFortilinkSettingsCreate,
-		Read:   resourceObjectSwitchControllerFortilinkSettingsRead,
-		Update: resourceObjectSwitchControllerFortilinkSettingsUpdate,
-		Delete: resourceObjectSwitchControllerFortilinkSettingsDelete,
+		CreateContext: resourceObjectSwitchControllerFortilinkSettingsCreate,
+		ReadContext:   resourceObjectSwitchControllerFortilinkSettingsRead,
+		UpdateContext: resourceObjectSwitchControllerFortilinkSettingsUpdate,
+		DeleteContext: resourceObjectSwitchControllerFortilinkSettingsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -110,81 +112,76 @@ func resourceObjectSwitchControllerFortilinkSettings() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerFortilinkSettingsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerFortilinkSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerFortilinkSettings(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerFortilinkSettings resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerFortilinkSettings resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerFortilinkSettings(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerFortilinkSettings resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerFortilinkSettings resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerFortilinkSettingsRead(d, m)
+	return resourceObjectSwitchControllerFortilinkSettingsRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerFortilinkSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerFortilinkSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerFortilinkSettings(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerFortilinkSettings resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerFortilinkSettings resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerFortilinkSettings(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerFortilinkSettings resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerFortilinkSettings resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerFortilinkSettingsRead(d, m)
+	return resourceObjectSwitchControllerFortilinkSettingsRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerFortilinkSettingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerFortilinkSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerFortilinkSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerFortilinkSettings resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerFortilinkSettings resource: %v", err)
 	}
 
 	d.SetId("")
@@ -192,34 +189,36 @@ func resourceObjectSwitchControllerFortilinkSettingsDelete(d *schema.ResourceDat
 	return nil
 }
 
-func resourceObjectSwitchControllerFortilinkSettingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerFortilinkSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerFortilinkSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerFortilinkSettings resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerFortilinkSettings resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerFortilinkSettings resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerFortilinkSettings(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerFortilinkSettings resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerFortilinkSettings resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerLldpProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerLldpProfileCreate,
-		Read:   resourceObjectSwitchControllerLldpProfileRead,
-		Update: resourceObjectSwitchControllerLldpProfileUpdate,
-		Delete: resourceObjectSwitchControllerLldpProfileDelete,
+		CreateContext: resourceObjectSwitchControllerLldpProfileCreate,
+		ReadContext:   resourceObjectSwitchControllerLldpProfileRead,
+		UpdateContext: resourceObjectSwitchControllerLldpProfileUpdate,
+		DeleteContext: resourceObjectSwitchControllerLldpProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -182,81 +184,76 @@ func resourceObjectSwitchControllerLldpProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerLldpProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerLldpProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerLldpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerLldpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerLldpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerLldpProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerLldpProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerLldpProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerLldpProfileRead(d, m)
+	return resourceObjectSwitchControllerLldpProfileRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerLldpProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerLldpProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerLldpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerLldpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerLldpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerLldpProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerLldpProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerLldpProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerLldpProfileRead(d, m)
+	return resourceObjectSwitchControllerLldpProfileRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerLldpProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerLldpProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerLldpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerLldpProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerLldpProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -264,34 +261,36 @@ func resourceObjectSwitchControllerLldpProfileDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectSwitchControllerLldpProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerLldpProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerLldpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerLldpProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerLldpProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerLldpProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerLldpProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerLldpProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerLldpProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerMacPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerMacPolicyCreate,
-		Read:   resourceObjectSwitchControllerMacPolicyRead,
-		Update: resourceObjectSwitchControllerMacPolicyUpdate,
-		Delete: resourceObjectSwitchControllerMacPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerMacPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerMacPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerMacPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerMacPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -81,81 +83,76 @@ func resourceObjectSwitchControllerMacPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerMacPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerMacPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerMacPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerMacPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerMacPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerMacPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerMacPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerMacPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerMacPolicyRead(d, m)
+	return resourceObjectSwitchControllerMacPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerMacPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerMacPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerMacPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerMacPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerMacPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerMacPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerMacPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerMacPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerMacPolicyRead(d, m)
+	return resourceObjectSwitchControllerMacPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerMacPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerMacPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerMacPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerMacPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerMacPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -163,34 +160,36 @@ func resourceObjectSwitchControllerMacPolicyDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectSwitchControllerMacPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerMacPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerMacPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerMacPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerMacPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerMacPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerMacPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerMacPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerMacPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerQosDot1PMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerQosDot1PMapCreate,
-		Read:   resourceObjectSwitchControllerQosDot1PMapRead,
-		Update: resourceObjectSwitchControllerQosDot1PMapUpdate,
-		Delete: resourceObjectSwitchControllerQosDot1PMapDelete,
+		CreateContext: resourceObjectSwitchControllerQosDot1PMapCreate,
+		ReadContext:   resourceObjectSwitchControllerQosDot1PMapRead,
+		UpdateContext: resourceObjectSwitchControllerQosDot1PMapUpdate,
+		DeleteContext: resourceObjectSwitchControllerQosDot1PMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -103,81 +105,76 @@ func resourceObjectSwitchControllerQosDot1PMap() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerQosDot1PMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerQosDot1PMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosDot1PMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosDot1PMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosDot1PMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerQosDot1PMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosDot1PMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosDot1PMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosDot1PMapRead(d, m)
+	return resourceObjectSwitchControllerQosDot1PMapRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosDot1PMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosDot1PMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosDot1PMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosDot1PMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosDot1PMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerQosDot1PMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosDot1PMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosDot1PMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosDot1PMapRead(d, m)
+	return resourceObjectSwitchControllerQosDot1PMapRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosDot1PMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosDot1PMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerQosDot1PMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerQosDot1PMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerQosDot1PMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -185,34 +182,36 @@ func resourceObjectSwitchControllerQosDot1PMapDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectSwitchControllerQosDot1PMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosDot1PMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerQosDot1PMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosDot1PMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerQosDot1PMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerQosDot1PMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerQosDot1PMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosDot1PMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerQosDot1PMap resource from API: %v", err)
 	}
 	return nil
 }
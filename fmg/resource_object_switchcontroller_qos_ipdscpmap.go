@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerQosIpDscpMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerQosIpDscpMapCreate,
-		Read:   resourceObjectSwitchControllerQosIpDscpMapRead,
-		Update: resourceObjectSwitchControllerQosIpDscpMapUpdate,
-		Delete: resourceObjectSwitchControllerQosIpDscpMapDelete,
+		CreateContext: resourceObjectSwitchControllerQosIpDscpMapCreate,
+		ReadContext:   resourceObjectSwitchControllerQosIpDscpMapRead,
+		UpdateContext: resourceObjectSwitchControllerQosIpDscpMapUpdate,
+		DeleteContext: resourceObjectSwitchControllerQosIpDscpMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,81 +97,76 @@ func resourceObjectSwitchControllerQosIpDscpMap() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerQosIpDscpMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerQosIpDscpMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosIpDscpMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosIpDscpMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosIpDscpMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerQosIpDscpMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosIpDscpMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosIpDscpMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosIpDscpMapRead(d, m)
+	return resourceObjectSwitchControllerQosIpDscpMapRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosIpDscpMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosIpDscpMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosIpDscpMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosIpDscpMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosIpDscpMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerQosIpDscpMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosIpDscpMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosIpDscpMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosIpDscpMapRead(d, m)
+	return resourceObjectSwitchControllerQosIpDscpMapRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosIpDscpMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosIpDscpMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerQosIpDscpMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerQosIpDscpMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerQosIpDscpMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,34 +174,36 @@ func resourceObjectSwitchControllerQosIpDscpMapDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectSwitchControllerQosIpDscpMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosIpDscpMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerQosIpDscpMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosIpDscpMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerQosIpDscpMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerQosIpDscpMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerQosIpDscpMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosIpDscpMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerQosIpDscpMap resource from API: %v", err)
 	}
 	return nil
 }
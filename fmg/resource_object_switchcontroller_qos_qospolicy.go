@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerQosQosPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerQosQosPolicyCreate,
-		Read:   resourceObjectSwitchControllerQosQosPolicyRead,
-		Update: resourceObjectSwitchControllerQosQosPolicyUpdate,
-		Delete: resourceObjectSwitchControllerQosQosPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerQosQosPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerQosQosPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerQosQosPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerQosQosPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,81 +73,76 @@ func resourceObjectSwitchControllerQosQosPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerQosQosPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerQosQosPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosQosPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosQosPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosQosPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerQosQosPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosQosPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosQosPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosQosPolicyRead(d, m)
+	return resourceObjectSwitchControllerQosQosPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosQosPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQosPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosQosPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosQosPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosQosPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerQosQosPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosQosPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosQosPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosQosPolicyRead(d, m)
+	return resourceObjectSwitchControllerQosQosPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosQosPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQosPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerQosQosPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerQosQosPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerQosQosPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -153,34 +150,36 @@ func resourceObjectSwitchControllerQosQosPolicyDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectSwitchControllerQosQosPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQosPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerQosQosPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosQosPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQosPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQosPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerQosQosPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosQosPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQosPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerQosQueuePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerQosQueuePolicyCreate,
-		Read:   resourceObjectSwitchControllerQosQueuePolicyRead,
-		Update: resourceObjectSwitchControllerQosQueuePolicyUpdate,
-		Delete: resourceObjectSwitchControllerQosQueuePolicyDelete,
+		CreateContext: resourceObjectSwitchControllerQosQueuePolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerQosQueuePolicyRead,
+		UpdateContext: resourceObjectSwitchControllerQosQueuePolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerQosQueuePolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -113,81 +115,76 @@ func resourceObjectSwitchControllerQosQueuePolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerQosQueuePolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerQosQueuePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosQueuePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosQueuePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosQueuePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerQosQueuePolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerQosQueuePolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerQosQueuePolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosQueuePolicyRead(d, m)
+	return resourceObjectSwitchControllerQosQueuePolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosQueuePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQueuePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerQosQueuePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosQueuePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosQueuePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerQosQueuePolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerQosQueuePolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerQosQueuePolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerQosQueuePolicyRead(d, m)
+	return resourceObjectSwitchControllerQosQueuePolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerQosQueuePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQueuePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerQosQueuePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerQosQueuePolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerQosQueuePolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -195,34 +192,36 @@ func resourceObjectSwitchControllerQosQueuePolicyDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectSwitchControllerQosQueuePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerQosQueuePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerQosQueuePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosQueuePolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQueuePolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQueuePolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerQosQueuePolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerQosQueuePolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerQosQueuePolicy resource from API: %v", err)
 	}
 	return nil
 }
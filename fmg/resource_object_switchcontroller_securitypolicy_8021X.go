@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerSecurityPolicy8021X() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerSecurityPolicy8021XCreate,
-		Read:   resourceObjectSwitchControllerSecurityPolicy8021XRead,
-		Update: resourceObjectSwitchControllerSecurityPolicy8021XUpdate,
-		Delete: resourceObjectSwitchControllerSecurityPolicy8021XDelete,
+		CreateContext: resourceObjectSwitchControllerSecurityPolicy8021XCreate,
+		ReadContext:   resourceObjectSwitchControllerSecurityPolicy8021XRead,
+		UpdateContext: resourceObjectSwitchControllerSecurityPolicy8021XUpdate,
+		DeleteContext: resourceObjectSwitchControllerSecurityPolicy8021XDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -144,81 +146,76 @@ func resourceObjectSwitchControllerSecurityPolicy8021X() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerSecurityPolicy8021XCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerSecurityPolicy8021XCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerSecurityPolicy8021X(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerSecurityPolicy8021X resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerSecurityPolicy8021X resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerSecurityPolicy8021X(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerSecurityPolicy8021XRead(d, m)
+	return resourceObjectSwitchControllerSecurityPolicy8021XRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerSecurityPolicy8021XUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSecurityPolicy8021XUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerSecurityPolicy8021X(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerSecurityPolicy8021X resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerSecurityPolicy8021X resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerSecurityPolicy8021X(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerSecurityPolicy8021XRead(d, m)
+	return resourceObjectSwitchControllerSecurityPolicy8021XRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerSecurityPolicy8021XDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSecurityPolicy8021XDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerSecurityPolicy8021X(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
 	}
 
 	d.SetId("")
@@ -226,34 +223,36 @@ func resourceObjectSwitchControllerSecurityPolicy8021XDelete(d *schema.ResourceD
 	return nil
 }
 
-func resourceObjectSwitchControllerSecurityPolicy8021XRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSecurityPolicy8021XRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerSecurityPolicy8021X(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerSecurityPolicy8021X resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerSecurityPolicy8021X resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerSecurityPolicy8021X(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerSecurityPolicy8021X resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerSecurityPolicy8021X resource from API: %v", err)
 	}
 	return nil
 }
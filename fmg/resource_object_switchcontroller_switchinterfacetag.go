@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerSwitchInterfaceTag() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerSwitchInterfaceTagCreate,
-		Read:   resourceObjectSwitchControllerSwitchInterfaceTagRead,
-		Update: resourceObjectSwitchControllerSwitchInterfaceTagUpdate,
-		Delete: resourceObjectSwitchControllerSwitchInterfaceTagDelete,
+		CreateContext: resourceObjectSwitchControllerSwitchInterfaceTagCreate,
+		ReadContext:   resourceObjectSwitchControllerSwitchInterfaceTagRead,
+		UpdateContext: resourceObjectSwitchControllerSwitchInterfaceTagUpdate,
+		DeleteContext: resourceObjectSwitchControllerSwitchInterfaceTagDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,81 +55,76 @@ func resourceObjectSwitchControllerSwitchInterfaceTag() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerSwitchInterfaceTagCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerSwitchInterfaceTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerSwitchInterfaceTag(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerSwitchInterfaceTag resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerSwitchInterfaceTag resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerSwitchInterfaceTag(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerSwitchInterfaceTagRead(d, m)
+	return resourceObjectSwitchControllerSwitchInterfaceTagRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerSwitchInterfaceTagUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSwitchInterfaceTagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerSwitchInterfaceTag(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerSwitchInterfaceTag resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerSwitchInterfaceTag resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerSwitchInterfaceTag(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerSwitchInterfaceTagRead(d, m)
+	return resourceObjectSwitchControllerSwitchInterfaceTagRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerSwitchInterfaceTagDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSwitchInterfaceTagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerSwitchInterfaceTag(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +132,36 @@ func resourceObjectSwitchControllerSwitchInterfaceTagDelete(d *schema.ResourceDa
 	return nil
 }
 
-func resourceObjectSwitchControllerSwitchInterfaceTagRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerSwitchInterfaceTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerSwitchInterfaceTag(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerSwitchInterfaceTag resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerSwitchInterfaceTag resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerSwitchInterfaceTag(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerSwitchInterfaceTag resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerSwitchInterfaceTag resource from API: %v", err)
 	}
 	return nil
 }
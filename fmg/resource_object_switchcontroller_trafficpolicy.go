@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerTrafficPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerTrafficPolicyCreate,
-		Read:   resourceObjectSwitchControllerTrafficPolicyRead,
-		Update: resourceObjectSwitchControllerTrafficPolicyUpdate,
-		Delete: resourceObjectSwitchControllerTrafficPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerTrafficPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerTrafficPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerTrafficPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerTrafficPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectSwitchControllerTrafficPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerTrafficPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerTrafficPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerTrafficPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerTrafficPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerTrafficPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerTrafficPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerTrafficPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerTrafficPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerTrafficPolicyRead(d, m)
+	return resourceObjectSwitchControllerTrafficPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerTrafficPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerTrafficPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerTrafficPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerTrafficPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerTrafficPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerTrafficPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerTrafficPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerTrafficPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerTrafficPolicyRead(d, m)
+	return resourceObjectSwitchControllerTrafficPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerTrafficPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerTrafficPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerTrafficPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerTrafficPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerTrafficPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectSwitchControllerTrafficPolicyDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectSwitchControllerTrafficPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerTrafficPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerTrafficPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerTrafficPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerTrafficPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerTrafficPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerTrafficPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerTrafficPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerTrafficPolicy resource from API: %v", err)
 	}
 	return nil
 }
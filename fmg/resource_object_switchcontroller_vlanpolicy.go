@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSwitchControllerVlanPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSwitchControllerVlanPolicyCreate,
-		Read:   resourceObjectSwitchControllerVlanPolicyRead,
-		Update: resourceObjectSwitchControllerVlanPolicyUpdate,
-		Delete: resourceObjectSwitchControllerVlanPolicyDelete,
+		CreateContext: resourceObjectSwitchControllerVlanPolicyCreate,
+		ReadContext:   resourceObjectSwitchControllerVlanPolicyRead,
+		UpdateContext: resourceObjectSwitchControllerVlanPolicyUpdate,
+		DeleteContext: resourceObjectSwitchControllerVlanPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -84,81 +86,76 @@ func resourceObjectSwitchControllerVlanPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectSwitchControllerVlanPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSwitchControllerVlanPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerVlanPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerVlanPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerVlanPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSwitchControllerVlanPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSwitchControllerVlanPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectSwitchControllerVlanPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerVlanPolicyRead(d, m)
+	return resourceObjectSwitchControllerVlanPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerVlanPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerVlanPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSwitchControllerVlanPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerVlanPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerVlanPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSwitchControllerVlanPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSwitchControllerVlanPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectSwitchControllerVlanPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSwitchControllerVlanPolicyRead(d, m)
+	return resourceObjectSwitchControllerVlanPolicyRead(ctx, d, m)
 }
 
-func resourceObjectSwitchControllerVlanPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerVlanPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSwitchControllerVlanPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSwitchControllerVlanPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSwitchControllerVlanPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -166,34 +163,36 @@ func resourceObjectSwitchControllerVlanPolicyDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSwitchControllerVlanPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSwitchControllerVlanPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSwitchControllerVlanPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerVlanPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSwitchControllerVlanPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSwitchControllerVlanPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSwitchControllerVlanPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSwitchControllerVlanPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSwitchControllerVlanPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemCustomLanguage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemCustomLanguageCreate,
-		Read:   resourceObjectSystemCustomLanguageRead,
-		Update: resourceObjectSystemCustomLanguageUpdate,
-		Delete: resourceObjectSystemCustomLanguageDelete,
+		CreateContext: resourceObjectSystemCustomLanguageCreate,
+		ReadContext:   resourceObjectSystemCustomLanguageRead,
+		UpdateContext: resourceObjectSystemCustomLanguageUpdate,
+		DeleteContext: resourceObjectSystemCustomLanguageDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectSystemCustomLanguage() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemCustomLanguageCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemCustomLanguageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemCustomLanguage(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemCustomLanguage resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemCustomLanguage resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemCustomLanguage(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemCustomLanguage resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemCustomLanguage resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemCustomLanguageRead(d, m)
+	return resourceObjectSystemCustomLanguageRead(ctx, d, m)
 }
 
-func resourceObjectSystemCustomLanguageUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemCustomLanguageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemCustomLanguage(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemCustomLanguage resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemCustomLanguage resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemCustomLanguage(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemCustomLanguage resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemCustomLanguage resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemCustomLanguageRead(d, m)
+	return resourceObjectSystemCustomLanguageRead(ctx, d, m)
 }
 
-func resourceObjectSystemCustomLanguageDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemCustomLanguageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemCustomLanguage(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemCustomLanguage resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemCustomLanguage resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectSystemCustomLanguageDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectSystemCustomLanguageRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemCustomLanguageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemCustomLanguage(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemCustomLanguage resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemCustomLanguage resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemCustomLanguage resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemCustomLanguage(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemCustomLanguage resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemCustomLanguage resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemDhcpServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemDhcpServerCreate,
-		Read:   resourceObjectSystemDhcpServerRead,
-		Update: resourceObjectSystemDhcpServerUpdate,
-		Delete: resourceObjectSystemDhcpServerDelete,
+		CreateContext: resourceObjectSystemDhcpServerCreate,
+		ReadContext:   resourceObjectSystemDhcpServerRead,
+		UpdateContext: resourceObjectSystemDhcpServerUpdate,
+		DeleteContext: resourceObjectSystemDhcpServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -426,81 +428,76 @@ func resourceObjectSystemDhcpServer() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemDhcpServerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemDhcpServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemDhcpServer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemDhcpServer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemDhcpServer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemDhcpServer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemDhcpServer resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemDhcpServer resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemDhcpServerRead(d, m)
+	return resourceObjectSystemDhcpServerRead(ctx, d, m)
 }
 
-func resourceObjectSystemDhcpServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemDhcpServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemDhcpServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemDhcpServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemDhcpServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemDhcpServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemDhcpServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemDhcpServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemDhcpServerRead(d, m)
+	return resourceObjectSystemDhcpServerRead(ctx, d, m)
 }
 
-func resourceObjectSystemDhcpServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemDhcpServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemDhcpServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemDhcpServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemDhcpServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -508,34 +505,36 @@ func resourceObjectSystemDhcpServerDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSystemDhcpServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemDhcpServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemDhcpServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemDhcpServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemDhcpServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemDhcpServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemDhcpServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemDhcpServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemDhcpServer resource from API: %v", err)
 	}
 	return nil
 }
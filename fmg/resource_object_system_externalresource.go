@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemExternalResource() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemExternalResourceCreate,
-		Read:   resourceObjectSystemExternalResourceRead,
-		Update: resourceObjectSystemExternalResourceUpdate,
-		Delete: resourceObjectSystemExternalResourceDelete,
+		CreateContext: resourceObjectSystemExternalResourceCreate,
+		ReadContext:   resourceObjectSystemExternalResourceRead,
+		UpdateContext: resourceObjectSystemExternalResourceUpdate,
+		DeleteContext: resourceObjectSystemExternalResourceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -125,81 +127,76 @@ func resourceObjectSystemExternalResource() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemExternalResourceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemExternalResourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemExternalResource(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemExternalResource resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemExternalResource resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemExternalResource(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemExternalResource resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemExternalResource resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemExternalResourceRead(d, m)
+	return resourceObjectSystemExternalResourceRead(ctx, d, m)
 }
 
-func resourceObjectSystemExternalResourceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemExternalResourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemExternalResource(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemExternalResource resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemExternalResource resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemExternalResource(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemExternalResource resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemExternalResource resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemExternalResourceRead(d, m)
+	return resourceObjectSystemExternalResourceRead(ctx, d, m)
 }
 
-func resourceObjectSystemExternalResourceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemExternalResourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemExternalResource(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemExternalResource resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemExternalResource resource: %v", err)
 	}
 
 	d.SetId("")
@@ -207,34 +204,36 @@ func resourceObjectSystemExternalResourceDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectSystemExternalResourceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemExternalResourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemExternalResource(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemExternalResource resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemExternalResource resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemExternalResource resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemExternalResource(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemExternalResource resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemExternalResource resource from API: %v", err)
 	}
 	return nil
 }
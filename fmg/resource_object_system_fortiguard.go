@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemFortiguard() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemFortiguardUpdate,
-		Read:   resourceObjectSystemFortiguardRead,
-		Update: resourceObjectSystemFortiguardUpdate,
-		Delete: resourceObjectSystemFortiguardDelete,
+		CreateContext: resourceObjectSystemFortiguardUpdate,
+		ReadContext:   resourceObjectSystemFortiguardRead,
+		UpdateContext: resourceObjectSystemFortiguardUpdate,
+		DeleteContext: resourceObjectSystemFortiguardDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -333,53 +335,49 @@ func resourceObjectSystemFortiguard() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemFortiguardUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemFortiguardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemFortiguard(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemFortiguard resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemFortiguard resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemFortiguard(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemFortiguard resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemFortiguard resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemFortiguard")
 
-	return resourceObjectSystemFortiguardRead(d, m)
+	return resourceObjectSystemFortiguardRead(ctx, d, m)
 }
 
-func resourceObjectSystemFortiguardDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemFortiguardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemFortiguard(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemFortiguard resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemFortiguard resource: %v", err)
 	}
 
 	d.SetId("")
@@ -387,34 +385,36 @@ func resourceObjectSystemFortiguardDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSystemFortiguardRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemFortiguardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemFortiguard(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemFortiguard resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemFortiguard resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemFortiguard resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemFortiguard(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemFortiguard resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemFortiguard resource from API: %v", err)
 	}
 	return nil
 }
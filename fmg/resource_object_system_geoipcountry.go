@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemGeoipCountry() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemGeoipCountryCreate,
-		Read:   resourceObjectSystemGeoipCountryRead,
-		Update: resourceObjectSystemGeoipCountryUpdate,
-		Delete: resourceObjectSystemGeoipCountryDelete,
+		CreateContext: resourceObjectSystemGeoipCountryCreate,
+		ReadContext:   resourceObjectSystemGeoipCountryRead,
+		UpdateContext: resourceObjectSystemGeoipCountryUpdate,
+		DeleteContext: resourceObjectSystemGeoipCountryDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectSystemGeoipCountry() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemGeoipCountryCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemGeoipCountryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemGeoipCountry(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemGeoipCountry resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemGeoipCountry resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemGeoipCountry(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemGeoipCountry resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemGeoipCountry resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectSystemGeoipCountryRead(d, m)
+	return resourceObjectSystemGeoipCountryRead(ctx, d, m)
 }
 
-func resourceObjectSystemGeoipCountryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipCountryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemGeoipCountry(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemGeoipCountry resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemGeoipCountry resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemGeoipCountry(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemGeoipCountry resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemGeoipCountry resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectSystemGeoipCountryRead(d, m)
+	return resourceObjectSystemGeoipCountryRead(ctx, d, m)
 }
 
-func resourceObjectSystemGeoipCountryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipCountryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemGeoipCountry(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemGeoipCountry resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemGeoipCountry resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectSystemGeoipCountryDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectSystemGeoipCountryRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipCountryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemGeoipCountry(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemGeoipCountry resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemGeoipCountry resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemGeoipCountry resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemGeoipCountry(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemGeoipCountry resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemGeoipCountry resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemGeoipOverride() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemGeoipOverrideCreate,
-		Read:   resourceObjectSystemGeoipOverrideRead,
-		Update: resourceObjectSystemGeoipOverrideUpdate,
-		Delete: resourceObjectSystemGeoipOverrideDelete,
+		CreateContext: resourceObjectSystemGeoipOverrideCreate,
+		ReadContext:   resourceObjectSystemGeoipOverrideRead,
+		UpdateContext: resourceObjectSystemGeoipOverrideUpdate,
+		DeleteContext: resourceObjectSystemGeoipOverrideDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -112,81 +114,76 @@ func resourceObjectSystemGeoipOverride() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemGeoipOverrideCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemGeoipOverrideCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemGeoipOverride(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemGeoipOverride resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemGeoipOverride resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemGeoipOverride(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemGeoipOverride resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemGeoipOverride resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemGeoipOverrideRead(d, m)
+	return resourceObjectSystemGeoipOverrideRead(ctx, d, m)
 }
 
-func resourceObjectSystemGeoipOverrideUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipOverrideUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemGeoipOverride(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemGeoipOverride resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemGeoipOverride resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemGeoipOverride(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemGeoipOverride resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemGeoipOverride resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemGeoipOverrideRead(d, m)
+	return resourceObjectSystemGeoipOverrideRead(ctx, d, m)
 }
 
-func resourceObjectSystemGeoipOverrideDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipOverrideDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemGeoipOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemGeoipOverride resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemGeoipOverride resource: %v", err)
 	}
 
 	d.SetId("")
@@ -194,34 +191,36 @@ func resourceObjectSystemGeoipOverrideDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectSystemGeoipOverrideRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemGeoipOverrideRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemGeoipOverride(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemGeoipOverride resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemGeoipOverride resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemGeoipOverride resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemGeoipOverride(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemGeoipOverride resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemGeoipOverride resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemMeta() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemMetaCreate,
-		Read:   resourceObjectSystemMetaRead,
-		Update: resourceObjectSystemMetaUpdate,
-		Delete: resourceObjectSystemMetaDelete,
+		CreateContext: resourceObjectSystemMetaCreate,
+		ReadContext:   resourceObjectSystemMetaRead,
+		UpdateContext: resourceObjectSystemMetaUpdate,
+		DeleteContext: resourceObjectSystemMetaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectSystemMeta() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemMetaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemMetaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemMeta(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemMeta resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemMeta resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemMeta(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemMeta resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemMeta resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemMetaRead(d, m)
+	return resourceObjectSystemMetaRead(ctx, d, m)
 }
 
-func resourceObjectSystemMetaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemMetaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemMeta(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemMeta resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemMeta resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemMeta(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemMeta resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemMeta resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemMetaRead(d, m)
+	return resourceObjectSystemMetaRead(ctx, d, m)
 }
 
-func resourceObjectSystemMetaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemMetaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemMeta(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemMeta resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemMeta resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectSystemMetaDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectSystemMetaRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemMetaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemMeta(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemMeta resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemMeta resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemMeta resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemMeta(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemMeta resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemMeta resource from API: %v", err)
 	}
 	return nil
 }
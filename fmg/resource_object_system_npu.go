@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpu() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuUpdate,
-		Read:   resourceObjectSystemNpuRead,
-		Update: resourceObjectSystemNpuUpdate,
-		Delete: resourceObjectSystemNpuDelete,
+		CreateContext: resourceObjectSystemNpuUpdate,
+		ReadContext:   resourceObjectSystemNpuRead,
+		UpdateContext: resourceObjectSystemNpuUpdate,
+		DeleteContext: resourceObjectSystemNpuDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1657,53 +1659,49 @@ func resourceObjectSystemNpu() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpu(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpu resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpu resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpu(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpu resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpu resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpu")
 
-	return resourceObjectSystemNpuRead(d, m)
+	return resourceObjectSystemNpuRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpu(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpu resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpu resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1711,34 +1709,36 @@ func resourceObjectSystemNpuDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectSystemNpuRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpu(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpu resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpu resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpu resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpu(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpu resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpu resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuBackgroundSseScan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuBackgroundSseScanUpdate,
-		Read:   resourceObjectSystemNpuBackgroundSseScanRead,
-		Update: resourceObjectSystemNpuBackgroundSseScanUpdate,
-		Delete: resourceObjectSystemNpuBackgroundSseScanDelete,
+		CreateContext: resourceObjectSystemNpuBackgroundSseScanUpdate,
+		ReadContext:   resourceObjectSystemNpuBackgroundSseScanRead,
+		UpdateContext: resourceObjectSystemNpuBackgroundSseScanUpdate,
+		DeleteContext: resourceObjectSystemNpuBackgroundSseScanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -61,53 +63,49 @@ func resourceObjectSystemNpuBackgroundSseScan() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuBackgroundSseScanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuBackgroundSseScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuBackgroundSseScan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuBackgroundSseScan resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuBackgroundSseScan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuBackgroundSseScan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuBackgroundSseScan resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuBackgroundSseScan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuBackgroundSseScan")
 
-	return resourceObjectSystemNpuBackgroundSseScanRead(d, m)
+	return resourceObjectSystemNpuBackgroundSseScanRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuBackgroundSseScanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuBackgroundSseScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuBackgroundSseScan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuBackgroundSseScan resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuBackgroundSseScan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -115,34 +113,36 @@ func resourceObjectSystemNpuBackgroundSseScanDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSystemNpuBackgroundSseScanRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuBackgroundSseScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuBackgroundSseScan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuBackgroundSseScan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuBackgroundSseScan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuBackgroundSseScan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuBackgroundSseScan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuBackgroundSseScan resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuBackgroundSseScan resource from API: %v", err)
 	}
 	return nil
 }
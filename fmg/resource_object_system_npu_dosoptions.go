@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuDosOptions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuDosOptionsUpdate,
-		Read:   resourceObjectSystemNpuDosOptionsRead,
-		Update: resourceObjectSystemNpuDosOptionsUpdate,
-		Delete: resourceObjectSystemNpuDosOptionsDelete,
+		CreateContext: resourceObjectSystemNpuDosOptionsUpdate,
+		ReadContext:   resourceObjectSystemNpuDosOptionsRead,
+		UpdateContext: resourceObjectSystemNpuDosOptionsUpdate,
+		DeleteContext: resourceObjectSystemNpuDosOptionsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,53 +66,49 @@ func resourceObjectSystemNpuDosOptions() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuDosOptionsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDosOptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuDosOptions(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDosOptions resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDosOptions resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuDosOptions(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDosOptions resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDosOptions resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuDosOptions")
 
-	return resourceObjectSystemNpuDosOptionsRead(d, m)
+	return resourceObjectSystemNpuDosOptionsRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDosOptionsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDosOptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuDosOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuDosOptions resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuDosOptions resource: %v", err)
 	}
 
 	d.SetId("")
@@ -118,34 +116,36 @@ func resourceObjectSystemNpuDosOptionsDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectSystemNpuDosOptionsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDosOptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuDosOptions(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDosOptions resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuDosOptions resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuDosOptions resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuDosOptions(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDosOptions resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuDosOptions resource from API: %v", err)
 	}
 	return nil
 }
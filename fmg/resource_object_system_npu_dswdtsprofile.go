@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuDswDtsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuDswDtsProfileCreate,
-		Read:   resourceObjectSystemNpuDswDtsProfileRead,
-		Update: resourceObjectSystemNpuDswDtsProfileUpdate,
-		Delete: resourceObjectSystemNpuDswDtsProfileDelete,
+		CreateContext: resourceObjectSystemNpuDswDtsProfileCreate,
+		ReadContext:   resourceObjectSystemNpuDswDtsProfileRead,
+		UpdateContext: resourceObjectSystemNpuDswDtsProfileUpdate,
+		DeleteContext: resourceObjectSystemNpuDswDtsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectSystemNpuDswDtsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuDswDtsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuDswDtsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuDswDtsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuDswDtsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuDswDtsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuDswDtsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuDswDtsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuDswDtsProfile resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "profile_id")))
 
-	return resourceObjectSystemNpuDswDtsProfileRead(d, m)
+	return resourceObjectSystemNpuDswDtsProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDswDtsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswDtsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuDswDtsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDswDtsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDswDtsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuDswDtsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDswDtsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDswDtsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "profile_id")))
 
-	return resourceObjectSystemNpuDswDtsProfileRead(d, m)
+	return resourceObjectSystemNpuDswDtsProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDswDtsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswDtsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuDswDtsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuDswDtsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuDswDtsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectSystemNpuDswDtsProfileDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectSystemNpuDswDtsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswDtsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuDswDtsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDswDtsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuDswDtsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuDswDtsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuDswDtsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDswDtsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuDswDtsProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuDswQueueDtsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuDswQueueDtsProfileCreate,
-		Read:   resourceObjectSystemNpuDswQueueDtsProfileRead,
-		Update: resourceObjectSystemNpuDswQueueDtsProfileUpdate,
-		Delete: resourceObjectSystemNpuDswQueueDtsProfileDelete,
+		CreateContext: resourceObjectSystemNpuDswQueueDtsProfileCreate,
+		ReadContext:   resourceObjectSystemNpuDswQueueDtsProfileRead,
+		UpdateContext: resourceObjectSystemNpuDswQueueDtsProfileUpdate,
+		DeleteContext: resourceObjectSystemNpuDswQueueDtsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,81 +74,76 @@ func resourceObjectSystemNpuDswQueueDtsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuDswQueueDtsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuDswQueueDtsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuDswQueueDtsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuDswQueueDtsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuDswQueueDtsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuDswQueueDtsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuDswQueueDtsProfileRead(d, m)
+	return resourceObjectSystemNpuDswQueueDtsProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDswQueueDtsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswQueueDtsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuDswQueueDtsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDswQueueDtsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDswQueueDtsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuDswQueueDtsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuDswQueueDtsProfileRead(d, m)
+	return resourceObjectSystemNpuDswQueueDtsProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuDswQueueDtsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswQueueDtsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuDswQueueDtsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -154,34 +151,36 @@ func resourceObjectSystemNpuDswQueueDtsProfileDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectSystemNpuDswQueueDtsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuDswQueueDtsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuDswQueueDtsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuDswQueueDtsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuDswQueueDtsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuDswQueueDtsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuDswQueueDtsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuDswQueueDtsProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuFpAnomaly() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuFpAnomalyUpdate,
-		Read:   resourceObjectSystemNpuFpAnomalyRead,
-		Update: resourceObjectSystemNpuFpAnomalyUpdate,
-		Delete: resourceObjectSystemNpuFpAnomalyDelete,
+		CreateContext: resourceObjectSystemNpuFpAnomalyUpdate,
+		ReadContext:   resourceObjectSystemNpuFpAnomalyRead,
+		UpdateContext: resourceObjectSystemNpuFpAnomalyUpdate,
+		DeleteContext: resourceObjectSystemNpuFpAnomalyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -364,53 +366,49 @@ func resourceObjectSystemNpuFpAnomaly() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuFpAnomalyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuFpAnomalyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuFpAnomaly(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuFpAnomaly resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuFpAnomaly resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuFpAnomaly(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuFpAnomaly resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuFpAnomaly resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuFpAnomaly")
 
-	return resourceObjectSystemNpuFpAnomalyRead(d, m)
+	return resourceObjectSystemNpuFpAnomalyRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuFpAnomalyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuFpAnomalyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuFpAnomaly(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuFpAnomaly resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuFpAnomaly resource: %v", err)
 	}
 
 	d.SetId("")
@@ -418,34 +416,36 @@ func resourceObjectSystemNpuFpAnomalyDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectSystemNpuFpAnomalyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuFpAnomalyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuFpAnomaly(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuFpAnomaly resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuFpAnomaly resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuFpAnomaly resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuFpAnomaly(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuFpAnomaly resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuFpAnomaly resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuHpe() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuHpeUpdate,
-		Read:   resourceObjectSystemNpuHpeRead,
-		Update: resourceObjectSystemNpuHpeUpdate,
-		Delete: resourceObjectSystemNpuHpeDelete,
+		CreateContext: resourceObjectSystemNpuHpeUpdate,
+		ReadContext:   resourceObjectSystemNpuHpeRead,
+		UpdateContext: resourceObjectSystemNpuHpeUpdate,
+		DeleteContext: resourceObjectSystemNpuHpeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -129,53 +131,49 @@ func resourceObjectSystemNpuHpe() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuHpeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuHpeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuHpe(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuHpe resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuHpe resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuHpe(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuHpe resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuHpe resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuHpe")
 
-	return resourceObjectSystemNpuHpeRead(d, m)
+	return resourceObjectSystemNpuHpeRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuHpeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuHpeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuHpe(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuHpe resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuHpe resource: %v", err)
 	}
 
 	d.SetId("")
@@ -183,34 +181,36 @@ func resourceObjectSystemNpuHpeDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectSystemNpuHpeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuHpeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuHpe(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuHpe resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuHpe resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuHpe resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuHpe(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuHpe resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuHpe resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuIpReassembly() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuIpReassemblyUpdate,
-		Read:   resourceObjectSystemNpuIpReassemblyRead,
-		Update: resourceObjectSystemNpuIpReassemblyUpdate,
-		Delete: resourceObjectSystemNpuIpReassemblyDelete,
+		CreateContext: resourceObjectSystemNpuIpReassemblyUpdate,
+		ReadContext:   resourceObjectSystemNpuIpReassemblyRead,
+		UpdateContext: resourceObjectSystemNpuIpReassemblyUpdate,
+		DeleteContext: resourceObjectSystemNpuIpReassemblyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,53 +66,49 @@ func resourceObjectSystemNpuIpReassembly() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuIpReassemblyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIpReassemblyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuIpReassembly(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuIpReassembly resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuIpReassembly resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuIpReassembly(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuIpReassembly resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuIpReassembly resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuIpReassembly")
 
-	return resourceObjectSystemNpuIpReassemblyRead(d, m)
+	return resourceObjectSystemNpuIpReassemblyRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuIpReassemblyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIpReassemblyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuIpReassembly(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuIpReassembly resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuIpReassembly resource: %v", err)
 	}
 
 	d.SetId("")
@@ -118,34 +116,36 @@ func resourceObjectSystemNpuIpReassemblyDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectSystemNpuIpReassemblyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIpReassemblyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuIpReassembly(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuIpReassembly resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuIpReassembly resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuIpReassembly resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuIpReassembly(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuIpReassembly resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuIpReassembly resource from API: %v", err)
 	}
 	return nil
 }
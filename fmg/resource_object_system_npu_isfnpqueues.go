@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuIsfNpQueues() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuIsfNpQueuesUpdate,
-		Read:   resourceObjectSystemNpuIsfNpQueuesRead,
-		Update: resourceObjectSystemNpuIsfNpQueuesUpdate,
-		Delete: resourceObjectSystemNpuIsfNpQueuesDelete,
+		CreateContext: resourceObjectSystemNpuIsfNpQueuesUpdate,
+		ReadContext:   resourceObjectSystemNpuIsfNpQueuesRead,
+		UpdateContext: resourceObjectSystemNpuIsfNpQueuesUpdate,
+		DeleteContext: resourceObjectSystemNpuIsfNpQueuesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -81,53 +83,49 @@ func resourceObjectSystemNpuIsfNpQueues() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuIsfNpQueuesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIsfNpQueuesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuIsfNpQueues(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuIsfNpQueues resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuIsfNpQueues resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuIsfNpQueues(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuIsfNpQueues resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuIsfNpQueues resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuIsfNpQueues")
 
-	return resourceObjectSystemNpuIsfNpQueuesRead(d, m)
+	return resourceObjectSystemNpuIsfNpQueuesRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuIsfNpQueuesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIsfNpQueuesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuIsfNpQueues(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuIsfNpQueues resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuIsfNpQueues resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +133,36 @@ func resourceObjectSystemNpuIsfNpQueuesDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectSystemNpuIsfNpQueuesRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuIsfNpQueuesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuIsfNpQueues(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuIsfNpQueues resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuIsfNpQueues resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuIsfNpQueues resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuIsfNpQueues(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuIsfNpQueues resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuIsfNpQueues resource from API: %v", err)
 	}
 	return nil
 }
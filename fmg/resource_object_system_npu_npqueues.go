@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueues() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesUpdate,
-		Read:   resourceObjectSystemNpuNpQueuesRead,
-		Update: resourceObjectSystemNpuNpQueuesUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesUpdate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -537,53 +539,49 @@ func resourceObjectSystemNpuNpQueues() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueues(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueues resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueues resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueues(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueues resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueues resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuNpQueues")
 
-	return resourceObjectSystemNpuNpQueuesRead(d, m)
+	return resourceObjectSystemNpuNpQueuesRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueues(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueues resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueues resource: %v", err)
 	}
 
 	d.SetId("")
@@ -591,34 +589,36 @@ func resourceObjectSystemNpuNpQueuesDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueues(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueues resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueues resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueues resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueues(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueues resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueues resource from API: %v", err)
 	}
 	return nil
 }
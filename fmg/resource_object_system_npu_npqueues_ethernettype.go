@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueuesEthernetType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesEthernetTypeCreate,
-		Read:   resourceObjectSystemNpuNpQueuesEthernetTypeRead,
-		Update: resourceObjectSystemNpuNpQueuesEthernetTypeUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesEthernetTypeDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesEthernetTypeCreate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesEthernetTypeRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesEthernetTypeUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesEthernetTypeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectSystemNpuNpQueuesEthernetType() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesEthernetTypeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuNpQueuesEthernetTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesEthernetType(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesEthernetType resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesEthernetType resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuNpQueuesEthernetType(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesEthernetTypeRead(d, m)
+	return resourceObjectSystemNpuNpQueuesEthernetTypeRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesEthernetTypeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesEthernetTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesEthernetType(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesEthernetType resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesEthernetType resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueuesEthernetType(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesEthernetTypeRead(d, m)
+	return resourceObjectSystemNpuNpQueuesEthernetTypeRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesEthernetTypeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesEthernetTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueuesEthernetType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectSystemNpuNpQueuesEthernetTypeDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesEthernetTypeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesEthernetTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueuesEthernetType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesEthernetType resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesEthernetType resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueuesEthernetType(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesEthernetType resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesEthernetType resource from API: %v", err)
 	}
 	return nil
 }
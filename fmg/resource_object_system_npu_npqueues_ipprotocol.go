@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueuesIpProtocol() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesIpProtocolCreate,
-		Read:   resourceObjectSystemNpuNpQueuesIpProtocolRead,
-		Update: resourceObjectSystemNpuNpQueuesIpProtocolUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesIpProtocolDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesIpProtocolCreate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesIpProtocolRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesIpProtocolUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesIpProtocolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectSystemNpuNpQueuesIpProtocol() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesIpProtocolCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuNpQueuesIpProtocolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesIpProtocol(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesIpProtocol resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesIpProtocol resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuNpQueuesIpProtocol(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesIpProtocolRead(d, m)
+	return resourceObjectSystemNpuNpQueuesIpProtocolRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesIpProtocolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpProtocolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesIpProtocol(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesIpProtocol resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesIpProtocol resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueuesIpProtocol(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesIpProtocolRead(d, m)
+	return resourceObjectSystemNpuNpQueuesIpProtocolRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesIpProtocolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpProtocolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueuesIpProtocol(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectSystemNpuNpQueuesIpProtocolDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesIpProtocolRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpProtocolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueuesIpProtocol(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpProtocol resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpProtocol resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueuesIpProtocol(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesIpProtocol resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpProtocol resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueuesIpService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesIpServiceCreate,
-		Read:   resourceObjectSystemNpuNpQueuesIpServiceRead,
-		Update: resourceObjectSystemNpuNpQueuesIpServiceUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesIpServiceDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesIpServiceCreate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesIpServiceRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesIpServiceUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesIpServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,81 +77,76 @@ func resourceObjectSystemNpuNpQueuesIpService() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesIpServiceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuNpQueuesIpServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesIpService(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesIpService resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesIpService resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuNpQueuesIpService(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesIpService resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesIpService resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesIpServiceRead(d, m)
+	return resourceObjectSystemNpuNpQueuesIpServiceRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesIpServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesIpService(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesIpService resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesIpService resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueuesIpService(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesIpService resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesIpService resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesIpServiceRead(d, m)
+	return resourceObjectSystemNpuNpQueuesIpServiceRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesIpServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueuesIpService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueuesIpService resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueuesIpService resource: %v", err)
 	}
 
 	d.SetId("")
@@ -157,34 +154,36 @@ func resourceObjectSystemNpuNpQueuesIpServiceDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesIpServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesIpServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueuesIpService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesIpService resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpService resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpService resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueuesIpService(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesIpService resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesIpService resource from API: %v", err)
 	}
 	return nil
 }
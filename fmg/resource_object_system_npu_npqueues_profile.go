@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueuesProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesProfileCreate,
-		Read:   resourceObjectSystemNpuNpQueuesProfileRead,
-		Update: resourceObjectSystemNpuNpQueuesProfileUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesProfileDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesProfileCreate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesProfileRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesProfileUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -424,81 +426,76 @@ func resourceObjectSystemNpuNpQueuesProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuNpQueuesProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuNpQueuesProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesProfile resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuNpQueuesProfileRead(d, m)
+	return resourceObjectSystemNpuNpQueuesProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueuesProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuNpQueuesProfileRead(d, m)
+	return resourceObjectSystemNpuNpQueuesProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueuesProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueuesProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueuesProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -506,34 +503,36 @@ func resourceObjectSystemNpuNpQueuesProfileDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueuesProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueuesProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuNpQueuesScheduler() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuNpQueuesSchedulerCreate,
-		Read:   resourceObjectSystemNpuNpQueuesSchedulerRead,
-		Update: resourceObjectSystemNpuNpQueuesSchedulerUpdate,
-		Delete: resourceObjectSystemNpuNpQueuesSchedulerDelete,
+		CreateContext: resourceObjectSystemNpuNpQueuesSchedulerCreate,
+		ReadContext:   resourceObjectSystemNpuNpQueuesSchedulerRead,
+		UpdateContext: resourceObjectSystemNpuNpQueuesSchedulerUpdate,
+		DeleteContext: resourceObjectSystemNpuNpQueuesSchedulerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,81 +62,76 @@ func resourceObjectSystemNpuNpQueuesScheduler() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuNpQueuesSchedulerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuNpQueuesSchedulerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesScheduler(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesScheduler resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesScheduler resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuNpQueuesScheduler(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuNpQueuesScheduler resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuNpQueuesScheduler resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesSchedulerRead(d, m)
+	return resourceObjectSystemNpuNpQueuesSchedulerRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesSchedulerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesSchedulerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuNpQueuesScheduler(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesScheduler resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesScheduler resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuNpQueuesScheduler(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuNpQueuesScheduler resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuNpQueuesScheduler resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemNpuNpQueuesSchedulerRead(d, m)
+	return resourceObjectSystemNpuNpQueuesSchedulerRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuNpQueuesSchedulerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesSchedulerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuNpQueuesScheduler(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuNpQueuesScheduler resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuNpQueuesScheduler resource: %v", err)
 	}
 
 	d.SetId("")
@@ -142,34 +139,36 @@ func resourceObjectSystemNpuNpQueuesSchedulerDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSystemNpuNpQueuesSchedulerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuNpQueuesSchedulerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuNpQueuesScheduler(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesScheduler resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesScheduler resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesScheduler resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuNpQueuesScheduler(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuNpQueuesScheduler resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuNpQueuesScheduler resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuPortCpuMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuPortCpuMapCreate,
-		Read:   resourceObjectSystemNpuPortCpuMapRead,
-		Update: resourceObjectSystemNpuPortCpuMapUpdate,
-		Delete: resourceObjectSystemNpuPortCpuMapDelete,
+		CreateContext: resourceObjectSystemNpuPortCpuMapCreate,
+		ReadContext:   resourceObjectSystemNpuPortCpuMapRead,
+		UpdateContext: resourceObjectSystemNpuPortCpuMapUpdate,
+		DeleteContext: resourceObjectSystemNpuPortCpuMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,81 +62,76 @@ func resourceObjectSystemNpuPortCpuMap() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuPortCpuMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuPortCpuMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPortCpuMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuPortCpuMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuPortCpuMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuPortCpuMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuPortCpuMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuPortCpuMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "interface"))
 
-	return resourceObjectSystemNpuPortCpuMapRead(d, m)
+	return resourceObjectSystemNpuPortCpuMapRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPortCpuMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortCpuMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPortCpuMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortCpuMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortCpuMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuPortCpuMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortCpuMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortCpuMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "interface"))
 
-	return resourceObjectSystemNpuPortCpuMapRead(d, m)
+	return resourceObjectSystemNpuPortCpuMapRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPortCpuMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortCpuMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuPortCpuMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuPortCpuMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuPortCpuMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -142,34 +139,36 @@ func resourceObjectSystemNpuPortCpuMapDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectSystemNpuPortCpuMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortCpuMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuPortCpuMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortCpuMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuPortCpuMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuPortCpuMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuPortCpuMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortCpuMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuPortCpuMap resource from API: %v", err)
 	}
 	return nil
 }
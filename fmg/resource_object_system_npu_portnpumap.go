@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuPortNpuMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuPortNpuMapCreate,
-		Read:   resourceObjectSystemNpuPortNpuMapRead,
-		Update: resourceObjectSystemNpuPortNpuMapUpdate,
-		Delete: resourceObjectSystemNpuPortNpuMapDelete,
+		CreateContext: resourceObjectSystemNpuPortNpuMapCreate,
+		ReadContext:   resourceObjectSystemNpuPortNpuMapRead,
+		UpdateContext: resourceObjectSystemNpuPortNpuMapUpdate,
+		DeleteContext: resourceObjectSystemNpuPortNpuMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -59,81 +61,76 @@ func resourceObjectSystemNpuPortNpuMap() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuPortNpuMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuPortNpuMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPortNpuMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuPortNpuMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuPortNpuMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuPortNpuMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuPortNpuMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuPortNpuMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "interface"))
 
-	return resourceObjectSystemNpuPortNpuMapRead(d, m)
+	return resourceObjectSystemNpuPortNpuMapRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPortNpuMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortNpuMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPortNpuMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortNpuMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortNpuMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuPortNpuMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortNpuMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortNpuMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "interface"))
 
-	return resourceObjectSystemNpuPortNpuMapRead(d, m)
+	return resourceObjectSystemNpuPortNpuMapRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPortNpuMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortNpuMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuPortNpuMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuPortNpuMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuPortNpuMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -141,34 +138,36 @@ func resourceObjectSystemNpuPortNpuMapDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectSystemNpuPortNpuMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortNpuMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuPortNpuMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortNpuMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuPortNpuMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuPortNpuMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuPortNpuMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortNpuMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuPortNpuMap resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuPortPathOption() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuPortPathOptionUpdate,
-		Read:   resourceObjectSystemNpuPortPathOptionRead,
-		Update: resourceObjectSystemNpuPortPathOptionUpdate,
-		Delete: resourceObjectSystemNpuPortPathOptionDelete,
+		CreateContext: resourceObjectSystemNpuPortPathOptionUpdate,
+		ReadContext:   resourceObjectSystemNpuPortPathOptionRead,
+		UpdateContext: resourceObjectSystemNpuPortPathOptionUpdate,
+		DeleteContext: resourceObjectSystemNpuPortPathOptionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -55,53 +57,49 @@ func resourceObjectSystemNpuPortPathOption() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuPortPathOptionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortPathOptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPortPathOption(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortPathOption resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortPathOption resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuPortPathOption(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPortPathOption resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPortPathOption resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuPortPathOption")
 
-	return resourceObjectSystemNpuPortPathOptionRead(d, m)
+	return resourceObjectSystemNpuPortPathOptionRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPortPathOptionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortPathOptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuPortPathOption(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuPortPathOption resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuPortPathOption resource: %v", err)
 	}
 
 	d.SetId("")
@@ -109,34 +107,36 @@ func resourceObjectSystemNpuPortPathOptionDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceObjectSystemNpuPortPathOptionRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPortPathOptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuPortPathOption(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortPathOption resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuPortPathOption resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuPortPathOption resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuPortPathOption(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPortPathOption resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuPortPathOption resource from API: %v", err)
 	}
 	return nil
 }
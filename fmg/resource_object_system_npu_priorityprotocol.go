@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuPriorityProtocol() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuPriorityProtocolUpdate,
-		Read:   resourceObjectSystemNpuPriorityProtocolRead,
-		Update: resourceObjectSystemNpuPriorityProtocolUpdate,
-		Delete: resourceObjectSystemNpuPriorityProtocolDelete,
+		CreateContext: resourceObjectSystemNpuPriorityProtocolUpdate,
+		ReadContext:   resourceObjectSystemNpuPriorityProtocolRead,
+		UpdateContext: resourceObjectSystemNpuPriorityProtocolUpdate,
+		DeleteContext: resourceObjectSystemNpuPriorityProtocolDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,53 +66,49 @@ func resourceObjectSystemNpuPriorityProtocol() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuPriorityProtocolUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPriorityProtocolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuPriorityProtocol(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPriorityProtocol resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPriorityProtocol resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuPriorityProtocol(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuPriorityProtocol resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuPriorityProtocol resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuPriorityProtocol")
 
-	return resourceObjectSystemNpuPriorityProtocolRead(d, m)
+	return resourceObjectSystemNpuPriorityProtocolRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuPriorityProtocolDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPriorityProtocolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuPriorityProtocol(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuPriorityProtocol resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuPriorityProtocol resource: %v", err)
 	}
 
 	d.SetId("")
@@ -118,34 +116,36 @@ func resourceObjectSystemNpuPriorityProtocolDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectSystemNpuPriorityProtocolRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuPriorityProtocolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuPriorityProtocol(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPriorityProtocol resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuPriorityProtocol resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuPriorityProtocol resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuPriorityProtocol(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuPriorityProtocol resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuPriorityProtocol resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuSseHaScan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuSseHaScanUpdate,
-		Read:   resourceObjectSystemNpuSseHaScanRead,
-		Update: resourceObjectSystemNpuSseHaScanUpdate,
-		Delete: resourceObjectSystemNpuSseHaScanDelete,
+		CreateContext: resourceObjectSystemNpuSseHaScanUpdate,
+		ReadContext:   resourceObjectSystemNpuSseHaScanRead,
+		UpdateContext: resourceObjectSystemNpuSseHaScanUpdate,
+		DeleteContext: resourceObjectSystemNpuSseHaScanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,53 +64,49 @@ func resourceObjectSystemNpuSseHaScan() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuSseHaScanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSseHaScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuSseHaScan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuSseHaScan resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuSseHaScan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuSseHaScan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuSseHaScan resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuSseHaScan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuSseHaScan")
 
-	return resourceObjectSystemNpuSseHaScanRead(d, m)
+	return resourceObjectSystemNpuSseHaScanRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuSseHaScanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSseHaScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuSseHaScan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuSseHaScan resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuSseHaScan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -116,34 +114,36 @@ func resourceObjectSystemNpuSseHaScanDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectSystemNpuSseHaScanRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSseHaScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuSseHaScan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuSseHaScan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuSseHaScan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuSseHaScan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuSseHaScan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuSseHaScan resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuSseHaScan resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuSwEhHash() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuSwEhHashUpdate,
-		Read:   resourceObjectSystemNpuSwEhHashRead,
-		Update: resourceObjectSystemNpuSwEhHashUpdate,
-		Delete: resourceObjectSystemNpuSwEhHashDelete,
+		CreateContext: resourceObjectSystemNpuSwEhHashUpdate,
+		ReadContext:   resourceObjectSystemNpuSwEhHashRead,
+		UpdateContext: resourceObjectSystemNpuSwEhHashUpdate,
+		DeleteContext: resourceObjectSystemNpuSwEhHashDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -94,53 +96,49 @@ func resourceObjectSystemNpuSwEhHash() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuSwEhHashUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSwEhHashUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuSwEhHash(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuSwEhHash resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuSwEhHash resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuSwEhHash(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuSwEhHash resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuSwEhHash resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("ObjectSystemNpuSwEhHash")
 
-	return resourceObjectSystemNpuSwEhHashRead(d, m)
+	return resourceObjectSystemNpuSwEhHashRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuSwEhHashDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSwEhHashDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuSwEhHash(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuSwEhHash resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuSwEhHash resource: %v", err)
 	}
 
 	d.SetId("")
@@ -148,34 +146,36 @@ func resourceObjectSystemNpuSwEhHashDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceObjectSystemNpuSwEhHashRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuSwEhHashRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuSwEhHash(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuSwEhHash resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuSwEhHash resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuSwEhHash resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuSwEhHash(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuSwEhHash resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuSwEhHash resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuTcpTimeoutProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuTcpTimeoutProfileCreate,
-		Read:   resourceObjectSystemNpuTcpTimeoutProfileRead,
-		Update: resourceObjectSystemNpuTcpTimeoutProfileUpdate,
-		Delete: resourceObjectSystemNpuTcpTimeoutProfileDelete,
+		CreateContext: resourceObjectSystemNpuTcpTimeoutProfileCreate,
+		ReadContext:   resourceObjectSystemNpuTcpTimeoutProfileRead,
+		UpdateContext: resourceObjectSystemNpuTcpTimeoutProfileUpdate,
+		DeleteContext: resourceObjectSystemNpuTcpTimeoutProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -78,81 +80,76 @@ func resourceObjectSystemNpuTcpTimeoutProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuTcpTimeoutProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuTcpTimeoutProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuTcpTimeoutProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuTcpTimeoutProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuTcpTimeoutProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuTcpTimeoutProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuTcpTimeoutProfileRead(d, m)
+	return resourceObjectSystemNpuTcpTimeoutProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuTcpTimeoutProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuTcpTimeoutProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuTcpTimeoutProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuTcpTimeoutProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuTcpTimeoutProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuTcpTimeoutProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuTcpTimeoutProfileRead(d, m)
+	return resourceObjectSystemNpuTcpTimeoutProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuTcpTimeoutProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuTcpTimeoutProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuTcpTimeoutProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -160,34 +157,36 @@ func resourceObjectSystemNpuTcpTimeoutProfileDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSystemNpuTcpTimeoutProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuTcpTimeoutProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuTcpTimeoutProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuTcpTimeoutProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuTcpTimeoutProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuTcpTimeoutProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuTcpTimeoutProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuTcpTimeoutProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemNpuUdpTimeoutProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemNpuUdpTimeoutProfileCreate,
-		Read:   resourceObjectSystemNpuUdpTimeoutProfileRead,
-		Update: resourceObjectSystemNpuUdpTimeoutProfileUpdate,
-		Delete: resourceObjectSystemNpuUdpTimeoutProfileDelete,
+		CreateContext: resourceObjectSystemNpuUdpTimeoutProfileCreate,
+		ReadContext:   resourceObjectSystemNpuUdpTimeoutProfileRead,
+		UpdateContext: resourceObjectSystemNpuUdpTimeoutProfileUpdate,
+		DeleteContext: resourceObjectSystemNpuUdpTimeoutProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectSystemNpuUdpTimeoutProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemNpuUdpTimeoutProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemNpuUdpTimeoutProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuUdpTimeoutProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuUdpTimeoutProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuUdpTimeoutProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemNpuUdpTimeoutProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuUdpTimeoutProfileRead(d, m)
+	return resourceObjectSystemNpuUdpTimeoutProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuUdpTimeoutProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuUdpTimeoutProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemNpuUdpTimeoutProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuUdpTimeoutProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuUdpTimeoutProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemNpuUdpTimeoutProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectSystemNpuUdpTimeoutProfileRead(d, m)
+	return resourceObjectSystemNpuUdpTimeoutProfileRead(ctx, d, m)
 }
 
-func resourceObjectSystemNpuUdpTimeoutProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuUdpTimeoutProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemNpuUdpTimeoutProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectSystemNpuUdpTimeoutProfileDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectSystemNpuUdpTimeoutProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemNpuUdpTimeoutProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemNpuUdpTimeoutProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemNpuUdpTimeoutProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemNpuUdpTimeoutProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemNpuUdpTimeoutProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemNpuUdpTimeoutProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemNpuUdpTimeoutProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemObjectTagging() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemObjectTaggingCreate,
-		Read:   resourceObjectSystemObjectTaggingRead,
-		Update: resourceObjectSystemObjectTaggingUpdate,
-		Delete: resourceObjectSystemObjectTaggingDelete,
+		CreateContext: resourceObjectSystemObjectTaggingCreate,
+		ReadContext:   resourceObjectSystemObjectTaggingRead,
+		UpdateContext: resourceObjectSystemObjectTaggingUpdate,
+		DeleteContext: resourceObjectSystemObjectTaggingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -84,81 +86,76 @@ func resourceObjectSystemObjectTagging() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemObjectTaggingCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemObjectTaggingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemObjectTagging(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemObjectTagging resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemObjectTagging resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemObjectTagging(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemObjectTagging resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemObjectTagging resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "category"))
 
-	return resourceObjectSystemObjectTaggingRead(d, m)
+	return resourceObjectSystemObjectTaggingRead(ctx, d, m)
 }
 
-func resourceObjectSystemObjectTaggingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemObjectTaggingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemObjectTagging(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemObjectTagging resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemObjectTagging resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemObjectTagging(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemObjectTagging resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemObjectTagging resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "category"))
 
-	return resourceObjectSystemObjectTaggingRead(d, m)
+	return resourceObjectSystemObjectTaggingRead(ctx, d, m)
 }
 
-func resourceObjectSystemObjectTaggingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemObjectTaggingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemObjectTagging(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemObjectTagging resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemObjectTagging resource: %v", err)
 	}
 
 	d.SetId("")
@@ -166,34 +163,36 @@ func resourceObjectSystemObjectTaggingDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectSystemObjectTaggingRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemObjectTaggingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemObjectTagging(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemObjectTagging resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemObjectTagging resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemObjectTagging resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemObjectTagging(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemObjectTagging resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemObjectTagging resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemReplacemsgGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemReplacemsgGroupCreate,
-		Read:   resourceObjectSystemReplacemsgGroupRead,
-		Update: resourceObjectSystemReplacemsgGroupUpdate,
-		Delete: resourceObjectSystemReplacemsgGroupDelete,
+		CreateContext: resourceObjectSystemReplacemsgGroupCreate,
+		ReadContext:   resourceObjectSystemReplacemsgGroupRead,
+		UpdateContext: resourceObjectSystemReplacemsgGroupUpdate,
+		DeleteContext: resourceObjectSystemReplacemsgGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -862,81 +864,76 @@ func resourceObjectSystemReplacemsgGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemReplacemsgGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemReplacemsgGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemReplacemsgGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemReplacemsgGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemReplacemsgGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemReplacemsgGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemReplacemsgGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemReplacemsgGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemReplacemsgGroupRead(d, m)
+	return resourceObjectSystemReplacemsgGroupRead(ctx, d, m)
 }
 
-func resourceObjectSystemReplacemsgGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemReplacemsgGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemReplacemsgGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemReplacemsgGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemReplacemsgGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemReplacemsgGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemReplacemsgGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemReplacemsgGroupRead(d, m)
+	return resourceObjectSystemReplacemsgGroupRead(ctx, d, m)
 }
 
-func resourceObjectSystemReplacemsgGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemReplacemsgGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemReplacemsgGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemReplacemsgGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -944,34 +941,36 @@ func resourceObjectSystemReplacemsgGroupDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectSystemReplacemsgGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemReplacemsgGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemReplacemsgGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemReplacemsgGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemReplacemsgGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemReplacemsgGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemReplacemsgGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemReplacemsgGroup resource from API: %v", err)
 	}
 	return nil
 }
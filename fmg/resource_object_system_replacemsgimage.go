@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemReplacemsgImage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemReplacemsgImageCreate,
-		Read:   resourceObjectSystemReplacemsgImageRead,
-		Update: resourceObjectSystemReplacemsgImageUpdate,
-		Delete: resourceObjectSystemReplacemsgImageDelete,
+		CreateContext: resourceObjectSystemReplacemsgImageCreate,
+		ReadContext:   resourceObjectSystemReplacemsgImageRead,
+		UpdateContext: resourceObjectSystemReplacemsgImageUpdate,
+		DeleteContext: resourceObjectSystemReplacemsgImageDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,81 +65,76 @@ func resourceObjectSystemReplacemsgImage() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemReplacemsgImageCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemReplacemsgImageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemReplacemsgImage(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemReplacemsgImage resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemReplacemsgImage resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemReplacemsgImage(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemReplacemsgImage resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemReplacemsgImage resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemReplacemsgImageRead(d, m)
+	return resourceObjectSystemReplacemsgImageRead(ctx, d, m)
 }
 
-func resourceObjectSystemReplacemsgImageUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgImageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemReplacemsgImage(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemReplacemsgImage resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemReplacemsgImage resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemReplacemsgImage(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemReplacemsgImage resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemReplacemsgImage resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemReplacemsgImageRead(d, m)
+	return resourceObjectSystemReplacemsgImageRead(ctx, d, m)
 }
 
-func resourceObjectSystemReplacemsgImageDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgImageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemReplacemsgImage(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemReplacemsgImage resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemReplacemsgImage resource: %v", err)
 	}
 
 	d.SetId("")
@@ -145,34 +142,36 @@ func resourceObjectSystemReplacemsgImageDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectSystemReplacemsgImageRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemReplacemsgImageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemReplacemsgImage(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemReplacemsgImage resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemReplacemsgImage resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemReplacemsgImage resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemReplacemsgImage(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemReplacemsgImage resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemReplacemsgImage resource from API: %v", err)
 	}
 	return nil
 }
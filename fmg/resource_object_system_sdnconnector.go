@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemSdnConnector() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemSdnConnectorCreate,
-		Read:   resourceObjectSystemSdnConnectorRead,
-		Update: resourceObjectSystemSdnConnectorUpdate,
-		Delete: resourceObjectSystemSdnConnectorDelete,
+		CreateContext: resourceObjectSystemSdnConnectorCreate,
+		ReadContext:   resourceObjectSystemSdnConnectorRead,
+		UpdateContext: resourceObjectSystemSdnConnectorUpdate,
+		DeleteContext: resourceObjectSystemSdnConnectorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -453,81 +455,76 @@ func resourceObjectSystemSdnConnector() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemSdnConnectorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemSdnConnectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemSdnConnector(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemSdnConnector resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemSdnConnector resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemSdnConnector(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemSdnConnector resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemSdnConnector resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemSdnConnectorRead(d, m)
+	return resourceObjectSystemSdnConnectorRead(ctx, d, m)
 }
 
-func resourceObjectSystemSdnConnectorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSdnConnectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemSdnConnector(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemSdnConnector resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemSdnConnector resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemSdnConnector(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemSdnConnector resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemSdnConnector resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemSdnConnectorRead(d, m)
+	return resourceObjectSystemSdnConnectorRead(ctx, d, m)
 }
 
-func resourceObjectSystemSdnConnectorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSdnConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemSdnConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemSdnConnector resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemSdnConnector resource: %v", err)
 	}
 
 	d.SetId("")
@@ -535,34 +532,36 @@ func resourceObjectSystemSdnConnectorDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectSystemSdnConnectorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSdnConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemSdnConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemSdnConnector resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemSdnConnector resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemSdnConnector resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemSdnConnector(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemSdnConnector resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemSdnConnector resource from API: %v", err)
 	}
 	return nil
 }
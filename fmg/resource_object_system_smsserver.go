@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemSmsServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemSmsServerCreate,
-		Read:   resourceObjectSystemSmsServerRead,
-		Update: resourceObjectSystemSmsServerUpdate,
-		Delete: resourceObjectSystemSmsServerDelete,
+		CreateContext: resourceObjectSystemSmsServerCreate,
+		ReadContext:   resourceObjectSystemSmsServerRead,
+		UpdateContext: resourceObjectSystemSmsServerUpdate,
+		DeleteContext: resourceObjectSystemSmsServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectSystemSmsServer() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemSmsServerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemSmsServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemSmsServer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemSmsServer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemSmsServer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemSmsServer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemSmsServer resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemSmsServer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemSmsServerRead(d, m)
+	return resourceObjectSystemSmsServerRead(ctx, d, m)
 }
 
-func resourceObjectSystemSmsServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSmsServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemSmsServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemSmsServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemSmsServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemSmsServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemSmsServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemSmsServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemSmsServerRead(d, m)
+	return resourceObjectSystemSmsServerRead(ctx, d, m)
 }
 
-func resourceObjectSystemSmsServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSmsServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemSmsServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemSmsServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemSmsServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectSystemSmsServerDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectSystemSmsServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemSmsServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemSmsServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemSmsServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemSmsServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemSmsServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemSmsServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemSmsServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemSmsServer resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectSystemVirtualWirePair() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectSystemVirtualWirePairCreate,
-		Read:   resourceObjectSystemVirtualWirePairRead,
-		Update: resourceObjectSystemVirtualWirePairUpdate,
-		Delete: resourceObjectSystemVirtualWirePairDelete,
+		CreateContext: resourceObjectSystemVirtualWirePairCreate,
+		ReadContext:   resourceObjectSystemVirtualWirePairRead,
+		UpdateContext: resourceObjectSystemVirtualWirePairUpdate,
+		DeleteContext: resourceObjectSystemVirtualWirePairDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectSystemVirtualWirePair() *schema.Resource {
 	}
 }
 
-func resourceObjectSystemVirtualWirePairCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectSystemVirtualWirePairCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemVirtualWirePair(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemVirtualWirePair resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectSystemVirtualWirePair resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectSystemVirtualWirePair(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectSystemVirtualWirePair resource: %v", err)
+		return diag.Errorf("Error creating ObjectSystemVirtualWirePair resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemVirtualWirePairRead(d, m)
+	return resourceObjectSystemVirtualWirePairRead(ctx, d, m)
 }
 
-func resourceObjectSystemVirtualWirePairUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemVirtualWirePairUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectSystemVirtualWirePair(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemVirtualWirePair resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectSystemVirtualWirePair resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectSystemVirtualWirePair(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectSystemVirtualWirePair resource: %v", err)
+		return diag.Errorf("Error updating ObjectSystemVirtualWirePair resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectSystemVirtualWirePairRead(d, m)
+	return resourceObjectSystemVirtualWirePairRead(ctx, d, m)
 }
 
-func resourceObjectSystemVirtualWirePairDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemVirtualWirePairDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectSystemVirtualWirePair(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectSystemVirtualWirePair resource: %v", err)
+		return diag.Errorf("Error deleting ObjectSystemVirtualWirePair resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectSystemVirtualWirePairDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectSystemVirtualWirePairRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectSystemVirtualWirePairRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectSystemVirtualWirePair(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemVirtualWirePair resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectSystemVirtualWirePair resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectSystemVirtualWirePair resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectSystemVirtualWirePair(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectSystemVirtualWirePair resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectSystemVirtualWirePair resource from API: %v", err)
 	}
 	return nil
 }
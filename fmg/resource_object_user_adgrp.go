@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserAdgrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserAdgrpCreate,
-		Read:   resourceObjectUserAdgrpRead,
-		Update: resourceObjectUserAdgrpUpdate,
-		Delete: resourceObjectUserAdgrpDelete,
+		CreateContext: resourceObjectUserAdgrpCreate,
+		ReadContext:   resourceObjectUserAdgrpRead,
+		UpdateContext: resourceObjectUserAdgrpUpdate,
+		DeleteContext: resourceObjectUserAdgrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,81 +68,76 @@ func resourceObjectUserAdgrp() *schema.Resource {
 	}
 }
 
-func resourceObjectUserAdgrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserAdgrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserAdgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserAdgrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserAdgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserAdgrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserAdgrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserAdgrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserAdgrpRead(d, m)
+	return resourceObjectUserAdgrpRead(ctx, d, m)
 }
 
-func resourceObjectUserAdgrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserAdgrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserAdgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserAdgrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserAdgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserAdgrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserAdgrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserAdgrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserAdgrpRead(d, m)
+	return resourceObjectUserAdgrpRead(ctx, d, m)
 }
 
-func resourceObjectUserAdgrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserAdgrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserAdgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserAdgrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserAdgrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -148,34 +145,36 @@ func resourceObjectUserAdgrpDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserAdgrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserAdgrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserAdgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserAdgrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserAdgrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserAdgrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserAdgrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserAdgrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserAdgrp resource from API: %v", err)
 	}
 	return nil
 }
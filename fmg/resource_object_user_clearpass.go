@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserClearpass() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserClearpassCreate,
-		Read:   resourceObjectUserClearpassRead,
-		Update: resourceObjectUserClearpassUpdate,
-		Delete: resourceObjectUserClearpassDelete,
+		CreateContext: resourceObjectUserClearpassCreate,
+		ReadContext:   resourceObjectUserClearpassRead,
+		UpdateContext: resourceObjectUserClearpassUpdate,
+		DeleteContext: resourceObjectUserClearpassDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,81 +79,76 @@ func resourceObjectUserClearpass() *schema.Resource {
 	}
 }
 
-func resourceObjectUserClearpassCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserClearpassCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserClearpass(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserClearpass resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserClearpass resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserClearpass(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserClearpass resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserClearpass resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserClearpassRead(d, m)
+	return resourceObjectUserClearpassRead(ctx, d, m)
 }
 
-func resourceObjectUserClearpassUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserClearpassUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserClearpass(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserClearpass resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserClearpass resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserClearpass(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserClearpass resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserClearpass resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserClearpassRead(d, m)
+	return resourceObjectUserClearpassRead(ctx, d, m)
 }
 
-func resourceObjectUserClearpassDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserClearpassDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserClearpass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserClearpass resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserClearpass resource: %v", err)
 	}
 
 	d.SetId("")
@@ -159,34 +156,36 @@ func resourceObjectUserClearpassDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectUserClearpassRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserClearpassRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserClearpass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserClearpass resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserClearpass resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserClearpass resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserClearpass(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserClearpass resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserClearpass resource from API: %v", err)
 	}
 	return nil
 }
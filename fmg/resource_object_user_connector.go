@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserConnector() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserConnectorCreate,
-		Read:   resourceObjectUserConnectorRead,
-		Update: resourceObjectUserConnectorUpdate,
-		Delete: resourceObjectUserConnectorDelete,
+		CreateContext: resourceObjectUserConnectorCreate,
+		ReadContext:   resourceObjectUserConnectorRead,
+		UpdateContext: resourceObjectUserConnectorUpdate,
+		DeleteContext: resourceObjectUserConnectorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectUserConnector() *schema.Resource {
 	}
 }
 
-func resourceObjectUserConnectorCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserConnectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserConnector(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserConnector resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserConnector resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserConnector(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserConnector resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserConnector resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserConnectorRead(d, m)
+	return resourceObjectUserConnectorRead(ctx, d, m)
 }
 
-func resourceObjectUserConnectorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserConnectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserConnector(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserConnector resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserConnector resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserConnector(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserConnector resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserConnector resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserConnectorRead(d, m)
+	return resourceObjectUserConnectorRead(ctx, d, m)
 }
 
-func resourceObjectUserConnectorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserConnector resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserConnector resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectUserConnectorDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectUserConnectorRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserConnector resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserConnector resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserConnector resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserConnector(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserConnector resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserConnector resource from API: %v", err)
 	}
 	return nil
 }
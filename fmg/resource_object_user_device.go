@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserDeviceCreate,
-		Read:   resourceObjectUserDeviceRead,
-		Update: resourceObjectUserDeviceUpdate,
-		Delete: resourceObjectUserDeviceDelete,
+		CreateContext: resourceObjectUserDeviceCreate,
+		ReadContext:   resourceObjectUserDeviceRead,
+		UpdateContext: resourceObjectUserDeviceUpdate,
+		DeleteContext: resourceObjectUserDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -185,81 +187,76 @@ func resourceObjectUserDevice() *schema.Resource {
 	}
 }
 
-func resourceObjectUserDeviceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDevice resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserDevice(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDevice resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserDevice resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "alias"))
 
-	return resourceObjectUserDeviceRead(d, m)
+	return resourceObjectUserDeviceRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDevice resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "alias"))
 
-	return resourceObjectUserDeviceRead(d, m)
+	return resourceObjectUserDeviceRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserDevice(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserDevice resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserDevice resource: %v", err)
 	}
 
 	d.SetId("")
@@ -267,34 +264,36 @@ func resourceObjectUserDeviceDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserDevice(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDevice resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserDevice resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserDevice resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserDevice(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDevice resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserDevice resource from API: %v", err)
 	}
 	return nil
 }
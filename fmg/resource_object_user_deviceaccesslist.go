@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserDeviceAccessList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserDeviceAccessListCreate,
-		Read:   resourceObjectUserDeviceAccessListRead,
-		Update: resourceObjectUserDeviceAccessListUpdate,
-		Delete: resourceObjectUserDeviceAccessListDelete,
+		CreateContext: resourceObjectUserDeviceAccessListCreate,
+		ReadContext:   resourceObjectUserDeviceAccessListRead,
+		UpdateContext: resourceObjectUserDeviceAccessListUpdate,
+		DeleteContext: resourceObjectUserDeviceAccessListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,81 +85,76 @@ func resourceObjectUserDeviceAccessList() *schema.Resource {
 	}
 }
 
-func resourceObjectUserDeviceAccessListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserDeviceAccessListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceAccessList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceAccessList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceAccessList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserDeviceAccessList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceAccessList resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceAccessList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceAccessListRead(d, m)
+	return resourceObjectUserDeviceAccessListRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceAccessListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceAccessListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceAccessList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceAccessList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceAccessList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserDeviceAccessList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceAccessList resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceAccessList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceAccessListRead(d, m)
+	return resourceObjectUserDeviceAccessListRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceAccessListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceAccessListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserDeviceAccessList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserDeviceAccessList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserDeviceAccessList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -165,34 +162,36 @@ func resourceObjectUserDeviceAccessListDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectUserDeviceAccessListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceAccessListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserDeviceAccessList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceAccessList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserDeviceAccessList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserDeviceAccessList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserDeviceAccessList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceAccessList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserDeviceAccessList resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserDeviceCategory() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserDeviceCategoryCreate,
-		Read:   resourceObjectUserDeviceCategoryRead,
-		Update: resourceObjectUserDeviceCategoryUpdate,
-		Delete: resourceObjectUserDeviceCategoryDelete,
+		CreateContext: resourceObjectUserDeviceCategoryCreate,
+		ReadContext:   resourceObjectUserDeviceCategoryRead,
+		UpdateContext: resourceObjectUserDeviceCategoryUpdate,
+		DeleteContext: resourceObjectUserDeviceCategoryDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectUserDeviceCategory() *schema.Resource {
 	}
 }
 
-func resourceObjectUserDeviceCategoryCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserDeviceCategoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceCategory(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceCategory resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceCategory resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserDeviceCategory(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceCategory resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceCategory resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceCategoryRead(d, m)
+	return resourceObjectUserDeviceCategoryRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceCategoryUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceCategoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceCategory(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceCategory resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceCategory resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserDeviceCategory(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceCategory resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceCategory resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceCategoryRead(d, m)
+	return resourceObjectUserDeviceCategoryRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceCategoryDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceCategoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserDeviceCategory(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserDeviceCategory resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserDeviceCategory resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectUserDeviceCategoryDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectUserDeviceCategoryRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceCategoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserDeviceCategory(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceCategory resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserDeviceCategory resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserDeviceCategory resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserDeviceCategory(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceCategory resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserDeviceCategory resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserDeviceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserDeviceGroupCreate,
-		Read:   resourceObjectUserDeviceGroupRead,
-		Update: resourceObjectUserDeviceGroupUpdate,
-		Delete: resourceObjectUserDeviceGroupDelete,
+		CreateContext: resourceObjectUserDeviceGroupCreate,
+		ReadContext:   resourceObjectUserDeviceGroupRead,
+		UpdateContext: resourceObjectUserDeviceGroupUpdate,
+		DeleteContext: resourceObjectUserDeviceGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -129,81 +131,76 @@ func resourceObjectUserDeviceGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectUserDeviceGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserDeviceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserDeviceGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDeviceGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserDeviceGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceGroupRead(d, m)
+	return resourceObjectUserDeviceGroupRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDeviceGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserDeviceGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDeviceGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserDeviceGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDeviceGroupRead(d, m)
+	return resourceObjectUserDeviceGroupRead(ctx, d, m)
 }
 
-func resourceObjectUserDeviceGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserDeviceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserDeviceGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserDeviceGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -211,34 +208,36 @@ func resourceObjectUserDeviceGroupDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectUserDeviceGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDeviceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserDeviceGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserDeviceGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserDeviceGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserDeviceGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDeviceGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserDeviceGroup resource from API: %v", err)
 	}
 	return nil
 }
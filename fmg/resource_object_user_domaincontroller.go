@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserDomainController() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserDomainControllerCreate,
-		Read:   resourceObjectUserDomainControllerRead,
-		Update: resourceObjectUserDomainControllerUpdate,
-		Delete: resourceObjectUserDomainControllerDelete,
+		CreateContext: resourceObjectUserDomainControllerCreate,
+		ReadContext:   resourceObjectUserDomainControllerRead,
+		UpdateContext: resourceObjectUserDomainControllerUpdate,
+		DeleteContext: resourceObjectUserDomainControllerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -186,81 +188,76 @@ func resourceObjectUserDomainController() *schema.Resource {
 	}
 }
 
-func resourceObjectUserDomainControllerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserDomainControllerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserDomainController(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserDomainController resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserDomainController resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDomainControllerRead(d, m)
+	return resourceObjectUserDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectUserDomainControllerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDomainControllerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserDomainController(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDomainController resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserDomainController resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserDomainController(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserDomainController resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserDomainController resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserDomainControllerRead(d, m)
+	return resourceObjectUserDomainControllerRead(ctx, d, m)
 }
 
-func resourceObjectUserDomainControllerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDomainControllerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserDomainController resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserDomainController resource: %v", err)
 	}
 
 	d.SetId("")
@@ -268,34 +265,36 @@ func resourceObjectUserDomainControllerDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectUserDomainControllerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserDomainControllerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserDomainController(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDomainController resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserDomainController resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserDomainController resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserDomainController(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserDomainController resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserDomainController resource from API: %v", err)
 	}
 	return nil
 }
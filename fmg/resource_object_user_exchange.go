@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserExchange() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserExchangeCreate,
-		Read:   resourceObjectUserExchangeRead,
-		Update: resourceObjectUserExchangeUpdate,
-		Delete: resourceObjectUserExchangeDelete,
+		CreateContext: resourceObjectUserExchangeCreate,
+		ReadContext:   resourceObjectUserExchangeRead,
+		UpdateContext: resourceObjectUserExchangeUpdate,
+		DeleteContext: resourceObjectUserExchangeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -122,81 +124,76 @@ func resourceObjectUserExchange() *schema.Resource {
 	}
 }
 
-func resourceObjectUserExchangeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserExchangeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserExchange(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserExchange resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserExchange resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserExchange(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserExchange resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserExchange resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserExchangeRead(d, m)
+	return resourceObjectUserExchangeRead(ctx, d, m)
 }
 
-func resourceObjectUserExchangeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserExchangeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserExchange(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserExchange resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserExchange resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserExchange(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserExchange resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserExchange resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserExchangeRead(d, m)
+	return resourceObjectUserExchangeRead(ctx, d, m)
 }
 
-func resourceObjectUserExchangeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserExchangeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserExchange(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserExchange resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserExchange resource: %v", err)
 	}
 
 	d.SetId("")
@@ -204,34 +201,36 @@ func resourceObjectUserExchangeDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectUserExchangeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserExchangeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserExchange(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserExchange resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserExchange resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserExchange resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserExchange(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserExchange resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserExchange resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserFlexvm() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserFlexvmCreate,
-		Read:   resourceObjectUserFlexvmRead,
-		Update: resourceObjectUserFlexvmUpdate,
-		Delete: resourceObjectUserFlexvmDelete,
+		CreateContext: resourceObjectUserFlexvmCreate,
+		ReadContext:   resourceObjectUserFlexvmRead,
+		UpdateContext: resourceObjectUserFlexvmUpdate,
+		DeleteContext: resourceObjectUserFlexvmDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -78,81 +80,76 @@ func resourceObjectUserFlexvm() *schema.Resource {
 	}
 }
 
-func resourceObjectUserFlexvmCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserFlexvmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFlexvm(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFlexvm resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserFlexvm resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserFlexvm(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFlexvm resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserFlexvm resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserFlexvmRead(d, m)
+	return resourceObjectUserFlexvmRead(ctx, d, m)
 }
 
-func resourceObjectUserFlexvmUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFlexvmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFlexvm(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFlexvm resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserFlexvm resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserFlexvm(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFlexvm resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserFlexvm resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserFlexvmRead(d, m)
+	return resourceObjectUserFlexvmRead(ctx, d, m)
 }
 
-func resourceObjectUserFlexvmDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFlexvmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserFlexvm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserFlexvm resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserFlexvm resource: %v", err)
 	}
 
 	d.SetId("")
@@ -160,34 +157,36 @@ func resourceObjectUserFlexvmDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserFlexvmRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFlexvmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserFlexvm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFlexvm resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserFlexvm resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserFlexvm resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserFlexvm(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFlexvm resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserFlexvm resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserFortitoken() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserFortitokenCreate,
-		Read:   resourceObjectUserFortitokenRead,
-		Update: resourceObjectUserFortitokenUpdate,
-		Delete: resourceObjectUserFortitokenDelete,
+		CreateContext: resourceObjectUserFortitokenCreate,
+		ReadContext:   resourceObjectUserFortitokenRead,
+		UpdateContext: resourceObjectUserFortitokenUpdate,
+		DeleteContext: resourceObjectUserFortitokenDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,81 +69,76 @@ func resourceObjectUserFortitoken() *schema.Resource {
 	}
 }
 
-func resourceObjectUserFortitokenCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserFortitokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFortitoken(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFortitoken resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserFortitoken resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserFortitoken(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFortitoken resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserFortitoken resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "serial_number"))
 
-	return resourceObjectUserFortitokenRead(d, m)
+	return resourceObjectUserFortitokenRead(ctx, d, m)
 }
 
-func resourceObjectUserFortitokenUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFortitokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFortitoken(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFortitoken resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserFortitoken resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserFortitoken(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFortitoken resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserFortitoken resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "serial_number"))
 
-	return resourceObjectUserFortitokenRead(d, m)
+	return resourceObjectUserFortitokenRead(ctx, d, m)
 }
 
-func resourceObjectUserFortitokenDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFortitokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserFortitoken(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserFortitoken resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserFortitoken resource: %v", err)
 	}
 
 	d.SetId("")
@@ -149,34 +146,36 @@ func resourceObjectUserFortitokenDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectUserFortitokenRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFortitokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserFortitoken(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFortitoken resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserFortitoken resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserFortitoken resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserFortitoken(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFortitoken resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserFortitoken resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserFsso() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserFssoCreate,
-		Read:   resourceObjectUserFssoRead,
-		Update: resourceObjectUserFssoUpdate,
-		Delete: resourceObjectUserFssoDelete,
+		CreateContext: resourceObjectUserFssoCreate,
+		ReadContext:   resourceObjectUserFssoRead,
+		UpdateContext: resourceObjectUserFssoUpdate,
+		DeleteContext: resourceObjectUserFssoDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -393,81 +395,76 @@ func resourceObjectUserFsso() *schema.Resource {
 	}
 }
 
-func resourceObjectUserFssoCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserFssoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFsso(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFsso resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserFsso resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserFsso(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFsso resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserFsso resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserFssoRead(d, m)
+	return resourceObjectUserFssoRead(ctx, d, m)
 }
 
-func resourceObjectUserFssoUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFsso(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFsso resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserFsso resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserFsso(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFsso resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserFsso resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserFssoRead(d, m)
+	return resourceObjectUserFssoRead(ctx, d, m)
 }
 
-func resourceObjectUserFssoDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserFsso(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserFsso resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserFsso resource: %v", err)
 	}
 
 	d.SetId("")
@@ -475,34 +472,36 @@ func resourceObjectUserFssoDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserFssoRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserFsso(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFsso resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserFsso resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserFsso resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserFsso(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFsso resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserFsso resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserFssoPolling() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserFssoPollingCreate,
-		Read:   resourceObjectUserFssoPollingRead,
-		Update: resourceObjectUserFssoPollingUpdate,
-		Delete: resourceObjectUserFssoPollingDelete,
+		CreateContext: resourceObjectUserFssoPollingCreate,
+		ReadContext:   resourceObjectUserFssoPollingRead,
+		UpdateContext: resourceObjectUserFssoPollingUpdate,
+		DeleteContext: resourceObjectUserFssoPollingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -127,81 +129,76 @@ func resourceObjectUserFssoPolling() *schema.Resource {
 	}
 }
 
-func resourceObjectUserFssoPollingCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserFssoPollingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFssoPolling(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFssoPolling resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserFssoPolling resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserFssoPolling(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserFssoPolling resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserFssoPolling resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectUserFssoPollingRead(d, m)
+	return resourceObjectUserFssoPollingRead(ctx, d, m)
 }
 
-func resourceObjectUserFssoPollingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoPollingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserFssoPolling(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFssoPolling resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserFssoPolling resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserFssoPolling(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserFssoPolling resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserFssoPolling resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectUserFssoPollingRead(d, m)
+	return resourceObjectUserFssoPollingRead(ctx, d, m)
 }
 
-func resourceObjectUserFssoPollingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoPollingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserFssoPolling(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserFssoPolling resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserFssoPolling resource: %v", err)
 	}
 
 	d.SetId("")
@@ -209,34 +206,36 @@ func resourceObjectUserFssoPollingDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectUserFssoPollingRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserFssoPollingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserFssoPolling(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFssoPolling resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserFssoPolling resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserFssoPolling resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserFssoPolling(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserFssoPolling resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserFssoPolling resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserGroupCreate,
-		Read:   resourceObjectUserGroupRead,
-		Update: resourceObjectUserGroupUpdate,
-		Delete: resourceObjectUserGroupDelete,
+		CreateContext: resourceObjectUserGroupCreate,
+		ReadContext:   resourceObjectUserGroupRead,
+		UpdateContext: resourceObjectUserGroupUpdate,
+		DeleteContext: resourceObjectUserGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -531,81 +533,76 @@ func resourceObjectUserGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectUserGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserGroupRead(d, m)
+	return resourceObjectUserGroupRead(ctx, d, m)
 }
 
-func resourceObjectUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserGroupRead(d, m)
+	return resourceObjectUserGroupRead(ctx, d, m)
 }
 
-func resourceObjectUserGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -613,34 +610,36 @@ func resourceObjectUserGroupDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserJson() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserJsonCreate,
-		Read:   resourceObjectUserJsonRead,
-		Update: resourceObjectUserJsonUpdate,
-		Delete: resourceObjectUserJsonDelete,
+		CreateContext: resourceObjectUserJsonCreate,
+		ReadContext:   resourceObjectUserJsonRead,
+		UpdateContext: resourceObjectUserJsonUpdate,
+		DeleteContext: resourceObjectUserJsonDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectUserJson() *schema.Resource {
 	}
 }
 
-func resourceObjectUserJsonCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserJsonCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserJson(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserJson resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserJson resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserJson(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserJson resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserJson resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserJsonRead(d, m)
+	return resourceObjectUserJsonRead(ctx, d, m)
 }
 
-func resourceObjectUserJsonUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserJsonUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserJson(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserJson resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserJson resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserJson(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserJson resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserJson resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserJsonRead(d, m)
+	return resourceObjectUserJsonRead(ctx, d, m)
 }
 
-func resourceObjectUserJsonDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserJsonDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserJson(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserJson resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserJson resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectUserJsonDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserJsonRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserJsonRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserJson(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserJson resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserJson resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserJson resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserJson(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserJson resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserJson resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserKrbKeytab() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserKrbKeytabCreate,
-		Read:   resourceObjectUserKrbKeytabRead,
-		Update: resourceObjectUserKrbKeytabUpdate,
-		Delete: resourceObjectUserKrbKeytabDelete,
+		CreateContext: resourceObjectUserKrbKeytabCreate,
+		ReadContext:   resourceObjectUserKrbKeytabRead,
+		UpdateContext: resourceObjectUserKrbKeytabUpdate,
+		DeleteContext: resourceObjectUserKrbKeytabDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectUserKrbKeytab() *schema.Resource {
 	}
 }
 
-func resourceObjectUserKrbKeytabCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserKrbKeytabCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserKrbKeytab(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserKrbKeytab resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserKrbKeytab resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserKrbKeytab(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserKrbKeytab resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserKrbKeytab resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserKrbKeytabRead(d, m)
+	return resourceObjectUserKrbKeytabRead(ctx, d, m)
 }
 
-func resourceObjectUserKrbKeytabUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserKrbKeytabUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserKrbKeytab(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserKrbKeytab resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserKrbKeytab resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserKrbKeytab(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserKrbKeytab resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserKrbKeytab resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserKrbKeytabRead(d, m)
+	return resourceObjectUserKrbKeytabRead(ctx, d, m)
 }
 
-func resourceObjectUserKrbKeytabDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserKrbKeytabDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserKrbKeytab(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserKrbKeytab resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserKrbKeytab resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectUserKrbKeytabDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectUserKrbKeytabRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserKrbKeytabRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserKrbKeytab(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserKrbKeytab resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserKrbKeytab resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserKrbKeytab resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserKrbKeytab(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserKrbKeytab resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserKrbKeytab resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserLdap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserLdapCreate,
-		Read:   resourceObjectUserLdapRead,
-		Update: resourceObjectUserLdapUpdate,
-		Delete: resourceObjectUserLdapDelete,
+		CreateContext: resourceObjectUserLdapCreate,
+		ReadContext:   resourceObjectUserLdapRead,
+		UpdateContext: resourceObjectUserLdapUpdate,
+		DeleteContext: resourceObjectUserLdapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -443,81 +445,76 @@ func resourceObjectUserLdap() *schema.Resource {
 	}
 }
 
-func resourceObjectUserLdapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserLdapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserLdap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserLdap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserLdap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserLdap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserLdap resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserLdap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserLdapRead(d, m)
+	return resourceObjectUserLdapRead(ctx, d, m)
 }
 
-func resourceObjectUserLdapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLdapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserLdap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserLdap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserLdap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserLdap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserLdap resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserLdap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserLdapRead(d, m)
+	return resourceObjectUserLdapRead(ctx, d, m)
 }
 
-func resourceObjectUserLdapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLdapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserLdap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserLdap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserLdap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -525,34 +522,36 @@ func resourceObjectUserLdapDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserLdapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLdapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserLdap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserLdap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserLdap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserLdap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserLdap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserLdap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserLdap resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserLocal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserLocalCreate,
-		Read:   resourceObjectUserLocalRead,
-		Update: resourceObjectUserLocalUpdate,
-		Delete: resourceObjectUserLocalDelete,
+		CreateContext: resourceObjectUserLocalCreate,
+		ReadContext:   resourceObjectUserLocalRead,
+		UpdateContext: resourceObjectUserLocalUpdate,
+		DeleteContext: resourceObjectUserLocalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -168,81 +170,76 @@ func resourceObjectUserLocal() *schema.Resource {
 	}
 }
 
-func resourceObjectUserLocalCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserLocalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserLocal resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserLocal(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserLocal resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserLocal resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserLocalRead(d, m)
+	return resourceObjectUserLocalRead(ctx, d, m)
 }
 
-func resourceObjectUserLocalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLocalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserLocal resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserLocal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserLocal resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserLocal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserLocalRead(d, m)
+	return resourceObjectUserLocalRead(ctx, d, m)
 }
 
-func resourceObjectUserLocalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLocalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserLocal resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserLocal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -250,34 +247,36 @@ func resourceObjectUserLocalDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserLocalRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserLocalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserLocal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserLocal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserLocal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserLocal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserLocal resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserLocal resource from API: %v", err)
 	}
 	return nil
 }
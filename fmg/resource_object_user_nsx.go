@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserNsx() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserNsxCreate,
-		Read:   resourceObjectUserNsxRead,
-		Update: resourceObjectUserNsxUpdate,
-		Delete: resourceObjectUserNsxDelete,
+		CreateContext: resourceObjectUserNsxCreate,
+		ReadContext:   resourceObjectUserNsxRead,
+		UpdateContext: resourceObjectUserNsxUpdate,
+		DeleteContext: resourceObjectUserNsxDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -137,81 +139,76 @@ func resourceObjectUserNsx() *schema.Resource {
 	}
 }
 
-func resourceObjectUserNsxCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserNsxCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserNsx(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserNsx resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserNsx resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserNsx(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserNsx resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserNsx resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserNsxRead(d, m)
+	return resourceObjectUserNsxRead(ctx, d, m)
 }
 
-func resourceObjectUserNsxUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserNsxUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserNsx(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserNsx resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserNsx resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserNsx(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserNsx resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserNsx resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserNsxRead(d, m)
+	return resourceObjectUserNsxRead(ctx, d, m)
 }
 
-func resourceObjectUserNsxDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserNsxDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserNsx(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserNsx resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserNsx resource: %v", err)
 	}
 
 	d.SetId("")
@@ -219,34 +216,36 @@ func resourceObjectUserNsxDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserNsxRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserNsxRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserNsx(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserNsx resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserNsx resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserNsx resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserNsx(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserNsx resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserNsx resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserPasswordPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserPasswordPolicyCreate,
-		Read:   resourceObjectUserPasswordPolicyRead,
-		Update: resourceObjectUserPasswordPolicyUpdate,
-		Delete: resourceObjectUserPasswordPolicyDelete,
+		CreateContext: resourceObjectUserPasswordPolicyCreate,
+		ReadContext:   resourceObjectUserPasswordPolicyRead,
+		UpdateContext: resourceObjectUserPasswordPolicyUpdate,
+		DeleteContext: resourceObjectUserPasswordPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,81 +71,76 @@ func resourceObjectUserPasswordPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectUserPasswordPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserPasswordPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPasswordPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPasswordPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserPasswordPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserPasswordPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPasswordPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserPasswordPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPasswordPolicyRead(d, m)
+	return resourceObjectUserPasswordPolicyRead(ctx, d, m)
 }
 
-func resourceObjectUserPasswordPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPasswordPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPasswordPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPasswordPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserPasswordPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserPasswordPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPasswordPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserPasswordPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPasswordPolicyRead(d, m)
+	return resourceObjectUserPasswordPolicyRead(ctx, d, m)
 }
 
-func resourceObjectUserPasswordPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPasswordPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserPasswordPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserPasswordPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserPasswordPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -151,34 +148,36 @@ func resourceObjectUserPasswordPolicyDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectUserPasswordPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPasswordPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserPasswordPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPasswordPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserPasswordPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserPasswordPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserPasswordPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPasswordPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserPasswordPolicy resource from API: %v", err)
 	}
 	return nil
 }
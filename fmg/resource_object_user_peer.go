@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserPeer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserPeerCreate,
-		Read:   resourceObjectUserPeerRead,
-		Update: resourceObjectUserPeerUpdate,
-		Delete: resourceObjectUserPeerDelete,
+		CreateContext: resourceObjectUserPeerCreate,
+		ReadContext:   resourceObjectUserPeerRead,
+		UpdateContext: resourceObjectUserPeerUpdate,
+		DeleteContext: resourceObjectUserPeerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,81 +113,76 @@ func resourceObjectUserPeer() *schema.Resource {
 	}
 }
 
-func resourceObjectUserPeerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserPeerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPeer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserPeer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPeer resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserPeer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPeerRead(d, m)
+	return resourceObjectUserPeerRead(ctx, d, m)
 }
 
-func resourceObjectUserPeerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPeer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserPeer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPeer resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserPeer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPeerRead(d, m)
+	return resourceObjectUserPeerRead(ctx, d, m)
 }
 
-func resourceObjectUserPeerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserPeer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserPeer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -193,34 +190,36 @@ func resourceObjectUserPeerDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserPeerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPeer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserPeer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserPeer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserPeer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPeer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserPeer resource from API: %v", err)
 	}
 	return nil
 }
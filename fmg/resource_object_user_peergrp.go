@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserPeergrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserPeergrpCreate,
-		Read:   resourceObjectUserPeergrpRead,
-		Update: resourceObjectUserPeergrpUpdate,
-		Delete: resourceObjectUserPeergrpDelete,
+		CreateContext: resourceObjectUserPeergrpCreate,
+		ReadContext:   resourceObjectUserPeergrpRead,
+		UpdateContext: resourceObjectUserPeergrpUpdate,
+		DeleteContext: resourceObjectUserPeergrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,81 +62,76 @@ func resourceObjectUserPeergrp() *schema.Resource {
 	}
 }
 
-func resourceObjectUserPeergrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserPeergrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPeergrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPeergrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserPeergrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserPeergrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPeergrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserPeergrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPeergrpRead(d, m)
+	return resourceObjectUserPeergrpRead(ctx, d, m)
 }
 
-func resourceObjectUserPeergrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeergrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPeergrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPeergrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserPeergrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserPeergrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPeergrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserPeergrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPeergrpRead(d, m)
+	return resourceObjectUserPeergrpRead(ctx, d, m)
 }
 
-func resourceObjectUserPeergrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeergrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserPeergrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserPeergrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserPeergrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -142,34 +139,36 @@ func resourceObjectUserPeergrpDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectUserPeergrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPeergrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserPeergrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPeergrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserPeergrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserPeergrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserPeergrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPeergrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserPeergrp resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserPop3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserPop3Create,
-		Read:   resourceObjectUserPop3Read,
-		Update: resourceObjectUserPop3Update,
-		Delete: resourceObjectUserPop3Delete,
+		CreateContext: resourceObjectUserPop3Create,
+		ReadContext:   resourceObjectUserPop3Read,
+		UpdateContext: resourceObjectUserPop3Update,
+		DeleteContext: resourceObjectUserPop3Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,81 +75,76 @@ func resourceObjectUserPop3() *schema.Resource {
 	}
 }
 
-func resourceObjectUserPop3Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserPop3Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPop3(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPop3 resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserPop3 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserPop3(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPop3 resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserPop3 resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPop3Read(d, m)
+	return resourceObjectUserPop3Read(ctx, d, m)
 }
 
-func resourceObjectUserPop3Update(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPop3Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPop3(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPop3 resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserPop3 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserPop3(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPop3 resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserPop3 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPop3Read(d, m)
+	return resourceObjectUserPop3Read(ctx, d, m)
 }
 
-func resourceObjectUserPop3Delete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPop3Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserPop3(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserPop3 resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserPop3 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -155,34 +152,36 @@ func resourceObjectUserPop3Delete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserPop3Read(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPop3Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserPop3(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPop3 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserPop3 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserPop3 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserPop3(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPop3 resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserPop3 resource from API: %v", err)
 	}
 	return nil
 }
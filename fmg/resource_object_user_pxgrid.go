@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserPxgrid() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserPxgridCreate,
-		Read:   resourceObjectUserPxgridRead,
-		Update: resourceObjectUserPxgridUpdate,
-		Delete: resourceObjectUserPxgridDelete,
+		CreateContext: resourceObjectUserPxgridCreate,
+		ReadContext:   resourceObjectUserPxgridRead,
+		UpdateContext: resourceObjectUserPxgridUpdate,
+		DeleteContext: resourceObjectUserPxgridDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -70,81 +72,76 @@ func resourceObjectUserPxgrid() *schema.Resource {
 	}
 }
 
-func resourceObjectUserPxgridCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserPxgridCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPxgrid(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPxgrid resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserPxgrid resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserPxgrid(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserPxgrid resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserPxgrid resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPxgridRead(d, m)
+	return resourceObjectUserPxgridRead(ctx, d, m)
 }
 
-func resourceObjectUserPxgridUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPxgridUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserPxgrid(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPxgrid resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserPxgrid resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserPxgrid(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserPxgrid resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserPxgrid resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserPxgridRead(d, m)
+	return resourceObjectUserPxgridRead(ctx, d, m)
 }
 
-func resourceObjectUserPxgridDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPxgridDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserPxgrid(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserPxgrid resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserPxgrid resource: %v", err)
 	}
 
 	d.SetId("")
@@ -152,34 +149,36 @@ func resourceObjectUserPxgridDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserPxgridRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserPxgridRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserPxgrid(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPxgrid resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserPxgrid resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserPxgrid resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserPxgrid(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserPxgrid resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserPxgrid resource from API: %v", err)
 	}
 	return nil
 }
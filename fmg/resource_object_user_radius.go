@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserRadius() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserRadiusCreate,
-		Read:   resourceObjectUserRadiusRead,
-		Update: resourceObjectUserRadiusUpdate,
-		Delete: resourceObjectUserRadiusDelete,
+		CreateContext: resourceObjectUserRadiusCreate,
+		ReadContext:   resourceObjectUserRadiusRead,
+		UpdateContext: resourceObjectUserRadiusUpdate,
+		DeleteContext: resourceObjectUserRadiusDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -736,81 +738,76 @@ func resourceObjectUserRadius() *schema.Resource {
 	}
 }
 
-func resourceObjectUserRadiusCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserRadiusCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserRadius(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserRadius resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserRadius resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserRadius(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserRadius resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserRadius resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserRadiusRead(d, m)
+	return resourceObjectUserRadiusRead(ctx, d, m)
 }
 
-func resourceObjectUserRadiusUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserRadiusUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserRadius(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserRadius resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserRadius resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserRadius(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserRadius resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserRadius resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserRadiusRead(d, m)
+	return resourceObjectUserRadiusRead(ctx, d, m)
 }
 
-func resourceObjectUserRadiusDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserRadiusDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserRadius(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserRadius resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserRadius resource: %v", err)
 	}
 
 	d.SetId("")
@@ -818,34 +815,36 @@ func resourceObjectUserRadiusDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserRadiusRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserRadiusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserRadius(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserRadius resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserRadius resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserRadius resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserRadius(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserRadius resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserRadius resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserSaml() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserSamlCreate,
-		Read:   resourceObjectUserSamlRead,
-		Update: resourceObjectUserSamlUpdate,
-		Delete: resourceObjectUserSamlDelete,
+		CreateContext: resourceObjectUserSamlCreate,
+		ReadContext:   resourceObjectUserSamlRead,
+		UpdateContext: resourceObjectUserSamlUpdate,
+		DeleteContext: resourceObjectUserSamlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -223,81 +225,76 @@ func resourceObjectUserSaml() *schema.Resource {
 	}
 }
 
-func resourceObjectUserSamlCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserSamlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserSaml(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserSaml resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserSaml resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserSaml(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserSaml resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserSaml resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserSamlRead(d, m)
+	return resourceObjectUserSamlRead(ctx, d, m)
 }
 
-func resourceObjectUserSamlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSamlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserSaml(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserSaml resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserSaml resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserSaml(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserSaml resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserSaml resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserSamlRead(d, m)
+	return resourceObjectUserSamlRead(ctx, d, m)
 }
 
-func resourceObjectUserSamlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSamlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserSaml(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserSaml resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserSaml resource: %v", err)
 	}
 
 	d.SetId("")
@@ -305,34 +302,36 @@ func resourceObjectUserSamlDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceObjectUserSamlRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSamlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserSaml(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserSaml resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserSaml resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserSaml resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserSaml(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserSaml resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserSaml resource from API: %v", err)
 	}
 	return nil
 }
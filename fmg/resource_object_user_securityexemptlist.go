@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserSecurityExemptList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserSecurityExemptListCreate,
-		Read:   resourceObjectUserSecurityExemptListRead,
-		Update: resourceObjectUserSecurityExemptListUpdate,
-		Delete: resourceObjectUserSecurityExemptListDelete,
+		CreateContext: resourceObjectUserSecurityExemptListCreate,
+		ReadContext:   resourceObjectUserSecurityExemptListRead,
+		UpdateContext: resourceObjectUserSecurityExemptListUpdate,
+		DeleteContext: resourceObjectUserSecurityExemptListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,81 +93,76 @@ func resourceObjectUserSecurityExemptList() *schema.Resource {
 	}
 }
 
-func resourceObjectUserSecurityExemptListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserSecurityExemptListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserSecurityExemptList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserSecurityExemptList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserSecurityExemptList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserSecurityExemptList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserSecurityExemptList resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserSecurityExemptList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserSecurityExemptListRead(d, m)
+	return resourceObjectUserSecurityExemptListRead(ctx, d, m)
 }
 
-func resourceObjectUserSecurityExemptListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSecurityExemptListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserSecurityExemptList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserSecurityExemptList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserSecurityExemptList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserSecurityExemptList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserSecurityExemptList resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserSecurityExemptList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserSecurityExemptListRead(d, m)
+	return resourceObjectUserSecurityExemptListRead(ctx, d, m)
 }
 
-func resourceObjectUserSecurityExemptListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSecurityExemptListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserSecurityExemptList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserSecurityExemptList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserSecurityExemptList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -173,34 +170,36 @@ func resourceObjectUserSecurityExemptListDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectUserSecurityExemptListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserSecurityExemptListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserSecurityExemptList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserSecurityExemptList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserSecurityExemptList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserSecurityExemptList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserSecurityExemptList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserSecurityExemptList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserSecurityExemptList resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserTacacs() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserTacacsCreate,
-		Read:   resourceObjectUserTacacsRead,
-		Update: resourceObjectUserTacacsUpdate,
-		Delete: resourceObjectUserTacacsDelete,
+		CreateContext: resourceObjectUserTacacsCreate,
+		ReadContext:   resourceObjectUserTacacsRead,
+		UpdateContext: resourceObjectUserTacacsUpdate,
+		DeleteContext: resourceObjectUserTacacsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -205,81 +207,76 @@ func resourceObjectUserTacacs() *schema.Resource {
 	}
 }
 
-func resourceObjectUserTacacsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserTacacsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserTacacs(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserTacacs resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserTacacs resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserTacacs(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserTacacs resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserTacacs resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserTacacsRead(d, m)
+	return resourceObjectUserTacacsRead(ctx, d, m)
 }
 
-func resourceObjectUserTacacsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserTacacsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserTacacs(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserTacacs resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserTacacs resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserTacacs(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserTacacs resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserTacacs resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserTacacsRead(d, m)
+	return resourceObjectUserTacacsRead(ctx, d, m)
 }
 
-func resourceObjectUserTacacsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserTacacsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserTacacs(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserTacacs resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserTacacs resource: %v", err)
 	}
 
 	d.SetId("")
@@ -287,34 +284,36 @@ func resourceObjectUserTacacsDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectUserTacacsRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserTacacsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserTacacs(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserTacacs resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserTacacs resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserTacacs resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserTacacs(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserTacacs resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserTacacs resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectUserVcenter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectUserVcenterCreate,
-		Read:   resourceObjectUserVcenterRead,
-		Update: resourceObjectUserVcenterUpdate,
-		Delete: resourceObjectUserVcenterDelete,
+		CreateContext: resourceObjectUserVcenterCreate,
+		ReadContext:   resourceObjectUserVcenterRead,
+		UpdateContext: resourceObjectUserVcenterUpdate,
+		DeleteContext: resourceObjectUserVcenterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -100,81 +102,76 @@ func resourceObjectUserVcenter() *schema.Resource {
 	}
 }
 
-func resourceObjectUserVcenterCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectUserVcenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserVcenter(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserVcenter resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectUserVcenter resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectUserVcenter(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectUserVcenter resource: %v", err)
+		return diag.Errorf("Error creating ObjectUserVcenter resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserVcenterRead(d, m)
+	return resourceObjectUserVcenterRead(ctx, d, m)
 }
 
-func resourceObjectUserVcenterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserVcenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectUserVcenter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserVcenter resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectUserVcenter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectUserVcenter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectUserVcenter resource: %v", err)
+		return diag.Errorf("Error updating ObjectUserVcenter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectUserVcenterRead(d, m)
+	return resourceObjectUserVcenterRead(ctx, d, m)
 }
 
-func resourceObjectUserVcenterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserVcenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectUserVcenter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectUserVcenter resource: %v", err)
+		return diag.Errorf("Error deleting ObjectUserVcenter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -182,34 +179,36 @@ func resourceObjectUserVcenterDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectUserVcenterRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectUserVcenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectUserVcenter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserVcenter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectUserVcenter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectUserVcenter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectUserVcenter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectUserVcenter resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectUserVcenter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVideofilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVideofilterProfileCreate,
-		Read:   resourceObjectVideofilterProfileRead,
-		Update: resourceObjectVideofilterProfileUpdate,
-		Delete: resourceObjectVideofilterProfileDelete,
+		CreateContext: resourceObjectVideofilterProfileCreate,
+		ReadContext:   resourceObjectVideofilterProfileRead,
+		UpdateContext: resourceObjectVideofilterProfileUpdate,
+		DeleteContext: resourceObjectVideofilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -126,81 +128,76 @@ func resourceObjectVideofilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectVideofilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVideofilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVideofilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVideofilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVideofilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVideofilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVideofilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectVideofilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVideofilterProfileRead(d, m)
+	return resourceObjectVideofilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectVideofilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVideofilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVideofilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVideofilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVideofilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVideofilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectVideofilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVideofilterProfileRead(d, m)
+	return resourceObjectVideofilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectVideofilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVideofilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVideofilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVideofilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -208,34 +205,36 @@ func resourceObjectVideofilterProfileDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectVideofilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVideofilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVideofilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVideofilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVideofilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVideofilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVideofilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVideofilterProfile resource from API: %v", err)
 	}
 	return nil
 }
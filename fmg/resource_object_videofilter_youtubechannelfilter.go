@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVideofilterYoutubeChannelFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVideofilterYoutubeChannelFilterCreate,
-		Read:   resourceObjectVideofilterYoutubeChannelFilterRead,
-		Update: resourceObjectVideofilterYoutubeChannelFilterUpdate,
-		Delete: resourceObjectVideofilterYoutubeChannelFilterDelete,
+		CreateContext: resourceObjectVideofilterYoutubeChannelFilterCreate,
+		ReadContext:   resourceObjectVideofilterYoutubeChannelFilterRead,
+		UpdateContext: resourceObjectVideofilterYoutubeChannelFilterUpdate,
+		DeleteContext: resourceObjectVideofilterYoutubeChannelFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -106,81 +108,76 @@ func resourceObjectVideofilterYoutubeChannelFilter() *schema.Resource {
 	}
 }
 
-func resourceObjectVideofilterYoutubeChannelFilterCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVideofilterYoutubeChannelFilterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVideofilterYoutubeChannelFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVideofilterYoutubeChannelFilter resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVideofilterYoutubeChannelFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVideofilterYoutubeChannelFilter(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVideofilterYoutubeChannelFilter resource: %v", err)
+		return diag.Errorf("Error creating ObjectVideofilterYoutubeChannelFilter resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectVideofilterYoutubeChannelFilterRead(d, m)
+	return resourceObjectVideofilterYoutubeChannelFilterRead(ctx, d, m)
 }
 
-func resourceObjectVideofilterYoutubeChannelFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterYoutubeChannelFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVideofilterYoutubeChannelFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVideofilterYoutubeChannelFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVideofilterYoutubeChannelFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVideofilterYoutubeChannelFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVideofilterYoutubeChannelFilter resource: %v", err)
+		return diag.Errorf("Error updating ObjectVideofilterYoutubeChannelFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectVideofilterYoutubeChannelFilterRead(d, m)
+	return resourceObjectVideofilterYoutubeChannelFilterRead(ctx, d, m)
 }
 
-func resourceObjectVideofilterYoutubeChannelFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterYoutubeChannelFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVideofilterYoutubeChannelFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVideofilterYoutubeChannelFilter resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVideofilterYoutubeChannelFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -188,34 +185,36 @@ func resourceObjectVideofilterYoutubeChannelFilterDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectVideofilterYoutubeChannelFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVideofilterYoutubeChannelFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVideofilterYoutubeChannelFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVideofilterYoutubeChannelFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVideofilterYoutubeChannelFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVideofilterYoutubeChannelFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVideofilterYoutubeChannelFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVideofilterYoutubeChannelFilter resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVideofilterYoutubeChannelFilter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVoipProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVoipProfileCreate,
-		Read:   resourceObjectVoipProfileRead,
-		Update: resourceObjectVoipProfileUpdate,
-		Delete: resourceObjectVoipProfileDelete,
+		CreateContext: resourceObjectVoipProfileCreate,
+		ReadContext:   resourceObjectVoipProfileRead,
+		UpdateContext: resourceObjectVoipProfileUpdate,
+		DeleteContext: resourceObjectVoipProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -679,81 +681,76 @@ func resourceObjectVoipProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectVoipProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVoipProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVoipProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVoipProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVoipProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVoipProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVoipProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectVoipProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVoipProfileRead(d, m)
+	return resourceObjectVoipProfileRead(ctx, d, m)
 }
 
-func resourceObjectVoipProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVoipProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVoipProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVoipProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVoipProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVoipProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVoipProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectVoipProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVoipProfileRead(d, m)
+	return resourceObjectVoipProfileRead(ctx, d, m)
 }
 
-func resourceObjectVoipProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVoipProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVoipProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVoipProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVoipProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -761,34 +758,36 @@ func resourceObjectVoipProfileDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectVoipProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVoipProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVoipProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVoipProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVoipProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVoipProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVoipProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVoipProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVoipProfile resource from API: %v", err)
 	}
 	return nil
 }
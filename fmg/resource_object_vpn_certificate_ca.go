@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnCertificateCa() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnCertificateCaCreate,
-		Read:   resourceObjectVpnCertificateCaRead,
-		Update: resourceObjectVpnCertificateCaUpdate,
-		Delete: resourceObjectVpnCertificateCaDelete,
+		CreateContext: resourceObjectVpnCertificateCaCreate,
+		ReadContext:   resourceObjectVpnCertificateCaRead,
+		UpdateContext: resourceObjectVpnCertificateCaUpdate,
+		DeleteContext: resourceObjectVpnCertificateCaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,81 +113,76 @@ func resourceObjectVpnCertificateCa() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnCertificateCaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnCertificateCaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateCa(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateCa resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateCa resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnCertificateCa(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateCa resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateCa resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateCaRead(d, m)
+	return resourceObjectVpnCertificateCaRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateCaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateCaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateCa(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateCa resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateCa resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnCertificateCa(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateCa resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateCa resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateCaRead(d, m)
+	return resourceObjectVpnCertificateCaRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateCaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateCaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnCertificateCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnCertificateCa resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnCertificateCa resource: %v", err)
 	}
 
 	d.SetId("")
@@ -193,34 +190,36 @@ func resourceObjectVpnCertificateCaDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectVpnCertificateCaRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateCaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnCertificateCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateCa resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnCertificateCa resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnCertificateCa resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnCertificateCa(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateCa resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnCertificateCa resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnCertificateOcspServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnCertificateOcspServerCreate,
-		Read:   resourceObjectVpnCertificateOcspServerRead,
-		Update: resourceObjectVpnCertificateOcspServerUpdate,
-		Delete: resourceObjectVpnCertificateOcspServerDelete,
+		CreateContext: resourceObjectVpnCertificateOcspServerCreate,
+		ReadContext:   resourceObjectVpnCertificateOcspServerRead,
+		UpdateContext: resourceObjectVpnCertificateOcspServerUpdate,
+		DeleteContext: resourceObjectVpnCertificateOcspServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,81 +82,76 @@ func resourceObjectVpnCertificateOcspServer() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnCertificateOcspServerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnCertificateOcspServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateOcspServer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateOcspServer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateOcspServer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnCertificateOcspServer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateOcspServer resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateOcspServer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateOcspServerRead(d, m)
+	return resourceObjectVpnCertificateOcspServerRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateOcspServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateOcspServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateOcspServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateOcspServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateOcspServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnCertificateOcspServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateOcspServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateOcspServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateOcspServerRead(d, m)
+	return resourceObjectVpnCertificateOcspServerRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateOcspServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateOcspServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnCertificateOcspServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnCertificateOcspServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnCertificateOcspServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -162,34 +159,36 @@ func resourceObjectVpnCertificateOcspServerDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceObjectVpnCertificateOcspServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateOcspServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnCertificateOcspServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateOcspServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnCertificateOcspServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnCertificateOcspServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnCertificateOcspServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateOcspServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnCertificateOcspServer resource from API: %v", err)
 	}
 	return nil
 }
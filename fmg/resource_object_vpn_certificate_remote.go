@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnCertificateRemote() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnCertificateRemoteCreate,
-		Read:   resourceObjectVpnCertificateRemoteRead,
-		Update: resourceObjectVpnCertificateRemoteUpdate,
-		Delete: resourceObjectVpnCertificateRemoteDelete,
+		CreateContext: resourceObjectVpnCertificateRemoteCreate,
+		ReadContext:   resourceObjectVpnCertificateRemoteRead,
+		UpdateContext: resourceObjectVpnCertificateRemoteUpdate,
+		DeleteContext: resourceObjectVpnCertificateRemoteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,81 +70,76 @@ func resourceObjectVpnCertificateRemote() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnCertificateRemoteCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnCertificateRemoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateRemote(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateRemote resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateRemote resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnCertificateRemote(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnCertificateRemote resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnCertificateRemote resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateRemoteRead(d, m)
+	return resourceObjectVpnCertificateRemoteRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateRemoteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateRemoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnCertificateRemote(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateRemote resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateRemote resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnCertificateRemote(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnCertificateRemote resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnCertificateRemote resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnCertificateRemoteRead(d, m)
+	return resourceObjectVpnCertificateRemoteRead(ctx, d, m)
 }
 
-func resourceObjectVpnCertificateRemoteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateRemoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnCertificateRemote(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnCertificateRemote resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnCertificateRemote resource: %v", err)
 	}
 
 	d.SetId("")
@@ -150,34 +147,36 @@ func resourceObjectVpnCertificateRemoteDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceObjectVpnCertificateRemoteRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnCertificateRemoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnCertificateRemote(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateRemote resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnCertificateRemote resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnCertificateRemote resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnCertificateRemote(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnCertificateRemote resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnCertificateRemote resource from API: %v", err)
 	}
 	return nil
 }
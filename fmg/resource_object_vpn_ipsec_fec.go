@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnIpsecFec() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnIpsecFecCreate,
-		Read:   resourceObjectVpnIpsecFecRead,
-		Update: resourceObjectVpnIpsecFecUpdate,
-		Delete: resourceObjectVpnIpsecFecDelete,
+		CreateContext: resourceObjectVpnIpsecFecCreate,
+		ReadContext:   resourceObjectVpnIpsecFecRead,
+		UpdateContext: resourceObjectVpnIpsecFecUpdate,
+		DeleteContext: resourceObjectVpnIpsecFecDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -100,81 +102,76 @@ func resourceObjectVpnIpsecFec() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnIpsecFecCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnIpsecFecCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnIpsecFec(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnIpsecFec resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnIpsecFec resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnIpsecFec(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnIpsecFec resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnIpsecFec resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnIpsecFecRead(d, m)
+	return resourceObjectVpnIpsecFecRead(ctx, d, m)
 }
 
-func resourceObjectVpnIpsecFecUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnIpsecFecUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnIpsecFec(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnIpsecFec resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnIpsecFec resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnIpsecFec(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnIpsecFec resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnIpsecFec resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnIpsecFecRead(d, m)
+	return resourceObjectVpnIpsecFecRead(ctx, d, m)
 }
 
-func resourceObjectVpnIpsecFecDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnIpsecFecDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnIpsecFec(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnIpsecFec resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnIpsecFec resource: %v", err)
 	}
 
 	d.SetId("")
@@ -182,34 +179,36 @@ func resourceObjectVpnIpsecFecDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectVpnIpsecFecRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnIpsecFecRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnIpsecFec(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnIpsecFec resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnIpsecFec resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnIpsecFec resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnIpsecFec(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnIpsecFec resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnIpsecFec resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnSslWebHostCheckSoftware() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnSslWebHostCheckSoftwareCreate,
-		Read:   resourceObjectVpnSslWebHostCheckSoftwareRead,
-		Update: resourceObjectVpnSslWebHostCheckSoftwareUpdate,
-		Delete: resourceObjectVpnSslWebHostCheckSoftwareDelete,
+		CreateContext: resourceObjectVpnSslWebHostCheckSoftwareCreate,
+		ReadContext:   resourceObjectVpnSslWebHostCheckSoftwareRead,
+		UpdateContext: resourceObjectVpnSslWebHostCheckSoftwareUpdate,
+		DeleteContext: resourceObjectVpnSslWebHostCheckSoftwareDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,81 +116,76 @@ func resourceObjectVpnSslWebHostCheckSoftware() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnSslWebHostCheckSoftwareCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnSslWebHostCheckSoftwareCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebHostCheckSoftware(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebHostCheckSoftware resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebHostCheckSoftware resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnSslWebHostCheckSoftware(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebHostCheckSoftware resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebHostCheckSoftware resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnSslWebHostCheckSoftwareRead(d, m)
+	return resourceObjectVpnSslWebHostCheckSoftwareRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebHostCheckSoftwareUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebHostCheckSoftwareUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebHostCheckSoftware(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebHostCheckSoftware resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebHostCheckSoftware resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnSslWebHostCheckSoftware(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebHostCheckSoftware resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebHostCheckSoftware resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnSslWebHostCheckSoftwareRead(d, m)
+	return resourceObjectVpnSslWebHostCheckSoftwareRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebHostCheckSoftwareDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebHostCheckSoftwareDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnSslWebHostCheckSoftware(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnSslWebHostCheckSoftware resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnSslWebHostCheckSoftware resource: %v", err)
 	}
 
 	d.SetId("")
@@ -196,34 +193,36 @@ func resourceObjectVpnSslWebHostCheckSoftwareDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectVpnSslWebHostCheckSoftwareRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebHostCheckSoftwareRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnSslWebHostCheckSoftware(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebHostCheckSoftware resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnSslWebHostCheckSoftware resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnSslWebHostCheckSoftware resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnSslWebHostCheckSoftware(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebHostCheckSoftware resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnSslWebHostCheckSoftware resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnSslWebPortal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnSslWebPortalCreate,
-		Read:   resourceObjectVpnSslWebPortalRead,
-		Update: resourceObjectVpnSslWebPortalUpdate,
-		Delete: resourceObjectVpnSslWebPortalDelete,
+		CreateContext: resourceObjectVpnSslWebPortalCreate,
+		ReadContext:   resourceObjectVpnSslWebPortalRead,
+		UpdateContext: resourceObjectVpnSslWebPortalUpdate,
+		DeleteContext: resourceObjectVpnSslWebPortalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -593,81 +595,76 @@ func resourceObjectVpnSslWebPortal() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnSslWebPortalCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnSslWebPortalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebPortal(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebPortal resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebPortal resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnSslWebPortal(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebPortal resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebPortal resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnSslWebPortalRead(d, m)
+	return resourceObjectVpnSslWebPortalRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebPortalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebPortalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebPortal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebPortal resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebPortal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnSslWebPortal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebPortal resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebPortal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectVpnSslWebPortalRead(d, m)
+	return resourceObjectVpnSslWebPortalRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebPortalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebPortalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnSslWebPortal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnSslWebPortal resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnSslWebPortal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -675,34 +672,36 @@ func resourceObjectVpnSslWebPortalDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectVpnSslWebPortalRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebPortalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnSslWebPortal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebPortal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnSslWebPortal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnSslWebPortal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnSslWebPortal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebPortal resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnSslWebPortal resource from API: %v", err)
 	}
 	return nil
 }
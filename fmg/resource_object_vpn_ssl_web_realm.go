@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnSslWebRealm() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnSslWebRealmCreate,
-		Read:   resourceObjectVpnSslWebRealmRead,
-		Update: resourceObjectVpnSslWebRealmUpdate,
-		Delete: resourceObjectVpnSslWebRealmDelete,
+		CreateContext: resourceObjectVpnSslWebRealmCreate,
+		ReadContext:   resourceObjectVpnSslWebRealmRead,
+		UpdateContext: resourceObjectVpnSslWebRealmUpdate,
+		DeleteContext: resourceObjectVpnSslWebRealmDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,81 +90,76 @@ func resourceObjectVpnSslWebRealm() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnSslWebRealmCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnSslWebRealmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebRealm(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebRealm resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebRealm resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnSslWebRealm(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnSslWebRealm resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnSslWebRealm resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "url_path"))
 
-	return resourceObjectVpnSslWebRealmRead(d, m)
+	return resourceObjectVpnSslWebRealmRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebRealmUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebRealmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnSslWebRealm(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebRealm resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebRealm resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnSslWebRealm(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnSslWebRealm resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnSslWebRealm resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "url_path"))
 
-	return resourceObjectVpnSslWebRealmRead(d, m)
+	return resourceObjectVpnSslWebRealmRead(ctx, d, m)
 }
 
-func resourceObjectVpnSslWebRealmDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebRealmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnSslWebRealm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnSslWebRealm resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnSslWebRealm resource: %v", err)
 	}
 
 	d.SetId("")
@@ -170,34 +167,36 @@ func resourceObjectVpnSslWebRealmDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceObjectVpnSslWebRealmRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnSslWebRealmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnSslWebRealm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebRealm resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnSslWebRealm resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnSslWebRealm resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnSslWebRealm(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnSslWebRealm resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnSslWebRealm resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectVpnmgrNode() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectVpnmgrNodeCreate,
-		Read:   resourceObjectVpnmgrNodeRead,
-		Update: resourceObjectVpnmgrNodeUpdate,
-		Delete: resourceObjectVpnmgrNodeDelete,
+		CreateContext: resourceObjectVpnmgrNodeCreate,
+		ReadContext:   resourceObjectVpnmgrNodeRead,
+		UpdateContext: resourceObjectVpnmgrNodeUpdate,
+		DeleteContext: resourceObjectVpnmgrNodeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -421,81 +423,76 @@ func resourceObjectVpnmgrNode() *schema.Resource {
 	}
 }
 
-func resourceObjectVpnmgrNodeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectVpnmgrNodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnmgrNode(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnmgrNode resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectVpnmgrNode resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectVpnmgrNode(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectVpnmgrNode resource: %v", err)
+		return diag.Errorf("Error creating ObjectVpnmgrNode resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectVpnmgrNodeRead(d, m)
+	return resourceObjectVpnmgrNodeRead(ctx, d, m)
 }
 
-func resourceObjectVpnmgrNodeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnmgrNodeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectVpnmgrNode(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnmgrNode resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectVpnmgrNode resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectVpnmgrNode(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectVpnmgrNode resource: %v", err)
+		return diag.Errorf("Error updating ObjectVpnmgrNode resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectVpnmgrNodeRead(d, m)
+	return resourceObjectVpnmgrNodeRead(ctx, d, m)
 }
 
-func resourceObjectVpnmgrNodeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnmgrNodeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectVpnmgrNode(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectVpnmgrNode resource: %v", err)
+		return diag.Errorf("Error deleting ObjectVpnmgrNode resource: %v", err)
 	}
 
 	d.SetId("")
@@ -503,34 +500,36 @@ func resourceObjectVpnmgrNodeDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectVpnmgrNodeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectVpnmgrNodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectVpnmgrNode(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnmgrNode resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectVpnmgrNode resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectVpnmgrNode resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectVpnmgrNode(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectVpnmgrNode resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectVpnmgrNode resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWafMainClass() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWafMainClassCreate,
-		Read:   resourceObjectWafMainClassRead,
-		Update: resourceObjectWafMainClassUpdate,
-		Delete: resourceObjectWafMainClassDelete,
+		CreateContext: resourceObjectWafMainClassCreate,
+		ReadContext:   resourceObjectWafMainClassRead,
+		UpdateContext: resourceObjectWafMainClassUpdate,
+		DeleteContext: resourceObjectWafMainClassDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectWafMainClass() *schema.Resource {
 	}
 }
 
-func resourceObjectWafMainClassCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWafMainClassCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafMainClass(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafMainClass resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWafMainClass resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWafMainClass(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafMainClass resource: %v", err)
+		return diag.Errorf("Error creating ObjectWafMainClass resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafMainClassRead(d, m)
+	return resourceObjectWafMainClassRead(ctx, d, m)
 }
 
-func resourceObjectWafMainClassUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafMainClassUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafMainClass(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafMainClass resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWafMainClass resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWafMainClass(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafMainClass resource: %v", err)
+		return diag.Errorf("Error updating ObjectWafMainClass resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafMainClassRead(d, m)
+	return resourceObjectWafMainClassRead(ctx, d, m)
 }
 
-func resourceObjectWafMainClassDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafMainClassDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWafMainClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWafMainClass resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWafMainClass resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectWafMainClassDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectWafMainClassRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafMainClassRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWafMainClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafMainClass resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWafMainClass resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWafMainClass resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWafMainClass(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafMainClass resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWafMainClass resource from API: %v", err)
 	}
 	return nil
 }
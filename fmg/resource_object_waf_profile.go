@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWafProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWafProfileCreate,
-		Read:   resourceObjectWafProfileRead,
-		Update: resourceObjectWafProfileUpdate,
-		Delete: resourceObjectWafProfileDelete,
+		CreateContext: resourceObjectWafProfileCreate,
+		ReadContext:   resourceObjectWafProfileRead,
+		UpdateContext: resourceObjectWafProfileUpdate,
+		DeleteContext: resourceObjectWafProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -855,81 +857,76 @@ func resourceObjectWafProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWafProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWafProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWafProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWafProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWafProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWafProfileRead(d, m)
+	return resourceObjectWafProfileRead(ctx, d, m)
 }
 
-func resourceObjectWafProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWafProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWafProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWafProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWafProfileRead(d, m)
+	return resourceObjectWafProfileRead(ctx, d, m)
 }
 
-func resourceObjectWafProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWafProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWafProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWafProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -937,34 +934,36 @@ func resourceObjectWafProfileDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectWafProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWafProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWafProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWafProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWafProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWafProfile resource from API: %v", err)
 	}
 	return nil
 }
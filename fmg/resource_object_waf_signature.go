@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWafSignature() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWafSignatureCreate,
-		Read:   resourceObjectWafSignatureRead,
-		Update: resourceObjectWafSignatureUpdate,
-		Delete: resourceObjectWafSignatureDelete,
+		CreateContext: resourceObjectWafSignatureCreate,
+		ReadContext:   resourceObjectWafSignatureRead,
+		UpdateContext: resourceObjectWafSignatureUpdate,
+		DeleteContext: resourceObjectWafSignatureDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectWafSignature() *schema.Resource {
 	}
 }
 
-func resourceObjectWafSignatureCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWafSignatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafSignature(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafSignature resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWafSignature resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWafSignature(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafSignature resource: %v", err)
+		return diag.Errorf("Error creating ObjectWafSignature resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafSignatureRead(d, m)
+	return resourceObjectWafSignatureRead(ctx, d, m)
 }
 
-func resourceObjectWafSignatureUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSignatureUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafSignature(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafSignature resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWafSignature resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWafSignature(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafSignature resource: %v", err)
+		return diag.Errorf("Error updating ObjectWafSignature resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafSignatureRead(d, m)
+	return resourceObjectWafSignatureRead(ctx, d, m)
 }
 
-func resourceObjectWafSignatureDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSignatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWafSignature(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWafSignature resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWafSignature resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectWafSignatureDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectWafSignatureRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSignatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWafSignature(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafSignature resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWafSignature resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWafSignature resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWafSignature(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafSignature resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWafSignature resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWafSubClass() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWafSubClassCreate,
-		Read:   resourceObjectWafSubClassRead,
-		Update: resourceObjectWafSubClassUpdate,
-		Delete: resourceObjectWafSubClassDelete,
+		CreateContext: resourceObjectWafSubClassCreate,
+		ReadContext:   resourceObjectWafSubClassRead,
+		UpdateContext: resourceObjectWafSubClassUpdate,
+		DeleteContext: resourceObjectWafSubClassDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectWafSubClass() *schema.Resource {
 	}
 }
 
-func resourceObjectWafSubClassCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWafSubClassCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafSubClass(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafSubClass resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWafSubClass resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWafSubClass(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWafSubClass resource: %v", err)
+		return diag.Errorf("Error creating ObjectWafSubClass resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafSubClassRead(d, m)
+	return resourceObjectWafSubClassRead(ctx, d, m)
 }
 
-func resourceObjectWafSubClassUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSubClassUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWafSubClass(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafSubClass resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWafSubClass resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWafSubClass(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWafSubClass resource: %v", err)
+		return diag.Errorf("Error updating ObjectWafSubClass resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWafSubClassRead(d, m)
+	return resourceObjectWafSubClassRead(ctx, d, m)
 }
 
-func resourceObjectWafSubClassDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSubClassDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWafSubClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWafSubClass resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWafSubClass resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectWafSubClassDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceObjectWafSubClassRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWafSubClassRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWafSubClass(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafSubClass resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWafSubClass resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWafSubClass resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWafSubClass(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWafSubClass resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWafSubClass resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWanoptAuthGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWanoptAuthGroupCreate,
-		Read:   resourceObjectWanoptAuthGroupRead,
-		Update: resourceObjectWanoptAuthGroupUpdate,
-		Delete: resourceObjectWanoptAuthGroupDelete,
+		CreateContext: resourceObjectWanoptAuthGroupCreate,
+		ReadContext:   resourceObjectWanoptAuthGroupRead,
+		UpdateContext: resourceObjectWanoptAuthGroupUpdate,
+		DeleteContext: resourceObjectWanoptAuthGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -78,81 +80,76 @@ func resourceObjectWanoptAuthGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectWanoptAuthGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWanoptAuthGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptAuthGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptAuthGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptAuthGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWanoptAuthGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptAuthGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptAuthGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWanoptAuthGroupRead(d, m)
+	return resourceObjectWanoptAuthGroupRead(ctx, d, m)
 }
 
-func resourceObjectWanoptAuthGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptAuthGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptAuthGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptAuthGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptAuthGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWanoptAuthGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptAuthGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptAuthGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWanoptAuthGroupRead(d, m)
+	return resourceObjectWanoptAuthGroupRead(ctx, d, m)
 }
 
-func resourceObjectWanoptAuthGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptAuthGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWanoptAuthGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWanoptAuthGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWanoptAuthGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -160,34 +157,36 @@ func resourceObjectWanoptAuthGroupDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectWanoptAuthGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptAuthGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWanoptAuthGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptAuthGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWanoptAuthGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWanoptAuthGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWanoptAuthGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptAuthGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWanoptAuthGroup resource from API: %v", err)
 	}
 	return nil
 }
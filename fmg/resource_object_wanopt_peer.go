@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWanoptPeer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWanoptPeerCreate,
-		Read:   resourceObjectWanoptPeerRead,
-		Update: resourceObjectWanoptPeerUpdate,
-		Delete: resourceObjectWanoptPeerDelete,
+		CreateContext: resourceObjectWanoptPeerCreate,
+		ReadContext:   resourceObjectWanoptPeerRead,
+		UpdateContext: resourceObjectWanoptPeerUpdate,
+		DeleteContext: resourceObjectWanoptPeerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,81 +60,76 @@ func resourceObjectWanoptPeer() *schema.Resource {
 	}
 }
 
-func resourceObjectWanoptPeerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWanoptPeerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptPeer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWanoptPeer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptPeer resource: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptPeer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "peer_host_id"))
 
-	return resourceObjectWanoptPeerRead(d, m)
+	return resourceObjectWanoptPeerRead(ctx, d, m)
 }
 
-func resourceObjectWanoptPeerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptPeerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptPeer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWanoptPeer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptPeer resource: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptPeer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "peer_host_id"))
 
-	return resourceObjectWanoptPeerRead(d, m)
+	return resourceObjectWanoptPeerRead(ctx, d, m)
 }
 
-func resourceObjectWanoptPeerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptPeerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWanoptPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWanoptPeer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWanoptPeer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -140,34 +137,36 @@ func resourceObjectWanoptPeerDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceObjectWanoptPeerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptPeerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWanoptPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptPeer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWanoptPeer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWanoptPeer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWanoptPeer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptPeer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWanoptPeer resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWanoptProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWanoptProfileCreate,
-		Read:   resourceObjectWanoptProfileRead,
-		Update: resourceObjectWanoptProfileUpdate,
-		Delete: resourceObjectWanoptProfileDelete,
+		CreateContext: resourceObjectWanoptProfileCreate,
+		ReadContext:   resourceObjectWanoptProfileRead,
+		UpdateContext: resourceObjectWanoptProfileUpdate,
+		DeleteContext: resourceObjectWanoptProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -341,81 +343,76 @@ func resourceObjectWanoptProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWanoptProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWanoptProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWanoptProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWanoptProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWanoptProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWanoptProfileRead(d, m)
+	return resourceObjectWanoptProfileRead(ctx, d, m)
 }
 
-func resourceObjectWanoptProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWanoptProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWanoptProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWanoptProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWanoptProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWanoptProfileRead(d, m)
+	return resourceObjectWanoptProfileRead(ctx, d, m)
 }
 
-func resourceObjectWanoptProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWanoptProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWanoptProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWanoptProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -423,34 +420,36 @@ func resourceObjectWanoptProfileDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceObjectWanoptProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWanoptProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWanoptProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWanoptProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWanoptProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWanoptProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWanoptProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWanoptProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterCategories() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterCategoriesCreate,
-		Read:   resourceObjectWebfilterCategoriesRead,
-		Update: resourceObjectWebfilterCategoriesUpdate,
-		Delete: resourceObjectWebfilterCategoriesDelete,
+		CreateContext: resourceObjectWebfilterCategoriesCreate,
+		ReadContext:   resourceObjectWebfilterCategoriesRead,
+		UpdateContext: resourceObjectWebfilterCategoriesUpdate,
+		DeleteContext: resourceObjectWebfilterCategoriesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,81 +55,76 @@ func resourceObjectWebfilterCategories() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterCategoriesCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterCategoriesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterCategories(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterCategories resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterCategories resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterCategories(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterCategories resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterCategories resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWebfilterCategoriesRead(d, m)
+	return resourceObjectWebfilterCategoriesRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterCategoriesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterCategoriesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterCategories(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterCategories resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterCategories resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterCategories(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterCategories resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterCategories resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWebfilterCategoriesRead(d, m)
+	return resourceObjectWebfilterCategoriesRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterCategoriesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterCategoriesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterCategories(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterCategories resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterCategories resource: %v", err)
 	}
 
 	d.SetId("")
@@ -135,34 +132,36 @@ func resourceObjectWebfilterCategoriesDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceObjectWebfilterCategoriesRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterCategoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterCategories(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterCategories resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterCategories resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterCategories resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterCategories(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterCategories resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterCategories resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterContent() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterContentCreate,
-		Read:   resourceObjectWebfilterContentRead,
-		Update: resourceObjectWebfilterContentUpdate,
-		Delete: resourceObjectWebfilterContentDelete,
+		CreateContext: resourceObjectWebfilterContentCreate,
+		ReadContext:   resourceObjectWebfilterContentRead,
+		UpdateContext: resourceObjectWebfilterContentUpdate,
+		DeleteContext: resourceObjectWebfilterContentDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,81 +101,76 @@ func resourceObjectWebfilterContent() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterContentCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterContentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterContent(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterContent resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterContent resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterContent(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterContent resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterContent resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterContentRead(d, m)
+	return resourceObjectWebfilterContentRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterContentUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterContent(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterContent resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterContent resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterContent(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterContent resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterContent resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterContentRead(d, m)
+	return resourceObjectWebfilterContentRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterContentDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterContent(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterContent resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterContent resource: %v", err)
 	}
 
 	d.SetId("")
@@ -181,34 +178,36 @@ func resourceObjectWebfilterContentDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectWebfilterContentRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterContent(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterContent resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterContent resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterContent resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterContent(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterContent resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterContent resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterContentHeader() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterContentHeaderCreate,
-		Read:   resourceObjectWebfilterContentHeaderRead,
-		Update: resourceObjectWebfilterContentHeaderUpdate,
-		Delete: resourceObjectWebfilterContentHeaderDelete,
+		CreateContext: resourceObjectWebfilterContentHeaderCreate,
+		ReadContext:   resourceObjectWebfilterContentHeaderRead,
+		UpdateContext: resourceObjectWebfilterContentHeaderUpdate,
+		DeleteContext: resourceObjectWebfilterContentHeaderDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectWebfilterContentHeader() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterContentHeaderCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterContentHeaderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterContentHeader(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterContentHeader resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterContentHeader resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterContentHeader(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterContentHeader resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterContentHeader resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterContentHeaderRead(d, m)
+	return resourceObjectWebfilterContentHeaderRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterContentHeaderUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentHeaderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterContentHeader(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterContentHeader resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterContentHeader resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterContentHeader(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterContentHeader resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterContentHeader resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterContentHeaderRead(d, m)
+	return resourceObjectWebfilterContentHeaderRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterContentHeaderDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentHeaderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterContentHeader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterContentHeader resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterContentHeader resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectWebfilterContentHeaderDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceObjectWebfilterContentHeaderRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterContentHeaderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterContentHeader(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterContentHeader resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterContentHeader resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterContentHeader resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterContentHeader(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterContentHeader resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterContentHeader resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterFtgdLocalCat() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterFtgdLocalCatCreate,
-		Read:   resourceObjectWebfilterFtgdLocalCatRead,
-		Update: resourceObjectWebfilterFtgdLocalCatUpdate,
-		Delete: resourceObjectWebfilterFtgdLocalCatDelete,
+		CreateContext: resourceObjectWebfilterFtgdLocalCatCreate,
+		ReadContext:   resourceObjectWebfilterFtgdLocalCatRead,
+		UpdateContext: resourceObjectWebfilterFtgdLocalCatUpdate,
+		DeleteContext: resourceObjectWebfilterFtgdLocalCatDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,81 +66,76 @@ func resourceObjectWebfilterFtgdLocalCat() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterFtgdLocalCatCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterFtgdLocalCatCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterFtgdLocalCat(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterFtgdLocalCat resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterFtgdLocalCat resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterFtgdLocalCat(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterFtgdLocalCat resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterFtgdLocalCat resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "desc"))
 
-	return resourceObjectWebfilterFtgdLocalCatRead(d, m)
+	return resourceObjectWebfilterFtgdLocalCatRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterFtgdLocalCatUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalCatUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterFtgdLocalCat(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterFtgdLocalCat resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterFtgdLocalCat resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterFtgdLocalCat(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterFtgdLocalCat resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterFtgdLocalCat resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "desc"))
 
-	return resourceObjectWebfilterFtgdLocalCatRead(d, m)
+	return resourceObjectWebfilterFtgdLocalCatRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterFtgdLocalCatDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalCatDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterFtgdLocalCat(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterFtgdLocalCat resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterFtgdLocalCat resource: %v", err)
 	}
 
 	d.SetId("")
@@ -146,34 +143,36 @@ func resourceObjectWebfilterFtgdLocalCatDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectWebfilterFtgdLocalCatRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalCatRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterFtgdLocalCat(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterFtgdLocalCat resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalCat resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalCat resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterFtgdLocalCat(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterFtgdLocalCat resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalCat resource from API: %v", err)
 	}
 	return nil
 }
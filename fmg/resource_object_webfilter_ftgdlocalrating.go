@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterFtgdLocalRating() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterFtgdLocalRatingCreate,
-		Read:   resourceObjectWebfilterFtgdLocalRatingRead,
-		Update: resourceObjectWebfilterFtgdLocalRatingUpdate,
-		Delete: resourceObjectWebfilterFtgdLocalRatingDelete,
+		CreateContext: resourceObjectWebfilterFtgdLocalRatingCreate,
+		ReadContext:   resourceObjectWebfilterFtgdLocalRatingRead,
+		UpdateContext: resourceObjectWebfilterFtgdLocalRatingUpdate,
+		DeleteContext: resourceObjectWebfilterFtgdLocalRatingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,81 +71,76 @@ func resourceObjectWebfilterFtgdLocalRating() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterFtgdLocalRatingCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterFtgdLocalRatingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterFtgdLocalRating(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterFtgdLocalRating resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterFtgdLocalRating resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterFtgdLocalRating(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterFtgdLocalRating resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterFtgdLocalRating resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "url"))
 
-	return resourceObjectWebfilterFtgdLocalRatingRead(d, m)
+	return resourceObjectWebfilterFtgdLocalRatingRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterFtgdLocalRatingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalRatingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterFtgdLocalRating(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterFtgdLocalRating resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterFtgdLocalRating resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterFtgdLocalRating(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterFtgdLocalRating resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterFtgdLocalRating resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "url"))
 
-	return resourceObjectWebfilterFtgdLocalRatingRead(d, m)
+	return resourceObjectWebfilterFtgdLocalRatingRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterFtgdLocalRatingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalRatingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterFtgdLocalRating(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterFtgdLocalRating resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterFtgdLocalRating resource: %v", err)
 	}
 
 	d.SetId("")
@@ -151,34 +148,36 @@ func resourceObjectWebfilterFtgdLocalRatingDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourceObjectWebfilterFtgdLocalRatingRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterFtgdLocalRatingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterFtgdLocalRating(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterFtgdLocalRating resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalRating resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalRating resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterFtgdLocalRating(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterFtgdLocalRating resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterFtgdLocalRating resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterProfileCreate,
-		Read:   resourceObjectWebfilterProfileRead,
-		Update: resourceObjectWebfilterProfileUpdate,
-		Delete: resourceObjectWebfilterProfileDelete,
+		CreateContext: resourceObjectWebfilterProfileCreate,
+		ReadContext:   resourceObjectWebfilterProfileRead,
+		UpdateContext: resourceObjectWebfilterProfileUpdate,
+		DeleteContext: resourceObjectWebfilterProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -677,81 +679,76 @@ func resourceObjectWebfilterProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebfilterProfileRead(d, m)
+	return resourceObjectWebfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebfilterProfileRead(d, m)
+	return resourceObjectWebfilterProfileRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -759,34 +756,36 @@ func resourceObjectWebfilterProfileDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectWebfilterProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebfilterUrlfilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebfilterUrlfilterCreate,
-		Read:   resourceObjectWebfilterUrlfilterRead,
-		Update: resourceObjectWebfilterUrlfilterUpdate,
-		Delete: resourceObjectWebfilterUrlfilterDelete,
+		CreateContext: resourceObjectWebfilterUrlfilterCreate,
+		ReadContext:   resourceObjectWebfilterUrlfilterRead,
+		UpdateContext: resourceObjectWebfilterUrlfilterUpdate,
+		DeleteContext: resourceObjectWebfilterUrlfilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -132,81 +134,76 @@ func resourceObjectWebfilterUrlfilter() *schema.Resource {
 	}
 }
 
-func resourceObjectWebfilterUrlfilterCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebfilterUrlfilterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterUrlfilter(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterUrlfilter resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterUrlfilter resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebfilterUrlfilter(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebfilterUrlfilter resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebfilterUrlfilter resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterUrlfilterRead(d, m)
+	return resourceObjectWebfilterUrlfilterRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterUrlfilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterUrlfilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebfilterUrlfilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterUrlfilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterUrlfilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebfilterUrlfilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebfilterUrlfilter resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebfilterUrlfilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceObjectWebfilterUrlfilterRead(d, m)
+	return resourceObjectWebfilterUrlfilterRead(ctx, d, m)
 }
 
-func resourceObjectWebfilterUrlfilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterUrlfilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebfilterUrlfilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebfilterUrlfilter resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebfilterUrlfilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -214,34 +211,36 @@ func resourceObjectWebfilterUrlfilterDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceObjectWebfilterUrlfilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebfilterUrlfilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebfilterUrlfilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterUrlfilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebfilterUrlfilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebfilterUrlfilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebfilterUrlfilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebfilterUrlfilter resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebfilterUrlfilter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebProxyForwardServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebProxyForwardServerCreate,
-		Read:   resourceObjectWebProxyForwardServerRead,
-		Update: resourceObjectWebProxyForwardServerUpdate,
-		Delete: resourceObjectWebProxyForwardServerDelete,
+		CreateContext: resourceObjectWebProxyForwardServerCreate,
+		ReadContext:   resourceObjectWebProxyForwardServerRead,
+		UpdateContext: resourceObjectWebProxyForwardServerUpdate,
+		DeleteContext: resourceObjectWebProxyForwardServerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -103,81 +105,76 @@ func resourceObjectWebProxyForwardServer() *schema.Resource {
 	}
 }
 
-func resourceObjectWebProxyForwardServerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebProxyForwardServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyForwardServer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyForwardServer resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyForwardServer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebProxyForwardServer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyForwardServer resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyForwardServer resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyForwardServerRead(d, m)
+	return resourceObjectWebProxyForwardServerRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyForwardServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyForwardServer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyForwardServer resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyForwardServer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebProxyForwardServer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyForwardServer resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyForwardServer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyForwardServerRead(d, m)
+	return resourceObjectWebProxyForwardServerRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyForwardServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebProxyForwardServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebProxyForwardServer resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebProxyForwardServer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -185,34 +182,36 @@ func resourceObjectWebProxyForwardServerDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectWebProxyForwardServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebProxyForwardServer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyForwardServer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebProxyForwardServer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebProxyForwardServer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebProxyForwardServer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyForwardServer resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebProxyForwardServer resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebProxyForwardServerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebProxyForwardServerGroupCreate,
-		Read:   resourceObjectWebProxyForwardServerGroupRead,
-		Update: resourceObjectWebProxyForwardServerGroupUpdate,
-		Delete: resourceObjectWebProxyForwardServerGroupDelete,
+		CreateContext: resourceObjectWebProxyForwardServerGroupCreate,
+		ReadContext:   resourceObjectWebProxyForwardServerGroupRead,
+		UpdateContext: resourceObjectWebProxyForwardServerGroupUpdate,
+		DeleteContext: resourceObjectWebProxyForwardServerGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -90,81 +92,76 @@ func resourceObjectWebProxyForwardServerGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectWebProxyForwardServerGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebProxyForwardServerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyForwardServerGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyForwardServerGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyForwardServerGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebProxyForwardServerGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyForwardServerGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyForwardServerGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyForwardServerGroupRead(d, m)
+	return resourceObjectWebProxyForwardServerGroupRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyForwardServerGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyForwardServerGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyForwardServerGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyForwardServerGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebProxyForwardServerGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyForwardServerGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyForwardServerGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyForwardServerGroupRead(d, m)
+	return resourceObjectWebProxyForwardServerGroupRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyForwardServerGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebProxyForwardServerGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebProxyForwardServerGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebProxyForwardServerGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -172,34 +169,36 @@ func resourceObjectWebProxyForwardServerGroupDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectWebProxyForwardServerGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyForwardServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebProxyForwardServerGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyForwardServerGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebProxyForwardServerGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebProxyForwardServerGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebProxyForwardServerGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyForwardServerGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebProxyForwardServerGroup resource from API: %v", err)
 	}
 	return nil
 }
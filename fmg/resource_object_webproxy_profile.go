@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebProxyProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebProxyProfileCreate,
-		Read:   resourceObjectWebProxyProfileRead,
-		Update: resourceObjectWebProxyProfileUpdate,
-		Delete: resourceObjectWebProxyProfileDelete,
+		CreateContext: resourceObjectWebProxyProfileCreate,
+		ReadContext:   resourceObjectWebProxyProfileRead,
+		UpdateContext: resourceObjectWebProxyProfileUpdate,
+		DeleteContext: resourceObjectWebProxyProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -159,81 +161,76 @@ func resourceObjectWebProxyProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWebProxyProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebProxyProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebProxyProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyProfileRead(d, m)
+	return resourceObjectWebProxyProfileRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebProxyProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyProfileRead(d, m)
+	return resourceObjectWebProxyProfileRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebProxyProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebProxyProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebProxyProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -241,34 +238,36 @@ func resourceObjectWebProxyProfileDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceObjectWebProxyProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebProxyProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebProxyProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebProxyProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebProxyProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebProxyProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWebProxyWisp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWebProxyWispCreate,
-		Read:   resourceObjectWebProxyWispRead,
-		Update: resourceObjectWebProxyWispUpdate,
-		Delete: resourceObjectWebProxyWispDelete,
+		CreateContext: resourceObjectWebProxyWispCreate,
+		ReadContext:   resourceObjectWebProxyWispRead,
+		UpdateContext: resourceObjectWebProxyWispUpdate,
+		DeleteContext: resourceObjectWebProxyWispDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,81 +85,76 @@ func resourceObjectWebProxyWisp() *schema.Resource {
 	}
 }
 
-func resourceObjectWebProxyWispCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWebProxyWispCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyWisp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyWisp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyWisp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWebProxyWisp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWebProxyWisp resource: %v", err)
+		return diag.Errorf("Error creating ObjectWebProxyWisp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyWispRead(d, m)
+	return resourceObjectWebProxyWispRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyWispUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyWispUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWebProxyWisp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyWisp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyWisp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWebProxyWisp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWebProxyWisp resource: %v", err)
+		return diag.Errorf("Error updating ObjectWebProxyWisp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWebProxyWispRead(d, m)
+	return resourceObjectWebProxyWispRead(ctx, d, m)
 }
 
-func resourceObjectWebProxyWispDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyWispDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWebProxyWisp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWebProxyWisp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWebProxyWisp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -165,34 +162,36 @@ func resourceObjectWebProxyWispDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceObjectWebProxyWispRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWebProxyWispRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWebProxyWisp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyWisp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWebProxyWisp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWebProxyWisp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWebProxyWisp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWebProxyWisp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWebProxyWisp resource from API: %v", err)
 	}
 	return nil
 }
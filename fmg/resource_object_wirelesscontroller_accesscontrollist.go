@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerAccessControlList() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerAccessControlListCreate,
-		Read:   resourceObjectWirelessControllerAccessControlListRead,
-		Update: resourceObjectWirelessControllerAccessControlListUpdate,
-		Delete: resourceObjectWirelessControllerAccessControlListDelete,
+		CreateContext: resourceObjectWirelessControllerAccessControlListCreate,
+		ReadContext:   resourceObjectWirelessControllerAccessControlListRead,
+		UpdateContext: resourceObjectWirelessControllerAccessControlListUpdate,
+		DeleteContext: resourceObjectWirelessControllerAccessControlListDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -149,81 +151,76 @@ func resourceObjectWirelessControllerAccessControlList() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerAccessControlListCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerAccessControlListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAccessControlList(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAccessControlList resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAccessControlList resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerAccessControlList(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAccessControlList resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAccessControlList resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerAccessControlListRead(d, m)
+	return resourceObjectWirelessControllerAccessControlListRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAccessControlListUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAccessControlListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAccessControlList(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAccessControlList resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAccessControlList resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerAccessControlList(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAccessControlList resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAccessControlList resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerAccessControlListRead(d, m)
+	return resourceObjectWirelessControllerAccessControlListRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAccessControlListDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAccessControlListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerAccessControlList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerAccessControlList resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerAccessControlList resource: %v", err)
 	}
 
 	d.SetId("")
@@ -231,34 +228,36 @@ func resourceObjectWirelessControllerAccessControlListDelete(d *schema.ResourceD
 	return nil
 }
 
-func resourceObjectWirelessControllerAccessControlListRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAccessControlListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerAccessControlList(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAccessControlList resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerAccessControlList resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerAccessControlList resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerAccessControlList(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAccessControlList resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerAccessControlList resource from API: %v", err)
 	}
 	return nil
 }
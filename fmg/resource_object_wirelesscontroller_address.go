@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerAddressCreate,
-		Read:   resourceObjectWirelessControllerAddressRead,
-		Update: resourceObjectWirelessControllerAddressUpdate,
-		Delete: resourceObjectWirelessControllerAddressDelete,
+		CreateContext: resourceObjectWirelessControllerAddressCreate,
+		ReadContext:   resourceObjectWirelessControllerAddressRead,
+		UpdateContext: resourceObjectWirelessControllerAddressUpdate,
+		DeleteContext: resourceObjectWirelessControllerAddressDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,81 +66,76 @@ func resourceObjectWirelessControllerAddress() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerAddressCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAddress resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerAddress(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAddress resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAddress resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWirelessControllerAddressRead(d, m)
+	return resourceObjectWirelessControllerAddressRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAddressUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAddress(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAddress resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAddress resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerAddress(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAddress resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAddress resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWirelessControllerAddressRead(d, m)
+	return resourceObjectWirelessControllerAddressRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAddressDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerAddress resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerAddress resource: %v", err)
 	}
 
 	d.SetId("")
@@ -146,34 +143,36 @@ func resourceObjectWirelessControllerAddressDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectWirelessControllerAddressRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerAddress(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAddress resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerAddress resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerAddress resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerAddress(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAddress resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerAddress resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerAddrgrp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerAddrgrpCreate,
-		Read:   resourceObjectWirelessControllerAddrgrpRead,
-		Update: resourceObjectWirelessControllerAddrgrpUpdate,
-		Delete: resourceObjectWirelessControllerAddrgrpDelete,
+		CreateContext: resourceObjectWirelessControllerAddrgrpCreate,
+		ReadContext:   resourceObjectWirelessControllerAddrgrpRead,
+		UpdateContext: resourceObjectWirelessControllerAddrgrpUpdate,
+		DeleteContext: resourceObjectWirelessControllerAddrgrpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,81 +65,76 @@ func resourceObjectWirelessControllerAddrgrp() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerAddrgrpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerAddrgrpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerAddrgrp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerAddrgrp resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerAddrgrp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWirelessControllerAddrgrpRead(d, m)
+	return resourceObjectWirelessControllerAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAddrgrpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddrgrpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerAddrgrp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAddrgrp resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAddrgrp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerAddrgrp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerAddrgrp resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerAddrgrp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceObjectWirelessControllerAddrgrpRead(d, m)
+	return resourceObjectWirelessControllerAddrgrpRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerAddrgrpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddrgrpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerAddrgrp resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerAddrgrp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -145,34 +142,36 @@ func resourceObjectWirelessControllerAddrgrpDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceObjectWirelessControllerAddrgrpRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerAddrgrpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerAddrgrp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAddrgrp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerAddrgrp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerAddrgrp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerAddrgrp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerAddrgrp resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerAddrgrp resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerArrpProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerArrpProfileCreate,
-		Read:   resourceObjectWirelessControllerArrpProfileRead,
-		Update: resourceObjectWirelessControllerArrpProfileUpdate,
-		Delete: resourceObjectWirelessControllerArrpProfileDelete,
+		CreateContext: resourceObjectWirelessControllerArrpProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerArrpProfileRead,
+		UpdateContext: resourceObjectWirelessControllerArrpProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerArrpProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -159,81 +161,76 @@ func resourceObjectWirelessControllerArrpProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerArrpProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerArrpProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerArrpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerArrpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerArrpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerArrpProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerArrpProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerArrpProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerArrpProfileRead(d, m)
+	return resourceObjectWirelessControllerArrpProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerArrpProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerArrpProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerArrpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerArrpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerArrpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerArrpProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerArrpProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerArrpProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerArrpProfileRead(d, m)
+	return resourceObjectWirelessControllerArrpProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerArrpProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerArrpProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerArrpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerArrpProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerArrpProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -241,34 +238,36 @@ func resourceObjectWirelessControllerArrpProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerArrpProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerArrpProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerArrpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerArrpProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerArrpProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerArrpProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerArrpProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerArrpProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerArrpProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerBleProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerBleProfileCreate,
-		Read:   resourceObjectWirelessControllerBleProfileRead,
-		Update: resourceObjectWirelessControllerBleProfileUpdate,
-		Delete: resourceObjectWirelessControllerBleProfileDelete,
+		CreateContext: resourceObjectWirelessControllerBleProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerBleProfileRead,
+		UpdateContext: resourceObjectWirelessControllerBleProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerBleProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,81 +116,76 @@ func resourceObjectWirelessControllerBleProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerBleProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerBleProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerBleProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerBleProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerBleProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerBleProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerBleProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerBleProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerBleProfileRead(d, m)
+	return resourceObjectWirelessControllerBleProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerBleProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerBleProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerBleProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerBleProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerBleProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerBleProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerBleProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerBleProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerBleProfileRead(d, m)
+	return resourceObjectWirelessControllerBleProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerBleProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerBleProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerBleProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerBleProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerBleProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -196,34 +193,36 @@ func resourceObjectWirelessControllerBleProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerBleProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerBleProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerBleProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerBleProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerBleProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerBleProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerBleProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerBleProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerBleProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20Anqp3GppCellular() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20Anqp3GppCellularCreate,
-		Read:   resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead,
-		Update: resourceObjectWirelessControllerHotspot20Anqp3GppCellularUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20Anqp3GppCellularDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20Anqp3GppCellularCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20Anqp3GppCellularUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20Anqp3GppCellularDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectWirelessControllerHotspot20Anqp3GppCellular() *schema.Resourc
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20Anqp3GppCellularCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20Anqp3GppCellularCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20Anqp3GppCellular(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20Anqp3GppCellular resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20Anqp3GppCellular resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20Anqp3GppCellular(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(d, m)
+	return resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20Anqp3GppCellularUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20Anqp3GppCellularUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20Anqp3GppCellular(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20Anqp3GppCellular resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20Anqp3GppCellular resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20Anqp3GppCellular(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(d, m)
+	return resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20Anqp3GppCellularDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20Anqp3GppCellularDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20Anqp3GppCellular(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectWirelessControllerHotspot20Anqp3GppCellularDelete(d *schema.R
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20Anqp3GppCellularRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20Anqp3GppCellular(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20Anqp3GppCellular resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20Anqp3GppCellular resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20Anqp3GppCellular(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20Anqp3GppCellular resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20Anqp3GppCellular resource from API: %v", err)
 	}
 	return nil
 }
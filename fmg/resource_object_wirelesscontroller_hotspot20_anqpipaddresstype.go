@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20AnqpIpAddressType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeCreate,
-		Read:   resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead,
-		Update: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,81 +66,76 @@ func resourceObjectWirelessControllerHotspot20AnqpIpAddressType() *schema.Resour
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpIpAddressType(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpIpAddressType resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpIpAddressType resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20AnqpIpAddressType(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpIpAddressType(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpIpAddressType resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpIpAddressType resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20AnqpIpAddressType(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20AnqpIpAddressType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
 	}
 
 	d.SetId("")
@@ -146,34 +143,36 @@ func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeDelete(d *schema.
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpIpAddressTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20AnqpIpAddressType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpIpAddressType resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpIpAddressType resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20AnqpIpAddressType(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpIpAddressType resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpIpAddressType resource from API: %v", err)
 	}
 	return nil
 }
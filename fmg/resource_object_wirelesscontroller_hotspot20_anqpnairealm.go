@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20AnqpNaiRealm() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20AnqpNaiRealmCreate,
-		Read:   resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead,
-		Update: resourceObjectWirelessControllerHotspot20AnqpNaiRealmUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20AnqpNaiRealmDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20AnqpNaiRealmCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20AnqpNaiRealmUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20AnqpNaiRealmDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -119,81 +121,76 @@ func resourceObjectWirelessControllerHotspot20AnqpNaiRealm() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNaiRealmCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20AnqpNaiRealmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpNaiRealm(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNaiRealm resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNaiRealm resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20AnqpNaiRealm(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNaiRealmUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNaiRealmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpNaiRealm(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNaiRealm resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNaiRealm resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20AnqpNaiRealm(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNaiRealmDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNaiRealmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20AnqpNaiRealm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
 	}
 
 	d.SetId("")
@@ -201,34 +198,36 @@ func resourceObjectWirelessControllerHotspot20AnqpNaiRealmDelete(d *schema.Resou
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNaiRealmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20AnqpNaiRealm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNaiRealm resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNaiRealm resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20AnqpNaiRealm(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNaiRealm resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNaiRealm resource from API: %v", err)
 	}
 	return nil
 }
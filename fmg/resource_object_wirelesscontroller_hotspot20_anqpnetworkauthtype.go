@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeCreate,
-		Read:   resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead,
-		Update: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,81 +65,76 @@ func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthType() *schema.Reso
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpNetworkAuthType(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20AnqpNetworkAuthType(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpNetworkAuthType(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20AnqpNetworkAuthType(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20AnqpNetworkAuthType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
 	}
 
 	d.SetId("")
@@ -145,34 +142,36 @@ func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeDelete(d *schem
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpNetworkAuthTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20AnqpNetworkAuthType(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20AnqpNetworkAuthType(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpNetworkAuthType resource from API: %v", err)
 	}
 	return nil
 }
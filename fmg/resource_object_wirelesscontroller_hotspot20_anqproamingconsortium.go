@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortium() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumCreate,
-		Read:   resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead,
-		Update: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -79,81 +81,76 @@ func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortium() *schema.Re
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpRoamingConsortium(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20AnqpRoamingConsortium(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpRoamingConsortium(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20AnqpRoamingConsortium(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20AnqpRoamingConsortium(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,34 +158,36 @@ func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumDelete(d *sch
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpRoamingConsortiumRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20AnqpRoamingConsortium(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20AnqpRoamingConsortium(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpRoamingConsortium resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20AnqpVenueName() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20AnqpVenueNameCreate,
-		Read:   resourceObjectWirelessControllerHotspot20AnqpVenueNameRead,
-		Update: resourceObjectWirelessControllerHotspot20AnqpVenueNameUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20AnqpVenueNameDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20AnqpVenueNameCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20AnqpVenueNameRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20AnqpVenueNameUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20AnqpVenueNameDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,81 +82,76 @@ func resourceObjectWirelessControllerHotspot20AnqpVenueName() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpVenueNameCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20AnqpVenueNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpVenueName(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpVenueName resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpVenueName resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20AnqpVenueName(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpVenueNameUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpVenueNameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20AnqpVenueName(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpVenueName resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpVenueName resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20AnqpVenueName(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(d, m)
+	return resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpVenueNameDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpVenueNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20AnqpVenueName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
 	}
 
 	d.SetId("")
@@ -162,34 +159,36 @@ func resourceObjectWirelessControllerHotspot20AnqpVenueNameDelete(d *schema.Reso
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20AnqpVenueNameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20AnqpVenueName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpVenueName resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpVenueName resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20AnqpVenueName(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpVenueName resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20AnqpVenueName resource from API: %v", err)
 	}
 	return nil
 }
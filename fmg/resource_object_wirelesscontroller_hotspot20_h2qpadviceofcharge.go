@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20H2QpAdviceOfCharge() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeCreate,
-		Read:   resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead,
-		Update: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -108,81 +110,76 @@ func resourceObjectWirelessControllerHotspot20H2QpAdviceOfCharge() *schema.Resou
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpAdviceOfCharge(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20H2QpAdviceOfCharge(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpAdviceOfCharge(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20H2QpAdviceOfCharge(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20H2QpAdviceOfCharge(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
 	}
 
 	d.SetId("")
@@ -190,34 +187,36 @@ func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeDelete(d *schema
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpAdviceOfChargeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20H2QpAdviceOfCharge(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20H2QpAdviceOfCharge(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpAdviceOfCharge resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20H2QpConnCapability() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityCreate,
-		Read:   resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead,
-		Update: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20H2QpConnCapabilityDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -109,81 +111,76 @@ func resourceObjectWirelessControllerHotspot20H2QpConnCapability() *schema.Resou
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpConnCapability(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpConnCapability resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpConnCapability resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20H2QpConnCapability(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpConnCapability(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpConnCapability resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpConnCapability resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20H2QpConnCapability(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20H2QpConnCapability(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
 	}
 
 	d.SetId("")
@@ -191,34 +188,36 @@ func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityDelete(d *schema
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpConnCapabilityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20H2QpConnCapability(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpConnCapability resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpConnCapability resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20H2QpConnCapability(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpConnCapability resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpConnCapability resource from API: %v", err)
 	}
 	return nil
 }
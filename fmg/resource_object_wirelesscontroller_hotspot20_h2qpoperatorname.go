@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20H2QpOperatorName() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20H2QpOperatorNameCreate,
-		Read:   resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead,
-		Update: resourceObjectWirelessControllerHotspot20H2QpOperatorNameUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20H2QpOperatorNameDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20H2QpOperatorNameCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20H2QpOperatorNameUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20H2QpOperatorNameDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -80,81 +82,76 @@ func resourceObjectWirelessControllerHotspot20H2QpOperatorName() *schema.Resourc
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOperatorNameCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20H2QpOperatorNameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOperatorName(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOperatorName resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOperatorName resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20H2QpOperatorName(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOperatorNameUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOperatorNameUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOperatorName(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOperatorName resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOperatorName resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20H2QpOperatorName(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOperatorNameDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOperatorNameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20H2QpOperatorName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
 	}
 
 	d.SetId("")
@@ -162,34 +159,36 @@ func resourceObjectWirelessControllerHotspot20H2QpOperatorNameDelete(d *schema.R
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOperatorNameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20H2QpOperatorName(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOperatorName resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOperatorName resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20H2QpOperatorName(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOperatorName resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOperatorName resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20H2QpOsuProvider() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20H2QpOsuProviderCreate,
-		Read:   resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead,
-		Update: resourceObjectWirelessControllerHotspot20H2QpOsuProviderUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20H2QpOsuProviderDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -119,81 +121,76 @@ func resourceObjectWirelessControllerHotspot20H2QpOsuProvider() *schema.Resource
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOsuProvider(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProvider resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProvider resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20H2QpOsuProvider(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOsuProvider(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProvider resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProvider resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20H2QpOsuProvider(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20H2QpOsuProvider(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
 	}
 
 	d.SetId("")
@@ -201,34 +198,36 @@ func resourceObjectWirelessControllerHotspot20H2QpOsuProviderDelete(d *schema.Re
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20H2QpOsuProvider(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProvider resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProvider resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20H2QpOsuProvider(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProvider resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProvider resource from API: %v", err)
 	}
 	return nil
 }
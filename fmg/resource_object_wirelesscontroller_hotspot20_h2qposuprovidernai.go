@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNai() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiCreate,
-		Read:   resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead,
-		Update: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,81 +77,76 @@ func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNai() *schema.Resou
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOsuProviderNai(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20H2QpOsuProviderNai(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20H2QpOsuProviderNai(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20H2QpOsuProviderNai(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(d, m)
+	return resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20H2QpOsuProviderNai(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
 	}
 
 	d.SetId("")
@@ -157,34 +154,36 @@ func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiDelete(d *schema
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20H2QpOsuProviderNaiRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20H2QpOsuProviderNai(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20H2QpOsuProviderNai(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20H2QpOsuProviderNai resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20HsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20HsProfileCreate,
-		Read:   resourceObjectWirelessControllerHotspot20HsProfileRead,
-		Update: resourceObjectWirelessControllerHotspot20HsProfileUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20HsProfileDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20HsProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20HsProfileRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20HsProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20HsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -222,81 +224,76 @@ func resourceObjectWirelessControllerHotspot20HsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20HsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20HsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20HsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20HsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20HsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20HsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20HsProfileRead(d, m)
+	return resourceObjectWirelessControllerHotspot20HsProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20HsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20HsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20HsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20HsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20HsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20HsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20HsProfileRead(d, m)
+	return resourceObjectWirelessControllerHotspot20HsProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20HsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20HsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20HsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -304,34 +301,36 @@ func resourceObjectWirelessControllerHotspot20HsProfileDelete(d *schema.Resource
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20HsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20HsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20HsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20HsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20HsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20HsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20HsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20HsProfile resource from API: %v", err)
 	}
 	return nil
 }
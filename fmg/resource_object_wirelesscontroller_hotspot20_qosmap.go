@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerHotspot20QosMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerHotspot20QosMapCreate,
-		Read:   resourceObjectWirelessControllerHotspot20QosMapRead,
-		Update: resourceObjectWirelessControllerHotspot20QosMapUpdate,
-		Delete: resourceObjectWirelessControllerHotspot20QosMapDelete,
+		CreateContext: resourceObjectWirelessControllerHotspot20QosMapCreate,
+		ReadContext:   resourceObjectWirelessControllerHotspot20QosMapRead,
+		UpdateContext: resourceObjectWirelessControllerHotspot20QosMapUpdate,
+		DeleteContext: resourceObjectWirelessControllerHotspot20QosMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -105,81 +107,76 @@ func resourceObjectWirelessControllerHotspot20QosMap() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerHotspot20QosMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerHotspot20QosMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20QosMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20QosMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20QosMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerHotspot20QosMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerHotspot20QosMap resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerHotspot20QosMap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20QosMapRead(d, m)
+	return resourceObjectWirelessControllerHotspot20QosMapRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20QosMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20QosMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerHotspot20QosMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20QosMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20QosMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerHotspot20QosMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerHotspot20QosMap resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerHotspot20QosMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerHotspot20QosMapRead(d, m)
+	return resourceObjectWirelessControllerHotspot20QosMapRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerHotspot20QosMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20QosMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerHotspot20QosMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerHotspot20QosMap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerHotspot20QosMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -187,34 +184,36 @@ func resourceObjectWirelessControllerHotspot20QosMapDelete(d *schema.ResourceDat
 	return nil
 }
 
-func resourceObjectWirelessControllerHotspot20QosMapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerHotspot20QosMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerHotspot20QosMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20QosMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20QosMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20QosMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerHotspot20QosMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerHotspot20QosMap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerHotspot20QosMap resource from API: %v", err)
 	}
 	return nil
 }
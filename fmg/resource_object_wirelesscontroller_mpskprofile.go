@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerMpskProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerMpskProfileCreate,
-		Read:   resourceObjectWirelessControllerMpskProfileRead,
-		Update: resourceObjectWirelessControllerMpskProfileUpdate,
-		Delete: resourceObjectWirelessControllerMpskProfileDelete,
+		CreateContext: resourceObjectWirelessControllerMpskProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerMpskProfileRead,
+		UpdateContext: resourceObjectWirelessControllerMpskProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerMpskProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -136,81 +138,76 @@ func resourceObjectWirelessControllerMpskProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerMpskProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerMpskProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerMpskProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerMpskProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerMpskProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerMpskProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerMpskProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerMpskProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerMpskProfileRead(d, m)
+	return resourceObjectWirelessControllerMpskProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerMpskProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerMpskProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerMpskProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerMpskProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerMpskProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerMpskProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerMpskProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerMpskProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerMpskProfileRead(d, m)
+	return resourceObjectWirelessControllerMpskProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerMpskProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerMpskProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerMpskProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerMpskProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerMpskProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -218,34 +215,36 @@ func resourceObjectWirelessControllerMpskProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerMpskProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerMpskProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerMpskProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerMpskProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerMpskProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerMpskProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerMpskProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerMpskProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerMpskProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerNacProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerNacProfileCreate,
-		Read:   resourceObjectWirelessControllerNacProfileRead,
-		Update: resourceObjectWirelessControllerNacProfileUpdate,
-		Delete: resourceObjectWirelessControllerNacProfileDelete,
+		CreateContext: resourceObjectWirelessControllerNacProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerNacProfileRead,
+		UpdateContext: resourceObjectWirelessControllerNacProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerNacProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectWirelessControllerNacProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerNacProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerNacProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerNacProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerNacProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerNacProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerNacProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerNacProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerNacProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerNacProfileRead(d, m)
+	return resourceObjectWirelessControllerNacProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerNacProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerNacProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerNacProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerNacProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerNacProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerNacProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerNacProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerNacProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerNacProfileRead(d, m)
+	return resourceObjectWirelessControllerNacProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerNacProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerNacProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerNacProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerNacProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerNacProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectWirelessControllerNacProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerNacProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerNacProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerNacProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerNacProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerNacProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerNacProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerNacProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerNacProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerNacProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerQosProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerQosProfileCreate,
-		Read:   resourceObjectWirelessControllerQosProfileRead,
-		Update: resourceObjectWirelessControllerQosProfileUpdate,
-		Delete: resourceObjectWirelessControllerQosProfileDelete,
+		CreateContext: resourceObjectWirelessControllerQosProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerQosProfileRead,
+		UpdateContext: resourceObjectWirelessControllerQosProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerQosProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -160,81 +162,76 @@ func resourceObjectWirelessControllerQosProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerQosProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerQosProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerQosProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerQosProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerQosProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerQosProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerQosProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerQosProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerQosProfileRead(d, m)
+	return resourceObjectWirelessControllerQosProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerQosProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerQosProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerQosProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerQosProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerQosProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerQosProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerQosProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerQosProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerQosProfileRead(d, m)
+	return resourceObjectWirelessControllerQosProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerQosProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerQosProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerQosProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerQosProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerQosProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -242,34 +239,36 @@ func resourceObjectWirelessControllerQosProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerQosProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerQosProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerQosProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerQosProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerQosProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerQosProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerQosProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerQosProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerQosProfile resource from API: %v", err)
 	}
 	return nil
 }
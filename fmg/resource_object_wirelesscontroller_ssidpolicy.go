@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerSsidPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerSsidPolicyCreate,
-		Read:   resourceObjectWirelessControllerSsidPolicyRead,
-		Update: resourceObjectWirelessControllerSsidPolicyUpdate,
-		Delete: resourceObjectWirelessControllerSsidPolicyDelete,
+		CreateContext: resourceObjectWirelessControllerSsidPolicyCreate,
+		ReadContext:   resourceObjectWirelessControllerSsidPolicyRead,
+		UpdateContext: resourceObjectWirelessControllerSsidPolicyUpdate,
+		DeleteContext: resourceObjectWirelessControllerSsidPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -62,81 +64,76 @@ func resourceObjectWirelessControllerSsidPolicy() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerSsidPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerSsidPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerSsidPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerSsidPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerSsidPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerSsidPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerSsidPolicy resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerSsidPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerSsidPolicyRead(d, m)
+	return resourceObjectWirelessControllerSsidPolicyRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerSsidPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSsidPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerSsidPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerSsidPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerSsidPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerSsidPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerSsidPolicy resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerSsidPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerSsidPolicyRead(d, m)
+	return resourceObjectWirelessControllerSsidPolicyRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerSsidPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSsidPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerSsidPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerSsidPolicy resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerSsidPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -144,34 +141,36 @@ func resourceObjectWirelessControllerSsidPolicyDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerSsidPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSsidPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerSsidPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerSsidPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerSsidPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerSsidPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerSsidPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerSsidPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerSsidPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerSyslogProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerSyslogProfileCreate,
-		Read:   resourceObjectWirelessControllerSyslogProfileRead,
-		Update: resourceObjectWirelessControllerSyslogProfileUpdate,
-		Delete: resourceObjectWirelessControllerSyslogProfileDelete,
+		CreateContext: resourceObjectWirelessControllerSyslogProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerSyslogProfileRead,
+		UpdateContext: resourceObjectWirelessControllerSyslogProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerSyslogProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,81 +89,76 @@ func resourceObjectWirelessControllerSyslogProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerSyslogProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerSyslogProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerSyslogProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerSyslogProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerSyslogProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerSyslogProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerSyslogProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerSyslogProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerSyslogProfileRead(d, m)
+	return resourceObjectWirelessControllerSyslogProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerSyslogProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSyslogProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerSyslogProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerSyslogProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerSyslogProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerSyslogProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerSyslogProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerSyslogProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerSyslogProfileRead(d, m)
+	return resourceObjectWirelessControllerSyslogProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerSyslogProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSyslogProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerSyslogProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerSyslogProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerSyslogProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -169,34 +166,36 @@ func resourceObjectWirelessControllerSyslogProfileDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceObjectWirelessControllerSyslogProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerSyslogProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerSyslogProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerSyslogProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerSyslogProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerSyslogProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerSyslogProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerSyslogProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerSyslogProfile resource from API: %v", err)
 	}
 	return nil
 }
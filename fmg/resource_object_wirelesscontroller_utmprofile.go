@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerUtmProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerUtmProfileCreate,
-		Read:   resourceObjectWirelessControllerUtmProfileRead,
-		Update: resourceObjectWirelessControllerUtmProfileUpdate,
-		Delete: resourceObjectWirelessControllerUtmProfileDelete,
+		CreateContext: resourceObjectWirelessControllerUtmProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerUtmProfileRead,
+		UpdateContext: resourceObjectWirelessControllerUtmProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerUtmProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -84,81 +86,76 @@ func resourceObjectWirelessControllerUtmProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerUtmProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerUtmProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerUtmProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerUtmProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerUtmProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerUtmProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerUtmProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerUtmProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerUtmProfileRead(d, m)
+	return resourceObjectWirelessControllerUtmProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerUtmProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerUtmProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerUtmProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerUtmProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerUtmProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerUtmProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerUtmProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerUtmProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerUtmProfileRead(d, m)
+	return resourceObjectWirelessControllerUtmProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerUtmProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerUtmProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerUtmProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerUtmProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerUtmProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -166,34 +163,36 @@ func resourceObjectWirelessControllerUtmProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerUtmProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerUtmProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerUtmProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerUtmProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerUtmProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerUtmProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerUtmProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerUtmProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerUtmProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerVap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerVapCreate,
-		Read:   resourceObjectWirelessControllerVapRead,
-		Update: resourceObjectWirelessControllerVapUpdate,
-		Delete: resourceObjectWirelessControllerVapDelete,
+		CreateContext: resourceObjectWirelessControllerVapCreate,
+		ReadContext:   resourceObjectWirelessControllerVapRead,
+		UpdateContext: resourceObjectWirelessControllerVapUpdate,
+		DeleteContext: resourceObjectWirelessControllerVapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1965,81 +1967,76 @@ func resourceObjectWirelessControllerVap() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerVapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerVapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerVap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerVap resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerVap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerVap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerVap resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerVap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerVapRead(d, m)
+	return resourceObjectWirelessControllerVapRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerVapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerVap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerVap resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerVap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerVap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerVap resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerVap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerVapRead(d, m)
+	return resourceObjectWirelessControllerVapRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerVapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerVap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerVap resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerVap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -2047,34 +2044,36 @@ func resourceObjectWirelessControllerVapDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceObjectWirelessControllerVapRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerVap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerVap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerVap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerVap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerVap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerVap resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerVap resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerVapGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerVapGroupCreate,
-		Read:   resourceObjectWirelessControllerVapGroupRead,
-		Update: resourceObjectWirelessControllerVapGroupUpdate,
-		Delete: resourceObjectWirelessControllerVapGroupDelete,
+		CreateContext: resourceObjectWirelessControllerVapGroupCreate,
+		ReadContext:   resourceObjectWirelessControllerVapGroupRead,
+		UpdateContext: resourceObjectWirelessControllerVapGroupUpdate,
+		DeleteContext: resourceObjectWirelessControllerVapGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -64,81 +66,76 @@ func resourceObjectWirelessControllerVapGroup() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerVapGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerVapGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerVapGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerVapGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerVapGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerVapGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerVapGroup resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerVapGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerVapGroupRead(d, m)
+	return resourceObjectWirelessControllerVapGroupRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerVapGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerVapGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerVapGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerVapGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerVapGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerVapGroup resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerVapGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerVapGroupRead(d, m)
+	return resourceObjectWirelessControllerVapGroupRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerVapGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerVapGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerVapGroup resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerVapGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -146,34 +143,36 @@ func resourceObjectWirelessControllerVapGroupDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceObjectWirelessControllerVapGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerVapGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerVapGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerVapGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerVapGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerVapGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerVapGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerVapGroup resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerVapGroup resource from API: %v", err)
 	}
 	return nil
 }
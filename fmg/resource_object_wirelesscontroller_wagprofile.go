@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerWagProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerWagProfileCreate,
-		Read:   resourceObjectWirelessControllerWagProfileRead,
-		Update: resourceObjectWirelessControllerWagProfileUpdate,
-		Delete: resourceObjectWirelessControllerWagProfileDelete,
+		CreateContext: resourceObjectWirelessControllerWagProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerWagProfileRead,
+		UpdateContext: resourceObjectWirelessControllerWagProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerWagProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -93,81 +95,76 @@ func resourceObjectWirelessControllerWagProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerWagProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerWagProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWagProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWagProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWagProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerWagProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWagProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWagProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWagProfileRead(d, m)
+	return resourceObjectWirelessControllerWagProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWagProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWagProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWagProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWagProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWagProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerWagProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWagProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWagProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWagProfileRead(d, m)
+	return resourceObjectWirelessControllerWagProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWagProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWagProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerWagProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerWagProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerWagProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -175,34 +172,36 @@ func resourceObjectWirelessControllerWagProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerWagProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWagProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerWagProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWagProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerWagProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerWagProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerWagProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWagProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerWagProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerWidsProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerWidsProfileCreate,
-		Read:   resourceObjectWirelessControllerWidsProfileRead,
-		Update: resourceObjectWirelessControllerWidsProfileUpdate,
-		Delete: resourceObjectWirelessControllerWidsProfileDelete,
+		CreateContext: resourceObjectWirelessControllerWidsProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerWidsProfileRead,
+		UpdateContext: resourceObjectWirelessControllerWidsProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerWidsProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -294,81 +296,76 @@ func resourceObjectWirelessControllerWidsProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerWidsProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerWidsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWidsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWidsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWidsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerWidsProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWidsProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWidsProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWidsProfileRead(d, m)
+	return resourceObjectWirelessControllerWidsProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWidsProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWidsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWidsProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWidsProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWidsProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerWidsProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWidsProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWidsProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWidsProfileRead(d, m)
+	return resourceObjectWirelessControllerWidsProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWidsProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWidsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerWidsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerWidsProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerWidsProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -376,34 +373,36 @@ func resourceObjectWirelessControllerWidsProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerWidsProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWidsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerWidsProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWidsProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerWidsProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerWidsProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerWidsProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWidsProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerWidsProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceObjectWirelessControllerWtpProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceObjectWirelessControllerWtpProfileCreate,
-		Read:   resourceObjectWirelessControllerWtpProfileRead,
-		Update: resourceObjectWirelessControllerWtpProfileUpdate,
-		Delete: resourceObjectWirelessControllerWtpProfileDelete,
+		CreateContext: resourceObjectWirelessControllerWtpProfileCreate,
+		ReadContext:   resourceObjectWirelessControllerWtpProfileRead,
+		UpdateContext: resourceObjectWirelessControllerWtpProfileUpdate,
+		DeleteContext: resourceObjectWirelessControllerWtpProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -2022,81 +2024,76 @@ func resourceObjectWirelessControllerWtpProfile() *schema.Resource {
 	}
 }
 
-func resourceObjectWirelessControllerWtpProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceObjectWirelessControllerWtpProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWtpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWtpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWtpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateObjectWirelessControllerWtpProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating ObjectWirelessControllerWtpProfile resource: %v", err)
+		return diag.Errorf("Error creating ObjectWirelessControllerWtpProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWtpProfileRead(d, m)
+	return resourceObjectWirelessControllerWtpProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWtpProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWtpProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectObjectWirelessControllerWtpProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWtpProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWtpProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateObjectWirelessControllerWtpProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating ObjectWirelessControllerWtpProfile resource: %v", err)
+		return diag.Errorf("Error updating ObjectWirelessControllerWtpProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceObjectWirelessControllerWtpProfileRead(d, m)
+	return resourceObjectWirelessControllerWtpProfileRead(ctx, d, m)
 }
 
-func resourceObjectWirelessControllerWtpProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWtpProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteObjectWirelessControllerWtpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting ObjectWirelessControllerWtpProfile resource: %v", err)
+		return diag.Errorf("Error deleting ObjectWirelessControllerWtpProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -2104,34 +2101,36 @@ func resourceObjectWirelessControllerWtpProfileDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceObjectWirelessControllerWtpProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceObjectWirelessControllerWtpProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadObjectWirelessControllerWtpProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWtpProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading ObjectWirelessControllerWtpProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading ObjectWirelessControllerWtpProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectObjectWirelessControllerWtpProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading ObjectWirelessControllerWtpProfile resource from API: %v", err)
+		return diag.Errorf("Error reading ObjectWirelessControllerWtpProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesAuthenticationRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesAuthenticationRuleCreate,
-		Read:   resourcePackagesAuthenticationRuleRead,
-		Update: resourcePackagesAuthenticationRuleUpdate,
-		Delete: resourcePackagesAuthenticationRuleDelete,
+		CreateContext: resourcePackagesAuthenticationRuleCreate,
+		ReadContext:   resourcePackagesAuthenticationRuleRead,
+		UpdateContext: resourcePackagesAuthenticationRuleUpdate,
+		DeleteContext: resourcePackagesAuthenticationRuleDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -128,15 +130,14 @@ func resourcePackagesAuthenticationRule() *schema.Resource {
 	}
 }
 
-func resourcePackagesAuthenticationRuleCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesAuthenticationRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -145,30 +146,29 @@ func resourcePackagesAuthenticationRuleCreate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesAuthenticationRule(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesAuthenticationRule resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesAuthenticationRule resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesAuthenticationRule(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesAuthenticationRule resource: %v", err)
+		return diag.Errorf("Error creating PackagesAuthenticationRule resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesAuthenticationRuleRead(d, m)
+	return resourcePackagesAuthenticationRuleRead(ctx, d, m)
 }
 
-func resourcePackagesAuthenticationRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -177,32 +177,29 @@ func resourcePackagesAuthenticationRuleUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesAuthenticationRule(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesAuthenticationRule resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesAuthenticationRule resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesAuthenticationRule(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesAuthenticationRule resource: %v", err)
+		return diag.Errorf("Error updating PackagesAuthenticationRule resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesAuthenticationRuleRead(d, m)
+	return resourcePackagesAuthenticationRuleRead(ctx, d, m)
 }
 
-func resourcePackagesAuthenticationRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -211,7 +208,7 @@ func resourcePackagesAuthenticationRuleDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeletePackagesAuthenticationRule(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesAuthenticationRule resource: %v", err)
+		return diag.Errorf("Error deleting PackagesAuthenticationRule resource: %v", err)
 	}
 
 	d.SetId("")
@@ -219,17 +216,16 @@ func resourcePackagesAuthenticationRuleDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourcePackagesAuthenticationRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -237,25 +233,28 @@ func resourcePackagesAuthenticationRuleRead(d *schema.ResourceData, m interface{
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesAuthenticationRule(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesAuthenticationRule resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesAuthenticationRule resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesAuthenticationRule resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesAuthenticationRule(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesAuthenticationRule resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesAuthenticationRule resource from API: %v", err)
 	}
 	return nil
 }
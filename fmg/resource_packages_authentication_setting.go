@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesAuthenticationSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesAuthenticationSettingUpdate,
-		Read:   resourcePackagesAuthenticationSettingRead,
-		Update: resourcePackagesAuthenticationSettingUpdate,
-		Delete: resourcePackagesAuthenticationSettingDelete,
+		CreateContext: resourcePackagesAuthenticationSettingUpdate,
+		ReadContext:   resourcePackagesAuthenticationSettingRead,
+		UpdateContext: resourcePackagesAuthenticationSettingUpdate,
+		DeleteContext: resourcePackagesAuthenticationSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -154,16 +156,15 @@ func resourcePackagesAuthenticationSetting() *schema.Resource {
 	}
 }
 
-func resourcePackagesAuthenticationSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -172,32 +173,29 @@ func resourcePackagesAuthenticationSettingUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectPackagesAuthenticationSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesAuthenticationSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesAuthenticationSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesAuthenticationSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesAuthenticationSetting resource: %v", err)
+		return diag.Errorf("Error updating PackagesAuthenticationSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesAuthenticationSetting")
 
-	return resourcePackagesAuthenticationSettingRead(d, m)
+	return resourcePackagesAuthenticationSettingRead(ctx, d, m)
 }
 
-func resourcePackagesAuthenticationSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -206,7 +204,7 @@ func resourcePackagesAuthenticationSettingDelete(d *schema.ResourceData, m inter
 
 	err = c.DeletePackagesAuthenticationSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesAuthenticationSetting resource: %v", err)
+		return diag.Errorf("Error deleting PackagesAuthenticationSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -214,17 +212,16 @@ func resourcePackagesAuthenticationSettingDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourcePackagesAuthenticationSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesAuthenticationSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -232,25 +229,28 @@ func resourcePackagesAuthenticationSettingRead(d *schema.ResourceData, m interfa
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesAuthenticationSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesAuthenticationSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesAuthenticationSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesAuthenticationSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesAuthenticationSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesAuthenticationSetting resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesAuthenticationSetting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallDosPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallDosPolicyCreate,
-		Read:   resourcePackagesFirewallDosPolicyRead,
-		Update: resourcePackagesFirewallDosPolicyUpdate,
-		Delete: resourcePackagesFirewallDosPolicyDelete,
+		CreateContext: resourcePackagesFirewallDosPolicyCreate,
+		ReadContext:   resourcePackagesFirewallDosPolicyRead,
+		UpdateContext: resourcePackagesFirewallDosPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallDosPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -182,15 +184,14 @@ func resourcePackagesFirewallDosPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallDosPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallDosPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -199,39 +200,38 @@ func resourcePackagesFirewallDosPolicyCreate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectPackagesFirewallDosPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallDosPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallDosPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesFirewallDosPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallDosPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallDosPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesFirewallDosPolicyRead(d, m)
+			return resourcePackagesFirewallDosPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesFirewallDosPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesFirewallDosPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallDosPolicyRead(d, m)
+	return resourcePackagesFirewallDosPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -240,32 +240,29 @@ func resourcePackagesFirewallDosPolicyUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectPackagesFirewallDosPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallDosPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallDosPolicyRead(d, m)
+	return resourcePackagesFirewallDosPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -274,7 +271,7 @@ func resourcePackagesFirewallDosPolicyDelete(d *schema.ResourceData, m interface
 
 	err = c.DeletePackagesFirewallDosPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallDosPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallDosPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -282,17 +279,16 @@ func resourcePackagesFirewallDosPolicyDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourcePackagesFirewallDosPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -300,25 +296,28 @@ func resourcePackagesFirewallDosPolicyRead(d *schema.ResourceData, m interface{}
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallDosPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallDosPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy resource from API: %v", err)
 	}
 	return nil
 }
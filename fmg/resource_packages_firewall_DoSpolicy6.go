@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallDosPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallDosPolicy6Create,
-		Read:   resourcePackagesFirewallDosPolicy6Read,
-		Update: resourcePackagesFirewallDosPolicy6Update,
-		Delete: resourcePackagesFirewallDosPolicy6Delete,
+		CreateContext: resourcePackagesFirewallDosPolicy6Create,
+		ReadContext:   resourcePackagesFirewallDosPolicy6Read,
+		UpdateContext: resourcePackagesFirewallDosPolicy6Update,
+		DeleteContext: resourcePackagesFirewallDosPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -182,15 +184,14 @@ func resourcePackagesFirewallDosPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallDosPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallDosPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -199,39 +200,38 @@ func resourcePackagesFirewallDosPolicy6Create(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesFirewallDosPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallDosPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallDosPolicy6 resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesFirewallDosPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallDosPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallDosPolicy6 resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesFirewallDosPolicy6Read(d, m)
+			return resourcePackagesFirewallDosPolicy6Read(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesFirewallDosPolicy6 resource: %v", err)
+			return diag.Errorf("Error creating PackagesFirewallDosPolicy6 resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallDosPolicy6Read(d, m)
+	return resourcePackagesFirewallDosPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -240,32 +240,29 @@ func resourcePackagesFirewallDosPolicy6Update(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesFirewallDosPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallDosPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallDosPolicy6Read(d, m)
+	return resourcePackagesFirewallDosPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -274,7 +271,7 @@ func resourcePackagesFirewallDosPolicy6Delete(d *schema.ResourceData, m interfac
 
 	err = c.DeletePackagesFirewallDosPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallDosPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallDosPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -282,17 +279,16 @@ func resourcePackagesFirewallDosPolicy6Delete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourcePackagesFirewallDosPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -300,25 +296,28 @@ func resourcePackagesFirewallDosPolicy6Read(d *schema.ResourceData, m interface{
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallDosPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallDosPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallDosPolicy6Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallDosPolicy6MoveUpdate,
-		Read:   resourcePackagesFirewallDosPolicy6MoveRead,
-		Update: resourcePackagesFirewallDosPolicy6MoveUpdate,
-		Delete: resourcePackagesFirewallDosPolicy6MoveDelete,
+		CreateContext: resourcePackagesFirewallDosPolicy6MoveUpdate,
+		ReadContext:   resourcePackagesFirewallDosPolicy6MoveRead,
+		UpdateContext: resourcePackagesFirewallDosPolicy6MoveUpdate,
+		DeleteContext: resourcePackagesFirewallDosPolicy6MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallDosPolicy6Move() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallDosPolicy6MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallDosPolicy6MoveUpdate(d *schema.ResourceData, m inte
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallDosPolicy6Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy6Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy6Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallDosPolicy6Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicy6Move resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicy6Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallDosPolicy6Move" + "_" + pkg + "_" + dos_policy6 + "_" + target)
 
-	return resourcePackagesFirewallDosPolicy6MoveRead(d, m)
+	return resourcePackagesFirewallDosPolicy6MoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicy6MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallDosPolicy6MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicy6MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("dos_policy6").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallDosPolicy6MoveRead(d *schema.ResourceData, m interf
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallDosPolicy6Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallDosPolicy6MoveRead(d *schema.ResourceData, m interf
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallDosPolicy6MoveRead(d *schema.ResourceData, m interf
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallDosPolicy6Move resource: no valid map string.")
 			}
 
 			i += 1
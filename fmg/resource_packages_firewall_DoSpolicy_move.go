@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallDosPolicyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallDosPolicyMoveUpdate,
-		Read:   resourcePackagesFirewallDosPolicyMoveRead,
-		Update: resourcePackagesFirewallDosPolicyMoveUpdate,
-		Delete: resourcePackagesFirewallDosPolicyMoveDelete,
+		CreateContext: resourcePackagesFirewallDosPolicyMoveUpdate,
+		ReadContext:   resourcePackagesFirewallDosPolicyMoveRead,
+		UpdateContext: resourcePackagesFirewallDosPolicyMoveUpdate,
+		DeleteContext: resourcePackagesFirewallDosPolicyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallDosPolicyMove() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallDosPolicyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallDosPolicyMoveUpdate(d *schema.ResourceData, m inter
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallDosPolicyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallDosPolicyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallDosPolicyMove resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallDosPolicyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallDosPolicyMove" + "_" + pkg + "_" + dos_policy + "_" + target)
 
-	return resourcePackagesFirewallDosPolicyMoveRead(d, m)
+	return resourcePackagesFirewallDosPolicyMoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallDosPolicyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallDosPolicyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallDosPolicyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("dos_policy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallDosPolicyMoveRead(d *schema.ResourceData, m interfa
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallDosPolicyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallDosPolicyMoveRead(d *schema.ResourceData, m interfa
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallDosPolicyMoveRead(d *schema.ResourceData, m interfa
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallDosPolicyMove resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallDosPolicyMove resource: no valid map string.")
 			}
 
 			i += 1
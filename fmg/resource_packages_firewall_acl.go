@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallAcl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallAclCreate,
-		Read:   resourcePackagesFirewallAclRead,
-		Update: resourcePackagesFirewallAclUpdate,
-		Delete: resourcePackagesFirewallAclDelete,
+		CreateContext: resourcePackagesFirewallAclCreate,
+		ReadContext:   resourcePackagesFirewallAclRead,
+		UpdateContext: resourcePackagesFirewallAclUpdate,
+		DeleteContext: resourcePackagesFirewallAclDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -99,15 +101,14 @@ func resourcePackagesFirewallAcl() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallAclCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallAclCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -116,30 +117,29 @@ func resourcePackagesFirewallAclCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectPackagesFirewallAcl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallAcl resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallAcl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallAcl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallAcl resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallAcl resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallAclRead(d, m)
+	return resourcePackagesFirewallAclRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallAclUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAclUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -148,32 +148,29 @@ func resourcePackagesFirewallAclUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectPackagesFirewallAcl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallAcl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallAclRead(d, m)
+	return resourcePackagesFirewallAclRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallAclDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAclDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -182,7 +179,7 @@ func resourcePackagesFirewallAclDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeletePackagesFirewallAcl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallAcl resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallAcl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -190,17 +187,16 @@ func resourcePackagesFirewallAclDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourcePackagesFirewallAclRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAclRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -208,25 +204,28 @@ func resourcePackagesFirewallAclRead(d *schema.ResourceData, m interface{}) erro
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallAcl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallAcl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallAcl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallAcl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallAcl resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallAcl6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallAcl6Create,
-		Read:   resourcePackagesFirewallAcl6Read,
-		Update: resourcePackagesFirewallAcl6Update,
-		Delete: resourcePackagesFirewallAcl6Delete,
+		CreateContext: resourcePackagesFirewallAcl6Create,
+		ReadContext:   resourcePackagesFirewallAcl6Read,
+		UpdateContext: resourcePackagesFirewallAcl6Update,
+		DeleteContext: resourcePackagesFirewallAcl6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -98,15 +100,14 @@ func resourcePackagesFirewallAcl6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallAcl6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallAcl6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -115,30 +116,29 @@ func resourcePackagesFirewallAcl6Create(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectPackagesFirewallAcl6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallAcl6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallAcl6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallAcl6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallAcl6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallAcl6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallAcl6Read(d, m)
+	return resourcePackagesFirewallAcl6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallAcl6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -147,32 +147,29 @@ func resourcePackagesFirewallAcl6Update(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectPackagesFirewallAcl6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallAcl6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallAcl6Read(d, m)
+	return resourcePackagesFirewallAcl6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallAcl6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -181,7 +178,7 @@ func resourcePackagesFirewallAcl6Delete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeletePackagesFirewallAcl6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallAcl6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallAcl6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,17 +186,16 @@ func resourcePackagesFirewallAcl6Delete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourcePackagesFirewallAcl6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -207,25 +203,28 @@ func resourcePackagesFirewallAcl6Read(d *schema.ResourceData, m interface{}) err
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallAcl6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallAcl6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallAcl6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallAcl6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallAcl6 resource from API: %v", err)
 	}
 	return nil
 }
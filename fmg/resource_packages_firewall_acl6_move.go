@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallAcl6Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallAcl6MoveUpdate,
-		Read:   resourcePackagesFirewallAcl6MoveRead,
-		Update: resourcePackagesFirewallAcl6MoveUpdate,
-		Delete: resourcePackagesFirewallAcl6MoveDelete,
+		CreateContext: resourcePackagesFirewallAcl6MoveUpdate,
+		ReadContext:   resourcePackagesFirewallAcl6MoveRead,
+		UpdateContext: resourcePackagesFirewallAcl6MoveUpdate,
+		DeleteContext: resourcePackagesFirewallAcl6MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallAcl6Move() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallAcl6MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallAcl6MoveUpdate(d *schema.ResourceData, m interface{
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallAcl6Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl6Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl6Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallAcl6Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallAcl6Move resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallAcl6Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallAcl6Move" + "_" + pkg + "_" + acl6 + "_" + target)
 
-	return resourcePackagesFirewallAcl6MoveRead(d, m)
+	return resourcePackagesFirewallAcl6MoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallAcl6MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallAcl6MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallAcl6MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("acl6").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallAcl6MoveRead(d *schema.ResourceData, m interface{})
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallAcl6Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallAcl6MoveRead(d *schema.ResourceData, m interface{})
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallAcl6MoveRead(d *schema.ResourceData, m interface{})
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallAcl6Move resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallAcl6Move resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallCentralSnatMap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallCentralSnatMapCreate,
-		Read:   resourcePackagesFirewallCentralSnatMapRead,
-		Update: resourcePackagesFirewallCentralSnatMapUpdate,
-		Delete: resourcePackagesFirewallCentralSnatMapDelete,
+		CreateContext: resourcePackagesFirewallCentralSnatMapCreate,
+		ReadContext:   resourcePackagesFirewallCentralSnatMapRead,
+		UpdateContext: resourcePackagesFirewallCentralSnatMapUpdate,
+		DeleteContext: resourcePackagesFirewallCentralSnatMapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -147,15 +149,14 @@ func resourcePackagesFirewallCentralSnatMap() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallCentralSnatMapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallCentralSnatMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -164,30 +165,29 @@ func resourcePackagesFirewallCentralSnatMapCreate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallCentralSnatMap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallCentralSnatMap resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallCentralSnatMap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallCentralSnatMap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallCentralSnatMap resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallCentralSnatMap resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallCentralSnatMapRead(d, m)
+	return resourcePackagesFirewallCentralSnatMapRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallCentralSnatMapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallCentralSnatMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -196,32 +196,29 @@ func resourcePackagesFirewallCentralSnatMapUpdate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallCentralSnatMap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallCentralSnatMap resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallCentralSnatMap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallCentralSnatMap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallCentralSnatMap resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallCentralSnatMap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallCentralSnatMapRead(d, m)
+	return resourcePackagesFirewallCentralSnatMapRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallCentralSnatMapDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallCentralSnatMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -230,7 +227,7 @@ func resourcePackagesFirewallCentralSnatMapDelete(d *schema.ResourceData, m inte
 
 	err = c.DeletePackagesFirewallCentralSnatMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallCentralSnatMap resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallCentralSnatMap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -238,17 +235,16 @@ func resourcePackagesFirewallCentralSnatMapDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourcePackagesFirewallCentralSnatMapRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallCentralSnatMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -256,25 +252,28 @@ func resourcePackagesFirewallCentralSnatMapRead(d *schema.ResourceData, m interf
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallCentralSnatMap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallCentralSnatMap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallCentralSnatMap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallCentralSnatMap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallCentralSnatMap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallCentralSnatMap resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallCentralSnatMap resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallConsolidatedPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallConsolidatedPolicyCreate,
-		Read:   resourcePackagesFirewallConsolidatedPolicyRead,
-		Update: resourcePackagesFirewallConsolidatedPolicyUpdate,
-		Delete: resourcePackagesFirewallConsolidatedPolicyDelete,
+		CreateContext: resourcePackagesFirewallConsolidatedPolicyCreate,
+		ReadContext:   resourcePackagesFirewallConsolidatedPolicyRead,
+		UpdateContext: resourcePackagesFirewallConsolidatedPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallConsolidatedPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -418,15 +420,14 @@ func resourcePackagesFirewallConsolidatedPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallConsolidatedPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallConsolidatedPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -435,30 +436,29 @@ func resourcePackagesFirewallConsolidatedPolicyCreate(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallConsolidatedPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallConsolidatedPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallConsolidatedPolicyRead(d, m)
+	return resourcePackagesFirewallConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallConsolidatedPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -467,32 +467,29 @@ func resourcePackagesFirewallConsolidatedPolicyUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallConsolidatedPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallConsolidatedPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallConsolidatedPolicyRead(d, m)
+	return resourcePackagesFirewallConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallConsolidatedPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -501,7 +498,7 @@ func resourcePackagesFirewallConsolidatedPolicyDelete(d *schema.ResourceData, m
 
 	err = c.DeletePackagesFirewallConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallConsolidatedPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -509,17 +506,16 @@ func resourcePackagesFirewallConsolidatedPolicyDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourcePackagesFirewallConsolidatedPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -527,25 +523,28 @@ func resourcePackagesFirewallConsolidatedPolicyRead(d *schema.ResourceData, m in
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallConsolidatedPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicy resource from API: %v", err)
 	}
 	return nil
 }
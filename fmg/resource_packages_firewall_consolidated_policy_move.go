@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallConsolidatedPolicyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallConsolidatedPolicyMoveUpdate,
-		Read:   resourcePackagesFirewallConsolidatedPolicyMoveRead,
-		Update: resourcePackagesFirewallConsolidatedPolicyMoveUpdate,
-		Delete: resourcePackagesFirewallConsolidatedPolicyMoveDelete,
+		CreateContext: resourcePackagesFirewallConsolidatedPolicyMoveUpdate,
+		ReadContext:   resourcePackagesFirewallConsolidatedPolicyMoveRead,
+		UpdateContext: resourcePackagesFirewallConsolidatedPolicyMoveUpdate,
+		DeleteContext: resourcePackagesFirewallConsolidatedPolicyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallConsolidatedPolicyMove() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallConsolidatedPolicyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallConsolidatedPolicyMoveUpdate(d *schema.ResourceData
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallConsolidatedPolicyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallConsolidatedPolicyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallConsolidatedPolicyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallConsolidatedPolicyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallConsolidatedPolicyMove resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallConsolidatedPolicyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallConsolidatedPolicyMove" + "_" + pkg + "_" + policy + "_" + target)
 
-	return resourcePackagesFirewallConsolidatedPolicyMoveRead(d, m)
+	return resourcePackagesFirewallConsolidatedPolicyMoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallConsolidatedPolicyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallConsolidatedPolicyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallConsolidatedPolicyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("policy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallConsolidatedPolicyMoveRead(d *schema.ResourceData,
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallConsolidatedPolicyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallConsolidatedPolicyMoveRead(d *schema.ResourceData,
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallConsolidatedPolicyMoveRead(d *schema.ResourceData,
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallConsolidatedPolicyMove resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallHyperscalePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallHyperscalePolicyCreate,
-		Read:   resourcePackagesFirewallHyperscalePolicyRead,
-		Update: resourcePackagesFirewallHyperscalePolicyUpdate,
-		Delete: resourcePackagesFirewallHyperscalePolicyDelete,
+		CreateContext: resourcePackagesFirewallHyperscalePolicyCreate,
+		ReadContext:   resourcePackagesFirewallHyperscalePolicyRead,
+		UpdateContext: resourcePackagesFirewallHyperscalePolicyUpdate,
+		DeleteContext: resourcePackagesFirewallHyperscalePolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -210,15 +212,14 @@ func resourcePackagesFirewallHyperscalePolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallHyperscalePolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallHyperscalePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -227,30 +228,29 @@ func resourcePackagesFirewallHyperscalePolicyCreate(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallHyperscalePolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicyRead(d, m)
+	return resourcePackagesFirewallHyperscalePolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -259,32 +259,29 @@ func resourcePackagesFirewallHyperscalePolicyUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallHyperscalePolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicyRead(d, m)
+	return resourcePackagesFirewallHyperscalePolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -293,7 +290,7 @@ func resourcePackagesFirewallHyperscalePolicyDelete(d *schema.ResourceData, m in
 
 	err = c.DeletePackagesFirewallHyperscalePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallHyperscalePolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallHyperscalePolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -301,17 +298,16 @@ func resourcePackagesFirewallHyperscalePolicyDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourcePackagesFirewallHyperscalePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -319,25 +315,28 @@ func resourcePackagesFirewallHyperscalePolicyRead(d *schema.ResourceData, m inte
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallHyperscalePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallHyperscalePolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallHyperscalePolicy46() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallHyperscalePolicy46Create,
-		Read:   resourcePackagesFirewallHyperscalePolicy46Read,
-		Update: resourcePackagesFirewallHyperscalePolicy46Update,
-		Delete: resourcePackagesFirewallHyperscalePolicy46Delete,
+		CreateContext: resourcePackagesFirewallHyperscalePolicy46Create,
+		ReadContext:   resourcePackagesFirewallHyperscalePolicy46Read,
+		UpdateContext: resourcePackagesFirewallHyperscalePolicy46Update,
+		DeleteContext: resourcePackagesFirewallHyperscalePolicy46Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -124,15 +126,14 @@ func resourcePackagesFirewallHyperscalePolicy46() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallHyperscalePolicy46Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallHyperscalePolicy46Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -141,30 +142,29 @@ func resourcePackagesFirewallHyperscalePolicy46Create(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy46(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy46 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy46 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallHyperscalePolicy46(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy46 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy46 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy46Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy46Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy46Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy46Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -173,32 +173,29 @@ func resourcePackagesFirewallHyperscalePolicy46Update(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy46(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy46 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy46 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallHyperscalePolicy46(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy46 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy46 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy46Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy46Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy46Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy46Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -207,7 +204,7 @@ func resourcePackagesFirewallHyperscalePolicy46Delete(d *schema.ResourceData, m
 
 	err = c.DeletePackagesFirewallHyperscalePolicy46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallHyperscalePolicy46 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallHyperscalePolicy46 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -215,17 +212,16 @@ func resourcePackagesFirewallHyperscalePolicy46Delete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourcePackagesFirewallHyperscalePolicy46Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy46Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -233,25 +229,28 @@ func resourcePackagesFirewallHyperscalePolicy46Read(d *schema.ResourceData, m in
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallHyperscalePolicy46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy46 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy46 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy46 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallHyperscalePolicy46(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy46 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy46 resource from API: %v", err)
 	}
 	return nil
 }
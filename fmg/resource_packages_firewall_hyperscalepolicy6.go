@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallHyperscalePolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallHyperscalePolicy6Create,
-		Read:   resourcePackagesFirewallHyperscalePolicy6Read,
-		Update: resourcePackagesFirewallHyperscalePolicy6Update,
-		Delete: resourcePackagesFirewallHyperscalePolicy6Delete,
+		CreateContext: resourcePackagesFirewallHyperscalePolicy6Create,
+		ReadContext:   resourcePackagesFirewallHyperscalePolicy6Read,
+		UpdateContext: resourcePackagesFirewallHyperscalePolicy6Update,
+		DeleteContext: resourcePackagesFirewallHyperscalePolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -142,15 +144,14 @@ func resourcePackagesFirewallHyperscalePolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallHyperscalePolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallHyperscalePolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -159,30 +160,29 @@ func resourcePackagesFirewallHyperscalePolicy6Create(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallHyperscalePolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy6Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -191,32 +191,29 @@ func resourcePackagesFirewallHyperscalePolicy6Update(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallHyperscalePolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy6Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -225,7 +222,7 @@ func resourcePackagesFirewallHyperscalePolicy6Delete(d *schema.ResourceData, m i
 
 	err = c.DeletePackagesFirewallHyperscalePolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallHyperscalePolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallHyperscalePolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -233,17 +230,16 @@ func resourcePackagesFirewallHyperscalePolicy6Delete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourcePackagesFirewallHyperscalePolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -251,25 +247,28 @@ func resourcePackagesFirewallHyperscalePolicy6Read(d *schema.ResourceData, m int
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallHyperscalePolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallHyperscalePolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallHyperscalePolicy64() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallHyperscalePolicy64Create,
-		Read:   resourcePackagesFirewallHyperscalePolicy64Read,
-		Update: resourcePackagesFirewallHyperscalePolicy64Update,
-		Delete: resourcePackagesFirewallHyperscalePolicy64Delete,
+		CreateContext: resourcePackagesFirewallHyperscalePolicy64Create,
+		ReadContext:   resourcePackagesFirewallHyperscalePolicy64Read,
+		UpdateContext: resourcePackagesFirewallHyperscalePolicy64Update,
+		DeleteContext: resourcePackagesFirewallHyperscalePolicy64Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -156,15 +158,14 @@ func resourcePackagesFirewallHyperscalePolicy64() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallHyperscalePolicy64Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallHyperscalePolicy64Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -173,30 +174,29 @@ func resourcePackagesFirewallHyperscalePolicy64Create(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy64(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy64 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy64 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallHyperscalePolicy64(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallHyperscalePolicy64 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallHyperscalePolicy64 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy64Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy64Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy64Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy64Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -205,32 +205,29 @@ func resourcePackagesFirewallHyperscalePolicy64Update(d *schema.ResourceData, m
 
 	obj, err := getObjectPackagesFirewallHyperscalePolicy64(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy64 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy64 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallHyperscalePolicy64(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallHyperscalePolicy64 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallHyperscalePolicy64 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallHyperscalePolicy64Read(d, m)
+	return resourcePackagesFirewallHyperscalePolicy64Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallHyperscalePolicy64Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy64Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -239,7 +236,7 @@ func resourcePackagesFirewallHyperscalePolicy64Delete(d *schema.ResourceData, m
 
 	err = c.DeletePackagesFirewallHyperscalePolicy64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallHyperscalePolicy64 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallHyperscalePolicy64 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -247,17 +244,16 @@ func resourcePackagesFirewallHyperscalePolicy64Delete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourcePackagesFirewallHyperscalePolicy64Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallHyperscalePolicy64Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -265,25 +261,28 @@ func resourcePackagesFirewallHyperscalePolicy64Read(d *schema.ResourceData, m in
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallHyperscalePolicy64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy64 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy64 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy64 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallHyperscalePolicy64(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallHyperscalePolicy64 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallHyperscalePolicy64 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallInterfacePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallInterfacePolicyCreate,
-		Read:   resourcePackagesFirewallInterfacePolicyRead,
-		Update: resourcePackagesFirewallInterfacePolicyUpdate,
-		Delete: resourcePackagesFirewallInterfacePolicyDelete,
+		CreateContext: resourcePackagesFirewallInterfacePolicyCreate,
+		ReadContext:   resourcePackagesFirewallInterfacePolicyRead,
+		UpdateContext: resourcePackagesFirewallInterfacePolicyUpdate,
+		DeleteContext: resourcePackagesFirewallInterfacePolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -193,15 +195,14 @@ func resourcePackagesFirewallInterfacePolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallInterfacePolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallInterfacePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -210,30 +211,29 @@ func resourcePackagesFirewallInterfacePolicyCreate(d *schema.ResourceData, m int
 
 	obj, err := getObjectPackagesFirewallInterfacePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallInterfacePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallInterfacePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallInterfacePolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallInterfacePolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallInterfacePolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallInterfacePolicyRead(d, m)
+	return resourcePackagesFirewallInterfacePolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallInterfacePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -242,32 +242,29 @@ func resourcePackagesFirewallInterfacePolicyUpdate(d *schema.ResourceData, m int
 
 	obj, err := getObjectPackagesFirewallInterfacePolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallInterfacePolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallInterfacePolicyRead(d, m)
+	return resourcePackagesFirewallInterfacePolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallInterfacePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -276,7 +273,7 @@ func resourcePackagesFirewallInterfacePolicyDelete(d *schema.ResourceData, m int
 
 	err = c.DeletePackagesFirewallInterfacePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallInterfacePolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallInterfacePolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -284,17 +281,16 @@ func resourcePackagesFirewallInterfacePolicyDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourcePackagesFirewallInterfacePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -302,25 +298,28 @@ func resourcePackagesFirewallInterfacePolicyRead(d *schema.ResourceData, m inter
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallInterfacePolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallInterfacePolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy resource from API: %v", err)
 	}
 	return nil
 }
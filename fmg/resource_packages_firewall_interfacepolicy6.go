@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallInterfacePolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallInterfacePolicy6Create,
-		Read:   resourcePackagesFirewallInterfacePolicy6Read,
-		Update: resourcePackagesFirewallInterfacePolicy6Update,
-		Delete: resourcePackagesFirewallInterfacePolicy6Delete,
+		CreateContext: resourcePackagesFirewallInterfacePolicy6Create,
+		ReadContext:   resourcePackagesFirewallInterfacePolicy6Read,
+		UpdateContext: resourcePackagesFirewallInterfacePolicy6Update,
+		DeleteContext: resourcePackagesFirewallInterfacePolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -193,15 +195,14 @@ func resourcePackagesFirewallInterfacePolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallInterfacePolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallInterfacePolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -210,30 +211,29 @@ func resourcePackagesFirewallInterfacePolicy6Create(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallInterfacePolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallInterfacePolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallInterfacePolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallInterfacePolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallInterfacePolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallInterfacePolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallInterfacePolicy6Read(d, m)
+	return resourcePackagesFirewallInterfacePolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallInterfacePolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -242,32 +242,29 @@ func resourcePackagesFirewallInterfacePolicy6Update(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallInterfacePolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallInterfacePolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallInterfacePolicy6Read(d, m)
+	return resourcePackagesFirewallInterfacePolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallInterfacePolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -276,7 +273,7 @@ func resourcePackagesFirewallInterfacePolicy6Delete(d *schema.ResourceData, m in
 
 	err = c.DeletePackagesFirewallInterfacePolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallInterfacePolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallInterfacePolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -284,17 +281,16 @@ func resourcePackagesFirewallInterfacePolicy6Delete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourcePackagesFirewallInterfacePolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -302,25 +298,28 @@ func resourcePackagesFirewallInterfacePolicy6Read(d *schema.ResourceData, m inte
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallInterfacePolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallInterfacePolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6 resource from API: %v", err)
 	}
 	return nil
 }
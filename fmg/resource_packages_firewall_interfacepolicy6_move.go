@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallInterfacePolicy6Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallInterfacePolicy6MoveUpdate,
-		Read:   resourcePackagesFirewallInterfacePolicy6MoveRead,
-		Update: resourcePackagesFirewallInterfacePolicy6MoveUpdate,
-		Delete: resourcePackagesFirewallInterfacePolicy6MoveDelete,
+		CreateContext: resourcePackagesFirewallInterfacePolicy6MoveUpdate,
+		ReadContext:   resourcePackagesFirewallInterfacePolicy6MoveRead,
+		UpdateContext: resourcePackagesFirewallInterfacePolicy6MoveUpdate,
+		DeleteContext: resourcePackagesFirewallInterfacePolicy6MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallInterfacePolicy6Move() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallInterfacePolicy6MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallInterfacePolicy6MoveUpdate(d *schema.ResourceData,
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallInterfacePolicy6Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy6Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy6Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallInterfacePolicy6Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallInterfacePolicy6Move resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallInterfacePolicy6Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallInterfacePolicy6Move" + "_" + pkg + "_" + interface_policy6 + "_" + target)
 
-	return resourcePackagesFirewallInterfacePolicy6MoveRead(d, m)
+	return resourcePackagesFirewallInterfacePolicy6MoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallInterfacePolicy6MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallInterfacePolicy6MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallInterfacePolicy6MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("interface_policy6").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallInterfacePolicy6MoveRead(d *schema.ResourceData, m
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallInterfacePolicy6Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallInterfacePolicy6MoveRead(d *schema.ResourceData, m
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallInterfacePolicy6MoveRead(d *schema.ResourceData, m
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallInterfacePolicy6Move resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallLocalInPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallLocalInPolicyCreate,
-		Read:   resourcePackagesFirewallLocalInPolicyRead,
-		Update: resourcePackagesFirewallLocalInPolicyUpdate,
-		Delete: resourcePackagesFirewallLocalInPolicyDelete,
+		CreateContext: resourcePackagesFirewallLocalInPolicyCreate,
+		ReadContext:   resourcePackagesFirewallLocalInPolicyRead,
+		UpdateContext: resourcePackagesFirewallLocalInPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallLocalInPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -126,15 +128,14 @@ func resourcePackagesFirewallLocalInPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallLocalInPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallLocalInPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -143,30 +144,29 @@ func resourcePackagesFirewallLocalInPolicyCreate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectPackagesFirewallLocalInPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallLocalInPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallLocalInPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallLocalInPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallLocalInPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallLocalInPolicyRead(d, m)
+	return resourcePackagesFirewallLocalInPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallLocalInPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -175,32 +175,29 @@ func resourcePackagesFirewallLocalInPolicyUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectPackagesFirewallLocalInPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallLocalInPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallLocalInPolicyRead(d, m)
+	return resourcePackagesFirewallLocalInPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallLocalInPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -209,7 +206,7 @@ func resourcePackagesFirewallLocalInPolicyDelete(d *schema.ResourceData, m inter
 
 	err = c.DeletePackagesFirewallLocalInPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallLocalInPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -217,17 +214,16 @@ func resourcePackagesFirewallLocalInPolicyDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourcePackagesFirewallLocalInPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -235,25 +231,28 @@ func resourcePackagesFirewallLocalInPolicyRead(d *schema.ResourceData, m interfa
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallLocalInPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallLocalInPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy resource from API: %v", err)
 	}
 	return nil
 }
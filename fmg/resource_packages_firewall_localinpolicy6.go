@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallLocalInPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallLocalInPolicy6Create,
-		Read:   resourcePackagesFirewallLocalInPolicy6Read,
-		Update: resourcePackagesFirewallLocalInPolicy6Update,
-		Delete: resourcePackagesFirewallLocalInPolicy6Delete,
+		CreateContext: resourcePackagesFirewallLocalInPolicy6Create,
+		ReadContext:   resourcePackagesFirewallLocalInPolicy6Read,
+		UpdateContext: resourcePackagesFirewallLocalInPolicy6Update,
+		DeleteContext: resourcePackagesFirewallLocalInPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -121,15 +123,14 @@ func resourcePackagesFirewallLocalInPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallLocalInPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallLocalInPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -138,30 +139,29 @@ func resourcePackagesFirewallLocalInPolicy6Create(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallLocalInPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallLocalInPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallLocalInPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallLocalInPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallLocalInPolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallLocalInPolicy6Read(d, m)
+	return resourcePackagesFirewallLocalInPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallLocalInPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -170,32 +170,29 @@ func resourcePackagesFirewallLocalInPolicy6Update(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallLocalInPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallLocalInPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallLocalInPolicy6Read(d, m)
+	return resourcePackagesFirewallLocalInPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallLocalInPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -204,7 +201,7 @@ func resourcePackagesFirewallLocalInPolicy6Delete(d *schema.ResourceData, m inte
 
 	err = c.DeletePackagesFirewallLocalInPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallLocalInPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -212,17 +209,16 @@ func resourcePackagesFirewallLocalInPolicy6Delete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourcePackagesFirewallLocalInPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -230,25 +226,28 @@ func resourcePackagesFirewallLocalInPolicy6Read(d *schema.ResourceData, m interf
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallLocalInPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallLocalInPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
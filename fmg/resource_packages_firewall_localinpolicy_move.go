@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallLocalInPolicyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallLocalInPolicyMoveUpdate,
-		Read:   resourcePackagesFirewallLocalInPolicyMoveRead,
-		Update: resourcePackagesFirewallLocalInPolicyMoveUpdate,
-		Delete: resourcePackagesFirewallLocalInPolicyMoveDelete,
+		CreateContext: resourcePackagesFirewallLocalInPolicyMoveUpdate,
+		ReadContext:   resourcePackagesFirewallLocalInPolicyMoveRead,
+		UpdateContext: resourcePackagesFirewallLocalInPolicyMoveUpdate,
+		DeleteContext: resourcePackagesFirewallLocalInPolicyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallLocalInPolicyMove() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallLocalInPolicyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallLocalInPolicyMoveUpdate(d *schema.ResourceData, m i
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallLocalInPolicyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallLocalInPolicyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallLocalInPolicyMove resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallLocalInPolicyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallLocalInPolicyMove" + "_" + pkg + "_" + local_in_policy + "_" + target)
 
-	return resourcePackagesFirewallLocalInPolicyMoveRead(d, m)
+	return resourcePackagesFirewallLocalInPolicyMoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallLocalInPolicyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallLocalInPolicyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallLocalInPolicyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("local_in_policy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallLocalInPolicyMoveRead(d *schema.ResourceData, m int
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallLocalInPolicyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallLocalInPolicyMoveRead(d *schema.ResourceData, m int
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallLocalInPolicyMoveRead(d *schema.ResourceData, m int
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallLocalInPolicyMove resource: no valid map string.")
 			}
 
 			i += 1
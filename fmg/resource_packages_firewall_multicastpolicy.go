@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallMulticastPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallMulticastPolicyCreate,
-		Read:   resourcePackagesFirewallMulticastPolicyRead,
-		Update: resourcePackagesFirewallMulticastPolicyUpdate,
-		Delete: resourcePackagesFirewallMulticastPolicyDelete,
+		CreateContext: resourcePackagesFirewallMulticastPolicyCreate,
+		ReadContext:   resourcePackagesFirewallMulticastPolicyRead,
+		UpdateContext: resourcePackagesFirewallMulticastPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallMulticastPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -145,15 +147,14 @@ func resourcePackagesFirewallMulticastPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallMulticastPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallMulticastPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -162,30 +163,29 @@ func resourcePackagesFirewallMulticastPolicyCreate(d *schema.ResourceData, m int
 
 	obj, err := getObjectPackagesFirewallMulticastPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallMulticastPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallMulticastPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallMulticastPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallMulticastPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallMulticastPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallMulticastPolicyRead(d, m)
+	return resourcePackagesFirewallMulticastPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallMulticastPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -194,32 +194,29 @@ func resourcePackagesFirewallMulticastPolicyUpdate(d *schema.ResourceData, m int
 
 	obj, err := getObjectPackagesFirewallMulticastPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallMulticastPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallMulticastPolicyRead(d, m)
+	return resourcePackagesFirewallMulticastPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallMulticastPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -228,7 +225,7 @@ func resourcePackagesFirewallMulticastPolicyDelete(d *schema.ResourceData, m int
 
 	err = c.DeletePackagesFirewallMulticastPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallMulticastPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallMulticastPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -236,17 +233,16 @@ func resourcePackagesFirewallMulticastPolicyDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourcePackagesFirewallMulticastPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -254,25 +250,28 @@ func resourcePackagesFirewallMulticastPolicyRead(d *schema.ResourceData, m inter
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallMulticastPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallMulticastPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy resource from API: %v", err)
 	}
 	return nil
 }
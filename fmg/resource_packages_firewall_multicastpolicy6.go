@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallMulticastPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallMulticastPolicy6Create,
-		Read:   resourcePackagesFirewallMulticastPolicy6Read,
-		Update: resourcePackagesFirewallMulticastPolicy6Update,
-		Delete: resourcePackagesFirewallMulticastPolicy6Delete,
+		CreateContext: resourcePackagesFirewallMulticastPolicy6Create,
+		ReadContext:   resourcePackagesFirewallMulticastPolicy6Read,
+		UpdateContext: resourcePackagesFirewallMulticastPolicy6Update,
+		DeleteContext: resourcePackagesFirewallMulticastPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -130,15 +132,14 @@ func resourcePackagesFirewallMulticastPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallMulticastPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallMulticastPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -147,30 +148,29 @@ func resourcePackagesFirewallMulticastPolicy6Create(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallMulticastPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallMulticastPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallMulticastPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallMulticastPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallMulticastPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallMulticastPolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallMulticastPolicy6Read(d, m)
+	return resourcePackagesFirewallMulticastPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallMulticastPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -179,32 +179,29 @@ func resourcePackagesFirewallMulticastPolicy6Update(d *schema.ResourceData, m in
 
 	obj, err := getObjectPackagesFirewallMulticastPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallMulticastPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallMulticastPolicy6Read(d, m)
+	return resourcePackagesFirewallMulticastPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallMulticastPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -213,7 +210,7 @@ func resourcePackagesFirewallMulticastPolicy6Delete(d *schema.ResourceData, m in
 
 	err = c.DeletePackagesFirewallMulticastPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallMulticastPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallMulticastPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -221,17 +218,16 @@ func resourcePackagesFirewallMulticastPolicy6Delete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourcePackagesFirewallMulticastPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -239,25 +235,28 @@ func resourcePackagesFirewallMulticastPolicy6Read(d *schema.ResourceData, m inte
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallMulticastPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallMulticastPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallMulticastPolicy6Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallMulticastPolicy6MoveUpdate,
-		Read:   resourcePackagesFirewallMulticastPolicy6MoveRead,
-		Update: resourcePackagesFirewallMulticastPolicy6MoveUpdate,
-		Delete: resourcePackagesFirewallMulticastPolicy6MoveDelete,
+		CreateContext: resourcePackagesFirewallMulticastPolicy6MoveUpdate,
+		ReadContext:   resourcePackagesFirewallMulticastPolicy6MoveRead,
+		UpdateContext: resourcePackagesFirewallMulticastPolicy6MoveUpdate,
+		DeleteContext: resourcePackagesFirewallMulticastPolicy6MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallMulticastPolicy6Move() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallMulticastPolicy6MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallMulticastPolicy6MoveUpdate(d *schema.ResourceData,
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallMulticastPolicy6Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy6Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy6Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallMulticastPolicy6Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallMulticastPolicy6Move resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallMulticastPolicy6Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallMulticastPolicy6Move" + "_" + pkg + "_" + multicast_policy6 + "_" + target)
 
-	return resourcePackagesFirewallMulticastPolicy6MoveRead(d, m)
+	return resourcePackagesFirewallMulticastPolicy6MoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallMulticastPolicy6MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallMulticastPolicy6MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallMulticastPolicy6MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("multicast_policy6").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallMulticastPolicy6MoveRead(d *schema.ResourceData, m
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallMulticastPolicy6Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallMulticastPolicy6MoveRead(d *schema.ResourceData, m
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["id"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: id doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: id doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["id"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: wrong id.")
+					return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: wrong id.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallMulticastPolicy6MoveRead(d *schema.ResourceData, m
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallMulticastPolicy6Move resource: no valid map string.")
 			}
 
 			i += 1
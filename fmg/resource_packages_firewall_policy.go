@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicyCreate,
-		Read:   resourcePackagesFirewallPolicyRead,
-		Update: resourcePackagesFirewallPolicyUpdate,
-		Delete: resourcePackagesFirewallPolicyDelete,
+		CreateContext: resourcePackagesFirewallPolicyCreate,
+		ReadContext:   resourcePackagesFirewallPolicyRead,
+		UpdateContext: resourcePackagesFirewallPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1003,15 +1005,14 @@ func resourcePackagesFirewallPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1020,39 +1021,38 @@ func resourcePackagesFirewallPolicyCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectPackagesFirewallPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesFirewallPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesFirewallPolicyRead(d, m)
+			return resourcePackagesFirewallPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicyRead(d, m)
+	return resourcePackagesFirewallPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1061,32 +1061,29 @@ func resourcePackagesFirewallPolicyUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectPackagesFirewallPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicyRead(d, m)
+	return resourcePackagesFirewallPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1095,7 +1092,7 @@ func resourcePackagesFirewallPolicyDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeletePackagesFirewallPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1103,17 +1100,16 @@ func resourcePackagesFirewallPolicyDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourcePackagesFirewallPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1121,25 +1117,28 @@ func resourcePackagesFirewallPolicyRead(d *schema.ResourceData, m interface{}) e
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicy46() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicy46Create,
-		Read:   resourcePackagesFirewallPolicy46Read,
-		Update: resourcePackagesFirewallPolicy46Update,
-		Delete: resourcePackagesFirewallPolicy46Delete,
+		CreateContext: resourcePackagesFirewallPolicy46Create,
+		ReadContext:   resourcePackagesFirewallPolicy46Read,
+		UpdateContext: resourcePackagesFirewallPolicy46Update,
+		DeleteContext: resourcePackagesFirewallPolicy46Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -168,15 +170,14 @@ func resourcePackagesFirewallPolicy46() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicy46Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallPolicy46Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -185,30 +186,29 @@ func resourcePackagesFirewallPolicy46Create(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectPackagesFirewallPolicy46(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy46 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy46 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallPolicy46(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy46 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy46 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy46Read(d, m)
+	return resourcePackagesFirewallPolicy46Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy46Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy46Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -217,32 +217,29 @@ func resourcePackagesFirewallPolicy46Update(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectPackagesFirewallPolicy46(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy46 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy46 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicy46(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy46 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy46 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy46Read(d, m)
+	return resourcePackagesFirewallPolicy46Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy46Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy46Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -251,7 +248,7 @@ func resourcePackagesFirewallPolicy46Delete(d *schema.ResourceData, m interface{
 
 	err = c.DeletePackagesFirewallPolicy46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallPolicy46 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallPolicy46 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -259,17 +256,16 @@ func resourcePackagesFirewallPolicy46Delete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourcePackagesFirewallPolicy46Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy46Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -277,25 +273,28 @@ func resourcePackagesFirewallPolicy46Read(d *schema.ResourceData, m interface{})
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicy46(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy46 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy46 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallPolicy46 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallPolicy46(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy46 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy46 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicy6Create,
-		Read:   resourcePackagesFirewallPolicy6Read,
-		Update: resourcePackagesFirewallPolicy6Update,
-		Delete: resourcePackagesFirewallPolicy6Delete,
+		CreateContext: resourcePackagesFirewallPolicy6Create,
+		ReadContext:   resourcePackagesFirewallPolicy6Read,
+		UpdateContext: resourcePackagesFirewallPolicy6Update,
+		DeleteContext: resourcePackagesFirewallPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -452,15 +454,14 @@ func resourcePackagesFirewallPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -469,30 +470,29 @@ func resourcePackagesFirewallPolicy6Create(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectPackagesFirewallPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy6Read(d, m)
+	return resourcePackagesFirewallPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -501,32 +501,29 @@ func resourcePackagesFirewallPolicy6Update(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectPackagesFirewallPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy6Read(d, m)
+	return resourcePackagesFirewallPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -535,7 +532,7 @@ func resourcePackagesFirewallPolicy6Delete(d *schema.ResourceData, m interface{}
 
 	err = c.DeletePackagesFirewallPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -543,17 +540,16 @@ func resourcePackagesFirewallPolicy6Delete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourcePackagesFirewallPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -561,25 +557,28 @@ func resourcePackagesFirewallPolicy6Read(d *schema.ResourceData, m interface{})
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicy64() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicy64Create,
-		Read:   resourcePackagesFirewallPolicy64Read,
-		Update: resourcePackagesFirewallPolicy64Update,
-		Delete: resourcePackagesFirewallPolicy64Delete,
+		CreateContext: resourcePackagesFirewallPolicy64Create,
+		ReadContext:   resourcePackagesFirewallPolicy64Read,
+		UpdateContext: resourcePackagesFirewallPolicy64Update,
+		DeleteContext: resourcePackagesFirewallPolicy64Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -191,15 +193,14 @@ func resourcePackagesFirewallPolicy64() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicy64Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallPolicy64Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -208,30 +209,29 @@ func resourcePackagesFirewallPolicy64Create(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectPackagesFirewallPolicy64(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy64 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy64 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallPolicy64(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy64 resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy64 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy64Read(d, m)
+	return resourcePackagesFirewallPolicy64Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy64Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -240,32 +240,29 @@ func resourcePackagesFirewallPolicy64Update(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectPackagesFirewallPolicy64(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy64 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy64 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicy64(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy64 resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy64 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicy64Read(d, m)
+	return resourcePackagesFirewallPolicy64Read(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy64Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -274,7 +271,7 @@ func resourcePackagesFirewallPolicy64Delete(d *schema.ResourceData, m interface{
 
 	err = c.DeletePackagesFirewallPolicy64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallPolicy64 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallPolicy64 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -282,17 +279,16 @@ func resourcePackagesFirewallPolicy64Delete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourcePackagesFirewallPolicy64Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -300,25 +296,28 @@ func resourcePackagesFirewallPolicy64Read(d *schema.ResourceData, m interface{})
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicy64(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy64 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy64 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallPolicy64 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallPolicy64(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy64 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy64 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicy64Move() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicy64MoveUpdate,
-		Read:   resourcePackagesFirewallPolicy64MoveRead,
-		Update: resourcePackagesFirewallPolicy64MoveUpdate,
-		Delete: resourcePackagesFirewallPolicy64MoveDelete,
+		CreateContext: resourcePackagesFirewallPolicy64MoveUpdate,
+		ReadContext:   resourcePackagesFirewallPolicy64MoveRead,
+		UpdateContext: resourcePackagesFirewallPolicy64MoveUpdate,
+		DeleteContext: resourcePackagesFirewallPolicy64MoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallPolicy64Move() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicy64MoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64MoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallPolicy64MoveUpdate(d *schema.ResourceData, m interf
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallPolicy64Move(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy64Move resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy64Move resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicy64Move(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy64Move resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy64Move resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallPolicy64Move" + "_" + pkg + "_" + policy64 + "_" + target)
 
-	return resourcePackagesFirewallPolicy64MoveRead(d, m)
+	return resourcePackagesFirewallPolicy64MoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicy64MoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64MoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallPolicy64MoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicy64MoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("policy64").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallPolicy64MoveRead(d *schema.ResourceData, m interfac
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicy64Move(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallPolicy64MoveRead(d *schema.ResourceData, m interfac
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallPolicy64MoveRead(d *schema.ResourceData, m interfac
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallPolicy64Move resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallPolicy64Move resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicyBlock() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicyBlockCreate,
-		Read:   resourcePackagesFirewallPolicyBlockRead,
-		Update: resourcePackagesFirewallPolicyBlockUpdate,
-		Delete: resourcePackagesFirewallPolicyBlockDelete,
+		CreateContext: resourcePackagesFirewallPolicyBlockCreate,
+		ReadContext:   resourcePackagesFirewallPolicyBlockRead,
+		UpdateContext: resourcePackagesFirewallPolicyBlockUpdate,
+		DeleteContext: resourcePackagesFirewallPolicyBlockDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1003,15 +1005,14 @@ func resourcePackagesFirewallPolicyBlock() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicyBlockCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallPolicyBlockCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1020,39 +1021,38 @@ func resourcePackagesFirewallPolicyBlockCreate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesFirewallPolicyBlock(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesFirewallPolicyBlock(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesFirewallPolicyBlockRead(d, m)
+			return resourcePackagesFirewallPolicyBlockRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesFirewallPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicyBlockRead(d, m)
+	return resourcePackagesFirewallPolicyBlockRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicyBlockUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyBlockUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1061,32 +1061,29 @@ func resourcePackagesFirewallPolicyBlockUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesFirewallPolicyBlock(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicyBlock(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallPolicyBlockRead(d, m)
+	return resourcePackagesFirewallPolicyBlockRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicyBlockDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyBlockDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1095,7 +1092,7 @@ func resourcePackagesFirewallPolicyBlockDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeletePackagesFirewallPolicyBlock(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1103,17 +1100,16 @@ func resourcePackagesFirewallPolicyBlockDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourcePackagesFirewallPolicyBlockRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyBlockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -1121,25 +1117,28 @@ func resourcePackagesFirewallPolicyBlockRead(d *schema.ResourceData, m interface
 	if pblock == "" {
 		pblock = importOptionChecking(m.(*FortiClient).Cfg, "pblock")
 		if err = d.Set("pblock", pblock); err != nil {
-			return fmt.Errorf("Error set params pblock: %v", err)
+			return diag.Errorf("Error set params pblock: %v", err)
 		}
 	}
 	paradict["pblock"] = pblock
 
 	o, err := c.ReadPackagesFirewallPolicyBlock(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallPolicyBlock(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicy resource from API: %v", err)
 	}
 	return nil
 }
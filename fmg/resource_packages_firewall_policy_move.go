@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallPolicyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallPolicyMoveUpdate,
-		Read:   resourcePackagesFirewallPolicyMoveRead,
-		Update: resourcePackagesFirewallPolicyMoveUpdate,
-		Delete: resourcePackagesFirewallPolicyMoveDelete,
+		CreateContext: resourcePackagesFirewallPolicyMoveUpdate,
+		ReadContext:   resourcePackagesFirewallPolicyMoveRead,
+		UpdateContext: resourcePackagesFirewallPolicyMoveUpdate,
+		DeleteContext: resourcePackagesFirewallPolicyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesFirewallPolicyMove() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallPolicyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesFirewallPolicyMoveUpdate(d *schema.ResourceData, m interfac
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesFirewallPolicyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallPolicyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallPolicyMove resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallPolicyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesFirewallPolicyMove" + "_" + pkg + "_" + policy + "_" + target)
 
-	return resourcePackagesFirewallPolicyMoveRead(d, m)
+	return resourcePackagesFirewallPolicyMoveRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallPolicyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesFirewallPolicyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallPolicyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("policy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesFirewallPolicyMoveRead(d *schema.ResourceData, m interface{
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallPolicyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesFirewallPolicyMoveRead(d *schema.ResourceData, m interface{
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["policyid"]; !ok {
-					return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: policyid doesn't exist.")
+					return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: policyid doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["policyid"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: wrong policyid.")
+					return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: wrong policyid.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesFirewallPolicyMoveRead(d *schema.ResourceData, m interface{
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesFirewallPolicyMove resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesFirewallPolicyMove resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallProxyPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallProxyPolicyCreate,
-		Read:   resourcePackagesFirewallProxyPolicyRead,
-		Update: resourcePackagesFirewallProxyPolicyUpdate,
-		Delete: resourcePackagesFirewallProxyPolicyDelete,
+		CreateContext: resourcePackagesFirewallProxyPolicyCreate,
+		ReadContext:   resourcePackagesFirewallProxyPolicyRead,
+		UpdateContext: resourcePackagesFirewallProxyPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallProxyPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -386,15 +388,14 @@ func resourcePackagesFirewallProxyPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallProxyPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallProxyPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -403,30 +404,29 @@ func resourcePackagesFirewallProxyPolicyCreate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesFirewallProxyPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallProxyPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallProxyPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallProxyPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallProxyPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallProxyPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallProxyPolicyRead(d, m)
+	return resourcePackagesFirewallProxyPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallProxyPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallProxyPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -435,32 +435,29 @@ func resourcePackagesFirewallProxyPolicyUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesFirewallProxyPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallProxyPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallProxyPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallProxyPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallProxyPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallProxyPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallProxyPolicyRead(d, m)
+	return resourcePackagesFirewallProxyPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallProxyPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallProxyPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -469,7 +466,7 @@ func resourcePackagesFirewallProxyPolicyDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeletePackagesFirewallProxyPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallProxyPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallProxyPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -477,17 +474,16 @@ func resourcePackagesFirewallProxyPolicyDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourcePackagesFirewallProxyPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallProxyPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -495,25 +491,28 @@ func resourcePackagesFirewallProxyPolicyRead(d *schema.ResourceData, m interface
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallProxyPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallProxyPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallProxyPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallProxyPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallProxyPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallProxyPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallProxyPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallSecurityPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallSecurityPolicyCreate,
-		Read:   resourcePackagesFirewallSecurityPolicyRead,
-		Update: resourcePackagesFirewallSecurityPolicyUpdate,
-		Delete: resourcePackagesFirewallSecurityPolicyDelete,
+		CreateContext: resourcePackagesFirewallSecurityPolicyCreate,
+		ReadContext:   resourcePackagesFirewallSecurityPolicyRead,
+		UpdateContext: resourcePackagesFirewallSecurityPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallSecurityPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -402,15 +404,14 @@ func resourcePackagesFirewallSecurityPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallSecurityPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -419,30 +420,29 @@ func resourcePackagesFirewallSecurityPolicyCreate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallSecurityPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallSecurityPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallSecurityPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallSecurityPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallSecurityPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallSecurityPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallSecurityPolicyRead(d, m)
+	return resourcePackagesFirewallSecurityPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallSecurityPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallSecurityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -451,32 +451,29 @@ func resourcePackagesFirewallSecurityPolicyUpdate(d *schema.ResourceData, m inte
 
 	obj, err := getObjectPackagesFirewallSecurityPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallSecurityPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallSecurityPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallSecurityPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallSecurityPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallSecurityPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesFirewallSecurityPolicyRead(d, m)
+	return resourcePackagesFirewallSecurityPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallSecurityPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -485,7 +482,7 @@ func resourcePackagesFirewallSecurityPolicyDelete(d *schema.ResourceData, m inte
 
 	err = c.DeletePackagesFirewallSecurityPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallSecurityPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallSecurityPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -493,17 +490,16 @@ func resourcePackagesFirewallSecurityPolicyDelete(d *schema.ResourceData, m inte
 	return nil
 }
 
-func resourcePackagesFirewallSecurityPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallSecurityPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -511,25 +507,28 @@ func resourcePackagesFirewallSecurityPolicyRead(d *schema.ResourceData, m interf
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallSecurityPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallSecurityPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallSecurityPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallSecurityPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallSecurityPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallSecurityPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallSecurityPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesFirewallShapingPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesFirewallShapingPolicyCreate,
-		Read:   resourcePackagesFirewallShapingPolicyRead,
-		Update: resourcePackagesFirewallShapingPolicyUpdate,
-		Delete: resourcePackagesFirewallShapingPolicyDelete,
+		CreateContext: resourcePackagesFirewallShapingPolicyCreate,
+		ReadContext:   resourcePackagesFirewallShapingPolicyRead,
+		UpdateContext: resourcePackagesFirewallShapingPolicyUpdate,
+		DeleteContext: resourcePackagesFirewallShapingPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -268,15 +270,14 @@ func resourcePackagesFirewallShapingPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesFirewallShapingPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesFirewallShapingPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -285,30 +286,29 @@ func resourcePackagesFirewallShapingPolicyCreate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectPackagesFirewallShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallShapingPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesFirewallShapingPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesFirewallShapingPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesFirewallShapingPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallShapingPolicyRead(d, m)
+	return resourcePackagesFirewallShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallShapingPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallShapingPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -317,32 +317,29 @@ func resourcePackagesFirewallShapingPolicyUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectPackagesFirewallShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallShapingPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesFirewallShapingPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesFirewallShapingPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesFirewallShapingPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesFirewallShapingPolicyRead(d, m)
+	return resourcePackagesFirewallShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesFirewallShapingPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallShapingPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -351,7 +348,7 @@ func resourcePackagesFirewallShapingPolicyDelete(d *schema.ResourceData, m inter
 
 	err = c.DeletePackagesFirewallShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesFirewallShapingPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesFirewallShapingPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -359,17 +356,16 @@ func resourcePackagesFirewallShapingPolicyDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourcePackagesFirewallShapingPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesFirewallShapingPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -377,25 +373,28 @@ func resourcePackagesFirewallShapingPolicyRead(d *schema.ResourceData, m interfa
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesFirewallShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallShapingPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesFirewallShapingPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesFirewallShapingPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesFirewallShapingPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesFirewallShapingPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesFirewallShapingPolicy resource from API: %v", err)
 	}
 	return nil
 }
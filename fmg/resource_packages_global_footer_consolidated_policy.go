@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalFooterConsolidatedPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalFooterConsolidatedPolicyCreate,
-		Read:   resourcePackagesGlobalFooterConsolidatedPolicyRead,
-		Update: resourcePackagesGlobalFooterConsolidatedPolicyUpdate,
-		Delete: resourcePackagesGlobalFooterConsolidatedPolicyDelete,
+		CreateContext: resourcePackagesGlobalFooterConsolidatedPolicyCreate,
+		ReadContext:   resourcePackagesGlobalFooterConsolidatedPolicyRead,
+		UpdateContext: resourcePackagesGlobalFooterConsolidatedPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalFooterConsolidatedPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -453,9 +455,8 @@ func resourcePackagesGlobalFooterConsolidatedPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalFooterConsolidatedPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalFooterConsolidatedPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -466,33 +467,32 @@ func resourcePackagesGlobalFooterConsolidatedPolicyCreate(d *schema.ResourceData
 
 	obj, err := getObjectPackagesGlobalFooterConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalFooterConsolidatedPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalFooterConsolidatedPolicyRead(d, m)
+			return resourcePackagesGlobalFooterConsolidatedPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterConsolidatedPolicyRead(d, m)
+	return resourcePackagesGlobalFooterConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterConsolidatedPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterConsolidatedPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -503,26 +503,23 @@ func resourcePackagesGlobalFooterConsolidatedPolicyUpdate(d *schema.ResourceData
 
 	obj, err := getObjectPackagesGlobalFooterConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalFooterConsolidatedPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterConsolidatedPolicyRead(d, m)
+	return resourcePackagesGlobalFooterConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterConsolidatedPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterConsolidatedPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -533,7 +530,7 @@ func resourcePackagesGlobalFooterConsolidatedPolicyDelete(d *schema.ResourceData
 
 	err = c.DeletePackagesGlobalFooterConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -541,11 +538,10 @@ func resourcePackagesGlobalFooterConsolidatedPolicyDelete(d *schema.ResourceData
 	return nil
 }
 
-func resourcePackagesGlobalFooterConsolidatedPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterConsolidatedPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -555,25 +551,28 @@ func resourcePackagesGlobalFooterConsolidatedPolicyRead(d *schema.ResourceData,
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalFooterConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalFooterConsolidatedPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalFooterConsolidatedPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalFooterConsolidatedPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterConsolidatedPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalFooterConsolidatedPolicy resource from API: %v", err)
 	}
 	return nil
 }
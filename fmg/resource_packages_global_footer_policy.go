@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalFooterPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalFooterPolicyCreate,
-		Read:   resourcePackagesGlobalFooterPolicyRead,
-		Update: resourcePackagesGlobalFooterPolicyUpdate,
-		Delete: resourcePackagesGlobalFooterPolicyDelete,
+		CreateContext: resourcePackagesGlobalFooterPolicyCreate,
+		ReadContext:   resourcePackagesGlobalFooterPolicyRead,
+		UpdateContext: resourcePackagesGlobalFooterPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalFooterPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1209,9 +1211,8 @@ func resourcePackagesGlobalFooterPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalFooterPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalFooterPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1222,33 +1223,32 @@ func resourcePackagesGlobalFooterPolicyCreate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesGlobalFooterPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalFooterPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalFooterPolicyRead(d, m)
+			return resourcePackagesGlobalFooterPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalFooterPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalFooterPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterPolicyRead(d, m)
+	return resourcePackagesGlobalFooterPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1259,26 +1259,23 @@ func resourcePackagesGlobalFooterPolicyUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesGlobalFooterPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalFooterPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterPolicyRead(d, m)
+	return resourcePackagesGlobalFooterPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1289,7 +1286,7 @@ func resourcePackagesGlobalFooterPolicyDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeletePackagesGlobalFooterPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalFooterPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalFooterPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1297,11 +1294,10 @@ func resourcePackagesGlobalFooterPolicyDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourcePackagesGlobalFooterPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1311,25 +1307,28 @@ func resourcePackagesGlobalFooterPolicyRead(d *schema.ResourceData, m interface{
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalFooterPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalFooterPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy resource from API: %v", err)
 	}
 	return nil
 }
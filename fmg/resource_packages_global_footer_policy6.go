@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalFooterPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalFooterPolicy6Create,
-		Read:   resourcePackagesGlobalFooterPolicy6Read,
-		Update: resourcePackagesGlobalFooterPolicy6Update,
-		Delete: resourcePackagesGlobalFooterPolicy6Delete,
+		CreateContext: resourcePackagesGlobalFooterPolicy6Create,
+		ReadContext:   resourcePackagesGlobalFooterPolicy6Read,
+		UpdateContext: resourcePackagesGlobalFooterPolicy6Update,
+		DeleteContext: resourcePackagesGlobalFooterPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -552,9 +554,8 @@ func resourcePackagesGlobalFooterPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalFooterPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalFooterPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -565,33 +566,32 @@ func resourcePackagesGlobalFooterPolicy6Create(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesGlobalFooterPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterPolicy6 resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalFooterPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterPolicy6 resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalFooterPolicy6Read(d, m)
+			return resourcePackagesGlobalFooterPolicy6Read(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalFooterPolicy6 resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalFooterPolicy6 resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterPolicy6Read(d, m)
+	return resourcePackagesGlobalFooterPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -602,26 +602,23 @@ func resourcePackagesGlobalFooterPolicy6Update(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesGlobalFooterPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalFooterPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalFooterPolicy6Read(d, m)
+	return resourcePackagesGlobalFooterPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -632,7 +629,7 @@ func resourcePackagesGlobalFooterPolicy6Delete(d *schema.ResourceData, m interfa
 
 	err = c.DeletePackagesGlobalFooterPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalFooterPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalFooterPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -640,11 +637,10 @@ func resourcePackagesGlobalFooterPolicy6Delete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourcePackagesGlobalFooterPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -654,25 +650,28 @@ func resourcePackagesGlobalFooterPolicy6Read(d *schema.ResourceData, m interface
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalFooterPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalFooterPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalFooterPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalFooterShapingPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalFooterShapingPolicyCreate,
-		Read:   resourcePackagesGlobalFooterShapingPolicyRead,
-		Update: resourcePackagesGlobalFooterShapingPolicyUpdate,
-		Delete: resourcePackagesGlobalFooterShapingPolicyDelete,
+		CreateContext: resourcePackagesGlobalFooterShapingPolicyCreate,
+		ReadContext:   resourcePackagesGlobalFooterShapingPolicyRead,
+		UpdateContext: resourcePackagesGlobalFooterShapingPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalFooterShapingPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -237,9 +239,8 @@ func resourcePackagesGlobalFooterShapingPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalFooterShapingPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalFooterShapingPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -250,33 +251,32 @@ func resourcePackagesGlobalFooterShapingPolicyCreate(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesGlobalFooterShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalFooterShapingPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource: %v", err)
 	}
 
 	if v != nil && v["id"] != nil {
 		if vidn, ok := v["id"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalFooterShapingPolicyRead(d, m)
+			return resourcePackagesGlobalFooterShapingPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalFooterShapingPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesGlobalFooterShapingPolicyRead(d, m)
+	return resourcePackagesGlobalFooterShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterShapingPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterShapingPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -287,26 +287,23 @@ func resourcePackagesGlobalFooterShapingPolicyUpdate(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesGlobalFooterShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterShapingPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalFooterShapingPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalFooterShapingPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalFooterShapingPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesGlobalFooterShapingPolicyRead(d, m)
+	return resourcePackagesGlobalFooterShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalFooterShapingPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterShapingPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -317,7 +314,7 @@ func resourcePackagesGlobalFooterShapingPolicyDelete(d *schema.ResourceData, m i
 
 	err = c.DeletePackagesGlobalFooterShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalFooterShapingPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalFooterShapingPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -325,11 +322,10 @@ func resourcePackagesGlobalFooterShapingPolicyDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourcePackagesGlobalFooterShapingPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalFooterShapingPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -339,25 +335,28 @@ func resourcePackagesGlobalFooterShapingPolicyRead(d *schema.ResourceData, m int
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalFooterShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterShapingPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalFooterShapingPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalFooterShapingPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalFooterShapingPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalFooterShapingPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalFooterShapingPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalHeaderConsolidatedPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalHeaderConsolidatedPolicyCreate,
-		Read:   resourcePackagesGlobalHeaderConsolidatedPolicyRead,
-		Update: resourcePackagesGlobalHeaderConsolidatedPolicyUpdate,
-		Delete: resourcePackagesGlobalHeaderConsolidatedPolicyDelete,
+		CreateContext: resourcePackagesGlobalHeaderConsolidatedPolicyCreate,
+		ReadContext:   resourcePackagesGlobalHeaderConsolidatedPolicyRead,
+		UpdateContext: resourcePackagesGlobalHeaderConsolidatedPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalHeaderConsolidatedPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -454,9 +456,8 @@ func resourcePackagesGlobalHeaderConsolidatedPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalHeaderConsolidatedPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalHeaderConsolidatedPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -467,33 +468,32 @@ func resourcePackagesGlobalHeaderConsolidatedPolicyCreate(d *schema.ResourceData
 
 	obj, err := getObjectPackagesGlobalHeaderConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalHeaderConsolidatedPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalHeaderConsolidatedPolicyRead(d, m)
+			return resourcePackagesGlobalHeaderConsolidatedPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderConsolidatedPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderConsolidatedPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderConsolidatedPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -504,26 +504,23 @@ func resourcePackagesGlobalHeaderConsolidatedPolicyUpdate(d *schema.ResourceData
 
 	obj, err := getObjectPackagesGlobalHeaderConsolidatedPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderConsolidatedPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderConsolidatedPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalHeaderConsolidatedPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderConsolidatedPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderConsolidatedPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderConsolidatedPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderConsolidatedPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -534,7 +531,7 @@ func resourcePackagesGlobalHeaderConsolidatedPolicyDelete(d *schema.ResourceData
 
 	err = c.DeletePackagesGlobalHeaderConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -542,11 +539,10 @@ func resourcePackagesGlobalHeaderConsolidatedPolicyDelete(d *schema.ResourceData
 	return nil
 }
 
-func resourcePackagesGlobalHeaderConsolidatedPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderConsolidatedPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -556,25 +552,28 @@ func resourcePackagesGlobalHeaderConsolidatedPolicyRead(d *schema.ResourceData,
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalHeaderConsolidatedPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalHeaderConsolidatedPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalHeaderConsolidatedPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalHeaderConsolidatedPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderConsolidatedPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalHeaderConsolidatedPolicy resource from API: %v", err)
 	}
 	return nil
 }
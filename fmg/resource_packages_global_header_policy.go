@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalHeaderPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalHeaderPolicyCreate,
-		Read:   resourcePackagesGlobalHeaderPolicyRead,
-		Update: resourcePackagesGlobalHeaderPolicyUpdate,
-		Delete: resourcePackagesGlobalHeaderPolicyDelete,
+		CreateContext: resourcePackagesGlobalHeaderPolicyCreate,
+		ReadContext:   resourcePackagesGlobalHeaderPolicyRead,
+		UpdateContext: resourcePackagesGlobalHeaderPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalHeaderPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1209,9 +1211,8 @@ func resourcePackagesGlobalHeaderPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalHeaderPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalHeaderPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1222,33 +1223,32 @@ func resourcePackagesGlobalHeaderPolicyCreate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesGlobalHeaderPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalHeaderPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderPolicy resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalHeaderPolicyRead(d, m)
+			return resourcePackagesGlobalHeaderPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalHeaderPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1259,26 +1259,23 @@ func resourcePackagesGlobalHeaderPolicyUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectPackagesGlobalHeaderPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalHeaderPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1289,7 +1286,7 @@ func resourcePackagesGlobalHeaderPolicyDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeletePackagesGlobalHeaderPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalHeaderPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalHeaderPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -1297,11 +1294,10 @@ func resourcePackagesGlobalHeaderPolicyDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourcePackagesGlobalHeaderPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -1311,25 +1307,28 @@ func resourcePackagesGlobalHeaderPolicyRead(d *schema.ResourceData, m interface{
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalHeaderPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalHeaderPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy resource from API: %v", err)
 	}
 	return nil
 }
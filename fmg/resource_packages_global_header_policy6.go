@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalHeaderPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalHeaderPolicy6Create,
-		Read:   resourcePackagesGlobalHeaderPolicy6Read,
-		Update: resourcePackagesGlobalHeaderPolicy6Update,
-		Delete: resourcePackagesGlobalHeaderPolicy6Delete,
+		CreateContext: resourcePackagesGlobalHeaderPolicy6Create,
+		ReadContext:   resourcePackagesGlobalHeaderPolicy6Read,
+		UpdateContext: resourcePackagesGlobalHeaderPolicy6Update,
+		DeleteContext: resourcePackagesGlobalHeaderPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -552,9 +554,8 @@ func resourcePackagesGlobalHeaderPolicy6() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalHeaderPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalHeaderPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -565,33 +566,32 @@ func resourcePackagesGlobalHeaderPolicy6Create(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesGlobalHeaderPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalHeaderPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource: %v", err)
 	}
 
 	if v != nil && v["policyid"] != nil {
 		if vidn, ok := v["policyid"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalHeaderPolicy6Read(d, m)
+			return resourcePackagesGlobalHeaderPolicy6Read(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalHeaderPolicy6 resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderPolicy6Read(d, m)
+	return resourcePackagesGlobalHeaderPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -602,26 +602,23 @@ func resourcePackagesGlobalHeaderPolicy6Update(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectPackagesGlobalHeaderPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalHeaderPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "policyid")))
 
-	return resourcePackagesGlobalHeaderPolicy6Read(d, m)
+	return resourcePackagesGlobalHeaderPolicy6Read(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -632,7 +629,7 @@ func resourcePackagesGlobalHeaderPolicy6Delete(d *schema.ResourceData, m interfa
 
 	err = c.DeletePackagesGlobalHeaderPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalHeaderPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalHeaderPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -640,11 +637,10 @@ func resourcePackagesGlobalHeaderPolicy6Delete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourcePackagesGlobalHeaderPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -654,25 +650,28 @@ func resourcePackagesGlobalHeaderPolicy6Read(d *schema.ResourceData, m interface
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalHeaderPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalHeaderPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalHeaderPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
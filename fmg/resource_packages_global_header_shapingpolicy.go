@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesGlobalHeaderShapingPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesGlobalHeaderShapingPolicyCreate,
-		Read:   resourcePackagesGlobalHeaderShapingPolicyRead,
-		Update: resourcePackagesGlobalHeaderShapingPolicyUpdate,
-		Delete: resourcePackagesGlobalHeaderShapingPolicyDelete,
+		CreateContext: resourcePackagesGlobalHeaderShapingPolicyCreate,
+		ReadContext:   resourcePackagesGlobalHeaderShapingPolicyRead,
+		UpdateContext: resourcePackagesGlobalHeaderShapingPolicyUpdate,
+		DeleteContext: resourcePackagesGlobalHeaderShapingPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -237,9 +239,8 @@ func resourcePackagesGlobalHeaderShapingPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesGlobalHeaderShapingPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesGlobalHeaderShapingPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -250,33 +251,32 @@ func resourcePackagesGlobalHeaderShapingPolicyCreate(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesGlobalHeaderShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource while getting object: %v", err)
 	}
 
 	v, err := c.CreatePackagesGlobalHeaderShapingPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource: %v", err)
 	}
 
 	if v != nil && v["id"] != nil {
 		if vidn, ok := v["id"].(float64); ok {
 			d.SetId(strconv.Itoa(int(vidn)))
-			return resourcePackagesGlobalHeaderShapingPolicyRead(d, m)
+			return resourcePackagesGlobalHeaderShapingPolicyRead(ctx, d, m)
 		} else {
-			return fmt.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource: %v", err)
+			return diag.Errorf("Error creating PackagesGlobalHeaderShapingPolicy resource: %v", err)
 		}
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesGlobalHeaderShapingPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderShapingPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderShapingPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -287,26 +287,23 @@ func resourcePackagesGlobalHeaderShapingPolicyUpdate(d *schema.ResourceData, m i
 
 	obj, err := getObjectPackagesGlobalHeaderShapingPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderShapingPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderShapingPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesGlobalHeaderShapingPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesGlobalHeaderShapingPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesGlobalHeaderShapingPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourcePackagesGlobalHeaderShapingPolicyRead(d, m)
+	return resourcePackagesGlobalHeaderShapingPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesGlobalHeaderShapingPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderShapingPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -317,7 +314,7 @@ func resourcePackagesGlobalHeaderShapingPolicyDelete(d *schema.ResourceData, m i
 
 	err = c.DeletePackagesGlobalHeaderShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesGlobalHeaderShapingPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesGlobalHeaderShapingPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -325,11 +322,10 @@ func resourcePackagesGlobalHeaderShapingPolicyDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourcePackagesGlobalHeaderShapingPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesGlobalHeaderShapingPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -339,25 +335,28 @@ func resourcePackagesGlobalHeaderShapingPolicyRead(d *schema.ResourceData, m int
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesGlobalHeaderShapingPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderShapingPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesGlobalHeaderShapingPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesGlobalHeaderShapingPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesGlobalHeaderShapingPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesGlobalHeaderShapingPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesGlobalHeaderShapingPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesPkg() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesPkgCreate,
-		Read:   resourcePackagesPkgRead,
-		Update: resourcePackagesPkgUpdate,
-		Delete: resourcePackagesPkgDelete,
+		CreateContext: resourcePackagesPkgCreate,
+		ReadContext:   resourcePackagesPkgRead,
+		UpdateContext: resourcePackagesPkgUpdate,
+		DeleteContext: resourcePackagesPkgDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -192,81 +194,76 @@ func resourcePackagesPkg() *schema.Resource {
 	}
 }
 
-func resourcePackagesPkgCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesPkgCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectPackagesPkg(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesPkg resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesPkg resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesPkg(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesPkg resource: %v", err)
+		return diag.Errorf("Error creating PackagesPkg resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesPkgRead(d, m)
+	return resourcePackagesPkgRead(ctx, d, m)
 }
 
-func resourcePackagesPkgUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesPkgUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectPackagesPkg(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesPkg resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesPkg resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesPkg(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesPkg resource: %v", err)
+		return diag.Errorf("Error updating PackagesPkg resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesPkgRead(d, m)
+	return resourcePackagesPkgRead(ctx, d, m)
 }
 
-func resourcePackagesPkgDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesPkgDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeletePackagesPkg(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesPkg resource: %v", err)
+		return diag.Errorf("Error deleting PackagesPkg resource: %v", err)
 	}
 
 	d.SetId("")
@@ -274,34 +271,36 @@ func resourcePackagesPkgDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePackagesPkgRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesPkgRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadPackagesPkg(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesPkg resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesPkg resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesPkg resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesPkg(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesPkg resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesPkg resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesUserNacPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesUserNacPolicyCreate,
-		Read:   resourcePackagesUserNacPolicyRead,
-		Update: resourcePackagesUserNacPolicyUpdate,
-		Delete: resourcePackagesUserNacPolicyDelete,
+		CreateContext: resourcePackagesUserNacPolicyCreate,
+		ReadContext:   resourcePackagesUserNacPolicyRead,
+		UpdateContext: resourcePackagesUserNacPolicyUpdate,
+		DeleteContext: resourcePackagesUserNacPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -125,15 +127,14 @@ func resourcePackagesUserNacPolicy() *schema.Resource {
 	}
 }
 
-func resourcePackagesUserNacPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourcePackagesUserNacPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -142,30 +143,29 @@ func resourcePackagesUserNacPolicyCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectPackagesUserNacPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesUserNacPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating PackagesUserNacPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreatePackagesUserNacPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating PackagesUserNacPolicy resource: %v", err)
+		return diag.Errorf("Error creating PackagesUserNacPolicy resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesUserNacPolicyRead(d, m)
+	return resourcePackagesUserNacPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesUserNacPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -174,32 +174,29 @@ func resourcePackagesUserNacPolicyUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectPackagesUserNacPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesUserNacPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesUserNacPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesUserNacPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesUserNacPolicy resource: %v", err)
+		return diag.Errorf("Error updating PackagesUserNacPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourcePackagesUserNacPolicyRead(d, m)
+	return resourcePackagesUserNacPolicyRead(ctx, d, m)
 }
 
-func resourcePackagesUserNacPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -208,7 +205,7 @@ func resourcePackagesUserNacPolicyDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeletePackagesUserNacPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting PackagesUserNacPolicy resource: %v", err)
+		return diag.Errorf("Error deleting PackagesUserNacPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -216,17 +213,16 @@ func resourcePackagesUserNacPolicyDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourcePackagesUserNacPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -234,25 +230,28 @@ func resourcePackagesUserNacPolicyRead(d *schema.ResourceData, m interface{}) er
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesUserNacPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesUserNacPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesUserNacPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading PackagesUserNacPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectPackagesUserNacPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesUserNacPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading PackagesUserNacPolicy resource from API: %v", err)
 	}
 	return nil
 }
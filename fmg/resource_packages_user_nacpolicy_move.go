@@ -9,23 +9,24 @@
 package fortimanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePackagesUserNacPolicyMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePackagesUserNacPolicyMoveUpdate,
-		Read:   resourcePackagesUserNacPolicyMoveRead,
-		Update: resourcePackagesUserNacPolicyMoveUpdate,
-		Delete: resourcePackagesUserNacPolicyMoveDelete,
+		CreateContext: resourcePackagesUserNacPolicyMoveUpdate,
+		ReadContext:   resourcePackagesUserNacPolicyMoveRead,
+		UpdateContext: resourcePackagesUserNacPolicyMoveUpdate,
+		DeleteContext: resourcePackagesUserNacPolicyMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -72,16 +73,15 @@ func resourcePackagesUserNacPolicyMove() *schema.Resource {
 	}
 }
 
-func resourcePackagesUserNacPolicyMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -93,48 +93,45 @@ func resourcePackagesUserNacPolicyMoveUpdate(d *schema.ResourceData, m interface
 	target := d.Get("target").(string)
 	obj, err := getObjectPackagesUserNacPolicyMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesUserNacPolicyMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating PackagesUserNacPolicyMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdatePackagesUserNacPolicyMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating PackagesUserNacPolicyMove resource: %v", err)
+		return diag.Errorf("Error updating PackagesUserNacPolicyMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("PackagesUserNacPolicyMove" + "_" + pkg + "_" + nac_policy + "_" + target)
 
-	return resourcePackagesUserNacPolicyMoveRead(d, m)
+	return resourcePackagesUserNacPolicyMoveRead(ctx, d, m)
 }
 
-func resourcePackagesUserNacPolicyMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourcePackagesUserNacPolicyMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourcePackagesUserNacPolicyMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	sid, err := strconv.Atoi(d.Get("nac_policy").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
 	}
 	did, err := strconv.Atoi(d.Get("target").(string))
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
+		return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
 	}
 	action := d.Get("option").(string)
 
@@ -142,14 +139,19 @@ func resourcePackagesUserNacPolicyMoveRead(d *schema.ResourceData, m interface{}
 	if pkg == "" {
 		pkg = importOptionChecking(m.(*FortiClient).Cfg, "pkg")
 		if err = d.Set("pkg", pkg); err != nil {
-			return fmt.Errorf("Error set params pkg: %v", err)
+			return diag.Errorf("Error set params pkg: %v", err)
 		}
 	}
 	paradict["pkg"] = pkg
 
 	o, err := c.ReadPackagesUserNacPolicyMove(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: %v", err)
 	}
 
 	if o == nil {
@@ -166,12 +168,12 @@ func resourcePackagesUserNacPolicyMoveRead(d *schema.ResourceData, m interface{}
 		for _, z := range o {
 			if v, ok := z.(map[string]interface{}); ok {
 				if _, ok := v["name"]; !ok {
-					return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: name doesn't exist.")
+					return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: name doesn't exist.")
 				}
 
 				idn := -1
 				if vidn, ok := v["name"].(float64); !ok {
-					return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: wrong name.")
+					return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: wrong name.")
 				} else {
 					idn = int(vidn)
 				}
@@ -184,7 +186,7 @@ func resourcePackagesUserNacPolicyMoveRead(d *schema.ResourceData, m interface{}
 					now_did = i
 				}
 			} else {
-				return fmt.Errorf("Error reading PackagesUserNacPolicyMove resource: no valid map string.")
+				return diag.Errorf("Error reading PackagesUserNacPolicyMove resource: no valid map string.")
 			}
 
 			i += 1
@@ -9,23 +9,26 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleAbort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleAbortUpdate,
-		Read:   resourceSecurityconsoleAbortRead,
-		Update: resourceSecurityconsoleAbortUpdate,
-		Delete: resourceSecurityconsoleAbortDelete,
+		CreateContext: resourceSecurityconsoleAbortUpdate,
+		ReadContext:   resourceSecurityconsoleAbortRead,
+		UpdateContext: resourceSecurityconsoleAbortUpdate,
+		DeleteContext: resourceSecurityconsoleAbortDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -44,10 +47,9 @@ func resourceSecurityconsoleAbort() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsoleAbortUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAbortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -55,28 +57,43 @@ func resourceSecurityconsoleAbortUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSecurityconsoleAbort(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleAbort resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleAbort resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsoleAbort(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleAbort resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleAbort resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleAbort")
 
-	return resourceSecurityconsoleAbortRead(d, m)
+	return resourceSecurityconsoleAbortRead(ctx, d, m)
+}
+
+// abortSecurityconsoleTask aborts the security console task, e.g. a package
+// or device install, running in the ADOM given by fmgadom of d
+func abortSecurityconsoleTask(c *FortiClient, d *schema.ResourceData) error {
+
+	obj := make(map[string]interface{})
+	if v, ok := d.GetOk("fmgadom"); ok {
+		obj["adom"] = v
+	}
+
+	paradict := make(map[string]string)
+	paradict["adom"] = ""
+
+	// The context of the interrupted operation is already cancelled
+	_, err := c.sdk(context.Background(), withRequestTimeout(time.Minute)).UpdateSecurityconsoleAbort(&obj, "", paradict)
+	return err
 }
 
-func resourceSecurityconsoleAbortDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAbortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleAbortRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAbortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
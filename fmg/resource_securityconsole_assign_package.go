@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleAssignPackage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleAssignPackageUpdate,
-		Read:   resourceSecurityconsoleAssignPackageRead,
-		Update: resourceSecurityconsoleAssignPackageUpdate,
-		Delete: resourceSecurityconsoleAssignPackageDelete,
+		CreateContext: resourceSecurityconsoleAssignPackageUpdate,
+		ReadContext:   resourceSecurityconsoleAssignPackageRead,
+		UpdateContext: resourceSecurityconsoleAssignPackageUpdate,
+		DeleteContext: resourceSecurityconsoleAssignPackageDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,10 +77,9 @@ func resourceSecurityconsoleAssignPackage() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsoleAssignPackageUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAssignPackageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -86,28 +87,26 @@ func resourceSecurityconsoleAssignPackageUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSecurityconsoleAssignPackage(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleAssignPackage resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleAssignPackage resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsoleAssignPackage(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleAssignPackage resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleAssignPackage resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleAssignPackage")
 
-	return resourceSecurityconsoleAssignPackageRead(d, m)
+	return resourceSecurityconsoleAssignPackageRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleAssignPackageDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAssignPackageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleAssignPackageRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleAssignPackageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
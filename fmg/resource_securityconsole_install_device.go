@@ -9,23 +9,31 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleInstallDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleInstallDeviceUpdate,
-		Read:   resourceSecurityconsoleInstallDeviceRead,
-		Update: resourceSecurityconsoleInstallDeviceUpdate,
-		Delete: resourceSecurityconsoleInstallDeviceDelete,
+		CreateContext: resourceSecurityconsoleInstallDeviceUpdate,
+		ReadContext:   resourceSecurityconsoleInstallDeviceRead,
+		UpdateContext: resourceSecurityconsoleInstallDeviceUpdate,
+		DeleteContext: resourceSecurityconsoleInstallDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,14 +79,30 @@ func resourceSecurityconsoleInstallDevice() *schema.Resource {
 				Optional: true,
 				Default:  "false",
 			},
+			"task_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_percent": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_results": taskResultsSchema(),
 		},
 	}
 }
 
-func resourceSecurityconsoleInstallDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Cancelling the wait for the task aborts it when Terraform is interrupted
+	ctx, cancel := m.(*FortiClient).withStop(ctx)
+	defer cancel()
+
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -86,28 +110,35 @@ func resourceSecurityconsoleInstallDeviceUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSecurityconsoleInstallDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallDevice resource while getting object: %v", err)
 	}
 
-	_, err = c.UpdateSecurityconsoleInstallDevice(obj, mkey, paradict)
+	o, err := c.UpdateSecurityconsoleInstallDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallDevice resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleInstallDevice")
 
-	return resourceSecurityconsoleInstallDeviceRead(d, m)
+	abort := func(int) error {
+		return abortSecurityconsoleTask(m.(*FortiClient), d)
+	}
+
+	_, err = waitExecTask(ctx, m.(*FortiClient), d, o, abort)
+	if err != nil {
+		return diag.Errorf("Error updating SecurityconsoleInstallDevice resource: %v", err)
+	}
+
+	return resourceSecurityconsoleInstallDeviceRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleInstallDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleInstallDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
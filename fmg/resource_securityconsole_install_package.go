@@ -9,23 +9,31 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleInstallPackage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleInstallPackageUpdate,
-		Read:   resourceSecurityconsoleInstallPackageRead,
-		Update: resourceSecurityconsoleInstallPackageUpdate,
-		Delete: resourceSecurityconsoleInstallPackageDelete,
+		CreateContext: resourceSecurityconsoleInstallPackageUpdate,
+		ReadContext:   resourceSecurityconsoleInstallPackageRead,
+		UpdateContext: resourceSecurityconsoleInstallPackageUpdate,
+		DeleteContext: resourceSecurityconsoleInstallPackageDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,14 +91,52 @@ func resourceSecurityconsoleInstallPackage() *schema.Resource {
 				Optional: true,
 				Default:  "false",
 			},
+			"install_log_file": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressInstallLogFileDiff,
+			},
+			"task_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"task_percent": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"task_results": taskResultsSchema(),
 		},
 	}
 }
 
-func resourceSecurityconsoleInstallPackageUpdate(d *schema.ResourceData, m interface{}) error {
+// suppressInstallLogFileDiff drops a change of install_log_file alone, it
+// only tells where the log of the next install is written and must not
+// install the package again
+func suppressInstallLogFileDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+
+	for k, s := range resourceSecurityconsoleInstallPackage().Schema {
+		if k != "install_log_file" && (s.Optional || s.Required) && d.HasChange(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func resourceSecurityconsoleInstallPackageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Cancelling the wait for the task aborts it when Terraform is interrupted
+	ctx, cancel := m.(*FortiClient).withStop(ctx)
+	defer cancel()
+
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -98,28 +144,38 @@ func resourceSecurityconsoleInstallPackageUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectSecurityconsoleInstallPackage(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallPackage resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallPackage resource while getting object: %v", err)
 	}
 
-	_, err = c.UpdateSecurityconsoleInstallPackage(obj, mkey, paradict)
+	o, err := c.UpdateSecurityconsoleInstallPackage(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallPackage resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallPackage resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleInstallPackage")
 
-	return resourceSecurityconsoleInstallPackageRead(d, m)
+	abort := func(int) error {
+		return abortSecurityconsoleTask(m.(*FortiClient), d)
+	}
+
+	task, err := waitExecTask(ctx, m.(*FortiClient), d, o, abort)
+	if err != nil && task != nil {
+		err = installLogError(ctx, m.(*FortiClient), task, err, d.Get("install_log_file").(string))
+	}
+	if err != nil {
+		return diag.Errorf("Error updating SecurityconsoleInstallPackage resource: %v", err)
+	}
+
+	return resourceSecurityconsoleInstallPackageRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleInstallPackageDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallPackageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleInstallPackageRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallPackageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleInstallPreview() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleInstallPreviewUpdate,
-		Read:   resourceSecurityconsoleInstallPreviewRead,
-		Update: resourceSecurityconsoleInstallPreviewUpdate,
-		Delete: resourceSecurityconsoleInstallPreviewDelete,
+		CreateContext: resourceSecurityconsoleInstallPreviewUpdate,
+		ReadContext:   resourceSecurityconsoleInstallPreviewRead,
+		UpdateContext: resourceSecurityconsoleInstallPreviewUpdate,
+		DeleteContext: resourceSecurityconsoleInstallPreviewDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,10 +62,9 @@ func resourceSecurityconsoleInstallPreview() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsoleInstallPreviewUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallPreviewUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -71,28 +72,26 @@ func resourceSecurityconsoleInstallPreviewUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectSecurityconsoleInstallPreview(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallPreview resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallPreview resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsoleInstallPreview(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleInstallPreview resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleInstallPreview resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleInstallPreview")
 
-	return resourceSecurityconsoleInstallPreviewRead(d, m)
+	return resourceSecurityconsoleInstallPreviewRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleInstallPreviewDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallPreviewDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleInstallPreviewRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleInstallPreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsolePackageCancelInstall() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsolePackageCancelInstallUpdate,
-		Read:   resourceSecurityconsolePackageCancelInstallRead,
-		Update: resourceSecurityconsolePackageCancelInstallUpdate,
-		Delete: resourceSecurityconsolePackageCancelInstallDelete,
+		CreateContext: resourceSecurityconsolePackageCancelInstallUpdate,
+		ReadContext:   resourceSecurityconsolePackageCancelInstallRead,
+		UpdateContext: resourceSecurityconsolePackageCancelInstallUpdate,
+		DeleteContext: resourceSecurityconsolePackageCancelInstallDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -44,10 +46,9 @@ func resourceSecurityconsolePackageCancelInstall() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsolePackageCancelInstallUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCancelInstallUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -55,28 +56,26 @@ func resourceSecurityconsolePackageCancelInstallUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSecurityconsolePackageCancelInstall(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageCancelInstall resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageCancelInstall resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsolePackageCancelInstall(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageCancelInstall resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageCancelInstall resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsolePackageCancelInstall")
 
-	return resourceSecurityconsolePackageCancelInstallRead(d, m)
+	return resourceSecurityconsolePackageCancelInstallRead(ctx, d, m)
 }
 
-func resourceSecurityconsolePackageCancelInstallDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCancelInstallDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsolePackageCancelInstallRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCancelInstallRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
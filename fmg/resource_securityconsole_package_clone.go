@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsolePackageClone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsolePackageCloneUpdate,
-		Read:   resourceSecurityconsolePackageCloneRead,
-		Update: resourceSecurityconsolePackageCloneUpdate,
-		Delete: resourceSecurityconsolePackageCloneDelete,
+		CreateContext: resourceSecurityconsolePackageCloneUpdate,
+		ReadContext:   resourceSecurityconsolePackageCloneRead,
+		UpdateContext: resourceSecurityconsolePackageCloneUpdate,
+		DeleteContext: resourceSecurityconsolePackageCloneDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,10 +79,9 @@ func resourceSecurityconsolePackageClone() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsolePackageCloneUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCloneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -88,28 +89,26 @@ func resourceSecurityconsolePackageCloneUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSecurityconsolePackageClone(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageClone resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageClone resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsolePackageClone(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageClone resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageClone resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsolePackageClone")
 
-	return resourceSecurityconsolePackageCloneRead(d, m)
+	return resourceSecurityconsolePackageCloneRead(ctx, d, m)
 }
 
-func resourceSecurityconsolePackageCloneDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCloneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsolePackageCloneRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCloneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsolePackageCommit() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsolePackageCommitUpdate,
-		Read:   resourceSecurityconsolePackageCommitRead,
-		Update: resourceSecurityconsolePackageCommitUpdate,
-		Delete: resourceSecurityconsolePackageCommitDelete,
+		CreateContext: resourceSecurityconsolePackageCommitUpdate,
+		ReadContext:   resourceSecurityconsolePackageCommitRead,
+		UpdateContext: resourceSecurityconsolePackageCommitUpdate,
+		DeleteContext: resourceSecurityconsolePackageCommitDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -65,10 +67,9 @@ func resourceSecurityconsolePackageCommit() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsolePackageCommitUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCommitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -76,28 +77,26 @@ func resourceSecurityconsolePackageCommitUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSecurityconsolePackageCommit(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageCommit resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageCommit resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsolePackageCommit(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageCommit resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageCommit resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsolePackageCommit")
 
-	return resourceSecurityconsolePackageCommitRead(d, m)
+	return resourceSecurityconsolePackageCommitRead(ctx, d, m)
 }
 
-func resourceSecurityconsolePackageCommitDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCommitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsolePackageCommitRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageCommitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
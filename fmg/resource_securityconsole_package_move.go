@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsolePackageMove() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsolePackageMoveUpdate,
-		Read:   resourceSecurityconsolePackageMoveRead,
-		Update: resourceSecurityconsolePackageMoveUpdate,
-		Delete: resourceSecurityconsolePackageMoveDelete,
+		CreateContext: resourceSecurityconsolePackageMoveUpdate,
+		ReadContext:   resourceSecurityconsolePackageMoveRead,
+		UpdateContext: resourceSecurityconsolePackageMoveUpdate,
+		DeleteContext: resourceSecurityconsolePackageMoveDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -56,10 +58,9 @@ func resourceSecurityconsolePackageMove() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsolePackageMoveUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageMoveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -67,28 +68,26 @@ func resourceSecurityconsolePackageMoveUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectSecurityconsolePackageMove(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageMove resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageMove resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsolePackageMove(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePackageMove resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePackageMove resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsolePackageMove")
 
-	return resourceSecurityconsolePackageMoveRead(d, m)
+	return resourceSecurityconsolePackageMoveRead(ctx, d, m)
 }
 
-func resourceSecurityconsolePackageMoveDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsolePackageMoveRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePackageMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
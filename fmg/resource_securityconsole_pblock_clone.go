@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsolePblockClone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsolePblockCloneUpdate,
-		Read:   resourceSecurityconsolePblockCloneRead,
-		Update: resourceSecurityconsolePblockCloneUpdate,
-		Delete: resourceSecurityconsolePblockCloneDelete,
+		CreateContext: resourceSecurityconsolePblockCloneUpdate,
+		ReadContext:   resourceSecurityconsolePblockCloneRead,
+		UpdateContext: resourceSecurityconsolePblockCloneUpdate,
+		DeleteContext: resourceSecurityconsolePblockCloneDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -52,10 +54,9 @@ func resourceSecurityconsolePblockClone() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsolePblockCloneUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePblockCloneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -63,28 +64,26 @@ func resourceSecurityconsolePblockCloneUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectSecurityconsolePblockClone(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePblockClone resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePblockClone resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsolePblockClone(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsolePblockClone resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsolePblockClone resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsolePblockClone")
 
-	return resourceSecurityconsolePblockCloneRead(d, m)
+	return resourceSecurityconsolePblockCloneRead(ctx, d, m)
 }
 
-func resourceSecurityconsolePblockCloneDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePblockCloneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsolePblockCloneRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsolePblockCloneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleReinstallPackage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleReinstallPackageUpdate,
-		Read:   resourceSecurityconsoleReinstallPackageRead,
-		Update: resourceSecurityconsoleReinstallPackageUpdate,
-		Delete: resourceSecurityconsoleReinstallPackageDelete,
+		CreateContext: resourceSecurityconsoleReinstallPackageUpdate,
+		ReadContext:   resourceSecurityconsoleReinstallPackageRead,
+		UpdateContext: resourceSecurityconsoleReinstallPackageUpdate,
+		DeleteContext: resourceSecurityconsoleReinstallPackageDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,10 +85,9 @@ func resourceSecurityconsoleReinstallPackage() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsoleReinstallPackageUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleReinstallPackageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -94,28 +95,26 @@ func resourceSecurityconsoleReinstallPackageUpdate(d *schema.ResourceData, m int
 
 	obj, err := getObjectSecurityconsoleReinstallPackage(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleReinstallPackage resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleReinstallPackage resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsoleReinstallPackage(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleReinstallPackage resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleReinstallPackage resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleReinstallPackage")
 
-	return resourceSecurityconsoleReinstallPackageRead(d, m)
+	return resourceSecurityconsoleReinstallPackageRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleReinstallPackageDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleReinstallPackageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleReinstallPackageRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleReinstallPackageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSecurityconsoleSignCertificateTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityconsoleSignCertificateTemplateUpdate,
-		Read:   resourceSecurityconsoleSignCertificateTemplateRead,
-		Update: resourceSecurityconsoleSignCertificateTemplateUpdate,
-		Delete: resourceSecurityconsoleSignCertificateTemplateDelete,
+		CreateContext: resourceSecurityconsoleSignCertificateTemplateUpdate,
+		ReadContext:   resourceSecurityconsoleSignCertificateTemplateRead,
+		UpdateContext: resourceSecurityconsoleSignCertificateTemplateUpdate,
+		DeleteContext: resourceSecurityconsoleSignCertificateTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,10 +71,9 @@ func resourceSecurityconsoleSignCertificateTemplate() *schema.Resource {
 	}
 }
 
-func resourceSecurityconsoleSignCertificateTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleSignCertificateTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "", fmt.Errorf("")
@@ -80,28 +81,26 @@ func resourceSecurityconsoleSignCertificateTemplateUpdate(d *schema.ResourceData
 
 	obj, err := getObjectSecurityconsoleSignCertificateTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleSignCertificateTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleSignCertificateTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSecurityconsoleSignCertificateTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SecurityconsoleSignCertificateTemplate resource: %v", err)
+		return diag.Errorf("Error updating SecurityconsoleSignCertificateTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SecurityconsoleSignCertificateTemplate")
 
-	return resourceSecurityconsoleSignCertificateTemplateRead(d, m)
+	return resourceSecurityconsoleSignCertificateTemplateRead(ctx, d, m)
 }
 
-func resourceSecurityconsoleSignCertificateTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleSignCertificateTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 
 	return nil
 }
 
-func resourceSecurityconsoleSignCertificateTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityconsoleSignCertificateTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminGroupCreate,
-		Read:   resourceSystemAdminGroupRead,
-		Update: resourceSystemAdminGroupUpdate,
-		Delete: resourceSystemAdminGroupDelete,
+		CreateContext: resourceSystemAdminGroupCreate,
+		ReadContext:   resourceSystemAdminGroupRead,
+		UpdateContext: resourceSystemAdminGroupUpdate,
+		DeleteContext: resourceSystemAdminGroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -55,9 +57,8 @@ func resourceSystemAdminGroup() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminGroupCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -65,24 +66,23 @@ func resourceSystemAdminGroupCreate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminGroup resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminGroup(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminGroup resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminGroup resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminGroupRead(d, m)
+	return resourceSystemAdminGroupRead(ctx, d, m)
 }
 
-func resourceSystemAdminGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -90,26 +90,23 @@ func resourceSystemAdminGroupUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminGroup(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminGroup resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminGroup resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminGroup(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminGroup resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminGroup resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminGroupRead(d, m)
+	return resourceSystemAdminGroupRead(ctx, d, m)
 }
 
-func resourceSystemAdminGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -117,7 +114,7 @@ func resourceSystemAdminGroupDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAdminGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminGroup resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminGroup resource: %v", err)
 	}
 
 	d.SetId("")
@@ -125,11 +122,10 @@ func resourceSystemAdminGroupDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAdminGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -137,18 +133,21 @@ func resourceSystemAdminGroupRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAdminGroup(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminGroup resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminGroup resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminGroup resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminGroup(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminGroup resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminGroup resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminLdap() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminLdapCreate,
-		Read:   resourceSystemAdminLdapRead,
-		Update: resourceSystemAdminLdapUpdate,
-		Delete: resourceSystemAdminLdapDelete,
+		CreateContext: resourceSystemAdminLdapCreate,
+		ReadContext:   resourceSystemAdminLdapRead,
+		UpdateContext: resourceSystemAdminLdapUpdate,
+		DeleteContext: resourceSystemAdminLdapDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -142,9 +144,8 @@ func resourceSystemAdminLdap() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminLdapCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminLdapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -152,24 +153,23 @@ func resourceSystemAdminLdapCreate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminLdap(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminLdap resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminLdap resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminLdap(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminLdap resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminLdap resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminLdapRead(d, m)
+	return resourceSystemAdminLdapRead(ctx, d, m)
 }
 
-func resourceSystemAdminLdapUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminLdapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -177,26 +177,23 @@ func resourceSystemAdminLdapUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminLdap(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminLdap resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminLdap resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminLdap(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminLdap resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminLdap resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminLdapRead(d, m)
+	return resourceSystemAdminLdapRead(ctx, d, m)
 }
 
-func resourceSystemAdminLdapDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminLdapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -204,7 +201,7 @@ func resourceSystemAdminLdapDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAdminLdap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminLdap resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminLdap resource: %v", err)
 	}
 
 	d.SetId("")
@@ -212,11 +209,10 @@ func resourceSystemAdminLdapDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAdminLdapRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminLdapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -224,18 +220,21 @@ func resourceSystemAdminLdapRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAdminLdap(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminLdap resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminLdap resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminLdap resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminLdap(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminLdap resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminLdap resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminProfileCreate,
-		Read:   resourceSystemAdminProfileRead,
-		Update: resourceSystemAdminProfileUpdate,
-		Delete: resourceSystemAdminProfileDelete,
+		CreateContext: resourceSystemAdminProfileCreate,
+		ReadContext:   resourceSystemAdminProfileRead,
+		UpdateContext: resourceSystemAdminProfileUpdate,
+		DeleteContext: resourceSystemAdminProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -472,9 +474,8 @@ func resourceSystemAdminProfile() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -482,24 +483,23 @@ func resourceSystemAdminProfileCreate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemAdminProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminProfile resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminProfile resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "profileid"))
 
-	return resourceSystemAdminProfileRead(d, m)
+	return resourceSystemAdminProfileRead(ctx, d, m)
 }
 
-func resourceSystemAdminProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -507,26 +507,23 @@ func resourceSystemAdminProfileUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemAdminProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminProfile resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "profileid"))
 
-	return resourceSystemAdminProfileRead(d, m)
+	return resourceSystemAdminProfileRead(ctx, d, m)
 }
 
-func resourceSystemAdminProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -534,7 +531,7 @@ func resourceSystemAdminProfileDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteSystemAdminProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminProfile resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -542,11 +539,10 @@ func resourceSystemAdminProfileDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSystemAdminProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -554,18 +550,21 @@ func resourceSystemAdminProfileRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemAdminProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminProfile resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminProfile resource from API: %v", err)
 	}
 	return nil
 }
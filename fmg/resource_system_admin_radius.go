@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminRadius() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminRadiusCreate,
-		Read:   resourceSystemAdminRadiusRead,
-		Update: resourceSystemAdminRadiusUpdate,
-		Delete: resourceSystemAdminRadiusDelete,
+		CreateContext: resourceSystemAdminRadiusCreate,
+		ReadContext:   resourceSystemAdminRadiusRead,
+		UpdateContext: resourceSystemAdminRadiusUpdate,
+		DeleteContext: resourceSystemAdminRadiusDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -75,9 +77,8 @@ func resourceSystemAdminRadius() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminRadiusCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminRadiusCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -85,24 +86,23 @@ func resourceSystemAdminRadiusCreate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemAdminRadius(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminRadius resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminRadius resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminRadius(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminRadius resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminRadius resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminRadiusRead(d, m)
+	return resourceSystemAdminRadiusRead(ctx, d, m)
 }
 
-func resourceSystemAdminRadiusUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminRadiusUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -110,26 +110,23 @@ func resourceSystemAdminRadiusUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemAdminRadius(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminRadius resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminRadius resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminRadius(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminRadius resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminRadius resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminRadiusRead(d, m)
+	return resourceSystemAdminRadiusRead(ctx, d, m)
 }
 
-func resourceSystemAdminRadiusDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminRadiusDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -137,7 +134,7 @@ func resourceSystemAdminRadiusDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteSystemAdminRadius(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminRadius resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminRadius resource: %v", err)
 	}
 
 	d.SetId("")
@@ -145,11 +142,10 @@ func resourceSystemAdminRadiusDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceSystemAdminRadiusRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminRadiusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -157,18 +153,21 @@ func resourceSystemAdminRadiusRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemAdminRadius(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminRadius resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminRadius resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminRadius resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminRadius(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminRadius resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminRadius resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminSettingUpdate,
-		Read:   resourceSystemAdminSettingRead,
-		Update: resourceSystemAdminSettingUpdate,
-		Delete: resourceSystemAdminSettingDelete,
+		CreateContext: resourceSystemAdminSettingUpdate,
+		ReadContext:   resourceSystemAdminSettingRead,
+		UpdateContext: resourceSystemAdminSettingUpdate,
+		DeleteContext: resourceSystemAdminSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -252,10 +254,9 @@ func resourceSystemAdminSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -263,26 +264,23 @@ func resourceSystemAdminSettingUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemAdminSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAdminSetting")
 
-	return resourceSystemAdminSettingRead(d, m)
+	return resourceSystemAdminSettingRead(ctx, d, m)
 }
 
-func resourceSystemAdminSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -290,7 +288,7 @@ func resourceSystemAdminSettingDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteSystemAdminSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -298,11 +296,10 @@ func resourceSystemAdminSettingDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSystemAdminSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -310,18 +307,21 @@ func resourceSystemAdminSettingRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemAdminSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminSetting resource from API: %v", err)
 	}
 	return nil
 }
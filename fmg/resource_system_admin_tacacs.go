@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminTacacs() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminTacacsCreate,
-		Read:   resourceSystemAdminTacacsRead,
-		Update: resourceSystemAdminTacacsUpdate,
-		Delete: resourceSystemAdminTacacsDelete,
+		CreateContext: resourceSystemAdminTacacsCreate,
+		ReadContext:   resourceSystemAdminTacacsRead,
+		UpdateContext: resourceSystemAdminTacacsUpdate,
+		DeleteContext: resourceSystemAdminTacacsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -86,9 +88,8 @@ func resourceSystemAdminTacacs() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminTacacsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminTacacsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,24 +97,23 @@ func resourceSystemAdminTacacsCreate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemAdminTacacs(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminTacacs resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminTacacs resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminTacacs(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminTacacs resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminTacacs resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminTacacsRead(d, m)
+	return resourceSystemAdminTacacsRead(ctx, d, m)
 }
 
-func resourceSystemAdminTacacsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminTacacsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -121,26 +121,23 @@ func resourceSystemAdminTacacsUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemAdminTacacs(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminTacacs resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminTacacs resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminTacacs(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminTacacs resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminTacacs resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAdminTacacsRead(d, m)
+	return resourceSystemAdminTacacsRead(ctx, d, m)
 }
 
-func resourceSystemAdminTacacsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminTacacsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -148,7 +145,7 @@ func resourceSystemAdminTacacsDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteSystemAdminTacacs(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminTacacs resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminTacacs resource: %v", err)
 	}
 
 	d.SetId("")
@@ -156,11 +153,10 @@ func resourceSystemAdminTacacsDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceSystemAdminTacacsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminTacacsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -168,18 +164,21 @@ func resourceSystemAdminTacacsRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemAdminTacacs(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminTacacs resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminTacacs resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminTacacs resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminTacacs(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminTacacs resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminTacacs resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAdminUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAdminUserCreate,
-		Read:   resourceSystemAdminUserRead,
-		Update: resourceSystemAdminUserUpdate,
-		Delete: resourceSystemAdminUserDelete,
+		CreateContext: resourceSystemAdminUserCreate,
+		ReadContext:   resourceSystemAdminUserRead,
+		UpdateContext: resourceSystemAdminUserUpdate,
+		DeleteContext: resourceSystemAdminUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -533,9 +535,8 @@ func resourceSystemAdminUser() *schema.Resource {
 	}
 }
 
-func resourceSystemAdminUserCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAdminUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -543,24 +544,23 @@ func resourceSystemAdminUserCreate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminUser(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminUser resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAdminUser resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAdminUser(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAdminUser resource: %v", err)
+		return diag.Errorf("Error creating SystemAdminUser resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "userid"))
 
-	return resourceSystemAdminUserRead(d, m)
+	return resourceSystemAdminUserRead(ctx, d, m)
 }
 
-func resourceSystemAdminUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -568,26 +568,23 @@ func resourceSystemAdminUserUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAdminUser(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminUser resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAdminUser resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAdminUser(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAdminUser resource: %v", err)
+		return diag.Errorf("Error updating SystemAdminUser resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "userid"))
 
-	return resourceSystemAdminUserRead(d, m)
+	return resourceSystemAdminUserRead(ctx, d, m)
 }
 
-func resourceSystemAdminUserDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -595,7 +592,7 @@ func resourceSystemAdminUserDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAdminUser(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAdminUser resource: %v", err)
+		return diag.Errorf("Error deleting SystemAdminUser resource: %v", err)
 	}
 
 	d.SetId("")
@@ -603,11 +600,10 @@ func resourceSystemAdminUserDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAdminUserRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAdminUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -615,18 +611,21 @@ func resourceSystemAdminUserRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAdminUser(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminUser resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAdminUser resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAdminUser resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAdminUser(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAdminUser resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAdminUser resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAlertConsole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAlertConsoleUpdate,
-		Read:   resourceSystemAlertConsoleRead,
-		Update: resourceSystemAlertConsoleUpdate,
-		Delete: resourceSystemAlertConsoleDelete,
+		CreateContext: resourceSystemAlertConsoleUpdate,
+		ReadContext:   resourceSystemAlertConsoleRead,
+		UpdateContext: resourceSystemAlertConsoleUpdate,
+		DeleteContext: resourceSystemAlertConsoleDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -44,10 +46,9 @@ func resourceSystemAlertConsole() *schema.Resource {
 	}
 }
 
-func resourceSystemAlertConsoleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertConsoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -55,26 +56,23 @@ func resourceSystemAlertConsoleUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemAlertConsole(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertConsole resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAlertConsole resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAlertConsole(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertConsole resource: %v", err)
+		return diag.Errorf("Error updating SystemAlertConsole resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAlertConsole")
 
-	return resourceSystemAlertConsoleRead(d, m)
+	return resourceSystemAlertConsoleRead(ctx, d, m)
 }
 
-func resourceSystemAlertConsoleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertConsoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -82,7 +80,7 @@ func resourceSystemAlertConsoleDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteSystemAlertConsole(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAlertConsole resource: %v", err)
+		return diag.Errorf("Error deleting SystemAlertConsole resource: %v", err)
 	}
 
 	d.SetId("")
@@ -90,11 +88,10 @@ func resourceSystemAlertConsoleDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSystemAlertConsoleRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertConsoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -102,18 +99,21 @@ func resourceSystemAlertConsoleRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemAlertConsole(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertConsole resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAlertConsole resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAlertConsole resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAlertConsole(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertConsole resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAlertConsole resource from API: %v", err)
 	}
 	return nil
 }
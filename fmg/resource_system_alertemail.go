@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAlertemail() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAlertemailUpdate,
-		Read:   resourceSystemAlertemailRead,
-		Update: resourceSystemAlertemailUpdate,
-		Delete: resourceSystemAlertemailDelete,
+		CreateContext: resourceSystemAlertemailUpdate,
+		ReadContext:   resourceSystemAlertemailRead,
+		UpdateContext: resourceSystemAlertemailUpdate,
+		DeleteContext: resourceSystemAlertemailDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,10 +71,9 @@ func resourceSystemAlertemail() *schema.Resource {
 	}
 }
 
-func resourceSystemAlertemailUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertemailUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -80,26 +81,23 @@ func resourceSystemAlertemailUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAlertemail(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertemail resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAlertemail resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAlertemail(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertemail resource: %v", err)
+		return diag.Errorf("Error updating SystemAlertemail resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAlertemail")
 
-	return resourceSystemAlertemailRead(d, m)
+	return resourceSystemAlertemailRead(ctx, d, m)
 }
 
-func resourceSystemAlertemailDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertemailDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -107,7 +105,7 @@ func resourceSystemAlertemailDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAlertemail(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAlertemail resource: %v", err)
+		return diag.Errorf("Error deleting SystemAlertemail resource: %v", err)
 	}
 
 	d.SetId("")
@@ -115,11 +113,10 @@ func resourceSystemAlertemailDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAlertemailRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertemailRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -127,18 +124,21 @@ func resourceSystemAlertemailRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAlertemail(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertemail resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAlertemail resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAlertemail resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAlertemail(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertemail resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAlertemail resource from API: %v", err)
 	}
 	return nil
 }
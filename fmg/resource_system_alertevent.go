@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAlertEvent() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAlertEventCreate,
-		Read:   resourceSystemAlertEventRead,
-		Update: resourceSystemAlertEventUpdate,
-		Delete: resourceSystemAlertEventDelete,
+		CreateContext: resourceSystemAlertEventCreate,
+		ReadContext:   resourceSystemAlertEventRead,
+		UpdateContext: resourceSystemAlertEventUpdate,
+		DeleteContext: resourceSystemAlertEventDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -119,9 +121,8 @@ func resourceSystemAlertEvent() *schema.Resource {
 	}
 }
 
-func resourceSystemAlertEventCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemAlertEventCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -129,24 +130,23 @@ func resourceSystemAlertEventCreate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAlertEvent(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAlertEvent resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemAlertEvent resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemAlertEvent(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemAlertEvent resource: %v", err)
+		return diag.Errorf("Error creating SystemAlertEvent resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAlertEventRead(d, m)
+	return resourceSystemAlertEventRead(ctx, d, m)
 }
 
-func resourceSystemAlertEventUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertEventUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -154,26 +154,23 @@ func resourceSystemAlertEventUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAlertEvent(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertEvent resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAlertEvent resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAlertEvent(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAlertEvent resource: %v", err)
+		return diag.Errorf("Error updating SystemAlertEvent resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemAlertEventRead(d, m)
+	return resourceSystemAlertEventRead(ctx, d, m)
 }
 
-func resourceSystemAlertEventDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertEventDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -181,7 +178,7 @@ func resourceSystemAlertEventDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAlertEvent(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAlertEvent resource: %v", err)
+		return diag.Errorf("Error deleting SystemAlertEvent resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,11 +186,10 @@ func resourceSystemAlertEventDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAlertEventRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAlertEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -201,18 +197,21 @@ func resourceSystemAlertEventRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAlertEvent(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertEvent resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAlertEvent resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAlertEvent resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAlertEvent(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAlertEvent resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAlertEvent resource from API: %v", err)
 	}
 	return nil
 }
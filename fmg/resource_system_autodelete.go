@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAutoDelete() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAutoDeleteUpdate,
-		Read:   resourceSystemAutoDeleteRead,
-		Update: resourceSystemAutoDeleteUpdate,
-		Delete: resourceSystemAutoDeleteDelete,
+		CreateContext: resourceSystemAutoDeleteUpdate,
+		ReadContext:   resourceSystemAutoDeleteRead,
+		UpdateContext: resourceSystemAutoDeleteUpdate,
+		DeleteContext: resourceSystemAutoDeleteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -157,10 +159,9 @@ func resourceSystemAutoDelete() *schema.Resource {
 	}
 }
 
-func resourceSystemAutoDeleteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -168,26 +169,23 @@ func resourceSystemAutoDeleteUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemAutoDelete(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDelete resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAutoDelete resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAutoDelete(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDelete resource: %v", err)
+		return diag.Errorf("Error updating SystemAutoDelete resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAutoDelete")
 
-	return resourceSystemAutoDeleteRead(d, m)
+	return resourceSystemAutoDeleteRead(ctx, d, m)
 }
 
-func resourceSystemAutoDeleteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -195,7 +193,7 @@ func resourceSystemAutoDeleteDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemAutoDelete(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAutoDelete resource: %v", err)
+		return diag.Errorf("Error deleting SystemAutoDelete resource: %v", err)
 	}
 
 	d.SetId("")
@@ -203,11 +201,10 @@ func resourceSystemAutoDeleteDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemAutoDeleteRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -215,18 +212,21 @@ func resourceSystemAutoDeleteRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemAutoDelete(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDelete resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAutoDelete resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAutoDelete resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAutoDelete(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDelete resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAutoDelete resource from API: %v", err)
 	}
 	return nil
 }
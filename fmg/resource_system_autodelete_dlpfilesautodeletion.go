@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAutoDeleteDlpFilesAutoDeletion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate,
-		Read:   resourceSystemAutoDeleteDlpFilesAutoDeletionRead,
-		Update: resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate,
-		Delete: resourceSystemAutoDeleteDlpFilesAutoDeletionDelete,
+		CreateContext: resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate,
+		ReadContext:   resourceSystemAutoDeleteDlpFilesAutoDeletionRead,
+		UpdateContext: resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate,
+		DeleteContext: resourceSystemAutoDeleteDlpFilesAutoDeletionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemAutoDeleteDlpFilesAutoDeletion() *schema.Resource {
 	}
 }
 
-func resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemAutoDeleteDlpFilesAutoDeletionUpdate(d *schema.ResourceData,
 
 	obj, err := getObjectSystemAutoDeleteDlpFilesAutoDeletion(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteDlpFilesAutoDeletion resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteDlpFilesAutoDeletion resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAutoDeleteDlpFilesAutoDeletion(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAutoDeleteDlpFilesAutoDeletion")
 
-	return resourceSystemAutoDeleteDlpFilesAutoDeletionRead(d, m)
+	return resourceSystemAutoDeleteDlpFilesAutoDeletionRead(ctx, d, m)
 }
 
-func resourceSystemAutoDeleteDlpFilesAutoDeletionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteDlpFilesAutoDeletionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemAutoDeleteDlpFilesAutoDeletionDelete(d *schema.ResourceData,
 
 	err = c.DeleteSystemAutoDeleteDlpFilesAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
+		return diag.Errorf("Error deleting SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemAutoDeleteDlpFilesAutoDeletionDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceSystemAutoDeleteDlpFilesAutoDeletionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteDlpFilesAutoDeletionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemAutoDeleteDlpFilesAutoDeletionRead(d *schema.ResourceData, m
 
 	o, err := c.ReadSystemAutoDeleteDlpFilesAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAutoDeleteDlpFilesAutoDeletion resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAutoDeleteDlpFilesAutoDeletion resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAutoDeleteDlpFilesAutoDeletion(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteDlpFilesAutoDeletion resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAutoDeleteDlpFilesAutoDeletion resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAutoDeleteLogAutoDeletion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAutoDeleteLogAutoDeletionUpdate,
-		Read:   resourceSystemAutoDeleteLogAutoDeletionRead,
-		Update: resourceSystemAutoDeleteLogAutoDeletionUpdate,
-		Delete: resourceSystemAutoDeleteLogAutoDeletionDelete,
+		CreateContext: resourceSystemAutoDeleteLogAutoDeletionUpdate,
+		ReadContext:   resourceSystemAutoDeleteLogAutoDeletionRead,
+		UpdateContext: resourceSystemAutoDeleteLogAutoDeletionUpdate,
+		DeleteContext: resourceSystemAutoDeleteLogAutoDeletionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemAutoDeleteLogAutoDeletion() *schema.Resource {
 	}
 }
 
-func resourceSystemAutoDeleteLogAutoDeletionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteLogAutoDeletionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemAutoDeleteLogAutoDeletionUpdate(d *schema.ResourceData, m int
 
 	obj, err := getObjectSystemAutoDeleteLogAutoDeletion(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteLogAutoDeletion resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteLogAutoDeletion resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAutoDeleteLogAutoDeletion(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteLogAutoDeletion resource: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteLogAutoDeletion resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAutoDeleteLogAutoDeletion")
 
-	return resourceSystemAutoDeleteLogAutoDeletionRead(d, m)
+	return resourceSystemAutoDeleteLogAutoDeletionRead(ctx, d, m)
 }
 
-func resourceSystemAutoDeleteLogAutoDeletionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteLogAutoDeletionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemAutoDeleteLogAutoDeletionDelete(d *schema.ResourceData, m int
 
 	err = c.DeleteSystemAutoDeleteLogAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAutoDeleteLogAutoDeletion resource: %v", err)
+		return diag.Errorf("Error deleting SystemAutoDeleteLogAutoDeletion resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemAutoDeleteLogAutoDeletionDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceSystemAutoDeleteLogAutoDeletionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteLogAutoDeletionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemAutoDeleteLogAutoDeletionRead(d *schema.ResourceData, m inter
 
 	o, err := c.ReadSystemAutoDeleteLogAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteLogAutoDeletion resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAutoDeleteLogAutoDeletion resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAutoDeleteLogAutoDeletion resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAutoDeleteLogAutoDeletion(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteLogAutoDeletion resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAutoDeleteLogAutoDeletion resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAutoDeleteQuarantineFilesAutoDeletion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate,
-		Read:   resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead,
-		Update: resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate,
-		Delete: resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete,
+		CreateContext: resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate,
+		ReadContext:   resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead,
+		UpdateContext: resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate,
+		DeleteContext: resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemAutoDeleteQuarantineFilesAutoDeletion() *schema.Resource {
 	}
 }
 
-func resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemAutoDeleteQuarantineFilesAutoDeletionUpdate(d *schema.Resourc
 
 	obj, err := getObjectSystemAutoDeleteQuarantineFilesAutoDeletion(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteQuarantineFilesAutoDeletion resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteQuarantineFilesAutoDeletion resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAutoDeleteQuarantineFilesAutoDeletion(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAutoDeleteQuarantineFilesAutoDeletion")
 
-	return resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead(d, m)
+	return resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead(ctx, d, m)
 }
 
-func resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete(d *schema.Resourc
 
 	err = c.DeleteSystemAutoDeleteQuarantineFilesAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
+		return diag.Errorf("Error deleting SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemAutoDeleteQuarantineFilesAutoDeletionDelete(d *schema.Resourc
 	return nil
 }
 
-func resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemAutoDeleteQuarantineFilesAutoDeletionRead(d *schema.ResourceD
 
 	o, err := c.ReadSystemAutoDeleteQuarantineFilesAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAutoDeleteQuarantineFilesAutoDeletion resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAutoDeleteQuarantineFilesAutoDeletion resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAutoDeleteQuarantineFilesAutoDeletion(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteQuarantineFilesAutoDeletion resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAutoDeleteQuarantineFilesAutoDeletion resource from API: %v", err)
 	}
 	return nil
 }
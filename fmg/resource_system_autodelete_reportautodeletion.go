@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemAutoDeleteReportAutoDeletion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemAutoDeleteReportAutoDeletionUpdate,
-		Read:   resourceSystemAutoDeleteReportAutoDeletionRead,
-		Update: resourceSystemAutoDeleteReportAutoDeletionUpdate,
-		Delete: resourceSystemAutoDeleteReportAutoDeletionDelete,
+		CreateContext: resourceSystemAutoDeleteReportAutoDeletionUpdate,
+		ReadContext:   resourceSystemAutoDeleteReportAutoDeletionRead,
+		UpdateContext: resourceSystemAutoDeleteReportAutoDeletionUpdate,
+		DeleteContext: resourceSystemAutoDeleteReportAutoDeletionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemAutoDeleteReportAutoDeletion() *schema.Resource {
 	}
 }
 
-func resourceSystemAutoDeleteReportAutoDeletionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteReportAutoDeletionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemAutoDeleteReportAutoDeletionUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemAutoDeleteReportAutoDeletion(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteReportAutoDeletion resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteReportAutoDeletion resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemAutoDeleteReportAutoDeletion(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemAutoDeleteReportAutoDeletion resource: %v", err)
+		return diag.Errorf("Error updating SystemAutoDeleteReportAutoDeletion resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemAutoDeleteReportAutoDeletion")
 
-	return resourceSystemAutoDeleteReportAutoDeletionRead(d, m)
+	return resourceSystemAutoDeleteReportAutoDeletionRead(ctx, d, m)
 }
 
-func resourceSystemAutoDeleteReportAutoDeletionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteReportAutoDeletionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemAutoDeleteReportAutoDeletionDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemAutoDeleteReportAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemAutoDeleteReportAutoDeletion resource: %v", err)
+		return diag.Errorf("Error deleting SystemAutoDeleteReportAutoDeletion resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemAutoDeleteReportAutoDeletionDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemAutoDeleteReportAutoDeletionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemAutoDeleteReportAutoDeletionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemAutoDeleteReportAutoDeletionRead(d *schema.ResourceData, m in
 
 	o, err := c.ReadSystemAutoDeleteReportAutoDeletion(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteReportAutoDeletion resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemAutoDeleteReportAutoDeletion resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemAutoDeleteReportAutoDeletion resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemAutoDeleteReportAutoDeletion(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemAutoDeleteReportAutoDeletion resource from API: %v", err)
+		return diag.Errorf("Error reading SystemAutoDeleteReportAutoDeletion resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemBackupAllSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemBackupAllSettingsUpdate,
-		Read:   resourceSystemBackupAllSettingsRead,
-		Update: resourceSystemBackupAllSettingsUpdate,
-		Delete: resourceSystemBackupAllSettingsDelete,
+		CreateContext: resourceSystemBackupAllSettingsUpdate,
+		ReadContext:   resourceSystemBackupAllSettingsRead,
+		UpdateContext: resourceSystemBackupAllSettingsUpdate,
+		DeleteContext: resourceSystemBackupAllSettingsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -84,10 +86,9 @@ func resourceSystemBackupAllSettings() *schema.Resource {
 	}
 }
 
-func resourceSystemBackupAllSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemBackupAllSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -95,26 +96,23 @@ func resourceSystemBackupAllSettingsUpdate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectSystemBackupAllSettings(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemBackupAllSettings resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemBackupAllSettings resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemBackupAllSettings(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemBackupAllSettings resource: %v", err)
+		return diag.Errorf("Error updating SystemBackupAllSettings resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemBackupAllSettings")
 
-	return resourceSystemBackupAllSettingsRead(d, m)
+	return resourceSystemBackupAllSettingsRead(ctx, d, m)
 }
 
-func resourceSystemBackupAllSettingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemBackupAllSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -122,7 +120,7 @@ func resourceSystemBackupAllSettingsDelete(d *schema.ResourceData, m interface{}
 
 	err = c.DeleteSystemBackupAllSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemBackupAllSettings resource: %v", err)
+		return diag.Errorf("Error deleting SystemBackupAllSettings resource: %v", err)
 	}
 
 	d.SetId("")
@@ -130,11 +128,10 @@ func resourceSystemBackupAllSettingsDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceSystemBackupAllSettingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemBackupAllSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -142,18 +139,21 @@ func resourceSystemBackupAllSettingsRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemBackupAllSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemBackupAllSettings resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemBackupAllSettings resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemBackupAllSettings resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemBackupAllSettings(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemBackupAllSettings resource from API: %v", err)
+		return diag.Errorf("Error reading SystemBackupAllSettings resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateCa() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateCaCreate,
-		Read:   resourceSystemCertificateCaRead,
-		Update: resourceSystemCertificateCaUpdate,
-		Delete: resourceSystemCertificateCaDelete,
+		CreateContext: resourceSystemCertificateCaCreate,
+		ReadContext:   resourceSystemCertificateCaRead,
+		UpdateContext: resourceSystemCertificateCaUpdate,
+		DeleteContext: resourceSystemCertificateCaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,9 +50,8 @@ func resourceSystemCertificateCa() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateCaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemCertificateCaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -58,24 +59,23 @@ func resourceSystemCertificateCaCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemCertificateCa(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateCa resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemCertificateCa resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemCertificateCa(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateCa resource: %v", err)
+		return diag.Errorf("Error creating SystemCertificateCa resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateCaRead(d, m)
+	return resourceSystemCertificateCaRead(ctx, d, m)
 }
 
-func resourceSystemCertificateCaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -83,26 +83,23 @@ func resourceSystemCertificateCaUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemCertificateCa(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateCa resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateCa resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateCa(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateCa resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateCa resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateCaRead(d, m)
+	return resourceSystemCertificateCaRead(ctx, d, m)
 }
 
-func resourceSystemCertificateCaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -110,7 +107,7 @@ func resourceSystemCertificateCaDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemCertificateCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateCa resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateCa resource: %v", err)
 	}
 
 	d.SetId("")
@@ -118,11 +115,10 @@ func resourceSystemCertificateCaDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemCertificateCaRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -130,18 +126,21 @@ func resourceSystemCertificateCaRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemCertificateCa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateCa resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateCa resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateCa resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateCa(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateCa resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateCa resource from API: %v", err)
 	}
 	return nil
 }
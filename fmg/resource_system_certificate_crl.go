@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateCrl() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateCrlCreate,
-		Read:   resourceSystemCertificateCrlRead,
-		Update: resourceSystemCertificateCrlUpdate,
-		Delete: resourceSystemCertificateCrlDelete,
+		CreateContext: resourceSystemCertificateCrlCreate,
+		ReadContext:   resourceSystemCertificateCrlRead,
+		UpdateContext: resourceSystemCertificateCrlUpdate,
+		DeleteContext: resourceSystemCertificateCrlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,9 +59,8 @@ func resourceSystemCertificateCrl() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateCrlCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemCertificateCrlCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -67,24 +68,23 @@ func resourceSystemCertificateCrlCreate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemCertificateCrl(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateCrl resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemCertificateCrl resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemCertificateCrl(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateCrl resource: %v", err)
+		return diag.Errorf("Error creating SystemCertificateCrl resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateCrlRead(d, m)
+	return resourceSystemCertificateCrlRead(ctx, d, m)
 }
 
-func resourceSystemCertificateCrlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCrlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -92,26 +92,23 @@ func resourceSystemCertificateCrlUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemCertificateCrl(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateCrl resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateCrl resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateCrl(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateCrl resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateCrl resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateCrlRead(d, m)
+	return resourceSystemCertificateCrlRead(ctx, d, m)
 }
 
-func resourceSystemCertificateCrlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCrlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -119,7 +116,7 @@ func resourceSystemCertificateCrlDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemCertificateCrl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateCrl resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateCrl resource: %v", err)
 	}
 
 	d.SetId("")
@@ -127,11 +124,10 @@ func resourceSystemCertificateCrlDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemCertificateCrlRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateCrlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -139,18 +135,21 @@ func resourceSystemCertificateCrlRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemCertificateCrl(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateCrl resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateCrl resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateCrl resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateCrl(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateCrl resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateCrl resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateLocal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateLocalCreate,
-		Read:   resourceSystemCertificateLocalRead,
-		Update: resourceSystemCertificateLocalUpdate,
-		Delete: resourceSystemCertificateLocalDelete,
+		CreateContext: resourceSystemCertificateLocalCreate,
+		ReadContext:   resourceSystemCertificateLocalRead,
+		UpdateContext: resourceSystemCertificateLocalUpdate,
+		DeleteContext: resourceSystemCertificateLocalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,9 +71,8 @@ func resourceSystemCertificateLocal() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateLocalCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemCertificateLocalCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -79,24 +80,23 @@ func resourceSystemCertificateLocalCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemCertificateLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateLocal resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemCertificateLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemCertificateLocal(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateLocal resource: %v", err)
+		return diag.Errorf("Error creating SystemCertificateLocal resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateLocalRead(d, m)
+	return resourceSystemCertificateLocalRead(ctx, d, m)
 }
 
-func resourceSystemCertificateLocalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateLocalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -104,26 +104,23 @@ func resourceSystemCertificateLocalUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemCertificateLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateLocal resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateLocal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateLocal resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateLocal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateLocalRead(d, m)
+	return resourceSystemCertificateLocalRead(ctx, d, m)
 }
 
-func resourceSystemCertificateLocalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateLocalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -131,7 +128,7 @@ func resourceSystemCertificateLocalDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemCertificateLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateLocal resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateLocal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -139,11 +136,10 @@ func resourceSystemCertificateLocalDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemCertificateLocalRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateLocalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -151,18 +147,21 @@ func resourceSystemCertificateLocalRead(d *schema.ResourceData, m interface{}) e
 
 	o, err := c.ReadSystemCertificateLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateLocal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateLocal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateLocal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateLocal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateLocal resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateLocal resource from API: %v", err)
 	}
 	return nil
 }
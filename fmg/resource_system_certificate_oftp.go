@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateOftp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateOftpUpdate,
-		Read:   resourceSystemCertificateOftpRead,
-		Update: resourceSystemCertificateOftpUpdate,
-		Delete: resourceSystemCertificateOftpDelete,
+		CreateContext: resourceSystemCertificateOftpUpdate,
+		ReadContext:   resourceSystemCertificateOftpRead,
+		UpdateContext: resourceSystemCertificateOftpUpdate,
+		DeleteContext: resourceSystemCertificateOftpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,10 +68,9 @@ func resourceSystemCertificateOftp() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateOftpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateOftpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -77,26 +78,23 @@ func resourceSystemCertificateOftpUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemCertificateOftp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateOftp resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateOftp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateOftp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateOftp resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateOftp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemCertificateOftp")
 
-	return resourceSystemCertificateOftpRead(d, m)
+	return resourceSystemCertificateOftpRead(ctx, d, m)
 }
 
-func resourceSystemCertificateOftpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateOftpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -104,7 +102,7 @@ func resourceSystemCertificateOftpDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemCertificateOftp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateOftp resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateOftp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -112,11 +110,10 @@ func resourceSystemCertificateOftpDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemCertificateOftpRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateOftpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -124,18 +121,21 @@ func resourceSystemCertificateOftpRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadSystemCertificateOftp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateOftp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateOftp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateOftp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateOftp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateOftp resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateOftp resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateRemote() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateRemoteCreate,
-		Read:   resourceSystemCertificateRemoteRead,
-		Update: resourceSystemCertificateRemoteUpdate,
-		Delete: resourceSystemCertificateRemoteDelete,
+		CreateContext: resourceSystemCertificateRemoteCreate,
+		ReadContext:   resourceSystemCertificateRemoteRead,
+		UpdateContext: resourceSystemCertificateRemoteUpdate,
+		DeleteContext: resourceSystemCertificateRemoteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,9 +51,8 @@ func resourceSystemCertificateRemote() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateRemoteCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemCertificateRemoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,24 +60,23 @@ func resourceSystemCertificateRemoteCreate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectSystemCertificateRemote(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateRemote resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemCertificateRemote resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemCertificateRemote(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateRemote resource: %v", err)
+		return diag.Errorf("Error creating SystemCertificateRemote resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateRemoteRead(d, m)
+	return resourceSystemCertificateRemoteRead(ctx, d, m)
 }
 
-func resourceSystemCertificateRemoteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateRemoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -84,26 +84,23 @@ func resourceSystemCertificateRemoteUpdate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectSystemCertificateRemote(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateRemote resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateRemote resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateRemote(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateRemote resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateRemote resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateRemoteRead(d, m)
+	return resourceSystemCertificateRemoteRead(ctx, d, m)
 }
 
-func resourceSystemCertificateRemoteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateRemoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,7 +108,7 @@ func resourceSystemCertificateRemoteDelete(d *schema.ResourceData, m interface{}
 
 	err = c.DeleteSystemCertificateRemote(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateRemote resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateRemote resource: %v", err)
 	}
 
 	d.SetId("")
@@ -119,11 +116,10 @@ func resourceSystemCertificateRemoteDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceSystemCertificateRemoteRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateRemoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -131,18 +127,21 @@ func resourceSystemCertificateRemoteRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemCertificateRemote(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateRemote resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateRemote resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateRemote resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateRemote(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateRemote resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateRemote resource from API: %v", err)
 	}
 	return nil
 }
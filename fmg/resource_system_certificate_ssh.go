@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemCertificateSsh() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemCertificateSshCreate,
-		Read:   resourceSystemCertificateSshRead,
-		Update: resourceSystemCertificateSshUpdate,
-		Delete: resourceSystemCertificateSshDelete,
+		CreateContext: resourceSystemCertificateSshCreate,
+		ReadContext:   resourceSystemCertificateSshRead,
+		UpdateContext: resourceSystemCertificateSshUpdate,
+		DeleteContext: resourceSystemCertificateSshDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -54,9 +56,8 @@ func resourceSystemCertificateSsh() *schema.Resource {
 	}
 }
 
-func resourceSystemCertificateSshCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemCertificateSshCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,24 +65,23 @@ func resourceSystemCertificateSshCreate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemCertificateSsh(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateSsh resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemCertificateSsh resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemCertificateSsh(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemCertificateSsh resource: %v", err)
+		return diag.Errorf("Error creating SystemCertificateSsh resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateSshRead(d, m)
+	return resourceSystemCertificateSshRead(ctx, d, m)
 }
 
-func resourceSystemCertificateSshUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateSshUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -89,26 +89,23 @@ func resourceSystemCertificateSshUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemCertificateSsh(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateSsh resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemCertificateSsh resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemCertificateSsh(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemCertificateSsh resource: %v", err)
+		return diag.Errorf("Error updating SystemCertificateSsh resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemCertificateSshRead(d, m)
+	return resourceSystemCertificateSshRead(ctx, d, m)
 }
 
-func resourceSystemCertificateSshDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateSshDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -116,7 +113,7 @@ func resourceSystemCertificateSshDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemCertificateSsh(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemCertificateSsh resource: %v", err)
+		return diag.Errorf("Error deleting SystemCertificateSsh resource: %v", err)
 	}
 
 	d.SetId("")
@@ -124,11 +121,10 @@ func resourceSystemCertificateSshDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemCertificateSshRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemCertificateSshRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -136,18 +132,21 @@ func resourceSystemCertificateSshRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemCertificateSsh(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateSsh resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemCertificateSsh resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemCertificateSsh resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemCertificateSsh(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemCertificateSsh resource from API: %v", err)
+		return diag.Errorf("Error reading SystemCertificateSsh resource from API: %v", err)
 	}
 	return nil
 }
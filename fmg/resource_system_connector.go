@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemConnector() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemConnectorUpdate,
-		Read:   resourceSystemConnectorRead,
-		Update: resourceSystemConnectorUpdate,
-		Delete: resourceSystemConnectorDelete,
+		CreateContext: resourceSystemConnectorUpdate,
+		ReadContext:   resourceSystemConnectorRead,
+		UpdateContext: resourceSystemConnectorUpdate,
+		DeleteContext: resourceSystemConnectorDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,10 +60,9 @@ func resourceSystemConnector() *schema.Resource {
 	}
 }
 
-func resourceSystemConnectorUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemConnectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -69,26 +70,23 @@ func resourceSystemConnectorUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemConnector(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemConnector resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemConnector resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemConnector(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemConnector resource: %v", err)
+		return diag.Errorf("Error updating SystemConnector resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemConnector")
 
-	return resourceSystemConnectorRead(d, m)
+	return resourceSystemConnectorRead(ctx, d, m)
 }
 
-func resourceSystemConnectorDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,7 +94,7 @@ func resourceSystemConnectorDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemConnector resource: %v", err)
+		return diag.Errorf("Error deleting SystemConnector resource: %v", err)
 	}
 
 	d.SetId("")
@@ -104,11 +102,10 @@ func resourceSystemConnectorDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemConnectorRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -116,18 +113,21 @@ func resourceSystemConnectorRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemConnector(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemConnector resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemConnector resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemConnector resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemConnector(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemConnector resource from API: %v", err)
+		return diag.Errorf("Error reading SystemConnector resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemDm() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemDmUpdate,
-		Read:   resourceSystemDmRead,
-		Update: resourceSystemDmUpdate,
-		Delete: resourceSystemDmDelete,
+		CreateContext: resourceSystemDmUpdate,
+		ReadContext:   resourceSystemDmRead,
+		UpdateContext: resourceSystemDmUpdate,
+		DeleteContext: resourceSystemDmDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -153,10 +155,9 @@ func resourceSystemDm() *schema.Resource {
 	}
 }
 
-func resourceSystemDmUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -164,26 +165,23 @@ func resourceSystemDmUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemDm(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDm resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemDm resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemDm(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDm resource: %v", err)
+		return diag.Errorf("Error updating SystemDm resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemDm")
 
-	return resourceSystemDmRead(d, m)
+	return resourceSystemDmRead(ctx, d, m)
 }
 
-func resourceSystemDmDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -191,7 +189,7 @@ func resourceSystemDmDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemDm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemDm resource: %v", err)
+		return diag.Errorf("Error deleting SystemDm resource: %v", err)
 	}
 
 	d.SetId("")
@@ -199,11 +197,10 @@ func resourceSystemDmDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemDmRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -211,18 +208,21 @@ func resourceSystemDmRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemDm(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDm resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemDm resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemDm resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemDm(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDm resource from API: %v", err)
+		return diag.Errorf("Error reading SystemDm resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemDns() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemDnsUpdate,
-		Read:   resourceSystemDnsRead,
-		Update: resourceSystemDnsUpdate,
-		Delete: resourceSystemDnsDelete,
+		CreateContext: resourceSystemDnsUpdate,
+		ReadContext:   resourceSystemDnsRead,
+		UpdateContext: resourceSystemDnsUpdate,
+		DeleteContext: resourceSystemDnsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemDns() *schema.Resource {
 	}
 }
 
-func resourceSystemDnsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDnsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemDnsUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemDns(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDns resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemDns resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemDns(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDns resource: %v", err)
+		return diag.Errorf("Error updating SystemDns resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemDns")
 
-	return resourceSystemDnsRead(d, m)
+	return resourceSystemDnsRead(ctx, d, m)
 }
 
-func resourceSystemDnsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDnsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemDnsDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemDns(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemDns resource: %v", err)
+		return diag.Errorf("Error deleting SystemDns resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemDnsDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemDnsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemDnsRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemDns(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDns resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemDns resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemDns resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemDns(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDns resource from API: %v", err)
+		return diag.Errorf("Error reading SystemDns resource from API: %v", err)
 	}
 	return nil
 }
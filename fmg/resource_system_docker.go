@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemDocker() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemDockerUpdate,
-		Read:   resourceSystemDockerRead,
-		Update: resourceSystemDockerUpdate,
-		Delete: resourceSystemDockerDelete,
+		CreateContext: resourceSystemDockerUpdate,
+		ReadContext:   resourceSystemDockerRead,
+		UpdateContext: resourceSystemDockerUpdate,
+		DeleteContext: resourceSystemDockerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,10 +116,9 @@ func resourceSystemDocker() *schema.Resource {
 	}
 }
 
-func resourceSystemDockerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDockerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -125,26 +126,23 @@ func resourceSystemDockerUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemDocker(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDocker resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemDocker resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemDocker(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemDocker resource: %v", err)
+		return diag.Errorf("Error updating SystemDocker resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemDocker")
 
-	return resourceSystemDockerRead(d, m)
+	return resourceSystemDockerRead(ctx, d, m)
 }
 
-func resourceSystemDockerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDockerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -152,7 +150,7 @@ func resourceSystemDockerDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemDocker(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemDocker resource: %v", err)
+		return diag.Errorf("Error deleting SystemDocker resource: %v", err)
 	}
 
 	d.SetId("")
@@ -160,11 +158,10 @@ func resourceSystemDockerDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemDockerRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemDockerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -172,18 +169,21 @@ func resourceSystemDockerRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemDocker(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDocker resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemDocker resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemDocker resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemDocker(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemDocker resource from API: %v", err)
+		return diag.Errorf("Error reading SystemDocker resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemFips() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemFipsUpdate,
-		Read:   resourceSystemFipsRead,
-		Update: resourceSystemFipsUpdate,
-		Delete: resourceSystemFipsDelete,
+		CreateContext: resourceSystemFipsUpdate,
+		ReadContext:   resourceSystemFipsRead,
+		UpdateContext: resourceSystemFipsUpdate,
+		DeleteContext: resourceSystemFipsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemFips() *schema.Resource {
 	}
 }
 
-func resourceSystemFipsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFipsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemFipsUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemFips(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFips resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemFips resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemFips(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFips resource: %v", err)
+		return diag.Errorf("Error updating SystemFips resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemFips")
 
-	return resourceSystemFipsRead(d, m)
+	return resourceSystemFipsRead(ctx, d, m)
 }
 
-func resourceSystemFipsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFipsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemFipsDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemFips(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemFips resource: %v", err)
+		return diag.Errorf("Error deleting SystemFips resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemFipsDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemFipsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFipsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemFipsRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemFips(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFips resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemFips resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemFips resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemFips(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFips resource from API: %v", err)
+		return diag.Errorf("Error reading SystemFips resource from API: %v", err)
 	}
 	return nil
 }
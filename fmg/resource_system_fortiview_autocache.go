@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemFortiviewAutoCache() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemFortiviewAutoCacheUpdate,
-		Read:   resourceSystemFortiviewAutoCacheRead,
-		Update: resourceSystemFortiviewAutoCacheUpdate,
-		Delete: resourceSystemFortiviewAutoCacheDelete,
+		CreateContext: resourceSystemFortiviewAutoCacheUpdate,
+		ReadContext:   resourceSystemFortiviewAutoCacheRead,
+		UpdateContext: resourceSystemFortiviewAutoCacheUpdate,
+		DeleteContext: resourceSystemFortiviewAutoCacheDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemFortiviewAutoCache() *schema.Resource {
 	}
 }
 
-func resourceSystemFortiviewAutoCacheUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewAutoCacheUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemFortiviewAutoCacheUpdate(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectSystemFortiviewAutoCache(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFortiviewAutoCache resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemFortiviewAutoCache resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemFortiviewAutoCache(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFortiviewAutoCache resource: %v", err)
+		return diag.Errorf("Error updating SystemFortiviewAutoCache resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemFortiviewAutoCache")
 
-	return resourceSystemFortiviewAutoCacheRead(d, m)
+	return resourceSystemFortiviewAutoCacheRead(ctx, d, m)
 }
 
-func resourceSystemFortiviewAutoCacheDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewAutoCacheDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemFortiviewAutoCacheDelete(d *schema.ResourceData, m interface{
 
 	err = c.DeleteSystemFortiviewAutoCache(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemFortiviewAutoCache resource: %v", err)
+		return diag.Errorf("Error deleting SystemFortiviewAutoCache resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemFortiviewAutoCacheDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceSystemFortiviewAutoCacheRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewAutoCacheRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemFortiviewAutoCacheRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemFortiviewAutoCache(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFortiviewAutoCache resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemFortiviewAutoCache resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemFortiviewAutoCache resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemFortiviewAutoCache(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFortiviewAutoCache resource from API: %v", err)
+		return diag.Errorf("Error reading SystemFortiviewAutoCache resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemFortiviewSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemFortiviewSettingUpdate,
-		Read:   resourceSystemFortiviewSettingRead,
-		Update: resourceSystemFortiviewSettingUpdate,
-		Delete: resourceSystemFortiviewSettingDelete,
+		CreateContext: resourceSystemFortiviewSettingUpdate,
+		ReadContext:   resourceSystemFortiviewSettingRead,
+		UpdateContext: resourceSystemFortiviewSettingUpdate,
+		DeleteContext: resourceSystemFortiviewSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemFortiviewSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemFortiviewSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemFortiviewSettingUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemFortiviewSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFortiviewSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemFortiviewSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemFortiviewSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemFortiviewSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemFortiviewSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemFortiviewSetting")
 
-	return resourceSystemFortiviewSettingRead(d, m)
+	return resourceSystemFortiviewSettingRead(ctx, d, m)
 }
 
-func resourceSystemFortiviewSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemFortiviewSettingDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemFortiviewSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemFortiviewSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemFortiviewSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemFortiviewSettingDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemFortiviewSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemFortiviewSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemFortiviewSettingRead(d *schema.ResourceData, m interface{}) e
 
 	o, err := c.ReadSystemFortiviewSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFortiviewSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemFortiviewSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemFortiviewSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemFortiviewSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemFortiviewSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemFortiviewSetting resource from API: %v", err)
 	}
 	return nil
 }
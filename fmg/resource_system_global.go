@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemGlobal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemGlobalUpdate,
-		Read:   resourceSystemGlobalRead,
-		Update: resourceSystemGlobalUpdate,
-		Delete: resourceSystemGlobalDelete,
+		CreateContext: resourceSystemGlobalUpdate,
+		ReadContext:   resourceSystemGlobalRead,
+		UpdateContext: resourceSystemGlobalUpdate,
+		DeleteContext: resourceSystemGlobalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -425,10 +427,9 @@ func resourceSystemGlobal() *schema.Resource {
 	}
 }
 
-func resourceSystemGlobalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -436,26 +437,23 @@ func resourceSystemGlobalUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemGlobal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGlobal resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemGlobal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemGlobal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGlobal resource: %v", err)
+		return diag.Errorf("Error updating SystemGlobal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemGlobal")
 
-	return resourceSystemGlobalRead(d, m)
+	return resourceSystemGlobalRead(ctx, d, m)
 }
 
-func resourceSystemGlobalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -463,7 +461,7 @@ func resourceSystemGlobalDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemGlobal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemGlobal resource: %v", err)
+		return diag.Errorf("Error deleting SystemGlobal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -471,11 +469,10 @@ func resourceSystemGlobalDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemGlobalRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -483,18 +480,21 @@ func resourceSystemGlobalRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemGlobal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGlobal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemGlobal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemGlobal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemGlobal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGlobal resource from API: %v", err)
+		return diag.Errorf("Error reading SystemGlobal resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemGlobalSslCipherSuites() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemGlobalSslCipherSuitesCreate,
-		Read:   resourceSystemGlobalSslCipherSuitesRead,
-		Update: resourceSystemGlobalSslCipherSuitesUpdate,
-		Delete: resourceSystemGlobalSslCipherSuitesDelete,
+		CreateContext: resourceSystemGlobalSslCipherSuitesCreate,
+		ReadContext:   resourceSystemGlobalSslCipherSuitesRead,
+		UpdateContext: resourceSystemGlobalSslCipherSuitesUpdate,
+		DeleteContext: resourceSystemGlobalSslCipherSuitesDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -46,9 +48,8 @@ func resourceSystemGlobalSslCipherSuites() *schema.Resource {
 	}
 }
 
-func resourceSystemGlobalSslCipherSuitesCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemGlobalSslCipherSuitesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -56,24 +57,23 @@ func resourceSystemGlobalSslCipherSuitesCreate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemGlobalSslCipherSuites(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemGlobalSslCipherSuites resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemGlobalSslCipherSuites resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemGlobalSslCipherSuites(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemGlobalSslCipherSuites resource: %v", err)
+		return diag.Errorf("Error creating SystemGlobalSslCipherSuites resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceSystemGlobalSslCipherSuitesRead(d, m)
+	return resourceSystemGlobalSslCipherSuitesRead(ctx, d, m)
 }
 
-func resourceSystemGlobalSslCipherSuitesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalSslCipherSuitesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,26 +81,23 @@ func resourceSystemGlobalSslCipherSuitesUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemGlobalSslCipherSuites(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGlobalSslCipherSuites resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemGlobalSslCipherSuites resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemGlobalSslCipherSuites(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGlobalSslCipherSuites resource: %v", err)
+		return diag.Errorf("Error updating SystemGlobalSslCipherSuites resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceSystemGlobalSslCipherSuitesRead(d, m)
+	return resourceSystemGlobalSslCipherSuitesRead(ctx, d, m)
 }
 
-func resourceSystemGlobalSslCipherSuitesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalSslCipherSuitesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -108,7 +105,7 @@ func resourceSystemGlobalSslCipherSuitesDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteSystemGlobalSslCipherSuites(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemGlobalSslCipherSuites resource: %v", err)
+		return diag.Errorf("Error deleting SystemGlobalSslCipherSuites resource: %v", err)
 	}
 
 	d.SetId("")
@@ -116,11 +113,10 @@ func resourceSystemGlobalSslCipherSuitesDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceSystemGlobalSslCipherSuitesRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGlobalSslCipherSuitesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -128,18 +124,21 @@ func resourceSystemGlobalSslCipherSuitesRead(d *schema.ResourceData, m interface
 
 	o, err := c.ReadSystemGlobalSslCipherSuites(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGlobalSslCipherSuites resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemGlobalSslCipherSuites resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemGlobalSslCipherSuites resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemGlobalSslCipherSuites(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGlobalSslCipherSuites resource from API: %v", err)
+		return diag.Errorf("Error reading SystemGlobalSslCipherSuites resource from API: %v", err)
 	}
 	return nil
 }
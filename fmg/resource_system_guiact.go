@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemGuiact() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemGuiactUpdate,
-		Read:   resourceSystemGuiactRead,
-		Update: resourceSystemGuiactUpdate,
-		Delete: resourceSystemGuiactDelete,
+		CreateContext: resourceSystemGuiactUpdate,
+		ReadContext:   resourceSystemGuiactRead,
+		UpdateContext: resourceSystemGuiactUpdate,
+		DeleteContext: resourceSystemGuiactDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,10 +71,9 @@ func resourceSystemGuiact() *schema.Resource {
 	}
 }
 
-func resourceSystemGuiactUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGuiactUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -80,26 +81,23 @@ func resourceSystemGuiactUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemGuiact(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGuiact resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemGuiact resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemGuiact(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemGuiact resource: %v", err)
+		return diag.Errorf("Error updating SystemGuiact resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemGuiact")
 
-	return resourceSystemGuiactRead(d, m)
+	return resourceSystemGuiactRead(ctx, d, m)
 }
 
-func resourceSystemGuiactDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGuiactDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -107,7 +105,7 @@ func resourceSystemGuiactDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemGuiact(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemGuiact resource: %v", err)
+		return diag.Errorf("Error deleting SystemGuiact resource: %v", err)
 	}
 
 	d.SetId("")
@@ -115,11 +113,10 @@ func resourceSystemGuiactDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemGuiactRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemGuiactRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -127,18 +124,21 @@ func resourceSystemGuiactRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemGuiact(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGuiact resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemGuiact resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemGuiact resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemGuiact(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemGuiact resource from API: %v", err)
+		return diag.Errorf("Error reading SystemGuiact resource from API: %v", err)
 	}
 	return nil
 }
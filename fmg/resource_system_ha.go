@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemHa() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemHaUpdate,
-		Read:   resourceSystemHaRead,
-		Update: resourceSystemHaUpdate,
-		Delete: resourceSystemHaDelete,
+		CreateContext: resourceSystemHaUpdate,
+		ReadContext:   resourceSystemHaRead,
+		UpdateContext: resourceSystemHaUpdate,
+		DeleteContext: resourceSystemHaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -165,10 +167,9 @@ func resourceSystemHa() *schema.Resource {
 	}
 }
 
-func resourceSystemHaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -176,26 +177,23 @@ func resourceSystemHaUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemHa(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHa resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemHa resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemHa(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHa resource: %v", err)
+		return diag.Errorf("Error updating SystemHa resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemHa")
 
-	return resourceSystemHaRead(d, m)
+	return resourceSystemHaRead(ctx, d, m)
 }
 
-func resourceSystemHaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -203,7 +201,7 @@ func resourceSystemHaDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemHa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemHa resource: %v", err)
+		return diag.Errorf("Error deleting SystemHa resource: %v", err)
 	}
 
 	d.SetId("")
@@ -211,11 +209,10 @@ func resourceSystemHaDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemHaRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -223,18 +220,21 @@ func resourceSystemHaRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemHa(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHa resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemHa resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemHa resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemHa(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHa resource from API: %v", err)
+		return diag.Errorf("Error reading SystemHa resource from API: %v", err)
 	}
 	return nil
 }
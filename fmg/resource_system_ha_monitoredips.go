@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemHaMonitoredIps() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemHaMonitoredIpsCreate,
-		Read:   resourceSystemHaMonitoredIpsRead,
-		Update: resourceSystemHaMonitoredIpsUpdate,
-		Delete: resourceSystemHaMonitoredIpsDelete,
+		CreateContext: resourceSystemHaMonitoredIpsCreate,
+		ReadContext:   resourceSystemHaMonitoredIpsRead,
+		UpdateContext: resourceSystemHaMonitoredIpsUpdate,
+		DeleteContext: resourceSystemHaMonitoredIpsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -46,9 +48,8 @@ func resourceSystemHaMonitoredIps() *schema.Resource {
 	}
 }
 
-func resourceSystemHaMonitoredIpsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemHaMonitoredIpsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -56,24 +57,23 @@ func resourceSystemHaMonitoredIpsCreate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemHaMonitoredIps(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemHaMonitoredIps resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemHaMonitoredIps resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemHaMonitoredIps(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemHaMonitoredIps resource: %v", err)
+		return diag.Errorf("Error creating SystemHaMonitoredIps resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemHaMonitoredIpsRead(d, m)
+	return resourceSystemHaMonitoredIpsRead(ctx, d, m)
 }
 
-func resourceSystemHaMonitoredIpsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaMonitoredIpsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,26 +81,23 @@ func resourceSystemHaMonitoredIpsUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemHaMonitoredIps(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaMonitoredIps resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemHaMonitoredIps resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemHaMonitoredIps(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaMonitoredIps resource: %v", err)
+		return diag.Errorf("Error updating SystemHaMonitoredIps resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemHaMonitoredIpsRead(d, m)
+	return resourceSystemHaMonitoredIpsRead(ctx, d, m)
 }
 
-func resourceSystemHaMonitoredIpsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaMonitoredIpsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -108,7 +105,7 @@ func resourceSystemHaMonitoredIpsDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemHaMonitoredIps(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemHaMonitoredIps resource: %v", err)
+		return diag.Errorf("Error deleting SystemHaMonitoredIps resource: %v", err)
 	}
 
 	d.SetId("")
@@ -116,11 +113,10 @@ func resourceSystemHaMonitoredIpsDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemHaMonitoredIpsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaMonitoredIpsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -128,18 +124,21 @@ func resourceSystemHaMonitoredIpsRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemHaMonitoredIps(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaMonitoredIps resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemHaMonitoredIps resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemHaMonitoredIps resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemHaMonitoredIps(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaMonitoredIps resource from API: %v", err)
+		return diag.Errorf("Error reading SystemHaMonitoredIps resource from API: %v", err)
 	}
 	return nil
 }
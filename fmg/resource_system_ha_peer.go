@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemHaPeer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemHaPeerCreate,
-		Read:   resourceSystemHaPeerRead,
-		Update: resourceSystemHaPeerUpdate,
-		Delete: resourceSystemHaPeerDelete,
+		CreateContext: resourceSystemHaPeerCreate,
+		ReadContext:   resourceSystemHaPeerRead,
+		UpdateContext: resourceSystemHaPeerUpdate,
+		DeleteContext: resourceSystemHaPeerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,9 +59,8 @@ func resourceSystemHaPeer() *schema.Resource {
 	}
 }
 
-func resourceSystemHaPeerCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemHaPeerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -67,24 +68,23 @@ func resourceSystemHaPeerCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemHaPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemHaPeer resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemHaPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemHaPeer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemHaPeer resource: %v", err)
+		return diag.Errorf("Error creating SystemHaPeer resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemHaPeerRead(d, m)
+	return resourceSystemHaPeerRead(ctx, d, m)
 }
 
-func resourceSystemHaPeerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaPeerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -92,26 +92,23 @@ func resourceSystemHaPeerUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemHaPeer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaPeer resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemHaPeer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemHaPeer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaPeer resource: %v", err)
+		return diag.Errorf("Error updating SystemHaPeer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemHaPeerRead(d, m)
+	return resourceSystemHaPeerRead(ctx, d, m)
 }
 
-func resourceSystemHaPeerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaPeerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -119,7 +116,7 @@ func resourceSystemHaPeerDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemHaPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemHaPeer resource: %v", err)
+		return diag.Errorf("Error deleting SystemHaPeer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -127,11 +124,10 @@ func resourceSystemHaPeerDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemHaPeerRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaPeerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -139,18 +135,21 @@ func resourceSystemHaPeerRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemHaPeer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaPeer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemHaPeer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemHaPeer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemHaPeer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaPeer resource from API: %v", err)
+		return diag.Errorf("Error reading SystemHaPeer resource from API: %v", err)
 	}
 	return nil
 }
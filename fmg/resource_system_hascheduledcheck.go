@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemHaScheduledCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemHaScheduledCheckUpdate,
-		Read:   resourceSystemHaScheduledCheckRead,
-		Update: resourceSystemHaScheduledCheckUpdate,
-		Delete: resourceSystemHaScheduledCheckDelete,
+		CreateContext: resourceSystemHaScheduledCheckUpdate,
+		ReadContext:   resourceSystemHaScheduledCheckRead,
+		UpdateContext: resourceSystemHaScheduledCheckUpdate,
+		DeleteContext: resourceSystemHaScheduledCheckDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemHaScheduledCheck() *schema.Resource {
 	}
 }
 
-func resourceSystemHaScheduledCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaScheduledCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemHaScheduledCheckUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemHaScheduledCheck(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaScheduledCheck resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemHaScheduledCheck resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemHaScheduledCheck(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemHaScheduledCheck resource: %v", err)
+		return diag.Errorf("Error updating SystemHaScheduledCheck resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemHaScheduledCheck")
 
-	return resourceSystemHaScheduledCheckRead(d, m)
+	return resourceSystemHaScheduledCheckRead(ctx, d, m)
 }
 
-func resourceSystemHaScheduledCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaScheduledCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemHaScheduledCheckDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemHaScheduledCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemHaScheduledCheck resource: %v", err)
+		return diag.Errorf("Error deleting SystemHaScheduledCheck resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemHaScheduledCheckDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemHaScheduledCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemHaScheduledCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemHaScheduledCheckRead(d *schema.ResourceData, m interface{}) e
 
 	o, err := c.ReadSystemHaScheduledCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaScheduledCheck resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemHaScheduledCheck resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemHaScheduledCheck resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemHaScheduledCheck(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemHaScheduledCheck resource from API: %v", err)
+		return diag.Errorf("Error reading SystemHaScheduledCheck resource from API: %v", err)
 	}
 	return nil
 }
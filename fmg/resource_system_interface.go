@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemInterface() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemInterfaceCreate,
-		Read:   resourceSystemInterfaceRead,
-		Update: resourceSystemInterfaceUpdate,
-		Delete: resourceSystemInterfaceDelete,
+		CreateContext: resourceSystemInterfaceCreate,
+		ReadContext:   resourceSystemInterfaceRead,
+		UpdateContext: resourceSystemInterfaceUpdate,
+		DeleteContext: resourceSystemInterfaceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -191,9 +193,8 @@ func resourceSystemInterface() *schema.Resource {
 	}
 }
 
-func resourceSystemInterfaceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -201,7 +202,7 @@ func resourceSystemInterfaceCreate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemInterface resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemInterface resource while getting object: %v", err)
 	}
 
 	v, _ := d.GetOk("type")
@@ -213,18 +214,17 @@ func resourceSystemInterfaceCreate(d *schema.ResourceData, m interface{}) error
 	}
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemInterface resource: %v", err)
+		return diag.Errorf("Error creating SystemInterface resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemInterfaceRead(d, m)
+	return resourceSystemInterfaceRead(ctx, d, m)
 }
 
-func resourceSystemInterfaceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -232,26 +232,23 @@ func resourceSystemInterfaceUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemInterface(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemInterface resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemInterface resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemInterface(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemInterface resource: %v", err)
+		return diag.Errorf("Error updating SystemInterface resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemInterfaceRead(d, m)
+	return resourceSystemInterfaceRead(ctx, d, m)
 }
 
-func resourceSystemInterfaceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -266,7 +263,7 @@ func resourceSystemInterfaceDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemInterface resource: %v", err)
+		return diag.Errorf("Error deleting SystemInterface resource: %v", err)
 	}
 
 	d.SetId("")
@@ -274,11 +271,10 @@ func resourceSystemInterfaceDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemInterfaceRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -286,18 +282,21 @@ func resourceSystemInterfaceRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemInterface(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemInterface resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemInterface resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemInterface resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemInterface(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemInterface resource from API: %v", err)
+		return diag.Errorf("Error reading SystemInterface resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocalInPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocalInPolicyCreate,
-		Read:   resourceSystemLocalInPolicyRead,
-		Update: resourceSystemLocalInPolicyUpdate,
-		Delete: resourceSystemLocalInPolicyDelete,
+		CreateContext: resourceSystemLocalInPolicyCreate,
+		ReadContext:   resourceSystemLocalInPolicyRead,
+		UpdateContext: resourceSystemLocalInPolicyUpdate,
+		DeleteContext: resourceSystemLocalInPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,9 +70,8 @@ func resourceSystemLocalInPolicy() *schema.Resource {
 	}
 }
 
-func resourceSystemLocalInPolicyCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLocalInPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -78,24 +79,23 @@ func resourceSystemLocalInPolicyCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemLocalInPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLocalInPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLocalInPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLocalInPolicy(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error creating SystemLocalInPolicy resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLocalInPolicyRead(d, m)
+	return resourceSystemLocalInPolicyRead(ctx, d, m)
 }
 
-func resourceSystemLocalInPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -103,26 +103,23 @@ func resourceSystemLocalInPolicyUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemLocalInPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocalInPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocalInPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocalInPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error updating SystemLocalInPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLocalInPolicyRead(d, m)
+	return resourceSystemLocalInPolicyRead(ctx, d, m)
 }
 
-func resourceSystemLocalInPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -130,7 +127,7 @@ func resourceSystemLocalInPolicyDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemLocalInPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocalInPolicy resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocalInPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -138,11 +135,10 @@ func resourceSystemLocalInPolicyDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemLocalInPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -150,18 +146,21 @@ func resourceSystemLocalInPolicyRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemLocalInPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocalInPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocalInPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocalInPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocalInPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocalInPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocalInPolicy resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocalInPolicy6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocalInPolicy6Create,
-		Read:   resourceSystemLocalInPolicy6Read,
-		Update: resourceSystemLocalInPolicy6Update,
-		Delete: resourceSystemLocalInPolicy6Delete,
+		CreateContext: resourceSystemLocalInPolicy6Create,
+		ReadContext:   resourceSystemLocalInPolicy6Read,
+		UpdateContext: resourceSystemLocalInPolicy6Update,
+		DeleteContext: resourceSystemLocalInPolicy6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,9 +68,8 @@ func resourceSystemLocalInPolicy6() *schema.Resource {
 	}
 }
 
-func resourceSystemLocalInPolicy6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLocalInPolicy6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,24 +77,23 @@ func resourceSystemLocalInPolicy6Create(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemLocalInPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLocalInPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLocalInPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLocalInPolicy6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error creating SystemLocalInPolicy6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLocalInPolicy6Read(d, m)
+	return resourceSystemLocalInPolicy6Read(ctx, d, m)
 }
 
-func resourceSystemLocalInPolicy6Update(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicy6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,26 +101,23 @@ func resourceSystemLocalInPolicy6Update(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemLocalInPolicy6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocalInPolicy6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocalInPolicy6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocalInPolicy6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error updating SystemLocalInPolicy6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLocalInPolicy6Read(d, m)
+	return resourceSystemLocalInPolicy6Read(ctx, d, m)
 }
 
-func resourceSystemLocalInPolicy6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicy6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -128,7 +125,7 @@ func resourceSystemLocalInPolicy6Delete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemLocalInPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocalInPolicy6 resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocalInPolicy6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -136,11 +133,10 @@ func resourceSystemLocalInPolicy6Delete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemLocalInPolicy6Read(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocalInPolicy6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -148,18 +144,21 @@ func resourceSystemLocalInPolicy6Read(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemLocalInPolicy6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocalInPolicy6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocalInPolicy6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocalInPolicy6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocalInPolicy6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocalInPolicy6 resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocalInPolicy6 resource from API: %v", err)
 	}
 	return nil
 }
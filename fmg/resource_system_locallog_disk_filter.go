@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogDiskFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogDiskFilterUpdate,
-		Read:   resourceSystemLocallogDiskFilterRead,
-		Update: resourceSystemLocallogDiskFilterUpdate,
-		Delete: resourceSystemLocallogDiskFilterDelete,
+		CreateContext: resourceSystemLocallogDiskFilterUpdate,
+		ReadContext:   resourceSystemLocallogDiskFilterRead,
+		UpdateContext: resourceSystemLocallogDiskFilterUpdate,
+		DeleteContext: resourceSystemLocallogDiskFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogDiskFilter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogDiskFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogDiskFilterUpdate(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectSystemLocallogDiskFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogDiskFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogDiskFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogDiskFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogDiskFilter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogDiskFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogDiskFilter")
 
-	return resourceSystemLocallogDiskFilterRead(d, m)
+	return resourceSystemLocallogDiskFilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogDiskFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogDiskFilterDelete(d *schema.ResourceData, m interface{
 
 	err = c.DeleteSystemLocallogDiskFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogDiskFilter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogDiskFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogDiskFilterDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceSystemLocallogDiskFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogDiskFilterRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemLocallogDiskFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogDiskFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogDiskFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogDiskFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogDiskFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogDiskFilter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogDiskFilter resource from API: %v", err)
 	}
 	return nil
 }
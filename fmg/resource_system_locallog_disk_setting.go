@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogDiskSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogDiskSettingUpdate,
-		Read:   resourceSystemLocallogDiskSettingRead,
-		Update: resourceSystemLocallogDiskSettingUpdate,
-		Delete: resourceSystemLocallogDiskSettingDelete,
+		CreateContext: resourceSystemLocallogDiskSettingUpdate,
+		ReadContext:   resourceSystemLocallogDiskSettingRead,
+		UpdateContext: resourceSystemLocallogDiskSettingUpdate,
+		DeleteContext: resourceSystemLocallogDiskSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -143,10 +145,9 @@ func resourceSystemLocallogDiskSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogDiskSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -154,26 +155,23 @@ func resourceSystemLocallogDiskSettingUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectSystemLocallogDiskSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogDiskSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogDiskSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogDiskSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogDiskSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogDiskSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogDiskSetting")
 
-	return resourceSystemLocallogDiskSettingRead(d, m)
+	return resourceSystemLocallogDiskSettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogDiskSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -181,7 +179,7 @@ func resourceSystemLocallogDiskSettingDelete(d *schema.ResourceData, m interface
 
 	err = c.DeleteSystemLocallogDiskSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogDiskSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogDiskSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -189,11 +187,10 @@ func resourceSystemLocallogDiskSettingDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceSystemLocallogDiskSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogDiskSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -201,18 +198,21 @@ func resourceSystemLocallogDiskSettingRead(d *schema.ResourceData, m interface{}
 
 	o, err := c.ReadSystemLocallogDiskSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogDiskSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogDiskSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogDiskSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogDiskSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogDiskSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogDiskSetting resource from API: %v", err)
 	}
 	return nil
 }
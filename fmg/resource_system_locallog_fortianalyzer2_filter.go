@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzer2Filter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzer2FilterUpdate,
-		Read:   resourceSystemLocallogFortianalyzer2FilterRead,
-		Update: resourceSystemLocallogFortianalyzer2FilterUpdate,
-		Delete: resourceSystemLocallogFortianalyzer2FilterDelete,
+		CreateContext: resourceSystemLocallogFortianalyzer2FilterUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzer2FilterRead,
+		UpdateContext: resourceSystemLocallogFortianalyzer2FilterUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzer2FilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogFortianalyzer2Filter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzer2FilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2FilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogFortianalyzer2FilterUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemLocallogFortianalyzer2Filter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer2Filter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer2Filter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzer2Filter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer2Filter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer2Filter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzer2Filter")
 
-	return resourceSystemLocallogFortianalyzer2FilterRead(d, m)
+	return resourceSystemLocallogFortianalyzer2FilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzer2FilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2FilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogFortianalyzer2FilterDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemLocallogFortianalyzer2Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzer2Filter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzer2Filter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogFortianalyzer2FilterDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzer2FilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2FilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogFortianalyzer2FilterRead(d *schema.ResourceData, m in
 
 	o, err := c.ReadSystemLocallogFortianalyzer2Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer2Filter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Filter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Filter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzer2Filter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer2Filter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Filter resource from API: %v", err)
 	}
 	return nil
 }
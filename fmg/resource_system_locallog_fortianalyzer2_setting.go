@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzer2Setting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzer2SettingUpdate,
-		Read:   resourceSystemLocallogFortianalyzer2SettingRead,
-		Update: resourceSystemLocallogFortianalyzer2SettingUpdate,
-		Delete: resourceSystemLocallogFortianalyzer2SettingDelete,
+		CreateContext: resourceSystemLocallogFortianalyzer2SettingUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzer2SettingRead,
+		UpdateContext: resourceSystemLocallogFortianalyzer2SettingUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzer2SettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,10 +69,9 @@ func resourceSystemLocallogFortianalyzer2Setting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzer2SettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2SettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -78,26 +79,23 @@ func resourceSystemLocallogFortianalyzer2SettingUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemLocallogFortianalyzer2Setting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer2Setting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer2Setting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzer2Setting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer2Setting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer2Setting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzer2Setting")
 
-	return resourceSystemLocallogFortianalyzer2SettingRead(d, m)
+	return resourceSystemLocallogFortianalyzer2SettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzer2SettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2SettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -105,7 +103,7 @@ func resourceSystemLocallogFortianalyzer2SettingDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemLocallogFortianalyzer2Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzer2Setting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzer2Setting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -113,11 +111,10 @@ func resourceSystemLocallogFortianalyzer2SettingDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzer2SettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer2SettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -125,18 +122,21 @@ func resourceSystemLocallogFortianalyzer2SettingRead(d *schema.ResourceData, m i
 
 	o, err := c.ReadSystemLocallogFortianalyzer2Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer2Setting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Setting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Setting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzer2Setting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer2Setting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer2Setting resource from API: %v", err)
 	}
 	return nil
 }
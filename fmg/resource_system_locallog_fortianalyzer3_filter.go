@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzer3Filter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzer3FilterUpdate,
-		Read:   resourceSystemLocallogFortianalyzer3FilterRead,
-		Update: resourceSystemLocallogFortianalyzer3FilterUpdate,
-		Delete: resourceSystemLocallogFortianalyzer3FilterDelete,
+		CreateContext: resourceSystemLocallogFortianalyzer3FilterUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzer3FilterRead,
+		UpdateContext: resourceSystemLocallogFortianalyzer3FilterUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzer3FilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogFortianalyzer3Filter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzer3FilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3FilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogFortianalyzer3FilterUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemLocallogFortianalyzer3Filter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer3Filter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer3Filter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzer3Filter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer3Filter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer3Filter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzer3Filter")
 
-	return resourceSystemLocallogFortianalyzer3FilterRead(d, m)
+	return resourceSystemLocallogFortianalyzer3FilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzer3FilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3FilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogFortianalyzer3FilterDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemLocallogFortianalyzer3Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzer3Filter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzer3Filter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogFortianalyzer3FilterDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzer3FilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3FilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogFortianalyzer3FilterRead(d *schema.ResourceData, m in
 
 	o, err := c.ReadSystemLocallogFortianalyzer3Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer3Filter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Filter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Filter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzer3Filter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer3Filter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Filter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzer3Setting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzer3SettingUpdate,
-		Read:   resourceSystemLocallogFortianalyzer3SettingRead,
-		Update: resourceSystemLocallogFortianalyzer3SettingUpdate,
-		Delete: resourceSystemLocallogFortianalyzer3SettingDelete,
+		CreateContext: resourceSystemLocallogFortianalyzer3SettingUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzer3SettingRead,
+		UpdateContext: resourceSystemLocallogFortianalyzer3SettingUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzer3SettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,10 +69,9 @@ func resourceSystemLocallogFortianalyzer3Setting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzer3SettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3SettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -78,26 +79,23 @@ func resourceSystemLocallogFortianalyzer3SettingUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemLocallogFortianalyzer3Setting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer3Setting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer3Setting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzer3Setting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzer3Setting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzer3Setting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzer3Setting")
 
-	return resourceSystemLocallogFortianalyzer3SettingRead(d, m)
+	return resourceSystemLocallogFortianalyzer3SettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzer3SettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3SettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -105,7 +103,7 @@ func resourceSystemLocallogFortianalyzer3SettingDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemLocallogFortianalyzer3Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzer3Setting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzer3Setting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -113,11 +111,10 @@ func resourceSystemLocallogFortianalyzer3SettingDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzer3SettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzer3SettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -125,18 +122,21 @@ func resourceSystemLocallogFortianalyzer3SettingRead(d *schema.ResourceData, m i
 
 	o, err := c.ReadSystemLocallogFortianalyzer3Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer3Setting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Setting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Setting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzer3Setting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzer3Setting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzer3Setting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzerFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzerFilterUpdate,
-		Read:   resourceSystemLocallogFortianalyzerFilterRead,
-		Update: resourceSystemLocallogFortianalyzerFilterUpdate,
-		Delete: resourceSystemLocallogFortianalyzerFilterDelete,
+		CreateContext: resourceSystemLocallogFortianalyzerFilterUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzerFilterRead,
+		UpdateContext: resourceSystemLocallogFortianalyzerFilterUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzerFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogFortianalyzerFilter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzerFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogFortianalyzerFilterUpdate(d *schema.ResourceData, m i
 
 	obj, err := getObjectSystemLocallogFortianalyzerFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzerFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzerFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzerFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzerFilter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzerFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzerFilter")
 
-	return resourceSystemLocallogFortianalyzerFilterRead(d, m)
+	return resourceSystemLocallogFortianalyzerFilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzerFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogFortianalyzerFilterDelete(d *schema.ResourceData, m i
 
 	err = c.DeleteSystemLocallogFortianalyzerFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzerFilter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzerFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogFortianalyzerFilterDelete(d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzerFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogFortianalyzerFilterRead(d *schema.ResourceData, m int
 
 	o, err := c.ReadSystemLocallogFortianalyzerFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzerFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzerFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzerFilter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerFilter resource from API: %v", err)
 	}
 	return nil
 }
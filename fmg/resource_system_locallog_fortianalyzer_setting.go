@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogFortianalyzerSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogFortianalyzerSettingUpdate,
-		Read:   resourceSystemLocallogFortianalyzerSettingRead,
-		Update: resourceSystemLocallogFortianalyzerSettingUpdate,
-		Delete: resourceSystemLocallogFortianalyzerSettingDelete,
+		CreateContext: resourceSystemLocallogFortianalyzerSettingUpdate,
+		ReadContext:   resourceSystemLocallogFortianalyzerSettingRead,
+		UpdateContext: resourceSystemLocallogFortianalyzerSettingUpdate,
+		DeleteContext: resourceSystemLocallogFortianalyzerSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,10 +69,9 @@ func resourceSystemLocallogFortianalyzerSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogFortianalyzerSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -78,26 +79,23 @@ func resourceSystemLocallogFortianalyzerSettingUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectSystemLocallogFortianalyzerSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzerSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzerSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogFortianalyzerSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogFortianalyzerSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogFortianalyzerSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogFortianalyzerSetting")
 
-	return resourceSystemLocallogFortianalyzerSettingRead(d, m)
+	return resourceSystemLocallogFortianalyzerSettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogFortianalyzerSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -105,7 +103,7 @@ func resourceSystemLocallogFortianalyzerSettingDelete(d *schema.ResourceData, m
 
 	err = c.DeleteSystemLocallogFortianalyzerSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogFortianalyzerSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogFortianalyzerSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -113,11 +111,10 @@ func resourceSystemLocallogFortianalyzerSettingDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceSystemLocallogFortianalyzerSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogFortianalyzerSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -125,18 +122,21 @@ func resourceSystemLocallogFortianalyzerSettingRead(d *schema.ResourceData, m in
 
 	o, err := c.ReadSystemLocallogFortianalyzerSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzerSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogFortianalyzerSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogFortianalyzerSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogFortianalyzerSetting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogMemoryFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogMemoryFilterUpdate,
-		Read:   resourceSystemLocallogMemoryFilterRead,
-		Update: resourceSystemLocallogMemoryFilterUpdate,
-		Delete: resourceSystemLocallogMemoryFilterDelete,
+		CreateContext: resourceSystemLocallogMemoryFilterUpdate,
+		ReadContext:   resourceSystemLocallogMemoryFilterRead,
+		UpdateContext: resourceSystemLocallogMemoryFilterUpdate,
+		DeleteContext: resourceSystemLocallogMemoryFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogMemoryFilter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogMemoryFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemoryFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogMemoryFilterUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectSystemLocallogMemoryFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogMemoryFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogMemoryFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogMemoryFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogMemoryFilter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogMemoryFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogMemoryFilter")
 
-	return resourceSystemLocallogMemoryFilterRead(d, m)
+	return resourceSystemLocallogMemoryFilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogMemoryFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemoryFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogMemoryFilterDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeleteSystemLocallogMemoryFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogMemoryFilter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogMemoryFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogMemoryFilterDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceSystemLocallogMemoryFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemoryFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogMemoryFilterRead(d *schema.ResourceData, m interface{
 
 	o, err := c.ReadSystemLocallogMemoryFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogMemoryFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogMemoryFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogMemoryFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogMemoryFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogMemoryFilter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogMemoryFilter resource from API: %v", err)
 	}
 	return nil
 }
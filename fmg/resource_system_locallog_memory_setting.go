@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogMemorySetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogMemorySettingUpdate,
-		Read:   resourceSystemLocallogMemorySettingRead,
-		Update: resourceSystemLocallogMemorySettingUpdate,
-		Delete: resourceSystemLocallogMemorySettingDelete,
+		CreateContext: resourceSystemLocallogMemorySettingUpdate,
+		ReadContext:   resourceSystemLocallogMemorySettingRead,
+		UpdateContext: resourceSystemLocallogMemorySettingUpdate,
+		DeleteContext: resourceSystemLocallogMemorySettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemLocallogMemorySetting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogMemorySettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemorySettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemLocallogMemorySettingUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemLocallogMemorySetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogMemorySetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogMemorySetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogMemorySetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogMemorySetting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogMemorySetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogMemorySetting")
 
-	return resourceSystemLocallogMemorySettingRead(d, m)
+	return resourceSystemLocallogMemorySettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogMemorySettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemorySettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemLocallogMemorySettingDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteSystemLocallogMemorySetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogMemorySetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogMemorySetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemLocallogMemorySettingDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceSystemLocallogMemorySettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogMemorySettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemLocallogMemorySettingRead(d *schema.ResourceData, m interface
 
 	o, err := c.ReadSystemLocallogMemorySetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogMemorySetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogMemorySetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogMemorySetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogMemorySetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogMemorySetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogMemorySetting resource from API: %v", err)
 	}
 	return nil
 }
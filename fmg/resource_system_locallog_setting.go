@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSettingUpdate,
-		Read:   resourceSystemLocallogSettingRead,
-		Update: resourceSystemLocallogSettingUpdate,
-		Delete: resourceSystemLocallogSettingDelete,
+		CreateContext: resourceSystemLocallogSettingUpdate,
+		ReadContext:   resourceSystemLocallogSettingRead,
+		UpdateContext: resourceSystemLocallogSettingUpdate,
+		DeleteContext: resourceSystemLocallogSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemLocallogSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemLocallogSettingUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemLocallogSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSetting")
 
-	return resourceSystemLocallogSettingRead(d, m)
+	return resourceSystemLocallogSettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemLocallogSettingDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemLocallogSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemLocallogSettingDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemLocallogSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemLocallogSettingRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadSystemLocallogSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSetting resource from API: %v", err)
 	}
 	return nil
 }
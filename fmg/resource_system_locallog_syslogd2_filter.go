@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogd2Filter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogd2FilterUpdate,
-		Read:   resourceSystemLocallogSyslogd2FilterRead,
-		Update: resourceSystemLocallogSyslogd2FilterUpdate,
-		Delete: resourceSystemLocallogSyslogd2FilterDelete,
+		CreateContext: resourceSystemLocallogSyslogd2FilterUpdate,
+		ReadContext:   resourceSystemLocallogSyslogd2FilterRead,
+		UpdateContext: resourceSystemLocallogSyslogd2FilterUpdate,
+		DeleteContext: resourceSystemLocallogSyslogd2FilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogSyslogd2Filter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogd2FilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2FilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogSyslogd2FilterUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLocallogSyslogd2Filter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd2Filter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd2Filter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogd2Filter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd2Filter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd2Filter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogd2Filter")
 
-	return resourceSystemLocallogSyslogd2FilterRead(d, m)
+	return resourceSystemLocallogSyslogd2FilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogd2FilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2FilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogSyslogd2FilterDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemLocallogSyslogd2Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogd2Filter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogd2Filter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogSyslogd2FilterDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemLocallogSyslogd2FilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2FilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogSyslogd2FilterRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemLocallogSyslogd2Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd2Filter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Filter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Filter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogd2Filter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd2Filter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Filter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogd2Setting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogd2SettingUpdate,
-		Read:   resourceSystemLocallogSyslogd2SettingRead,
-		Update: resourceSystemLocallogSyslogd2SettingUpdate,
-		Delete: resourceSystemLocallogSyslogd2SettingDelete,
+		CreateContext: resourceSystemLocallogSyslogd2SettingUpdate,
+		ReadContext:   resourceSystemLocallogSyslogd2SettingRead,
+		UpdateContext: resourceSystemLocallogSyslogd2SettingUpdate,
+		DeleteContext: resourceSystemLocallogSyslogd2SettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,10 +73,9 @@ func resourceSystemLocallogSyslogd2Setting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogd2SettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2SettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -82,26 +83,23 @@ func resourceSystemLocallogSyslogd2SettingUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectSystemLocallogSyslogd2Setting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd2Setting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd2Setting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogd2Setting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd2Setting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd2Setting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogd2Setting")
 
-	return resourceSystemLocallogSyslogd2SettingRead(d, m)
+	return resourceSystemLocallogSyslogd2SettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogd2SettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2SettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -109,7 +107,7 @@ func resourceSystemLocallogSyslogd2SettingDelete(d *schema.ResourceData, m inter
 
 	err = c.DeleteSystemLocallogSyslogd2Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogd2Setting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogd2Setting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -117,11 +115,10 @@ func resourceSystemLocallogSyslogd2SettingDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceSystemLocallogSyslogd2SettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd2SettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -129,18 +126,21 @@ func resourceSystemLocallogSyslogd2SettingRead(d *schema.ResourceData, m interfa
 
 	o, err := c.ReadSystemLocallogSyslogd2Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd2Setting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Setting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Setting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogd2Setting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd2Setting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogd2Setting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogd3Filter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogd3FilterUpdate,
-		Read:   resourceSystemLocallogSyslogd3FilterRead,
-		Update: resourceSystemLocallogSyslogd3FilterUpdate,
-		Delete: resourceSystemLocallogSyslogd3FilterDelete,
+		CreateContext: resourceSystemLocallogSyslogd3FilterUpdate,
+		ReadContext:   resourceSystemLocallogSyslogd3FilterRead,
+		UpdateContext: resourceSystemLocallogSyslogd3FilterUpdate,
+		DeleteContext: resourceSystemLocallogSyslogd3FilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogSyslogd3Filter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogd3FilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3FilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogSyslogd3FilterUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLocallogSyslogd3Filter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd3Filter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd3Filter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogd3Filter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd3Filter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd3Filter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogd3Filter")
 
-	return resourceSystemLocallogSyslogd3FilterRead(d, m)
+	return resourceSystemLocallogSyslogd3FilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogd3FilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3FilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogSyslogd3FilterDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemLocallogSyslogd3Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogd3Filter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogd3Filter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogSyslogd3FilterDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemLocallogSyslogd3FilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3FilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogSyslogd3FilterRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemLocallogSyslogd3Filter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd3Filter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Filter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Filter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogd3Filter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd3Filter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Filter resource from API: %v", err)
 	}
 	return nil
 }
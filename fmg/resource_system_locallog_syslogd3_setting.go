@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogd3Setting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogd3SettingUpdate,
-		Read:   resourceSystemLocallogSyslogd3SettingRead,
-		Update: resourceSystemLocallogSyslogd3SettingUpdate,
-		Delete: resourceSystemLocallogSyslogd3SettingDelete,
+		CreateContext: resourceSystemLocallogSyslogd3SettingUpdate,
+		ReadContext:   resourceSystemLocallogSyslogd3SettingRead,
+		UpdateContext: resourceSystemLocallogSyslogd3SettingUpdate,
+		DeleteContext: resourceSystemLocallogSyslogd3SettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,10 +73,9 @@ func resourceSystemLocallogSyslogd3Setting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogd3SettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3SettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -82,26 +83,23 @@ func resourceSystemLocallogSyslogd3SettingUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectSystemLocallogSyslogd3Setting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd3Setting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd3Setting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogd3Setting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogd3Setting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogd3Setting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogd3Setting")
 
-	return resourceSystemLocallogSyslogd3SettingRead(d, m)
+	return resourceSystemLocallogSyslogd3SettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogd3SettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3SettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -109,7 +107,7 @@ func resourceSystemLocallogSyslogd3SettingDelete(d *schema.ResourceData, m inter
 
 	err = c.DeleteSystemLocallogSyslogd3Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogd3Setting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogd3Setting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -117,11 +115,10 @@ func resourceSystemLocallogSyslogd3SettingDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceSystemLocallogSyslogd3SettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogd3SettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -129,18 +126,21 @@ func resourceSystemLocallogSyslogd3SettingRead(d *schema.ResourceData, m interfa
 
 	o, err := c.ReadSystemLocallogSyslogd3Setting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd3Setting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Setting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Setting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogd3Setting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogd3Setting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogd3Setting resource from API: %v", err)
 	}
 	return nil
 }
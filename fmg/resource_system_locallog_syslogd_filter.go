@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogdFilter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogdFilterUpdate,
-		Read:   resourceSystemLocallogSyslogdFilterRead,
-		Update: resourceSystemLocallogSyslogdFilterUpdate,
-		Delete: resourceSystemLocallogSyslogdFilterDelete,
+		CreateContext: resourceSystemLocallogSyslogdFilterUpdate,
+		ReadContext:   resourceSystemLocallogSyslogdFilterRead,
+		UpdateContext: resourceSystemLocallogSyslogdFilterUpdate,
+		DeleteContext: resourceSystemLocallogSyslogdFilterDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,10 +245,9 @@ func resourceSystemLocallogSyslogdFilter() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogdFilterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -254,26 +255,23 @@ func resourceSystemLocallogSyslogdFilterUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemLocallogSyslogdFilter(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogdFilter resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogdFilter resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogdFilter(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogdFilter resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogdFilter resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogdFilter")
 
-	return resourceSystemLocallogSyslogdFilterRead(d, m)
+	return resourceSystemLocallogSyslogdFilterRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogdFilterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdFilterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -281,7 +279,7 @@ func resourceSystemLocallogSyslogdFilterDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteSystemLocallogSyslogdFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogdFilter resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogdFilter resource: %v", err)
 	}
 
 	d.SetId("")
@@ -289,11 +287,10 @@ func resourceSystemLocallogSyslogdFilterDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceSystemLocallogSyslogdFilterRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdFilterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -301,18 +298,21 @@ func resourceSystemLocallogSyslogdFilterRead(d *schema.ResourceData, m interface
 
 	o, err := c.ReadSystemLocallogSyslogdFilter(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogdFilter resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogdFilter resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogdFilter resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogdFilter(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogdFilter resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogdFilter resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLocallogSyslogdSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLocallogSyslogdSettingUpdate,
-		Read:   resourceSystemLocallogSyslogdSettingRead,
-		Update: resourceSystemLocallogSyslogdSettingUpdate,
-		Delete: resourceSystemLocallogSyslogdSettingDelete,
+		CreateContext: resourceSystemLocallogSyslogdSettingUpdate,
+		ReadContext:   resourceSystemLocallogSyslogdSettingRead,
+		UpdateContext: resourceSystemLocallogSyslogdSettingUpdate,
+		DeleteContext: resourceSystemLocallogSyslogdSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,10 +73,9 @@ func resourceSystemLocallogSyslogdSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemLocallogSyslogdSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -82,26 +83,23 @@ func resourceSystemLocallogSyslogdSettingUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLocallogSyslogdSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogdSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogdSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLocallogSyslogdSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLocallogSyslogdSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemLocallogSyslogdSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLocallogSyslogdSetting")
 
-	return resourceSystemLocallogSyslogdSettingRead(d, m)
+	return resourceSystemLocallogSyslogdSettingRead(ctx, d, m)
 }
 
-func resourceSystemLocallogSyslogdSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -109,7 +107,7 @@ func resourceSystemLocallogSyslogdSettingDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemLocallogSyslogdSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLocallogSyslogdSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemLocallogSyslogdSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -117,11 +115,10 @@ func resourceSystemLocallogSyslogdSettingDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemLocallogSyslogdSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLocallogSyslogdSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -129,18 +126,21 @@ func resourceSystemLocallogSyslogdSettingRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemLocallogSyslogdSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogdSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLocallogSyslogdSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLocallogSyslogdSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLocallogSyslogdSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLocallogSyslogdSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLocallogSyslogdSetting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogAlert() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogAlertUpdate,
-		Read:   resourceSystemLogAlertRead,
-		Update: resourceSystemLogAlertUpdate,
-		Delete: resourceSystemLogAlertDelete,
+		CreateContext: resourceSystemLogAlertUpdate,
+		ReadContext:   resourceSystemLogAlertRead,
+		UpdateContext: resourceSystemLogAlertUpdate,
+		DeleteContext: resourceSystemLogAlertDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,10 +40,9 @@ func resourceSystemLogAlert() *schema.Resource {
 	}
 }
 
-func resourceSystemLogAlertUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogAlertUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -49,26 +50,23 @@ func resourceSystemLogAlertUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemLogAlert(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogAlert resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogAlert resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogAlert(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogAlert resource: %v", err)
+		return diag.Errorf("Error updating SystemLogAlert resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogAlert")
 
-	return resourceSystemLogAlertRead(d, m)
+	return resourceSystemLogAlertRead(ctx, d, m)
 }
 
-func resourceSystemLogAlertDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogAlertDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,7 +74,7 @@ func resourceSystemLogAlertDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemLogAlert(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogAlert resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogAlert resource: %v", err)
 	}
 
 	d.SetId("")
@@ -84,11 +82,10 @@ func resourceSystemLogAlertDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemLogAlertRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogAlertRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,18 +93,21 @@ func resourceSystemLogAlertRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemLogAlert(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogAlert resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogAlert resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogAlert resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogAlert(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogAlert resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogAlert resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogDeviceDisable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogDeviceDisableCreate,
-		Read:   resourceSystemLogDeviceDisableRead,
-		Update: resourceSystemLogDeviceDisableUpdate,
-		Delete: resourceSystemLogDeviceDisableDelete,
+		CreateContext: resourceSystemLogDeviceDisableCreate,
+		ReadContext:   resourceSystemLogDeviceDisableRead,
+		UpdateContext: resourceSystemLogDeviceDisableUpdate,
+		DeleteContext: resourceSystemLogDeviceDisableDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -46,9 +48,8 @@ func resourceSystemLogDeviceDisable() *schema.Resource {
 	}
 }
 
-func resourceSystemLogDeviceDisableCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLogDeviceDisableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -56,24 +57,23 @@ func resourceSystemLogDeviceDisableCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemLogDeviceDisable(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogDeviceDisable resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLogDeviceDisable resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLogDeviceDisable(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogDeviceDisable resource: %v", err)
+		return diag.Errorf("Error creating SystemLogDeviceDisable resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogDeviceDisableRead(d, m)
+	return resourceSystemLogDeviceDisableRead(ctx, d, m)
 }
 
-func resourceSystemLogDeviceDisableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogDeviceDisableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,26 +81,23 @@ func resourceSystemLogDeviceDisableUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemLogDeviceDisable(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogDeviceDisable resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogDeviceDisable resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogDeviceDisable(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogDeviceDisable resource: %v", err)
+		return diag.Errorf("Error updating SystemLogDeviceDisable resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogDeviceDisableRead(d, m)
+	return resourceSystemLogDeviceDisableRead(ctx, d, m)
 }
 
-func resourceSystemLogDeviceDisableDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogDeviceDisableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -108,7 +105,7 @@ func resourceSystemLogDeviceDisableDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemLogDeviceDisable(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogDeviceDisable resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogDeviceDisable resource: %v", err)
 	}
 
 	d.SetId("")
@@ -116,11 +113,10 @@ func resourceSystemLogDeviceDisableDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemLogDeviceDisableRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogDeviceDisableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -128,18 +124,21 @@ func resourceSystemLogDeviceDisableRead(d *schema.ResourceData, m interface{}) e
 
 	o, err := c.ReadSystemLogDeviceDisable(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogDeviceDisable resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogDeviceDisable resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogDeviceDisable resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogDeviceDisable(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogDeviceDisable resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogDeviceDisable resource from API: %v", err)
 	}
 	return nil
 }
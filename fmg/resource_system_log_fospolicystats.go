@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogFosPolicyStats() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogFosPolicyStatsUpdate,
-		Read:   resourceSystemLogFosPolicyStatsRead,
-		Update: resourceSystemLogFosPolicyStatsUpdate,
-		Delete: resourceSystemLogFosPolicyStatsDelete,
+		CreateContext: resourceSystemLogFosPolicyStatsUpdate,
+		ReadContext:   resourceSystemLogFosPolicyStatsRead,
+		UpdateContext: resourceSystemLogFosPolicyStatsUpdate,
+		DeleteContext: resourceSystemLogFosPolicyStatsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemLogFosPolicyStats() *schema.Resource {
 	}
 }
 
-func resourceSystemLogFosPolicyStatsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFosPolicyStatsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemLogFosPolicyStatsUpdate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectSystemLogFosPolicyStats(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFosPolicyStats resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogFosPolicyStats resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogFosPolicyStats(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFosPolicyStats resource: %v", err)
+		return diag.Errorf("Error updating SystemLogFosPolicyStats resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogFosPolicyStats")
 
-	return resourceSystemLogFosPolicyStatsRead(d, m)
+	return resourceSystemLogFosPolicyStatsRead(ctx, d, m)
 }
 
-func resourceSystemLogFosPolicyStatsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFosPolicyStatsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemLogFosPolicyStatsDelete(d *schema.ResourceData, m interface{}
 
 	err = c.DeleteSystemLogFosPolicyStats(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogFosPolicyStats resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogFosPolicyStats resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemLogFosPolicyStatsDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceSystemLogFosPolicyStatsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFosPolicyStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemLogFosPolicyStatsRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemLogFosPolicyStats(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFosPolicyStats resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogFosPolicyStats resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogFosPolicyStats resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogFosPolicyStats(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFosPolicyStats resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogFosPolicyStats resource from API: %v", err)
 	}
 	return nil
 }
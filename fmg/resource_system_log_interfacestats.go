@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogInterfaceStats() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogInterfaceStatsUpdate,
-		Read:   resourceSystemLogInterfaceStatsRead,
-		Update: resourceSystemLogInterfaceStatsUpdate,
-		Delete: resourceSystemLogInterfaceStatsDelete,
+		CreateContext: resourceSystemLogInterfaceStatsUpdate,
+		ReadContext:   resourceSystemLogInterfaceStatsRead,
+		UpdateContext: resourceSystemLogInterfaceStatsUpdate,
+		DeleteContext: resourceSystemLogInterfaceStatsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,10 +55,9 @@ func resourceSystemLogInterfaceStats() *schema.Resource {
 	}
 }
 
-func resourceSystemLogInterfaceStatsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogInterfaceStatsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -64,26 +65,23 @@ func resourceSystemLogInterfaceStatsUpdate(d *schema.ResourceData, m interface{}
 
 	obj, err := getObjectSystemLogInterfaceStats(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogInterfaceStats resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogInterfaceStats resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogInterfaceStats(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogInterfaceStats resource: %v", err)
+		return diag.Errorf("Error updating SystemLogInterfaceStats resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogInterfaceStats")
 
-	return resourceSystemLogInterfaceStatsRead(d, m)
+	return resourceSystemLogInterfaceStatsRead(ctx, d, m)
 }
 
-func resourceSystemLogInterfaceStatsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogInterfaceStatsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -91,7 +89,7 @@ func resourceSystemLogInterfaceStatsDelete(d *schema.ResourceData, m interface{}
 
 	err = c.DeleteSystemLogInterfaceStats(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogInterfaceStats resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogInterfaceStats resource: %v", err)
 	}
 
 	d.SetId("")
@@ -99,11 +97,10 @@ func resourceSystemLogInterfaceStatsDelete(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceSystemLogInterfaceStatsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogInterfaceStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -111,18 +108,21 @@ func resourceSystemLogInterfaceStatsRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemLogInterfaceStats(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogInterfaceStats resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogInterfaceStats resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogInterfaceStats resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogInterfaceStats(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogInterfaceStats resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogInterfaceStats resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogIoc() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogIocUpdate,
-		Read:   resourceSystemLogIocRead,
-		Update: resourceSystemLogIocUpdate,
-		Delete: resourceSystemLogIocDelete,
+		CreateContext: resourceSystemLogIocUpdate,
+		ReadContext:   resourceSystemLogIocRead,
+		UpdateContext: resourceSystemLogIocUpdate,
+		DeleteContext: resourceSystemLogIocDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,10 +65,9 @@ func resourceSystemLogIoc() *schema.Resource {
 	}
 }
 
-func resourceSystemLogIocUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogIocUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -74,26 +75,23 @@ func resourceSystemLogIocUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemLogIoc(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogIoc resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogIoc resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogIoc(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogIoc resource: %v", err)
+		return diag.Errorf("Error updating SystemLogIoc resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogIoc")
 
-	return resourceSystemLogIocRead(d, m)
+	return resourceSystemLogIocRead(ctx, d, m)
 }
 
-func resourceSystemLogIocDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogIocDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,7 +99,7 @@ func resourceSystemLogIocDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemLogIoc(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogIoc resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogIoc resource: %v", err)
 	}
 
 	d.SetId("")
@@ -109,11 +107,10 @@ func resourceSystemLogIocDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemLogIocRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogIocRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -121,18 +118,21 @@ func resourceSystemLogIocRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemLogIoc(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogIoc resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogIoc resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogIoc resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogIoc(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogIoc resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogIoc resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogMailDomain() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogMailDomainCreate,
-		Read:   resourceSystemLogMailDomainRead,
-		Update: resourceSystemLogMailDomainUpdate,
-		Delete: resourceSystemLogMailDomainDelete,
+		CreateContext: resourceSystemLogMailDomainCreate,
+		ReadContext:   resourceSystemLogMailDomainRead,
+		UpdateContext: resourceSystemLogMailDomainUpdate,
+		DeleteContext: resourceSystemLogMailDomainDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,9 +53,8 @@ func resourceSystemLogMailDomain() *schema.Resource {
 	}
 }
 
-func resourceSystemLogMailDomainCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLogMailDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -61,24 +62,23 @@ func resourceSystemLogMailDomainCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemLogMailDomain(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogMailDomain resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLogMailDomain resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLogMailDomain(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogMailDomain resource: %v", err)
+		return diag.Errorf("Error creating SystemLogMailDomain resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogMailDomainRead(d, m)
+	return resourceSystemLogMailDomainRead(ctx, d, m)
 }
 
-func resourceSystemLogMailDomainUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogMailDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,26 +86,23 @@ func resourceSystemLogMailDomainUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemLogMailDomain(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogMailDomain resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogMailDomain resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogMailDomain(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogMailDomain resource: %v", err)
+		return diag.Errorf("Error updating SystemLogMailDomain resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogMailDomainRead(d, m)
+	return resourceSystemLogMailDomainRead(ctx, d, m)
 }
 
-func resourceSystemLogMailDomainDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogMailDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -113,7 +110,7 @@ func resourceSystemLogMailDomainDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemLogMailDomain(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogMailDomain resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogMailDomain resource: %v", err)
 	}
 
 	d.SetId("")
@@ -121,11 +118,10 @@ func resourceSystemLogMailDomainDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemLogMailDomainRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogMailDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -133,18 +129,21 @@ func resourceSystemLogMailDomainRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemLogMailDomain(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogMailDomain resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogMailDomain resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogMailDomain resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogMailDomain(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogMailDomain resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogMailDomain resource from API: %v", err)
 	}
 	return nil
 }
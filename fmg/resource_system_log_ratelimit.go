@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogRatelimit() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogRatelimitUpdate,
-		Read:   resourceSystemLogRatelimitRead,
-		Update: resourceSystemLogRatelimitUpdate,
-		Delete: resourceSystemLogRatelimitDelete,
+		CreateContext: resourceSystemLogRatelimitUpdate,
+		ReadContext:   resourceSystemLogRatelimitRead,
+		UpdateContext: resourceSystemLogRatelimitUpdate,
+		DeleteContext: resourceSystemLogRatelimitDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -101,10 +103,9 @@ func resourceSystemLogRatelimit() *schema.Resource {
 	}
 }
 
-func resourceSystemLogRatelimitUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -112,26 +113,23 @@ func resourceSystemLogRatelimitUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemLogRatelimit(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimit resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimit resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogRatelimit(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimit resource: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimit resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogRatelimit")
 
-	return resourceSystemLogRatelimitRead(d, m)
+	return resourceSystemLogRatelimitRead(ctx, d, m)
 }
 
-func resourceSystemLogRatelimitDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -139,7 +137,7 @@ func resourceSystemLogRatelimitDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteSystemLogRatelimit(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogRatelimit resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogRatelimit resource: %v", err)
 	}
 
 	d.SetId("")
@@ -147,11 +145,10 @@ func resourceSystemLogRatelimitDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSystemLogRatelimitRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -159,18 +156,21 @@ func resourceSystemLogRatelimitRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemLogRatelimit(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimit resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogRatelimit resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogRatelimit resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogRatelimit(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimit resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogRatelimit resource from API: %v", err)
 	}
 	return nil
 }
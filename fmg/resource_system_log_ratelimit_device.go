@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogRatelimitDevice() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogRatelimitDeviceCreate,
-		Read:   resourceSystemLogRatelimitDeviceRead,
-		Update: resourceSystemLogRatelimitDeviceUpdate,
-		Delete: resourceSystemLogRatelimitDeviceDelete,
+		CreateContext: resourceSystemLogRatelimitDeviceCreate,
+		ReadContext:   resourceSystemLogRatelimitDeviceRead,
+		UpdateContext: resourceSystemLogRatelimitDeviceUpdate,
+		DeleteContext: resourceSystemLogRatelimitDeviceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,9 +53,8 @@ func resourceSystemLogRatelimitDevice() *schema.Resource {
 	}
 }
 
-func resourceSystemLogRatelimitDeviceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLogRatelimitDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -61,24 +62,23 @@ func resourceSystemLogRatelimitDeviceCreate(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectSystemLogRatelimitDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogRatelimitDevice resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLogRatelimitDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLogRatelimitDevice(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogRatelimitDevice resource: %v", err)
+		return diag.Errorf("Error creating SystemLogRatelimitDevice resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogRatelimitDeviceRead(d, m)
+	return resourceSystemLogRatelimitDeviceRead(ctx, d, m)
 }
 
-func resourceSystemLogRatelimitDeviceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,26 +86,23 @@ func resourceSystemLogRatelimitDeviceUpdate(d *schema.ResourceData, m interface{
 
 	obj, err := getObjectSystemLogRatelimitDevice(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimitDevice resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimitDevice resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogRatelimitDevice(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimitDevice resource: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimitDevice resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogRatelimitDeviceRead(d, m)
+	return resourceSystemLogRatelimitDeviceRead(ctx, d, m)
 }
 
-func resourceSystemLogRatelimitDeviceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -113,7 +110,7 @@ func resourceSystemLogRatelimitDeviceDelete(d *schema.ResourceData, m interface{
 
 	err = c.DeleteSystemLogRatelimitDevice(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogRatelimitDevice resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogRatelimitDevice resource: %v", err)
 	}
 
 	d.SetId("")
@@ -121,11 +118,10 @@ func resourceSystemLogRatelimitDeviceDelete(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceSystemLogRatelimitDeviceRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -133,18 +129,21 @@ func resourceSystemLogRatelimitDeviceRead(d *schema.ResourceData, m interface{})
 
 	o, err := c.ReadSystemLogRatelimitDevice(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimitDevice resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogRatelimitDevice resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogRatelimitDevice resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogRatelimitDevice(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimitDevice resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogRatelimitDevice resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogRatelimitRatelimits() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogRatelimitRatelimitsCreate,
-		Read:   resourceSystemLogRatelimitRatelimitsRead,
-		Update: resourceSystemLogRatelimitRatelimitsUpdate,
-		Delete: resourceSystemLogRatelimitRatelimitsDelete,
+		CreateContext: resourceSystemLogRatelimitRatelimitsCreate,
+		ReadContext:   resourceSystemLogRatelimitRatelimitsRead,
+		UpdateContext: resourceSystemLogRatelimitRatelimitsUpdate,
+		DeleteContext: resourceSystemLogRatelimitRatelimitsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,9 +53,8 @@ func resourceSystemLogRatelimitRatelimits() *schema.Resource {
 	}
 }
 
-func resourceSystemLogRatelimitRatelimitsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLogRatelimitRatelimitsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -61,24 +62,23 @@ func resourceSystemLogRatelimitRatelimitsCreate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLogRatelimitRatelimits(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogRatelimitRatelimits resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLogRatelimitRatelimits resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLogRatelimitRatelimits(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogRatelimitRatelimits resource: %v", err)
+		return diag.Errorf("Error creating SystemLogRatelimitRatelimits resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogRatelimitRatelimitsRead(d, m)
+	return resourceSystemLogRatelimitRatelimitsRead(ctx, d, m)
 }
 
-func resourceSystemLogRatelimitRatelimitsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitRatelimitsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,26 +86,23 @@ func resourceSystemLogRatelimitRatelimitsUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLogRatelimitRatelimits(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimitRatelimits resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimitRatelimits resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogRatelimitRatelimits(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogRatelimitRatelimits resource: %v", err)
+		return diag.Errorf("Error updating SystemLogRatelimitRatelimits resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogRatelimitRatelimitsRead(d, m)
+	return resourceSystemLogRatelimitRatelimitsRead(ctx, d, m)
 }
 
-func resourceSystemLogRatelimitRatelimitsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitRatelimitsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -113,7 +110,7 @@ func resourceSystemLogRatelimitRatelimitsDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemLogRatelimitRatelimits(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogRatelimitRatelimits resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogRatelimitRatelimits resource: %v", err)
 	}
 
 	d.SetId("")
@@ -121,11 +118,10 @@ func resourceSystemLogRatelimitRatelimitsDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemLogRatelimitRatelimitsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogRatelimitRatelimitsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -133,18 +129,21 @@ func resourceSystemLogRatelimitRatelimitsRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemLogRatelimitRatelimits(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimitRatelimits resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogRatelimitRatelimits resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogRatelimitRatelimits resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogRatelimitRatelimits(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogRatelimitRatelimits resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogRatelimitRatelimits resource from API: %v", err)
 	}
 	return nil
 }
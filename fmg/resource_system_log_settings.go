@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogSettingsUpdate,
-		Read:   resourceSystemLogSettingsRead,
-		Update: resourceSystemLogSettingsUpdate,
-		Delete: resourceSystemLogSettingsDelete,
+		CreateContext: resourceSystemLogSettingsUpdate,
+		ReadContext:   resourceSystemLogSettingsRead,
+		UpdateContext: resourceSystemLogSettingsUpdate,
+		DeleteContext: resourceSystemLogSettingsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -576,10 +578,9 @@ func resourceSystemLogSettings() *schema.Resource {
 	}
 }
 
-func resourceSystemLogSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -587,26 +588,23 @@ func resourceSystemLogSettingsUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemLogSettings(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettings resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogSettings resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogSettings(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettings resource: %v", err)
+		return diag.Errorf("Error updating SystemLogSettings resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogSettings")
 
-	return resourceSystemLogSettingsRead(d, m)
+	return resourceSystemLogSettingsRead(ctx, d, m)
 }
 
-func resourceSystemLogSettingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -614,7 +612,7 @@ func resourceSystemLogSettingsDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteSystemLogSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogSettings resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogSettings resource: %v", err)
 	}
 
 	d.SetId("")
@@ -622,11 +620,10 @@ func resourceSystemLogSettingsDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceSystemLogSettingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -634,18 +631,21 @@ func resourceSystemLogSettingsRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemLogSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettings resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogSettings resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogSettings resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogSettings(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettings resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogSettings resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogSettingsRollingAnalyzer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogSettingsRollingAnalyzerUpdate,
-		Read:   resourceSystemLogSettingsRollingAnalyzerRead,
-		Update: resourceSystemLogSettingsRollingAnalyzerUpdate,
-		Delete: resourceSystemLogSettingsRollingAnalyzerDelete,
+		CreateContext: resourceSystemLogSettingsRollingAnalyzerUpdate,
+		ReadContext:   resourceSystemLogSettingsRollingAnalyzerRead,
+		UpdateContext: resourceSystemLogSettingsRollingAnalyzerUpdate,
+		DeleteContext: resourceSystemLogSettingsRollingAnalyzerDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -176,10 +178,9 @@ func resourceSystemLogSettingsRollingAnalyzer() *schema.Resource {
 	}
 }
 
-func resourceSystemLogSettingsRollingAnalyzerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingAnalyzerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -187,26 +188,23 @@ func resourceSystemLogSettingsRollingAnalyzerUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectSystemLogSettingsRollingAnalyzer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingAnalyzer resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingAnalyzer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogSettingsRollingAnalyzer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingAnalyzer resource: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingAnalyzer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogSettingsRollingAnalyzer")
 
-	return resourceSystemLogSettingsRollingAnalyzerRead(d, m)
+	return resourceSystemLogSettingsRollingAnalyzerRead(ctx, d, m)
 }
 
-func resourceSystemLogSettingsRollingAnalyzerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingAnalyzerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -214,7 +212,7 @@ func resourceSystemLogSettingsRollingAnalyzerDelete(d *schema.ResourceData, m in
 
 	err = c.DeleteSystemLogSettingsRollingAnalyzer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogSettingsRollingAnalyzer resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogSettingsRollingAnalyzer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -222,11 +220,10 @@ func resourceSystemLogSettingsRollingAnalyzerDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceSystemLogSettingsRollingAnalyzerRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingAnalyzerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -234,18 +231,21 @@ func resourceSystemLogSettingsRollingAnalyzerRead(d *schema.ResourceData, m inte
 
 	o, err := c.ReadSystemLogSettingsRollingAnalyzer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingAnalyzer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogSettingsRollingAnalyzer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogSettingsRollingAnalyzer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogSettingsRollingAnalyzer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingAnalyzer resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogSettingsRollingAnalyzer resource from API: %v", err)
 	}
 	return nil
 }
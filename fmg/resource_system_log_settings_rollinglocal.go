@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogSettingsRollingLocal() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogSettingsRollingLocalUpdate,
-		Read:   resourceSystemLogSettingsRollingLocalRead,
-		Update: resourceSystemLogSettingsRollingLocalUpdate,
-		Delete: resourceSystemLogSettingsRollingLocalDelete,
+		CreateContext: resourceSystemLogSettingsRollingLocalUpdate,
+		ReadContext:   resourceSystemLogSettingsRollingLocalRead,
+		UpdateContext: resourceSystemLogSettingsRollingLocalUpdate,
+		DeleteContext: resourceSystemLogSettingsRollingLocalDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -176,10 +178,9 @@ func resourceSystemLogSettingsRollingLocal() *schema.Resource {
 	}
 }
 
-func resourceSystemLogSettingsRollingLocalUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingLocalUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -187,26 +188,23 @@ func resourceSystemLogSettingsRollingLocalUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectSystemLogSettingsRollingLocal(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingLocal resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingLocal resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogSettingsRollingLocal(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingLocal resource: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingLocal resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogSettingsRollingLocal")
 
-	return resourceSystemLogSettingsRollingLocalRead(d, m)
+	return resourceSystemLogSettingsRollingLocalRead(ctx, d, m)
 }
 
-func resourceSystemLogSettingsRollingLocalDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingLocalDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -214,7 +212,7 @@ func resourceSystemLogSettingsRollingLocalDelete(d *schema.ResourceData, m inter
 
 	err = c.DeleteSystemLogSettingsRollingLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogSettingsRollingLocal resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogSettingsRollingLocal resource: %v", err)
 	}
 
 	d.SetId("")
@@ -222,11 +220,10 @@ func resourceSystemLogSettingsRollingLocalDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceSystemLogSettingsRollingLocalRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingLocalRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -234,18 +231,21 @@ func resourceSystemLogSettingsRollingLocalRead(d *schema.ResourceData, m interfa
 
 	o, err := c.ReadSystemLogSettingsRollingLocal(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingLocal resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogSettingsRollingLocal resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogSettingsRollingLocal resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogSettingsRollingLocal(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingLocal resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogSettingsRollingLocal resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogSettingsRollingRegular() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogSettingsRollingRegularUpdate,
-		Read:   resourceSystemLogSettingsRollingRegularRead,
-		Update: resourceSystemLogSettingsRollingRegularUpdate,
-		Delete: resourceSystemLogSettingsRollingRegularDelete,
+		CreateContext: resourceSystemLogSettingsRollingRegularUpdate,
+		ReadContext:   resourceSystemLogSettingsRollingRegularRead,
+		UpdateContext: resourceSystemLogSettingsRollingRegularUpdate,
+		DeleteContext: resourceSystemLogSettingsRollingRegularDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -176,10 +178,9 @@ func resourceSystemLogSettingsRollingRegular() *schema.Resource {
 	}
 }
 
-func resourceSystemLogSettingsRollingRegularUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingRegularUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -187,26 +188,23 @@ func resourceSystemLogSettingsRollingRegularUpdate(d *schema.ResourceData, m int
 
 	obj, err := getObjectSystemLogSettingsRollingRegular(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingRegular resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingRegular resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogSettingsRollingRegular(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogSettingsRollingRegular resource: %v", err)
+		return diag.Errorf("Error updating SystemLogSettingsRollingRegular resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogSettingsRollingRegular")
 
-	return resourceSystemLogSettingsRollingRegularRead(d, m)
+	return resourceSystemLogSettingsRollingRegularRead(ctx, d, m)
 }
 
-func resourceSystemLogSettingsRollingRegularDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingRegularDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -214,7 +212,7 @@ func resourceSystemLogSettingsRollingRegularDelete(d *schema.ResourceData, m int
 
 	err = c.DeleteSystemLogSettingsRollingRegular(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogSettingsRollingRegular resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogSettingsRollingRegular resource: %v", err)
 	}
 
 	d.SetId("")
@@ -222,11 +220,10 @@ func resourceSystemLogSettingsRollingRegularDelete(d *schema.ResourceData, m int
 	return nil
 }
 
-func resourceSystemLogSettingsRollingRegularRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogSettingsRollingRegularRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -234,18 +231,21 @@ func resourceSystemLogSettingsRollingRegularRead(d *schema.ResourceData, m inter
 
 	o, err := c.ReadSystemLogSettingsRollingRegular(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingRegular resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogSettingsRollingRegular resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogSettingsRollingRegular resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogSettingsRollingRegular(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogSettingsRollingRegular resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogSettingsRollingRegular resource from API: %v", err)
 	}
 	return nil
 }
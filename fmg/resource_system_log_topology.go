@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogTopology() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogTopologyUpdate,
-		Read:   resourceSystemLogTopologyRead,
-		Update: resourceSystemLogTopologyUpdate,
-		Delete: resourceSystemLogTopologyDelete,
+		CreateContext: resourceSystemLogTopologyUpdate,
+		ReadContext:   resourceSystemLogTopologyRead,
+		UpdateContext: resourceSystemLogTopologyUpdate,
+		DeleteContext: resourceSystemLogTopologyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,10 +45,9 @@ func resourceSystemLogTopology() *schema.Resource {
 	}
 }
 
-func resourceSystemLogTopologyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogTopologyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -54,26 +55,23 @@ func resourceSystemLogTopologyUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemLogTopology(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogTopology resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogTopology resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogTopology(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogTopology resource: %v", err)
+		return diag.Errorf("Error updating SystemLogTopology resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogTopology")
 
-	return resourceSystemLogTopologyRead(d, m)
+	return resourceSystemLogTopologyRead(ctx, d, m)
 }
 
-func resourceSystemLogTopologyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogTopologyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,7 +79,7 @@ func resourceSystemLogTopologyDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteSystemLogTopology(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogTopology resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogTopology resource: %v", err)
 	}
 
 	d.SetId("")
@@ -89,11 +87,10 @@ func resourceSystemLogTopologyDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceSystemLogTopologyRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogTopologyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,18 +98,21 @@ func resourceSystemLogTopologyRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemLogTopology(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogTopology resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogTopology resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogTopology resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogTopology(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogTopology resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogTopology resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogFetchClientProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogFetchClientProfileCreate,
-		Read:   resourceSystemLogFetchClientProfileRead,
-		Update: resourceSystemLogFetchClientProfileUpdate,
-		Delete: resourceSystemLogFetchClientProfileDelete,
+		CreateContext: resourceSystemLogFetchClientProfileCreate,
+		ReadContext:   resourceSystemLogFetchClientProfileRead,
+		UpdateContext: resourceSystemLogFetchClientProfileUpdate,
+		DeleteContext: resourceSystemLogFetchClientProfileDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -174,9 +176,8 @@ func resourceSystemLogFetchClientProfile() *schema.Resource {
 	}
 }
 
-func resourceSystemLogFetchClientProfileCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemLogFetchClientProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -184,24 +185,23 @@ func resourceSystemLogFetchClientProfileCreate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemLogFetchClientProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogFetchClientProfile resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemLogFetchClientProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemLogFetchClientProfile(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemLogFetchClientProfile resource: %v", err)
+		return diag.Errorf("Error creating SystemLogFetchClientProfile resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogFetchClientProfileRead(d, m)
+	return resourceSystemLogFetchClientProfileRead(ctx, d, m)
 }
 
-func resourceSystemLogFetchClientProfileUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchClientProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -209,26 +209,23 @@ func resourceSystemLogFetchClientProfileUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectSystemLogFetchClientProfile(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFetchClientProfile resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogFetchClientProfile resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogFetchClientProfile(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFetchClientProfile resource: %v", err)
+		return diag.Errorf("Error updating SystemLogFetchClientProfile resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemLogFetchClientProfileRead(d, m)
+	return resourceSystemLogFetchClientProfileRead(ctx, d, m)
 }
 
-func resourceSystemLogFetchClientProfileDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchClientProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -236,7 +233,7 @@ func resourceSystemLogFetchClientProfileDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteSystemLogFetchClientProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogFetchClientProfile resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogFetchClientProfile resource: %v", err)
 	}
 
 	d.SetId("")
@@ -244,11 +241,10 @@ func resourceSystemLogFetchClientProfileDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceSystemLogFetchClientProfileRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchClientProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -256,18 +252,21 @@ func resourceSystemLogFetchClientProfileRead(d *schema.ResourceData, m interface
 
 	o, err := c.ReadSystemLogFetchClientProfile(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFetchClientProfile resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogFetchClientProfile resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogFetchClientProfile resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogFetchClientProfile(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFetchClientProfile resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogFetchClientProfile resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemLogFetchServerSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemLogFetchServerSettingsUpdate,
-		Read:   resourceSystemLogFetchServerSettingsRead,
-		Update: resourceSystemLogFetchServerSettingsUpdate,
-		Delete: resourceSystemLogFetchServerSettingsDelete,
+		CreateContext: resourceSystemLogFetchServerSettingsUpdate,
+		ReadContext:   resourceSystemLogFetchServerSettingsRead,
+		UpdateContext: resourceSystemLogFetchServerSettingsUpdate,
+		DeleteContext: resourceSystemLogFetchServerSettingsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemLogFetchServerSettings() *schema.Resource {
 	}
 }
 
-func resourceSystemLogFetchServerSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchServerSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemLogFetchServerSettingsUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemLogFetchServerSettings(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFetchServerSettings resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemLogFetchServerSettings resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemLogFetchServerSettings(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemLogFetchServerSettings resource: %v", err)
+		return diag.Errorf("Error updating SystemLogFetchServerSettings resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemLogFetchServerSettings")
 
-	return resourceSystemLogFetchServerSettingsRead(d, m)
+	return resourceSystemLogFetchServerSettingsRead(ctx, d, m)
 }
 
-func resourceSystemLogFetchServerSettingsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchServerSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemLogFetchServerSettingsDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemLogFetchServerSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemLogFetchServerSettings resource: %v", err)
+		return diag.Errorf("Error deleting SystemLogFetchServerSettings resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemLogFetchServerSettingsDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemLogFetchServerSettingsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemLogFetchServerSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemLogFetchServerSettingsRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemLogFetchServerSettings(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFetchServerSettings resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemLogFetchServerSettings resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemLogFetchServerSettings resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemLogFetchServerSettings(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemLogFetchServerSettings resource from API: %v", err)
+		return diag.Errorf("Error reading SystemLogFetchServerSettings resource from API: %v", err)
 	}
 	return nil
 }
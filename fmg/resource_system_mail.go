@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemMail() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemMailCreate,
-		Read:   resourceSystemMailRead,
-		Update: resourceSystemMailUpdate,
-		Delete: resourceSystemMailDelete,
+		CreateContext: resourceSystemMailCreate,
+		ReadContext:   resourceSystemMailRead,
+		UpdateContext: resourceSystemMailUpdate,
+		DeleteContext: resourceSystemMailDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -77,9 +79,8 @@ func resourceSystemMail() *schema.Resource {
 	}
 }
 
-func resourceSystemMailCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemMailCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -87,24 +88,23 @@ func resourceSystemMailCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemMail(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemMail resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemMail resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemMail(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemMail resource: %v", err)
+		return diag.Errorf("Error creating SystemMail resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceSystemMailRead(d, m)
+	return resourceSystemMailRead(ctx, d, m)
 }
 
-func resourceSystemMailUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMailUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -112,26 +112,23 @@ func resourceSystemMailUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemMail(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemMail resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemMail resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemMail(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemMail resource: %v", err)
+		return diag.Errorf("Error updating SystemMail resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fosid"))
 
-	return resourceSystemMailRead(d, m)
+	return resourceSystemMailRead(ctx, d, m)
 }
 
-func resourceSystemMailDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMailDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -139,7 +136,7 @@ func resourceSystemMailDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemMail(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemMail resource: %v", err)
+		return diag.Errorf("Error deleting SystemMail resource: %v", err)
 	}
 
 	d.SetId("")
@@ -147,11 +144,10 @@ func resourceSystemMailDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemMailRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMailRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -159,18 +155,21 @@ func resourceSystemMailRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemMail(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemMail resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemMail resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemMail resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemMail(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemMail resource from API: %v", err)
+		return diag.Errorf("Error reading SystemMail resource from API: %v", err)
 	}
 	return nil
 }
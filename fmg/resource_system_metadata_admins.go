@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemMetadataAdmins() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemMetadataAdminsCreate,
-		Read:   resourceSystemMetadataAdminsRead,
-		Update: resourceSystemMetadataAdminsUpdate,
-		Delete: resourceSystemMetadataAdminsDelete,
+		CreateContext: resourceSystemMetadataAdminsCreate,
+		ReadContext:   resourceSystemMetadataAdminsRead,
+		UpdateContext: resourceSystemMetadataAdminsUpdate,
+		DeleteContext: resourceSystemMetadataAdminsDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,9 +55,8 @@ func resourceSystemMetadataAdmins() *schema.Resource {
 	}
 }
 
-func resourceSystemMetadataAdminsCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemMetadataAdminsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -63,24 +64,23 @@ func resourceSystemMetadataAdminsCreate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemMetadataAdmins(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemMetadataAdmins resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemMetadataAdmins resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemMetadataAdmins(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemMetadataAdmins resource: %v", err)
+		return diag.Errorf("Error creating SystemMetadataAdmins resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "fieldname"))
 
-	return resourceSystemMetadataAdminsRead(d, m)
+	return resourceSystemMetadataAdminsRead(ctx, d, m)
 }
 
-func resourceSystemMetadataAdminsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMetadataAdminsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -88,26 +88,23 @@ func resourceSystemMetadataAdminsUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemMetadataAdmins(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemMetadataAdmins resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemMetadataAdmins resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemMetadataAdmins(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemMetadataAdmins resource: %v", err)
+		return diag.Errorf("Error updating SystemMetadataAdmins resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "fieldname"))
 
-	return resourceSystemMetadataAdminsRead(d, m)
+	return resourceSystemMetadataAdminsRead(ctx, d, m)
 }
 
-func resourceSystemMetadataAdminsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMetadataAdminsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -115,7 +112,7 @@ func resourceSystemMetadataAdminsDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemMetadataAdmins(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemMetadataAdmins resource: %v", err)
+		return diag.Errorf("Error deleting SystemMetadataAdmins resource: %v", err)
 	}
 
 	d.SetId("")
@@ -123,11 +120,10 @@ func resourceSystemMetadataAdminsDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemMetadataAdminsRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemMetadataAdminsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -135,18 +131,21 @@ func resourceSystemMetadataAdminsRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemMetadataAdmins(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemMetadataAdmins resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemMetadataAdmins resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemMetadataAdmins resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemMetadataAdmins(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemMetadataAdmins resource from API: %v", err)
+		return diag.Errorf("Error reading SystemMetadataAdmins resource from API: %v", err)
 	}
 	return nil
 }
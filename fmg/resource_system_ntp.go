@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemNtp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemNtpUpdate,
-		Read:   resourceSystemNtpRead,
-		Update: resourceSystemNtpUpdate,
-		Delete: resourceSystemNtpDelete,
+		CreateContext: resourceSystemNtpUpdate,
+		ReadContext:   resourceSystemNtpRead,
+		UpdateContext: resourceSystemNtpUpdate,
+		DeleteContext: resourceSystemNtpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -95,10 +97,9 @@ func resourceSystemNtp() *schema.Resource {
 	}
 }
 
-func resourceSystemNtpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,26 +107,23 @@ func resourceSystemNtpUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemNtp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemNtp resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemNtp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemNtp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemNtp resource: %v", err)
+		return diag.Errorf("Error updating SystemNtp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemNtp")
 
-	return resourceSystemNtpRead(d, m)
+	return resourceSystemNtpRead(ctx, d, m)
 }
 
-func resourceSystemNtpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -133,7 +131,7 @@ func resourceSystemNtpDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemNtp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemNtp resource: %v", err)
+		return diag.Errorf("Error deleting SystemNtp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -141,11 +139,10 @@ func resourceSystemNtpDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemNtpRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -153,18 +150,21 @@ func resourceSystemNtpRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemNtp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemNtp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemNtp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemNtp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemNtp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemNtp resource from API: %v", err)
+		return diag.Errorf("Error reading SystemNtp resource from API: %v", err)
 	}
 	return nil
 }
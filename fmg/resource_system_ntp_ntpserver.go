@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemNtpNtpserver() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemNtpNtpserverCreate,
-		Read:   resourceSystemNtpNtpserverRead,
-		Update: resourceSystemNtpNtpserverUpdate,
-		Delete: resourceSystemNtpNtpserverDelete,
+		CreateContext: resourceSystemNtpNtpserverCreate,
+		ReadContext:   resourceSystemNtpNtpserverRead,
+		UpdateContext: resourceSystemNtpNtpserverUpdate,
+		DeleteContext: resourceSystemNtpNtpserverDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,9 +75,8 @@ func resourceSystemNtpNtpserver() *schema.Resource {
 	}
 }
 
-func resourceSystemNtpNtpserverCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemNtpNtpserverCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -83,24 +84,23 @@ func resourceSystemNtpNtpserverCreate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemNtpNtpserver(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemNtpNtpserver resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemNtpNtpserver resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemNtpNtpserver(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemNtpNtpserver resource: %v", err)
+		return diag.Errorf("Error creating SystemNtpNtpserver resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemNtpNtpserverRead(d, m)
+	return resourceSystemNtpNtpserverRead(ctx, d, m)
 }
 
-func resourceSystemNtpNtpserverUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpNtpserverUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -108,26 +108,23 @@ func resourceSystemNtpNtpserverUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectSystemNtpNtpserver(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemNtpNtpserver resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemNtpNtpserver resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemNtpNtpserver(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemNtpNtpserver resource: %v", err)
+		return diag.Errorf("Error updating SystemNtpNtpserver resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemNtpNtpserverRead(d, m)
+	return resourceSystemNtpNtpserverRead(ctx, d, m)
 }
 
-func resourceSystemNtpNtpserverDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpNtpserverDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -135,7 +132,7 @@ func resourceSystemNtpNtpserverDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteSystemNtpNtpserver(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemNtpNtpserver resource: %v", err)
+		return diag.Errorf("Error deleting SystemNtpNtpserver resource: %v", err)
 	}
 
 	d.SetId("")
@@ -143,11 +140,10 @@ func resourceSystemNtpNtpserverDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceSystemNtpNtpserverRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemNtpNtpserverRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -155,18 +151,21 @@ func resourceSystemNtpNtpserverRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemNtpNtpserver(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemNtpNtpserver resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemNtpNtpserver resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemNtpNtpserver resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemNtpNtpserver(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemNtpNtpserver resource from API: %v", err)
+		return diag.Errorf("Error reading SystemNtpNtpserver resource from API: %v", err)
 	}
 	return nil
 }
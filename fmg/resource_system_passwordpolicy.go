@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemPasswordPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemPasswordPolicyUpdate,
-		Read:   resourceSystemPasswordPolicyRead,
-		Update: resourceSystemPasswordPolicyUpdate,
-		Delete: resourceSystemPasswordPolicyDelete,
+		CreateContext: resourceSystemPasswordPolicyUpdate,
+		ReadContext:   resourceSystemPasswordPolicyRead,
+		UpdateContext: resourceSystemPasswordPolicyUpdate,
+		DeleteContext: resourceSystemPasswordPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -58,10 +60,9 @@ func resourceSystemPasswordPolicy() *schema.Resource {
 	}
 }
 
-func resourceSystemPasswordPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemPasswordPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -69,26 +70,23 @@ func resourceSystemPasswordPolicyUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemPasswordPolicy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemPasswordPolicy resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemPasswordPolicy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemPasswordPolicy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemPasswordPolicy resource: %v", err)
+		return diag.Errorf("Error updating SystemPasswordPolicy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemPasswordPolicy")
 
-	return resourceSystemPasswordPolicyRead(d, m)
+	return resourceSystemPasswordPolicyRead(ctx, d, m)
 }
 
-func resourceSystemPasswordPolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemPasswordPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -96,7 +94,7 @@ func resourceSystemPasswordPolicyDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemPasswordPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemPasswordPolicy resource: %v", err)
+		return diag.Errorf("Error deleting SystemPasswordPolicy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -104,11 +102,10 @@ func resourceSystemPasswordPolicyDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemPasswordPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemPasswordPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -116,18 +113,21 @@ func resourceSystemPasswordPolicyRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemPasswordPolicy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemPasswordPolicy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemPasswordPolicy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemPasswordPolicy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemPasswordPolicy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemPasswordPolicy resource from API: %v", err)
+		return diag.Errorf("Error reading SystemPasswordPolicy resource from API: %v", err)
 	}
 	return nil
 }
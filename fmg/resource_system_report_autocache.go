@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemReportAutoCache() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemReportAutoCacheUpdate,
-		Read:   resourceSystemReportAutoCacheRead,
-		Update: resourceSystemReportAutoCacheUpdate,
-		Delete: resourceSystemReportAutoCacheDelete,
+		CreateContext: resourceSystemReportAutoCacheUpdate,
+		ReadContext:   resourceSystemReportAutoCacheRead,
+		UpdateContext: resourceSystemReportAutoCacheUpdate,
+		DeleteContext: resourceSystemReportAutoCacheDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,10 +50,9 @@ func resourceSystemReportAutoCache() *schema.Resource {
 	}
 }
 
-func resourceSystemReportAutoCacheUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportAutoCacheUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -59,26 +60,23 @@ func resourceSystemReportAutoCacheUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemReportAutoCache(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportAutoCache resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemReportAutoCache resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemReportAutoCache(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportAutoCache resource: %v", err)
+		return diag.Errorf("Error updating SystemReportAutoCache resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemReportAutoCache")
 
-	return resourceSystemReportAutoCacheRead(d, m)
+	return resourceSystemReportAutoCacheRead(ctx, d, m)
 }
 
-func resourceSystemReportAutoCacheDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportAutoCacheDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -86,7 +84,7 @@ func resourceSystemReportAutoCacheDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemReportAutoCache(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemReportAutoCache resource: %v", err)
+		return diag.Errorf("Error deleting SystemReportAutoCache resource: %v", err)
 	}
 
 	d.SetId("")
@@ -94,11 +92,10 @@ func resourceSystemReportAutoCacheDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemReportAutoCacheRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportAutoCacheRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,18 +103,21 @@ func resourceSystemReportAutoCacheRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadSystemReportAutoCache(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportAutoCache resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemReportAutoCache resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemReportAutoCache resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemReportAutoCache(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportAutoCache resource from API: %v", err)
+		return diag.Errorf("Error reading SystemReportAutoCache resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemReportEstBrowseTime() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemReportEstBrowseTimeUpdate,
-		Read:   resourceSystemReportEstBrowseTimeRead,
-		Update: resourceSystemReportEstBrowseTimeUpdate,
-		Delete: resourceSystemReportEstBrowseTimeDelete,
+		CreateContext: resourceSystemReportEstBrowseTimeUpdate,
+		ReadContext:   resourceSystemReportEstBrowseTimeRead,
+		UpdateContext: resourceSystemReportEstBrowseTimeUpdate,
+		DeleteContext: resourceSystemReportEstBrowseTimeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,10 +45,9 @@ func resourceSystemReportEstBrowseTime() *schema.Resource {
 	}
 }
 
-func resourceSystemReportEstBrowseTimeUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportEstBrowseTimeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -54,26 +55,23 @@ func resourceSystemReportEstBrowseTimeUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectSystemReportEstBrowseTime(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportEstBrowseTime resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemReportEstBrowseTime resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemReportEstBrowseTime(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportEstBrowseTime resource: %v", err)
+		return diag.Errorf("Error updating SystemReportEstBrowseTime resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemReportEstBrowseTime")
 
-	return resourceSystemReportEstBrowseTimeRead(d, m)
+	return resourceSystemReportEstBrowseTimeRead(ctx, d, m)
 }
 
-func resourceSystemReportEstBrowseTimeDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportEstBrowseTimeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -81,7 +79,7 @@ func resourceSystemReportEstBrowseTimeDelete(d *schema.ResourceData, m interface
 
 	err = c.DeleteSystemReportEstBrowseTime(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemReportEstBrowseTime resource: %v", err)
+		return diag.Errorf("Error deleting SystemReportEstBrowseTime resource: %v", err)
 	}
 
 	d.SetId("")
@@ -89,11 +87,10 @@ func resourceSystemReportEstBrowseTimeDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceSystemReportEstBrowseTimeRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportEstBrowseTimeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,18 +98,21 @@ func resourceSystemReportEstBrowseTimeRead(d *schema.ResourceData, m interface{}
 
 	o, err := c.ReadSystemReportEstBrowseTime(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportEstBrowseTime resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemReportEstBrowseTime resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemReportEstBrowseTime resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemReportEstBrowseTime(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportEstBrowseTime resource from API: %v", err)
+		return diag.Errorf("Error reading SystemReportEstBrowseTime resource from API: %v", err)
 	}
 	return nil
 }
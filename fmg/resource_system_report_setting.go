@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemReportSetting() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemReportSettingUpdate,
-		Read:   resourceSystemReportSettingRead,
-		Update: resourceSystemReportSettingUpdate,
-		Delete: resourceSystemReportSettingDelete,
+		CreateContext: resourceSystemReportSettingUpdate,
+		ReadContext:   resourceSystemReportSettingRead,
+		UpdateContext: resourceSystemReportSettingUpdate,
+		DeleteContext: resourceSystemReportSettingDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -87,10 +89,9 @@ func resourceSystemReportSetting() *schema.Resource {
 	}
 }
 
-func resourceSystemReportSettingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -98,26 +99,23 @@ func resourceSystemReportSettingUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemReportSetting(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportSetting resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemReportSetting resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemReportSetting(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemReportSetting resource: %v", err)
+		return diag.Errorf("Error updating SystemReportSetting resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemReportSetting")
 
-	return resourceSystemReportSettingRead(d, m)
+	return resourceSystemReportSettingRead(ctx, d, m)
 }
 
-func resourceSystemReportSettingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -125,7 +123,7 @@ func resourceSystemReportSettingDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemReportSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemReportSetting resource: %v", err)
+		return diag.Errorf("Error deleting SystemReportSetting resource: %v", err)
 	}
 
 	d.SetId("")
@@ -133,11 +131,10 @@ func resourceSystemReportSettingDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemReportSettingRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemReportSettingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -145,18 +142,21 @@ func resourceSystemReportSettingRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemReportSetting(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportSetting resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemReportSetting resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemReportSetting resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemReportSetting(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemReportSetting resource from API: %v", err)
+		return diag.Errorf("Error reading SystemReportSetting resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemRoute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemRouteCreate,
-		Read:   resourceSystemRouteRead,
-		Update: resourceSystemRouteUpdate,
-		Delete: resourceSystemRouteDelete,
+		CreateContext: resourceSystemRouteCreate,
+		ReadContext:   resourceSystemRouteRead,
+		UpdateContext: resourceSystemRouteUpdate,
+		DeleteContext: resourceSystemRouteDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,9 +55,8 @@ func resourceSystemRoute() *schema.Resource {
 	}
 }
 
-func resourceSystemRouteCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -63,24 +64,23 @@ func resourceSystemRouteCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemRoute(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemRoute resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemRoute resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemRoute(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemRoute resource: %v", err)
+		return diag.Errorf("Error creating SystemRoute resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "seq_num")))
 
-	return resourceSystemRouteRead(d, m)
+	return resourceSystemRouteRead(ctx, d, m)
 }
 
-func resourceSystemRouteUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -88,26 +88,23 @@ func resourceSystemRouteUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemRoute(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemRoute resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemRoute resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemRoute(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemRoute resource: %v", err)
+		return diag.Errorf("Error updating SystemRoute resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "seq_num")))
 
-	return resourceSystemRouteRead(d, m)
+	return resourceSystemRouteRead(ctx, d, m)
 }
 
-func resourceSystemRouteDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -115,7 +112,7 @@ func resourceSystemRouteDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemRoute(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemRoute resource: %v", err)
+		return diag.Errorf("Error deleting SystemRoute resource: %v", err)
 	}
 
 	d.SetId("")
@@ -123,11 +120,10 @@ func resourceSystemRouteDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemRouteRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -135,18 +131,21 @@ func resourceSystemRouteRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemRoute(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemRoute resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemRoute resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemRoute resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemRoute(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemRoute resource from API: %v", err)
+		return diag.Errorf("Error reading SystemRoute resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemRoute6() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemRoute6Create,
-		Read:   resourceSystemRoute6Read,
-		Update: resourceSystemRoute6Update,
-		Delete: resourceSystemRoute6Delete,
+		CreateContext: resourceSystemRoute6Create,
+		ReadContext:   resourceSystemRoute6Read,
+		UpdateContext: resourceSystemRoute6Update,
+		DeleteContext: resourceSystemRoute6Delete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,9 +55,8 @@ func resourceSystemRoute6() *schema.Resource {
 	}
 }
 
-func resourceSystemRoute6Create(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemRoute6Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -63,24 +64,23 @@ func resourceSystemRoute6Create(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemRoute6(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemRoute6 resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemRoute6 resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemRoute6(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemRoute6 resource: %v", err)
+		return diag.Errorf("Error creating SystemRoute6 resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "prio")))
 
-	return resourceSystemRoute6Read(d, m)
+	return resourceSystemRoute6Read(ctx, d, m)
 }
 
-func resourceSystemRoute6Update(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRoute6Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -88,26 +88,23 @@ func resourceSystemRoute6Update(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemRoute6(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemRoute6 resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemRoute6 resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemRoute6(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemRoute6 resource: %v", err)
+		return diag.Errorf("Error updating SystemRoute6 resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "prio")))
 
-	return resourceSystemRoute6Read(d, m)
+	return resourceSystemRoute6Read(ctx, d, m)
 }
 
-func resourceSystemRoute6Delete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRoute6Delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -115,7 +112,7 @@ func resourceSystemRoute6Delete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemRoute6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemRoute6 resource: %v", err)
+		return diag.Errorf("Error deleting SystemRoute6 resource: %v", err)
 	}
 
 	d.SetId("")
@@ -123,11 +120,10 @@ func resourceSystemRoute6Delete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemRoute6Read(d *schema.ResourceData, m interface{}) error {
+func resourceSystemRoute6Read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -135,18 +131,21 @@ func resourceSystemRoute6Read(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemRoute6(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemRoute6 resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemRoute6 resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemRoute6 resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemRoute6(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemRoute6 resource from API: %v", err)
+		return diag.Errorf("Error reading SystemRoute6 resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSaml() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSamlUpdate,
-		Read:   resourceSystemSamlRead,
-		Update: resourceSystemSamlUpdate,
-		Delete: resourceSystemSamlDelete,
+		CreateContext: resourceSystemSamlUpdate,
+		ReadContext:   resourceSystemSamlRead,
+		UpdateContext: resourceSystemSamlUpdate,
+		DeleteContext: resourceSystemSamlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -189,10 +191,9 @@ func resourceSystemSaml() *schema.Resource {
 	}
 }
 
-func resourceSystemSamlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -200,25 +201,22 @@ func resourceSystemSamlUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSaml(d, false)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSaml resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSaml resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSaml(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSaml resource: %v", err)
+		return diag.Errorf("Error updating SystemSaml resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemSaml")
 
-	return resourceSystemSamlRead(d, m)
+	return resourceSystemSamlRead(ctx, d, m)
 }
 
-func resourceSystemSamlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -227,12 +225,12 @@ func resourceSystemSamlDelete(d *schema.ResourceData, m interface{}) error {
 	obj, err := getObjectSystemSaml(d, true)
 
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSaml resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSaml resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSaml(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error clearing SystemSaml resource: %v", err)
+		return diag.Errorf("Error clearing SystemSaml resource: %v", err)
 	}
 
 	d.SetId("")
@@ -240,11 +238,10 @@ func resourceSystemSamlDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemSamlRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -252,18 +249,21 @@ func resourceSystemSamlRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSaml(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSaml resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSaml resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSaml resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSaml(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSaml resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSaml resource from API: %v", err)
 	}
 	return nil
 }
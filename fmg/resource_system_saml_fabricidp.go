@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSamlFabricIdp() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSamlFabricIdpCreate,
-		Read:   resourceSystemSamlFabricIdpRead,
-		Update: resourceSystemSamlFabricIdpUpdate,
-		Delete: resourceSystemSamlFabricIdpDelete,
+		CreateContext: resourceSystemSamlFabricIdpCreate,
+		ReadContext:   resourceSystemSamlFabricIdpRead,
+		UpdateContext: resourceSystemSamlFabricIdpUpdate,
+		DeleteContext: resourceSystemSamlFabricIdpDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -59,9 +61,8 @@ func resourceSystemSamlFabricIdp() *schema.Resource {
 	}
 }
 
-func resourceSystemSamlFabricIdpCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSamlFabricIdpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -69,24 +70,23 @@ func resourceSystemSamlFabricIdpCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemSamlFabricIdp(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSamlFabricIdp resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSamlFabricIdp resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSamlFabricIdp(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSamlFabricIdp resource: %v", err)
+		return diag.Errorf("Error creating SystemSamlFabricIdp resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "dev_id"))
 
-	return resourceSystemSamlFabricIdpRead(d, m)
+	return resourceSystemSamlFabricIdpRead(ctx, d, m)
 }
 
-func resourceSystemSamlFabricIdpUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlFabricIdpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -94,26 +94,23 @@ func resourceSystemSamlFabricIdpUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemSamlFabricIdp(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSamlFabricIdp resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSamlFabricIdp resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSamlFabricIdp(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSamlFabricIdp resource: %v", err)
+		return diag.Errorf("Error updating SystemSamlFabricIdp resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "dev_id"))
 
-	return resourceSystemSamlFabricIdpRead(d, m)
+	return resourceSystemSamlFabricIdpRead(ctx, d, m)
 }
 
-func resourceSystemSamlFabricIdpDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlFabricIdpDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -121,7 +118,7 @@ func resourceSystemSamlFabricIdpDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemSamlFabricIdp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSamlFabricIdp resource: %v", err)
+		return diag.Errorf("Error deleting SystemSamlFabricIdp resource: %v", err)
 	}
 
 	d.SetId("")
@@ -129,11 +126,10 @@ func resourceSystemSamlFabricIdpDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemSamlFabricIdpRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlFabricIdpRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -141,18 +137,21 @@ func resourceSystemSamlFabricIdpRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemSamlFabricIdp(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSamlFabricIdp resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSamlFabricIdp resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSamlFabricIdp resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSamlFabricIdp(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSamlFabricIdp resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSamlFabricIdp resource from API: %v", err)
 	}
 	return nil
 }
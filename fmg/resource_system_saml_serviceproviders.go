@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSamlServiceProviders() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSamlServiceProvidersCreate,
-		Read:   resourceSystemSamlServiceProvidersRead,
-		Update: resourceSystemSamlServiceProvidersUpdate,
-		Delete: resourceSystemSamlServiceProvidersDelete,
+		CreateContext: resourceSystemSamlServiceProvidersCreate,
+		ReadContext:   resourceSystemSamlServiceProvidersRead,
+		UpdateContext: resourceSystemSamlServiceProvidersUpdate,
+		DeleteContext: resourceSystemSamlServiceProvidersDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -78,9 +80,8 @@ func resourceSystemSamlServiceProviders() *schema.Resource {
 	}
 }
 
-func resourceSystemSamlServiceProvidersCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSamlServiceProvidersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -88,24 +89,23 @@ func resourceSystemSamlServiceProvidersCreate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectSystemSamlServiceProviders(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSamlServiceProviders resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSamlServiceProviders resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSamlServiceProviders(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSamlServiceProviders resource: %v", err)
+		return diag.Errorf("Error creating SystemSamlServiceProviders resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "idp_entity_id"))
 
-	return resourceSystemSamlServiceProvidersRead(d, m)
+	return resourceSystemSamlServiceProvidersRead(ctx, d, m)
 }
 
-func resourceSystemSamlServiceProvidersUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlServiceProvidersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -113,26 +113,23 @@ func resourceSystemSamlServiceProvidersUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectSystemSamlServiceProviders(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSamlServiceProviders resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSamlServiceProviders resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSamlServiceProviders(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSamlServiceProviders resource: %v", err)
+		return diag.Errorf("Error updating SystemSamlServiceProviders resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "idp_entity_id"))
 
-	return resourceSystemSamlServiceProvidersRead(d, m)
+	return resourceSystemSamlServiceProvidersRead(ctx, d, m)
 }
 
-func resourceSystemSamlServiceProvidersDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlServiceProvidersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -140,7 +137,7 @@ func resourceSystemSamlServiceProvidersDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeleteSystemSamlServiceProviders(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSamlServiceProviders resource: %v", err)
+		return diag.Errorf("Error deleting SystemSamlServiceProviders resource: %v", err)
 	}
 
 	d.SetId("")
@@ -148,11 +145,10 @@ func resourceSystemSamlServiceProvidersDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceSystemSamlServiceProvidersRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSamlServiceProvidersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -160,18 +156,21 @@ func resourceSystemSamlServiceProvidersRead(d *schema.ResourceData, m interface{
 
 	o, err := c.ReadSystemSamlServiceProviders(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSamlServiceProviders resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSamlServiceProviders resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSamlServiceProviders resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSamlServiceProviders(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSamlServiceProviders resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSamlServiceProviders resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSniffer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSnifferCreate,
-		Read:   resourceSystemSnifferRead,
-		Update: resourceSystemSnifferUpdate,
-		Delete: resourceSystemSnifferDelete,
+		CreateContext: resourceSystemSnifferCreate,
+		ReadContext:   resourceSystemSnifferRead,
+		UpdateContext: resourceSystemSnifferUpdate,
+		DeleteContext: resourceSystemSnifferDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,9 +75,8 @@ func resourceSystemSniffer() *schema.Resource {
 	}
 }
 
-func resourceSystemSnifferCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSnifferCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -83,24 +84,23 @@ func resourceSystemSnifferCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSniffer(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSniffer resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSniffer resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSniffer(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSniffer resource: %v", err)
+		return diag.Errorf("Error creating SystemSniffer resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSnifferRead(d, m)
+	return resourceSystemSnifferRead(ctx, d, m)
 }
 
-func resourceSystemSnifferUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnifferUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -108,26 +108,23 @@ func resourceSystemSnifferUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSniffer(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSniffer resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSniffer resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSniffer(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSniffer resource: %v", err)
+		return diag.Errorf("Error updating SystemSniffer resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSnifferRead(d, m)
+	return resourceSystemSnifferRead(ctx, d, m)
 }
 
-func resourceSystemSnifferDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnifferDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -135,7 +132,7 @@ func resourceSystemSnifferDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemSniffer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSniffer resource: %v", err)
+		return diag.Errorf("Error deleting SystemSniffer resource: %v", err)
 	}
 
 	d.SetId("")
@@ -143,11 +140,10 @@ func resourceSystemSnifferDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemSnifferRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnifferRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -155,18 +151,21 @@ func resourceSystemSnifferRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSniffer(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSniffer resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSniffer resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSniffer resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSniffer(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSniffer resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSniffer resource from API: %v", err)
 	}
 	return nil
 }
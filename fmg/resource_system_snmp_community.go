@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSnmpCommunity() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSnmpCommunityCreate,
-		Read:   resourceSystemSnmpCommunityRead,
-		Update: resourceSystemSnmpCommunityUpdate,
-		Delete: resourceSystemSnmpCommunityDelete,
+		CreateContext: resourceSystemSnmpCommunityCreate,
+		ReadContext:   resourceSystemSnmpCommunityRead,
+		UpdateContext: resourceSystemSnmpCommunityUpdate,
+		DeleteContext: resourceSystemSnmpCommunityDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -140,9 +142,8 @@ func resourceSystemSnmpCommunity() *schema.Resource {
 	}
 }
 
-func resourceSystemSnmpCommunityCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSnmpCommunityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -150,24 +151,23 @@ func resourceSystemSnmpCommunityCreate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemSnmpCommunity(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSnmpCommunity resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSnmpCommunity resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSnmpCommunity(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSnmpCommunity resource: %v", err)
+		return diag.Errorf("Error creating SystemSnmpCommunity resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSnmpCommunityRead(d, m)
+	return resourceSystemSnmpCommunityRead(ctx, d, m)
 }
 
-func resourceSystemSnmpCommunityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpCommunityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -175,26 +175,23 @@ func resourceSystemSnmpCommunityUpdate(d *schema.ResourceData, m interface{}) er
 
 	obj, err := getObjectSystemSnmpCommunity(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpCommunity resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSnmpCommunity resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSnmpCommunity(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpCommunity resource: %v", err)
+		return diag.Errorf("Error updating SystemSnmpCommunity resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSnmpCommunityRead(d, m)
+	return resourceSystemSnmpCommunityRead(ctx, d, m)
 }
 
-func resourceSystemSnmpCommunityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpCommunityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -202,7 +199,7 @@ func resourceSystemSnmpCommunityDelete(d *schema.ResourceData, m interface{}) er
 
 	err = c.DeleteSystemSnmpCommunity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSnmpCommunity resource: %v", err)
+		return diag.Errorf("Error deleting SystemSnmpCommunity resource: %v", err)
 	}
 
 	d.SetId("")
@@ -210,11 +207,10 @@ func resourceSystemSnmpCommunityDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSystemSnmpCommunityRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpCommunityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -222,18 +218,21 @@ func resourceSystemSnmpCommunityRead(d *schema.ResourceData, m interface{}) erro
 
 	o, err := c.ReadSystemSnmpCommunity(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpCommunity resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSnmpCommunity resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSnmpCommunity resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSnmpCommunity(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpCommunity resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSnmpCommunity resource from API: %v", err)
 	}
 	return nil
 }
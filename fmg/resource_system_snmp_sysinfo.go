@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSnmpSysinfo() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSnmpSysinfoUpdate,
-		Read:   resourceSystemSnmpSysinfoRead,
-		Update: resourceSystemSnmpSysinfoUpdate,
-		Delete: resourceSystemSnmpSysinfoDelete,
+		CreateContext: resourceSystemSnmpSysinfoUpdate,
+		ReadContext:   resourceSystemSnmpSysinfoRead,
+		UpdateContext: resourceSystemSnmpSysinfoUpdate,
+		DeleteContext: resourceSystemSnmpSysinfoDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -69,10 +71,9 @@ func resourceSystemSnmpSysinfo() *schema.Resource {
 	}
 }
 
-func resourceSystemSnmpSysinfoUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpSysinfoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -80,26 +81,23 @@ func resourceSystemSnmpSysinfoUpdate(d *schema.ResourceData, m interface{}) erro
 
 	obj, err := getObjectSystemSnmpSysinfo(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpSysinfo resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSnmpSysinfo resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSnmpSysinfo(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpSysinfo resource: %v", err)
+		return diag.Errorf("Error updating SystemSnmpSysinfo resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemSnmpSysinfo")
 
-	return resourceSystemSnmpSysinfoRead(d, m)
+	return resourceSystemSnmpSysinfoRead(ctx, d, m)
 }
 
-func resourceSystemSnmpSysinfoDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpSysinfoDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -107,7 +105,7 @@ func resourceSystemSnmpSysinfoDelete(d *schema.ResourceData, m interface{}) erro
 
 	err = c.DeleteSystemSnmpSysinfo(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSnmpSysinfo resource: %v", err)
+		return diag.Errorf("Error deleting SystemSnmpSysinfo resource: %v", err)
 	}
 
 	d.SetId("")
@@ -115,11 +113,10 @@ func resourceSystemSnmpSysinfoDelete(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceSystemSnmpSysinfoRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpSysinfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -127,18 +124,21 @@ func resourceSystemSnmpSysinfoRead(d *schema.ResourceData, m interface{}) error
 
 	o, err := c.ReadSystemSnmpSysinfo(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpSysinfo resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSnmpSysinfo resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSnmpSysinfo resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSnmpSysinfo(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpSysinfo resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSnmpSysinfo resource from API: %v", err)
 	}
 	return nil
 }
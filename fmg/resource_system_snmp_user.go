@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSnmpUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSnmpUserCreate,
-		Read:   resourceSystemSnmpUserRead,
-		Update: resourceSystemSnmpUserUpdate,
-		Delete: resourceSystemSnmpUserDelete,
+		CreateContext: resourceSystemSnmpUserCreate,
+		ReadContext:   resourceSystemSnmpUserRead,
+		UpdateContext: resourceSystemSnmpUserUpdate,
+		DeleteContext: resourceSystemSnmpUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,9 +93,8 @@ func resourceSystemSnmpUser() *schema.Resource {
 	}
 }
 
-func resourceSystemSnmpUserCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSnmpUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,24 +102,23 @@ func resourceSystemSnmpUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSnmpUser(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSnmpUser resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSnmpUser resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSnmpUser(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSnmpUser resource: %v", err)
+		return diag.Errorf("Error creating SystemSnmpUser resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemSnmpUserRead(d, m)
+	return resourceSystemSnmpUserRead(ctx, d, m)
 }
 
-func resourceSystemSnmpUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -126,26 +126,23 @@ func resourceSystemSnmpUserUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSnmpUser(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpUser resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSnmpUser resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSnmpUser(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSnmpUser resource: %v", err)
+		return diag.Errorf("Error updating SystemSnmpUser resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemSnmpUserRead(d, m)
+	return resourceSystemSnmpUserRead(ctx, d, m)
 }
 
-func resourceSystemSnmpUserDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -153,7 +150,7 @@ func resourceSystemSnmpUserDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemSnmpUser(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSnmpUser resource: %v", err)
+		return diag.Errorf("Error deleting SystemSnmpUser resource: %v", err)
 	}
 
 	d.SetId("")
@@ -161,11 +158,10 @@ func resourceSystemSnmpUserDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemSnmpUserRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSnmpUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -173,18 +169,21 @@ func resourceSystemSnmpUserRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSnmpUser(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpUser resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSnmpUser resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSnmpUser resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSnmpUser(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSnmpUser resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSnmpUser resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSocFabric() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSocFabricUpdate,
-		Read:   resourceSystemSocFabricRead,
-		Update: resourceSystemSocFabricUpdate,
-		Delete: resourceSystemSocFabricDelete,
+		CreateContext: resourceSystemSocFabricUpdate,
+		ReadContext:   resourceSystemSocFabricRead,
+		UpdateContext: resourceSystemSocFabricUpdate,
+		DeleteContext: resourceSystemSocFabricDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,10 +70,9 @@ func resourceSystemSocFabric() *schema.Resource {
 	}
 }
 
-func resourceSystemSocFabricUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSocFabricUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -79,26 +80,23 @@ func resourceSystemSocFabricUpdate(d *schema.ResourceData, m interface{}) error
 
 	obj, err := getObjectSystemSocFabric(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSocFabric resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSocFabric resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSocFabric(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSocFabric resource: %v", err)
+		return diag.Errorf("Error updating SystemSocFabric resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemSocFabric")
 
-	return resourceSystemSocFabricRead(d, m)
+	return resourceSystemSocFabricRead(ctx, d, m)
 }
 
-func resourceSystemSocFabricDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSocFabricDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -106,7 +104,7 @@ func resourceSystemSocFabricDelete(d *schema.ResourceData, m interface{}) error
 
 	err = c.DeleteSystemSocFabric(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSocFabric resource: %v", err)
+		return diag.Errorf("Error deleting SystemSocFabric resource: %v", err)
 	}
 
 	d.SetId("")
@@ -114,11 +112,10 @@ func resourceSystemSocFabricDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceSystemSocFabricRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSocFabricRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -126,18 +123,21 @@ func resourceSystemSocFabricRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSocFabric(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSocFabric resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSocFabric resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSocFabric resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSocFabric(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSocFabric resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSocFabric resource from API: %v", err)
 	}
 	return nil
 }
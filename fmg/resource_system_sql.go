@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSql() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSqlUpdate,
-		Read:   resourceSystemSqlRead,
-		Update: resourceSystemSqlUpdate,
-		Delete: resourceSystemSqlDelete,
+		CreateContext: resourceSystemSqlUpdate,
+		ReadContext:   resourceSystemSqlRead,
+		UpdateContext: resourceSystemSqlUpdate,
+		DeleteContext: resourceSystemSqlDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -205,10 +207,9 @@ func resourceSystemSql() *schema.Resource {
 	}
 }
 
-func resourceSystemSqlUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -216,26 +217,23 @@ func resourceSystemSqlUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSql(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSql resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSql resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSql(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSql resource: %v", err)
+		return diag.Errorf("Error updating SystemSql resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemSql")
 
-	return resourceSystemSqlRead(d, m)
+	return resourceSystemSqlRead(ctx, d, m)
 }
 
-func resourceSystemSqlDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -243,7 +241,7 @@ func resourceSystemSqlDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemSql(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSql resource: %v", err)
+		return diag.Errorf("Error deleting SystemSql resource: %v", err)
 	}
 
 	d.SetId("")
@@ -251,11 +249,10 @@ func resourceSystemSqlDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemSqlRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -263,18 +260,21 @@ func resourceSystemSqlRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSql(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSql resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSql resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSql resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSql(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSql resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSql resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSqlCustomIndex() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSqlCustomIndexCreate,
-		Read:   resourceSystemSqlCustomIndexRead,
-		Update: resourceSystemSqlCustomIndexUpdate,
-		Delete: resourceSystemSqlCustomIndexDelete,
+		CreateContext: resourceSystemSqlCustomIndexCreate,
+		ReadContext:   resourceSystemSqlCustomIndexRead,
+		UpdateContext: resourceSystemSqlCustomIndexUpdate,
+		DeleteContext: resourceSystemSqlCustomIndexDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,9 +59,8 @@ func resourceSystemSqlCustomIndex() *schema.Resource {
 	}
 }
 
-func resourceSystemSqlCustomIndexCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSqlCustomIndexCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -67,24 +68,23 @@ func resourceSystemSqlCustomIndexCreate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemSqlCustomIndex(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlCustomIndex resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSqlCustomIndex resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSqlCustomIndex(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlCustomIndex resource: %v", err)
+		return diag.Errorf("Error creating SystemSqlCustomIndex resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSqlCustomIndexRead(d, m)
+	return resourceSystemSqlCustomIndexRead(ctx, d, m)
 }
 
-func resourceSystemSqlCustomIndexUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomIndexUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -92,26 +92,23 @@ func resourceSystemSqlCustomIndexUpdate(d *schema.ResourceData, m interface{}) e
 
 	obj, err := getObjectSystemSqlCustomIndex(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlCustomIndex resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSqlCustomIndex resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSqlCustomIndex(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlCustomIndex resource: %v", err)
+		return diag.Errorf("Error updating SystemSqlCustomIndex resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSqlCustomIndexRead(d, m)
+	return resourceSystemSqlCustomIndexRead(ctx, d, m)
 }
 
-func resourceSystemSqlCustomIndexDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomIndexDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -119,7 +116,7 @@ func resourceSystemSqlCustomIndexDelete(d *schema.ResourceData, m interface{}) e
 
 	err = c.DeleteSystemSqlCustomIndex(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSqlCustomIndex resource: %v", err)
+		return diag.Errorf("Error deleting SystemSqlCustomIndex resource: %v", err)
 	}
 
 	d.SetId("")
@@ -127,11 +124,10 @@ func resourceSystemSqlCustomIndexDelete(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceSystemSqlCustomIndexRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomIndexRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -139,18 +135,21 @@ func resourceSystemSqlCustomIndexRead(d *schema.ResourceData, m interface{}) err
 
 	o, err := c.ReadSystemSqlCustomIndex(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlCustomIndex resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSqlCustomIndex resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSqlCustomIndex resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSqlCustomIndex(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlCustomIndex resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSqlCustomIndex resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSqlCustomSkipidx() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSqlCustomSkipidxCreate,
-		Read:   resourceSystemSqlCustomSkipidxRead,
-		Update: resourceSystemSqlCustomSkipidxUpdate,
-		Delete: resourceSystemSqlCustomSkipidxDelete,
+		CreateContext: resourceSystemSqlCustomSkipidxCreate,
+		ReadContext:   resourceSystemSqlCustomSkipidxRead,
+		UpdateContext: resourceSystemSqlCustomSkipidxUpdate,
+		DeleteContext: resourceSystemSqlCustomSkipidxDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -52,9 +54,8 @@ func resourceSystemSqlCustomSkipidx() *schema.Resource {
 	}
 }
 
-func resourceSystemSqlCustomSkipidxCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSqlCustomSkipidxCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -62,24 +63,23 @@ func resourceSystemSqlCustomSkipidxCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemSqlCustomSkipidx(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlCustomSkipidx resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSqlCustomSkipidx resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSqlCustomSkipidx(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlCustomSkipidx resource: %v", err)
+		return diag.Errorf("Error creating SystemSqlCustomSkipidx resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSqlCustomSkipidxRead(d, m)
+	return resourceSystemSqlCustomSkipidxRead(ctx, d, m)
 }
 
-func resourceSystemSqlCustomSkipidxUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomSkipidxUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -87,26 +87,23 @@ func resourceSystemSqlCustomSkipidxUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemSqlCustomSkipidx(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlCustomSkipidx resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSqlCustomSkipidx resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSqlCustomSkipidx(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlCustomSkipidx resource: %v", err)
+		return diag.Errorf("Error updating SystemSqlCustomSkipidx resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceSystemSqlCustomSkipidxRead(d, m)
+	return resourceSystemSqlCustomSkipidxRead(ctx, d, m)
 }
 
-func resourceSystemSqlCustomSkipidxDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomSkipidxDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -114,7 +111,7 @@ func resourceSystemSqlCustomSkipidxDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemSqlCustomSkipidx(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSqlCustomSkipidx resource: %v", err)
+		return diag.Errorf("Error deleting SystemSqlCustomSkipidx resource: %v", err)
 	}
 
 	d.SetId("")
@@ -122,11 +119,10 @@ func resourceSystemSqlCustomSkipidxDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemSqlCustomSkipidxRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlCustomSkipidxRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -134,18 +130,21 @@ func resourceSystemSqlCustomSkipidxRead(d *schema.ResourceData, m interface{}) e
 
 	o, err := c.ReadSystemSqlCustomSkipidx(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlCustomSkipidx resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSqlCustomSkipidx resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSqlCustomSkipidx resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSqlCustomSkipidx(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlCustomSkipidx resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSqlCustomSkipidx resource from API: %v", err)
 	}
 	return nil
 }
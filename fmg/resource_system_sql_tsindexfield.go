@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSqlTsIndexField() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSqlTsIndexFieldCreate,
-		Read:   resourceSystemSqlTsIndexFieldRead,
-		Update: resourceSystemSqlTsIndexFieldUpdate,
-		Delete: resourceSystemSqlTsIndexFieldDelete,
+		CreateContext: resourceSystemSqlTsIndexFieldCreate,
+		ReadContext:   resourceSystemSqlTsIndexFieldRead,
+		UpdateContext: resourceSystemSqlTsIndexFieldUpdate,
+		DeleteContext: resourceSystemSqlTsIndexFieldDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -42,9 +44,8 @@ func resourceSystemSqlTsIndexField() *schema.Resource {
 	}
 }
 
-func resourceSystemSqlTsIndexFieldCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSqlTsIndexFieldCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -52,24 +53,23 @@ func resourceSystemSqlTsIndexFieldCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemSqlTsIndexField(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlTsIndexField resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSqlTsIndexField resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSqlTsIndexField(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSqlTsIndexField resource: %v", err)
+		return diag.Errorf("Error creating SystemSqlTsIndexField resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "category"))
 
-	return resourceSystemSqlTsIndexFieldRead(d, m)
+	return resourceSystemSqlTsIndexFieldRead(ctx, d, m)
 }
 
-func resourceSystemSqlTsIndexFieldUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlTsIndexFieldUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -77,26 +77,23 @@ func resourceSystemSqlTsIndexFieldUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectSystemSqlTsIndexField(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlTsIndexField resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSqlTsIndexField resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSqlTsIndexField(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSqlTsIndexField resource: %v", err)
+		return diag.Errorf("Error updating SystemSqlTsIndexField resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "category"))
 
-	return resourceSystemSqlTsIndexFieldRead(d, m)
+	return resourceSystemSqlTsIndexFieldRead(ctx, d, m)
 }
 
-func resourceSystemSqlTsIndexFieldDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlTsIndexFieldDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -104,7 +101,7 @@ func resourceSystemSqlTsIndexFieldDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteSystemSqlTsIndexField(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSqlTsIndexField resource: %v", err)
+		return diag.Errorf("Error deleting SystemSqlTsIndexField resource: %v", err)
 	}
 
 	d.SetId("")
@@ -112,11 +109,10 @@ func resourceSystemSqlTsIndexFieldDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceSystemSqlTsIndexFieldRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSqlTsIndexFieldRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -124,18 +120,21 @@ func resourceSystemSqlTsIndexFieldRead(d *schema.ResourceData, m interface{}) er
 
 	o, err := c.ReadSystemSqlTsIndexField(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlTsIndexField resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSqlTsIndexField resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSqlTsIndexField resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSqlTsIndexField(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSqlTsIndexField resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSqlTsIndexField resource from API: %v", err)
 	}
 	return nil
 }
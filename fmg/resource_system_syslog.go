@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemSyslog() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemSyslogCreate,
-		Read:   resourceSystemSyslogRead,
-		Update: resourceSystemSyslogUpdate,
-		Delete: resourceSystemSyslogDelete,
+		CreateContext: resourceSystemSyslogCreate,
+		ReadContext:   resourceSystemSyslogRead,
+		UpdateContext: resourceSystemSyslogUpdate,
+		DeleteContext: resourceSystemSyslogDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,9 +68,8 @@ func resourceSystemSyslog() *schema.Resource {
 	}
 }
 
-func resourceSystemSyslogCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemSyslogCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -76,24 +77,23 @@ func resourceSystemSyslogCreate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSyslog(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSyslog resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemSyslog resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemSyslog(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemSyslog resource: %v", err)
+		return diag.Errorf("Error creating SystemSyslog resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemSyslogRead(d, m)
+	return resourceSystemSyslogRead(ctx, d, m)
 }
 
-func resourceSystemSyslogUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSyslogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,26 +101,23 @@ func resourceSystemSyslogUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemSyslog(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSyslog resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemSyslog resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemSyslog(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemSyslog resource: %v", err)
+		return diag.Errorf("Error updating SystemSyslog resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceSystemSyslogRead(d, m)
+	return resourceSystemSyslogRead(ctx, d, m)
 }
 
-func resourceSystemSyslogDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSyslogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -128,7 +125,7 @@ func resourceSystemSyslogDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemSyslog(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemSyslog resource: %v", err)
+		return diag.Errorf("Error deleting SystemSyslog resource: %v", err)
 	}
 
 	d.SetId("")
@@ -136,11 +133,10 @@ func resourceSystemSyslogDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemSyslogRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemSyslogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -148,18 +144,21 @@ func resourceSystemSyslogRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemSyslog(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSyslog resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemSyslog resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemSyslog resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemSyslog(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemSyslog resource from API: %v", err)
+		return diag.Errorf("Error reading SystemSyslog resource from API: %v", err)
 	}
 	return nil
 }
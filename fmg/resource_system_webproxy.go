@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemWebProxy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemWebProxyUpdate,
-		Read:   resourceSystemWebProxyRead,
-		Update: resourceSystemWebProxyUpdate,
-		Delete: resourceSystemWebProxyDelete,
+		CreateContext: resourceSystemWebProxyUpdate,
+		ReadContext:   resourceSystemWebProxyRead,
+		UpdateContext: resourceSystemWebProxyUpdate,
+		DeleteContext: resourceSystemWebProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,10 +65,9 @@ func resourceSystemWebProxy() *schema.Resource {
 	}
 }
 
-func resourceSystemWebProxyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWebProxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -74,26 +75,23 @@ func resourceSystemWebProxyUpdate(d *schema.ResourceData, m interface{}) error {
 
 	obj, err := getObjectSystemWebProxy(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemWebProxy resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemWebProxy resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemWebProxy(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemWebProxy resource: %v", err)
+		return diag.Errorf("Error updating SystemWebProxy resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("SystemWebProxy")
 
-	return resourceSystemWebProxyRead(d, m)
+	return resourceSystemWebProxyRead(ctx, d, m)
 }
 
-func resourceSystemWebProxyDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWebProxyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -101,7 +99,7 @@ func resourceSystemWebProxyDelete(d *schema.ResourceData, m interface{}) error {
 
 	err = c.DeleteSystemWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemWebProxy resource: %v", err)
+		return diag.Errorf("Error deleting SystemWebProxy resource: %v", err)
 	}
 
 	d.SetId("")
@@ -109,11 +107,10 @@ func resourceSystemWebProxyDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSystemWebProxyRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWebProxyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -121,18 +118,21 @@ func resourceSystemWebProxyRead(d *schema.ResourceData, m interface{}) error {
 
 	o, err := c.ReadSystemWebProxy(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemWebProxy resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemWebProxy resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemWebProxy resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemWebProxy(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemWebProxy resource from API: %v", err)
+		return diag.Errorf("Error reading SystemWebProxy resource from API: %v", err)
 	}
 	return nil
 }
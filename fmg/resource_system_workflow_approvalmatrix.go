@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSystemWorkflowApprovalMatrix() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSystemWorkflowApprovalMatrixCreate,
-		Read:   resourceSystemWorkflowApprovalMatrixRead,
-		Update: resourceSystemWorkflowApprovalMatrixUpdate,
-		Delete: resourceSystemWorkflowApprovalMatrixDelete,
+		CreateContext: resourceSystemWorkflowApprovalMatrixCreate,
+		ReadContext:   resourceSystemWorkflowApprovalMatrixRead,
+		UpdateContext: resourceSystemWorkflowApprovalMatrixUpdate,
+		DeleteContext: resourceSystemWorkflowApprovalMatrixDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,9 +69,8 @@ func resourceSystemWorkflowApprovalMatrix() *schema.Resource {
 	}
 }
 
-func resourceSystemWorkflowApprovalMatrixCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceSystemWorkflowApprovalMatrixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -77,24 +78,23 @@ func resourceSystemWorkflowApprovalMatrixCreate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemWorkflowApprovalMatrix(d)
 	if err != nil {
-		return fmt.Errorf("Error creating SystemWorkflowApprovalMatrix resource while getting object: %v", err)
+		return diag.Errorf("Error creating SystemWorkflowApprovalMatrix resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateSystemWorkflowApprovalMatrix(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating SystemWorkflowApprovalMatrix resource: %v", err)
+		return diag.Errorf("Error creating SystemWorkflowApprovalMatrix resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "adom_name"))
 
-	return resourceSystemWorkflowApprovalMatrixRead(d, m)
+	return resourceSystemWorkflowApprovalMatrixRead(ctx, d, m)
 }
 
-func resourceSystemWorkflowApprovalMatrixUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWorkflowApprovalMatrixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -102,26 +102,23 @@ func resourceSystemWorkflowApprovalMatrixUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectSystemWorkflowApprovalMatrix(d)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemWorkflowApprovalMatrix resource while getting object: %v", err)
+		return diag.Errorf("Error updating SystemWorkflowApprovalMatrix resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateSystemWorkflowApprovalMatrix(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating SystemWorkflowApprovalMatrix resource: %v", err)
+		return diag.Errorf("Error updating SystemWorkflowApprovalMatrix resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "adom_name"))
 
-	return resourceSystemWorkflowApprovalMatrixRead(d, m)
+	return resourceSystemWorkflowApprovalMatrixRead(ctx, d, m)
 }
 
-func resourceSystemWorkflowApprovalMatrixDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWorkflowApprovalMatrixDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -129,7 +126,7 @@ func resourceSystemWorkflowApprovalMatrixDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteSystemWorkflowApprovalMatrix(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting SystemWorkflowApprovalMatrix resource: %v", err)
+		return diag.Errorf("Error deleting SystemWorkflowApprovalMatrix resource: %v", err)
 	}
 
 	d.SetId("")
@@ -137,11 +134,10 @@ func resourceSystemWorkflowApprovalMatrixDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSystemWorkflowApprovalMatrixRead(d *schema.ResourceData, m interface{}) error {
+func resourceSystemWorkflowApprovalMatrixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	adomv, err := "global", fmt.Errorf("")
@@ -149,18 +145,21 @@ func resourceSystemWorkflowApprovalMatrixRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadSystemWorkflowApprovalMatrix(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemWorkflowApprovalMatrix resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SystemWorkflowApprovalMatrix resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading SystemWorkflowApprovalMatrix resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectSystemWorkflowApprovalMatrix(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading SystemWorkflowApprovalMatrix resource from API: %v", err)
+		return diag.Errorf("Error reading SystemWorkflowApprovalMatrix resource from API: %v", err)
 	}
 	return nil
 }
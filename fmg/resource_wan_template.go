@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWanTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWanTemplateCreate,
-		Read:   resourceWanTemplateRead,
-		Update: resourceWanTemplateUpdate,
-		Delete: resourceWanTemplateDelete,
+		CreateContext: resourceWanTemplateCreate,
+		ReadContext:   resourceWanTemplateRead,
+		UpdateContext: resourceWanTemplateUpdate,
+		DeleteContext: resourceWanTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -88,81 +90,76 @@ func resourceWanTemplate() *schema.Resource {
 	}
 }
 
-func resourceWanTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWanTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectWanTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WanTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error creating WanTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWanTemplate(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WanTemplate resource: %v", err)
+		return diag.Errorf("Error creating WanTemplate resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWanTemplateRead(d, m)
+	return resourceWanTemplateRead(ctx, d, m)
 }
 
-func resourceWanTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWanTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	obj, err := getObjectWanTemplate(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WanTemplate resource while getting object: %v", err)
+		return diag.Errorf("Error updating WanTemplate resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWanTemplate(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WanTemplate resource: %v", err)
+		return diag.Errorf("Error updating WanTemplate resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWanTemplateRead(d, m)
+	return resourceWanTemplateRead(ctx, d, m)
 }
 
-func resourceWanTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWanTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	err = c.DeleteWanTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WanTemplate resource: %v", err)
+		return diag.Errorf("Error deleting WanTemplate resource: %v", err)
 	}
 
 	d.SetId("")
@@ -170,34 +167,36 @@ func resourceWanTemplateDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceWanTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceWanTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
 	o, err := c.ReadWanTemplate(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WanTemplate resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WanTemplate resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WanTemplate resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWanTemplate(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WanTemplate resource from API: %v", err)
+		return diag.Errorf("Error reading WanTemplate resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwan() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanUpdate,
-		Read:   resourceWantempSystemSdwanRead,
-		Update: resourceWantempSystemSdwanUpdate,
-		Delete: resourceWantempSystemSdwanDelete,
+		CreateContext: resourceWantempSystemSdwanUpdate,
+		ReadContext:   resourceWantempSystemSdwanRead,
+		UpdateContext: resourceWantempSystemSdwanUpdate,
+		DeleteContext: resourceWantempSystemSdwanDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -788,16 +790,15 @@ func resourceWantempSystemSdwan() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -806,32 +807,29 @@ func resourceWantempSystemSdwanUpdate(d *schema.ResourceData, m interface{}) err
 
 	obj, err := getObjectWantempSystemSdwan(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwan resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwan resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwan(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwan resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwan resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("WantempSystemSdwan")
 
-	return resourceWantempSystemSdwanRead(d, m)
+	return resourceWantempSystemSdwanRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -840,7 +838,7 @@ func resourceWantempSystemSdwanDelete(d *schema.ResourceData, m interface{}) err
 
 	err = c.DeleteWantempSystemSdwan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwan resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwan resource: %v", err)
 	}
 
 	d.SetId("")
@@ -848,17 +846,16 @@ func resourceWantempSystemSdwanDelete(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceWantempSystemSdwanRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -866,25 +863,28 @@ func resourceWantempSystemSdwanRead(d *schema.ResourceData, m interface{}) error
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwan(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwan resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwan resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwan resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwan(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwan resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwan resource from API: %v", err)
 	}
 	return nil
 }
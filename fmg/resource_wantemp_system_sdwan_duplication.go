@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanDuplication() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanDuplicationCreate,
-		Read:   resourceWantempSystemSdwanDuplicationRead,
-		Update: resourceWantempSystemSdwanDuplicationUpdate,
-		Delete: resourceWantempSystemSdwanDuplicationDelete,
+		CreateContext: resourceWantempSystemSdwanDuplicationCreate,
+		ReadContext:   resourceWantempSystemSdwanDuplicationRead,
+		UpdateContext: resourceWantempSystemSdwanDuplicationUpdate,
+		DeleteContext: resourceWantempSystemSdwanDuplicationDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -106,15 +108,14 @@ func resourceWantempSystemSdwanDuplication() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanDuplicationCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanDuplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -123,30 +124,29 @@ func resourceWantempSystemSdwanDuplicationCreate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectWantempSystemSdwanDuplication(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanDuplication resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanDuplication resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanDuplication(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanDuplication resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanDuplication resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceWantempSystemSdwanDuplicationRead(d, m)
+	return resourceWantempSystemSdwanDuplicationRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanDuplicationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanDuplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -155,32 +155,29 @@ func resourceWantempSystemSdwanDuplicationUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectWantempSystemSdwanDuplication(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanDuplication resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanDuplication resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanDuplication(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanDuplication resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanDuplication resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "fosid")))
 
-	return resourceWantempSystemSdwanDuplicationRead(d, m)
+	return resourceWantempSystemSdwanDuplicationRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanDuplicationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanDuplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -189,7 +186,7 @@ func resourceWantempSystemSdwanDuplicationDelete(d *schema.ResourceData, m inter
 
 	err = c.DeleteWantempSystemSdwanDuplication(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanDuplication resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanDuplication resource: %v", err)
 	}
 
 	d.SetId("")
@@ -197,17 +194,16 @@ func resourceWantempSystemSdwanDuplicationDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceWantempSystemSdwanDuplicationRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanDuplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -215,25 +211,28 @@ func resourceWantempSystemSdwanDuplicationRead(d *schema.ResourceData, m interfa
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanDuplication(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanDuplication resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanDuplication resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanDuplication resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanDuplication(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanDuplication resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanDuplication resource from API: %v", err)
 	}
 	return nil
 }
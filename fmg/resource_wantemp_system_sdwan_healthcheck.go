@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanHealthCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanHealthCheckCreate,
-		Read:   resourceWantempSystemSdwanHealthCheckRead,
-		Update: resourceWantempSystemSdwanHealthCheckUpdate,
-		Delete: resourceWantempSystemSdwanHealthCheckDelete,
+		CreateContext: resourceWantempSystemSdwanHealthCheckCreate,
+		ReadContext:   resourceWantempSystemSdwanHealthCheckRead,
+		UpdateContext: resourceWantempSystemSdwanHealthCheckUpdate,
+		DeleteContext: resourceWantempSystemSdwanHealthCheckDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -299,15 +301,14 @@ func resourceWantempSystemSdwanHealthCheck() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanHealthCheckCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanHealthCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -316,30 +317,29 @@ func resourceWantempSystemSdwanHealthCheckCreate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectWantempSystemSdwanHealthCheck(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanHealthCheck resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanHealthCheck resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanHealthCheck(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanHealthCheck resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanHealthCheck resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWantempSystemSdwanHealthCheckRead(d, m)
+	return resourceWantempSystemSdwanHealthCheckRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanHealthCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -348,32 +348,29 @@ func resourceWantempSystemSdwanHealthCheckUpdate(d *schema.ResourceData, m inter
 
 	obj, err := getObjectWantempSystemSdwanHealthCheck(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanHealthCheck resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanHealthCheck resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanHealthCheck(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanHealthCheck resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanHealthCheck resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWantempSystemSdwanHealthCheckRead(d, m)
+	return resourceWantempSystemSdwanHealthCheckRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanHealthCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -382,7 +379,7 @@ func resourceWantempSystemSdwanHealthCheckDelete(d *schema.ResourceData, m inter
 
 	err = c.DeleteWantempSystemSdwanHealthCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanHealthCheck resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanHealthCheck resource: %v", err)
 	}
 
 	d.SetId("")
@@ -390,17 +387,16 @@ func resourceWantempSystemSdwanHealthCheckDelete(d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceWantempSystemSdwanHealthCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -408,25 +404,28 @@ func resourceWantempSystemSdwanHealthCheckRead(d *schema.ResourceData, m interfa
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanHealthCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanHealthCheck resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheck resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheck resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanHealthCheck(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanHealthCheck resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheck resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanHealthCheckSla() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanHealthCheckSlaCreate,
-		Read:   resourceWantempSystemSdwanHealthCheckSlaRead,
-		Update: resourceWantempSystemSdwanHealthCheckSlaUpdate,
-		Delete: resourceWantempSystemSdwanHealthCheckSlaDelete,
+		CreateContext: resourceWantempSystemSdwanHealthCheckSlaCreate,
+		ReadContext:   resourceWantempSystemSdwanHealthCheckSlaRead,
+		UpdateContext: resourceWantempSystemSdwanHealthCheckSlaUpdate,
+		DeleteContext: resourceWantempSystemSdwanHealthCheckSlaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -93,15 +95,14 @@ func resourceWantempSystemSdwanHealthCheckSla() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanHealthCheckSlaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanHealthCheckSlaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -112,30 +113,29 @@ func resourceWantempSystemSdwanHealthCheckSlaCreate(d *schema.ResourceData, m in
 
 	obj, err := getObjectWantempSystemSdwanHealthCheckSla(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanHealthCheckSla resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanHealthCheckSla resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanHealthCheckSla(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanHealthCheckSla resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanHealthCheckSlaRead(d, m)
+	return resourceWantempSystemSdwanHealthCheckSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanHealthCheckSlaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckSlaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -146,32 +146,29 @@ func resourceWantempSystemSdwanHealthCheckSlaUpdate(d *schema.ResourceData, m in
 
 	obj, err := getObjectWantempSystemSdwanHealthCheckSla(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanHealthCheckSla resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanHealthCheckSla resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanHealthCheckSla(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanHealthCheckSla resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanHealthCheckSlaRead(d, m)
+	return resourceWantempSystemSdwanHealthCheckSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanHealthCheckSlaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckSlaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -182,7 +179,7 @@ func resourceWantempSystemSdwanHealthCheckSlaDelete(d *schema.ResourceData, m in
 
 	err = c.DeleteWantempSystemSdwanHealthCheckSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanHealthCheckSla resource: %v", err)
 	}
 
 	d.SetId("")
@@ -190,17 +187,16 @@ func resourceWantempSystemSdwanHealthCheckSlaDelete(d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceWantempSystemSdwanHealthCheckSlaRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanHealthCheckSlaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -209,7 +205,7 @@ func resourceWantempSystemSdwanHealthCheckSlaRead(d *schema.ResourceData, m inte
 	if health_check == "" {
 		health_check = importOptionChecking(m.(*FortiClient).Cfg, "health_check")
 		if err = d.Set("health_check", health_check); err != nil {
-			return fmt.Errorf("Error set params health_check: %v", err)
+			return diag.Errorf("Error set params health_check: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
@@ -217,18 +213,21 @@ func resourceWantempSystemSdwanHealthCheckSlaRead(d *schema.ResourceData, m inte
 
 	o, err := c.ReadWantempSystemSdwanHealthCheckSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanHealthCheckSla resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheckSla resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheckSla resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanHealthCheckSla(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanHealthCheckSla resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanHealthCheckSla resource from API: %v", err)
 	}
 	return nil
 }
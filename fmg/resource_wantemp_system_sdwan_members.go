@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanMembers() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanMembersCreate,
-		Read:   resourceWantempSystemSdwanMembersRead,
-		Update: resourceWantempSystemSdwanMembersUpdate,
-		Delete: resourceWantempSystemSdwanMembersDelete,
+		CreateContext: resourceWantempSystemSdwanMembersCreate,
+		ReadContext:   resourceWantempSystemSdwanMembersRead,
+		UpdateContext: resourceWantempSystemSdwanMembersUpdate,
+		DeleteContext: resourceWantempSystemSdwanMembersDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -133,15 +135,14 @@ func resourceWantempSystemSdwanMembers() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanMembersCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -150,30 +151,29 @@ func resourceWantempSystemSdwanMembersCreate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectWantempSystemSdwanMembers(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanMembers resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanMembers resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanMembers(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanMembers resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanMembers resource: %v", err)
 	}
 
 	d.SetId(strconv.Itoa(getIntKey(d, "seq_num")))
 
-	return resourceWantempSystemSdwanMembersRead(d, m)
+	return resourceWantempSystemSdwanMembersRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanMembersUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -182,32 +182,29 @@ func resourceWantempSystemSdwanMembersUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectWantempSystemSdwanMembers(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanMembers resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanMembers resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanMembers(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanMembers resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanMembers resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(strconv.Itoa(getIntKey(d, "seq_num")))
 
-	return resourceWantempSystemSdwanMembersRead(d, m)
+	return resourceWantempSystemSdwanMembersRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanMembersDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -216,7 +213,7 @@ func resourceWantempSystemSdwanMembersDelete(d *schema.ResourceData, m interface
 
 	err = c.DeleteWantempSystemSdwanMembers(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanMembers resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanMembers resource: %v", err)
 	}
 
 	d.SetId("")
@@ -224,17 +221,16 @@ func resourceWantempSystemSdwanMembersDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceWantempSystemSdwanMembersRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -242,25 +238,28 @@ func resourceWantempSystemSdwanMembersRead(d *schema.ResourceData, m interface{}
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanMembers(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanMembers resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanMembers resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanMembers resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanMembers(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanMembers resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanMembers resource from API: %v", err)
 	}
 	return nil
 }
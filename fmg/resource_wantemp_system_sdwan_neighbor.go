@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanNeighbor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanNeighborCreate,
-		Read:   resourceWantempSystemSdwanNeighborRead,
-		Update: resourceWantempSystemSdwanNeighborUpdate,
-		Delete: resourceWantempSystemSdwanNeighborDelete,
+		CreateContext: resourceWantempSystemSdwanNeighborCreate,
+		ReadContext:   resourceWantempSystemSdwanNeighborRead,
+		UpdateContext: resourceWantempSystemSdwanNeighborUpdate,
+		DeleteContext: resourceWantempSystemSdwanNeighborDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -86,15 +88,14 @@ func resourceWantempSystemSdwanNeighbor() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanNeighborCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanNeighborCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -103,30 +104,29 @@ func resourceWantempSystemSdwanNeighborCreate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectWantempSystemSdwanNeighbor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanNeighbor resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanNeighbor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanNeighbor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanNeighbor resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanNeighbor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "ip"))
 
-	return resourceWantempSystemSdwanNeighborRead(d, m)
+	return resourceWantempSystemSdwanNeighborRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanNeighborUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanNeighborUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -135,32 +135,29 @@ func resourceWantempSystemSdwanNeighborUpdate(d *schema.ResourceData, m interfac
 
 	obj, err := getObjectWantempSystemSdwanNeighbor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanNeighbor resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanNeighbor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanNeighbor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanNeighbor resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanNeighbor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "ip"))
 
-	return resourceWantempSystemSdwanNeighborRead(d, m)
+	return resourceWantempSystemSdwanNeighborRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanNeighborDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanNeighborDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -169,7 +166,7 @@ func resourceWantempSystemSdwanNeighborDelete(d *schema.ResourceData, m interfac
 
 	err = c.DeleteWantempSystemSdwanNeighbor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanNeighbor resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanNeighbor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -177,17 +174,16 @@ func resourceWantempSystemSdwanNeighborDelete(d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceWantempSystemSdwanNeighborRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanNeighborRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -195,25 +191,28 @@ func resourceWantempSystemSdwanNeighborRead(d *schema.ResourceData, m interface{
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanNeighbor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanNeighbor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanNeighbor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanNeighbor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanNeighbor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanNeighbor resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanNeighbor resource from API: %v", err)
 	}
 	return nil
 }
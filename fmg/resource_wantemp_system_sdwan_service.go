@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanServiceCreate,
-		Read:   resourceWantempSystemSdwanServiceRead,
-		Update: resourceWantempSystemSdwanServiceUpdate,
-		Delete: resourceWantempSystemSdwanServiceDelete,
+		CreateContext: resourceWantempSystemSdwanServiceCreate,
+		ReadContext:   resourceWantempSystemSdwanServiceRead,
+		UpdateContext: resourceWantempSystemSdwanServiceUpdate,
+		DeleteContext: resourceWantempSystemSdwanServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -301,15 +303,14 @@ func resourceWantempSystemSdwanService() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanServiceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -318,30 +319,29 @@ func resourceWantempSystemSdwanServiceCreate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectWantempSystemSdwanService(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanService resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanService resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanService(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanService resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanService resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanServiceRead(d, m)
+	return resourceWantempSystemSdwanServiceRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -350,32 +350,29 @@ func resourceWantempSystemSdwanServiceUpdate(d *schema.ResourceData, m interface
 
 	obj, err := getObjectWantempSystemSdwanService(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanService resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanService resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanService(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanService resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanService resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanServiceRead(d, m)
+	return resourceWantempSystemSdwanServiceRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -384,7 +381,7 @@ func resourceWantempSystemSdwanServiceDelete(d *schema.ResourceData, m interface
 
 	err = c.DeleteWantempSystemSdwanService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanService resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanService resource: %v", err)
 	}
 
 	d.SetId("")
@@ -392,17 +389,16 @@ func resourceWantempSystemSdwanServiceDelete(d *schema.ResourceData, m interface
 	return nil
 }
 
-func resourceWantempSystemSdwanServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -410,25 +406,28 @@ func resourceWantempSystemSdwanServiceRead(d *schema.ResourceData, m interface{}
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanService resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanService resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanService resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanService(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanService resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanService resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanServiceSla() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanServiceSlaCreate,
-		Read:   resourceWantempSystemSdwanServiceSlaRead,
-		Update: resourceWantempSystemSdwanServiceSlaUpdate,
-		Delete: resourceWantempSystemSdwanServiceSlaDelete,
+		CreateContext: resourceWantempSystemSdwanServiceSlaCreate,
+		ReadContext:   resourceWantempSystemSdwanServiceSlaRead,
+		UpdateContext: resourceWantempSystemSdwanServiceSlaUpdate,
+		DeleteContext: resourceWantempSystemSdwanServiceSlaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,15 +69,14 @@ func resourceWantempSystemSdwanServiceSla() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanServiceSlaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanServiceSlaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -86,30 +87,29 @@ func resourceWantempSystemSdwanServiceSlaCreate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectWantempSystemSdwanServiceSla(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanServiceSla resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanServiceSla resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanServiceSla(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanServiceSla resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanServiceSla resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanServiceSlaRead(d, m)
+	return resourceWantempSystemSdwanServiceSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanServiceSlaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceSlaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -120,32 +120,29 @@ func resourceWantempSystemSdwanServiceSlaUpdate(d *schema.ResourceData, m interf
 
 	obj, err := getObjectWantempSystemSdwanServiceSla(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanServiceSla resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanServiceSla resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanServiceSla(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanServiceSla resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanServiceSla resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemSdwanServiceSlaRead(d, m)
+	return resourceWantempSystemSdwanServiceSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanServiceSlaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceSlaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -156,7 +153,7 @@ func resourceWantempSystemSdwanServiceSlaDelete(d *schema.ResourceData, m interf
 
 	err = c.DeleteWantempSystemSdwanServiceSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanServiceSla resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanServiceSla resource: %v", err)
 	}
 
 	d.SetId("")
@@ -164,17 +161,16 @@ func resourceWantempSystemSdwanServiceSlaDelete(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceWantempSystemSdwanServiceSlaRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanServiceSlaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -183,7 +179,7 @@ func resourceWantempSystemSdwanServiceSlaRead(d *schema.ResourceData, m interfac
 	if service == "" {
 		service = importOptionChecking(m.(*FortiClient).Cfg, "service")
 		if err = d.Set("service", service); err != nil {
-			return fmt.Errorf("Error set params service: %v", err)
+			return diag.Errorf("Error set params service: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
@@ -191,18 +187,21 @@ func resourceWantempSystemSdwanServiceSlaRead(d *schema.ResourceData, m interfac
 
 	o, err := c.ReadWantempSystemSdwanServiceSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanServiceSla resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanServiceSla resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanServiceSla resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanServiceSla(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanServiceSla resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanServiceSla resource from API: %v", err)
 	}
 	return nil
 }
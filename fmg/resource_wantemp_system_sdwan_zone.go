@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemSdwanZone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemSdwanZoneCreate,
-		Read:   resourceWantempSystemSdwanZoneRead,
-		Update: resourceWantempSystemSdwanZoneUpdate,
-		Delete: resourceWantempSystemSdwanZoneDelete,
+		CreateContext: resourceWantempSystemSdwanZoneCreate,
+		ReadContext:   resourceWantempSystemSdwanZoneRead,
+		UpdateContext: resourceWantempSystemSdwanZoneUpdate,
+		DeleteContext: resourceWantempSystemSdwanZoneDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,15 +65,14 @@ func resourceWantempSystemSdwanZone() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemSdwanZoneCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemSdwanZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -80,30 +81,29 @@ func resourceWantempSystemSdwanZoneCreate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectWantempSystemSdwanZone(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanZone resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanZone resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemSdwanZone(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemSdwanZone resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemSdwanZone resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWantempSystemSdwanZoneRead(d, m)
+	return resourceWantempSystemSdwanZoneRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanZoneUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -112,32 +112,29 @@ func resourceWantempSystemSdwanZoneUpdate(d *schema.ResourceData, m interface{})
 
 	obj, err := getObjectWantempSystemSdwanZone(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanZone resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanZone resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemSdwanZone(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemSdwanZone resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemSdwanZone resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, "name"))
 
-	return resourceWantempSystemSdwanZoneRead(d, m)
+	return resourceWantempSystemSdwanZoneRead(ctx, d, m)
 }
 
-func resourceWantempSystemSdwanZoneDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -146,7 +143,7 @@ func resourceWantempSystemSdwanZoneDelete(d *schema.ResourceData, m interface{})
 
 	err = c.DeleteWantempSystemSdwanZone(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemSdwanZone resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemSdwanZone resource: %v", err)
 	}
 
 	d.SetId("")
@@ -154,17 +151,16 @@ func resourceWantempSystemSdwanZoneDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceWantempSystemSdwanZoneRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemSdwanZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -172,25 +168,28 @@ func resourceWantempSystemSdwanZoneRead(d *schema.ResourceData, m interface{}) e
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemSdwanZone(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanZone resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemSdwanZone resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemSdwanZone resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemSdwanZone(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemSdwanZone resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemSdwanZone resource from API: %v", err)
 	}
 	return nil
 }
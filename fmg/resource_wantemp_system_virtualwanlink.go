@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLink() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkUpdate,
-		Read:   resourceWantempSystemVirtualWanLinkRead,
-		Update: resourceWantempSystemVirtualWanLinkUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkUpdate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -573,16 +575,15 @@ func resourceWantempSystemVirtualWanLink() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -591,32 +592,29 @@ func resourceWantempSystemVirtualWanLinkUpdate(d *schema.ResourceData, m interfa
 
 	obj, err := getObjectWantempSystemVirtualWanLink(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLink resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLink resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLink(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLink resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLink resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId("WantempSystemVirtualWanLink")
 
-	return resourceWantempSystemVirtualWanLinkRead(d, m)
+	return resourceWantempSystemVirtualWanLinkRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -625,7 +623,7 @@ func resourceWantempSystemVirtualWanLinkDelete(d *schema.ResourceData, m interfa
 
 	err = c.DeleteWantempSystemVirtualWanLink(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLink resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLink resource: %v", err)
 	}
 
 	d.SetId("")
@@ -633,17 +631,16 @@ func resourceWantempSystemVirtualWanLinkDelete(d *schema.ResourceData, m interfa
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -651,25 +648,28 @@ func resourceWantempSystemVirtualWanLinkRead(d *schema.ResourceData, m interface
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemVirtualWanLink(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLink resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLink resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLink resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLink(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLink resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLink resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkHealthCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkHealthCheckCreate,
-		Read:   resourceWantempSystemVirtualWanLinkHealthCheckRead,
-		Update: resourceWantempSystemVirtualWanLinkHealthCheckUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkHealthCheckDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkHealthCheckCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkHealthCheckRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkHealthCheckUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkHealthCheckDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -217,15 +219,14 @@ func resourceWantempSystemVirtualWanLinkHealthCheck() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkHealthCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -234,30 +235,29 @@ func resourceWantempSystemVirtualWanLinkHealthCheckCreate(d *schema.ResourceData
 
 	obj, err := getObjectWantempSystemVirtualWanLinkHealthCheck(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheck resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheck resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkHealthCheck(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkHealthCheckRead(d, m)
+	return resourceWantempSystemVirtualWanLinkHealthCheckRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -266,32 +266,29 @@ func resourceWantempSystemVirtualWanLinkHealthCheckUpdate(d *schema.ResourceData
 
 	obj, err := getObjectWantempSystemVirtualWanLinkHealthCheck(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheck resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheck resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkHealthCheck(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkHealthCheckRead(d, m)
+	return resourceWantempSystemVirtualWanLinkHealthCheckRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -300,7 +297,7 @@ func resourceWantempSystemVirtualWanLinkHealthCheckDelete(d *schema.ResourceData
 
 	err = c.DeleteWantempSystemVirtualWanLinkHealthCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
 	}
 
 	d.SetId("")
@@ -308,17 +305,16 @@ func resourceWantempSystemVirtualWanLinkHealthCheckDelete(d *schema.ResourceData
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -326,25 +322,28 @@ func resourceWantempSystemVirtualWanLinkHealthCheckRead(d *schema.ResourceData,
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemVirtualWanLinkHealthCheck(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheck resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheck resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkHealthCheck(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheck resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheck resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkHealthCheckSla() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkHealthCheckSlaCreate,
-		Read:   resourceWantempSystemVirtualWanLinkHealthCheckSlaRead,
-		Update: resourceWantempSystemVirtualWanLinkHealthCheckSlaUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkHealthCheckSlaCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkHealthCheckSlaRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkHealthCheckSlaUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -81,15 +83,14 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSla() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckSlaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkHealthCheckSlaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -100,30 +101,29 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaCreate(d *schema.ResourceD
 
 	obj, err := getObjectWantempSystemVirtualWanLinkHealthCheckSla(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheckSla resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheckSla resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkHealthCheckSla(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(d, m)
+	return resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckSlaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckSlaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -134,32 +134,29 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaUpdate(d *schema.ResourceD
 
 	obj, err := getObjectWantempSystemVirtualWanLinkHealthCheckSla(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheckSla resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheckSla resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkHealthCheckSla(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(d, m)
+	return resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -170,7 +167,7 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete(d *schema.ResourceD
 
 	err = c.DeleteWantempSystemVirtualWanLinkHealthCheckSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
 	}
 
 	d.SetId("")
@@ -178,17 +175,16 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaDelete(d *schema.ResourceD
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -197,7 +193,7 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(d *schema.ResourceDat
 	if health_check == "" {
 		health_check = importOptionChecking(m.(*FortiClient).Cfg, "health_check")
 		if err = d.Set("health_check", health_check); err != nil {
-			return fmt.Errorf("Error set params health_check: %v", err)
+			return diag.Errorf("Error set params health_check: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
@@ -205,18 +201,21 @@ func resourceWantempSystemVirtualWanLinkHealthCheckSlaRead(d *schema.ResourceDat
 
 	o, err := c.ReadWantempSystemVirtualWanLinkHealthCheckSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheckSla resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheckSla resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkHealthCheckSla(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheckSla resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkHealthCheckSla resource from API: %v", err)
 	}
 	return nil
 }
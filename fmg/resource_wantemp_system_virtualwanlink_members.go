@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkMembers() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkMembersCreate,
-		Read:   resourceWantempSystemVirtualWanLinkMembersRead,
-		Update: resourceWantempSystemVirtualWanLinkMembersUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkMembersDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkMembersCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkMembersRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkMembersUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkMembersDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -114,15 +116,14 @@ func resourceWantempSystemVirtualWanLinkMembers() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkMembersCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -131,30 +132,29 @@ func resourceWantempSystemVirtualWanLinkMembersCreate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkMembers(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkMembers resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkMembers resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkMembers(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkMembers resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkMembers resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkMembersRead(d, m)
+	return resourceWantempSystemVirtualWanLinkMembersRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkMembersUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -163,32 +163,29 @@ func resourceWantempSystemVirtualWanLinkMembersUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkMembers(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkMembers resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkMembers resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkMembers(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkMembers resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkMembers resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkMembersRead(d, m)
+	return resourceWantempSystemVirtualWanLinkMembersRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkMembersDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -197,7 +194,7 @@ func resourceWantempSystemVirtualWanLinkMembersDelete(d *schema.ResourceData, m
 
 	err = c.DeleteWantempSystemVirtualWanLinkMembers(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkMembers resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkMembers resource: %v", err)
 	}
 
 	d.SetId("")
@@ -205,17 +202,16 @@ func resourceWantempSystemVirtualWanLinkMembersDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkMembersRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -223,25 +219,28 @@ func resourceWantempSystemVirtualWanLinkMembersRead(d *schema.ResourceData, m in
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemVirtualWanLinkMembers(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkMembers resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkMembers resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkMembers resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkMembers(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkMembers resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkMembers resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkNeighbor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkNeighborCreate,
-		Read:   resourceWantempSystemVirtualWanLinkNeighborRead,
-		Update: resourceWantempSystemVirtualWanLinkNeighborUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkNeighborDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkNeighborCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkNeighborRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkNeighborUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkNeighborDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -74,15 +76,14 @@ func resourceWantempSystemVirtualWanLinkNeighbor() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkNeighborCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkNeighborCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -91,30 +92,29 @@ func resourceWantempSystemVirtualWanLinkNeighborCreate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkNeighbor(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkNeighbor resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkNeighbor resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkNeighbor(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkNeighbor resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkNeighbor resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkNeighborRead(d, m)
+	return resourceWantempSystemVirtualWanLinkNeighborRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkNeighborUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkNeighborUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -123,32 +123,29 @@ func resourceWantempSystemVirtualWanLinkNeighborUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkNeighbor(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkNeighbor resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkNeighbor resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkNeighbor(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkNeighbor resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkNeighbor resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkNeighborRead(d, m)
+	return resourceWantempSystemVirtualWanLinkNeighborRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkNeighborDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkNeighborDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -157,7 +154,7 @@ func resourceWantempSystemVirtualWanLinkNeighborDelete(d *schema.ResourceData, m
 
 	err = c.DeleteWantempSystemVirtualWanLinkNeighbor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkNeighbor resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkNeighbor resource: %v", err)
 	}
 
 	d.SetId("")
@@ -165,17 +162,16 @@ func resourceWantempSystemVirtualWanLinkNeighborDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkNeighborRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkNeighborRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -183,25 +179,28 @@ func resourceWantempSystemVirtualWanLinkNeighborRead(d *schema.ResourceData, m i
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemVirtualWanLinkNeighbor(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkNeighbor resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkNeighbor resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkNeighbor resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkNeighbor(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkNeighbor resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkNeighbor resource from API: %v", err)
 	}
 	return nil
 }
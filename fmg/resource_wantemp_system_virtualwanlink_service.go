@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkServiceCreate,
-		Read:   resourceWantempSystemVirtualWanLinkServiceRead,
-		Update: resourceWantempSystemVirtualWanLinkServiceUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkServiceDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkServiceCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkServiceRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkServiceUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -279,15 +281,14 @@ func resourceWantempSystemVirtualWanLinkService() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkServiceCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -296,30 +297,29 @@ func resourceWantempSystemVirtualWanLinkServiceCreate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkService(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkService resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkService resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkService(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkService resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkService resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkServiceRead(d, m)
+	return resourceWantempSystemVirtualWanLinkServiceRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkServiceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -328,32 +328,29 @@ func resourceWantempSystemVirtualWanLinkServiceUpdate(d *schema.ResourceData, m
 
 	obj, err := getObjectWantempSystemVirtualWanLinkService(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkService resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkService resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkService(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkService resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkService resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkServiceRead(d, m)
+	return resourceWantempSystemVirtualWanLinkServiceRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkServiceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -362,7 +359,7 @@ func resourceWantempSystemVirtualWanLinkServiceDelete(d *schema.ResourceData, m
 
 	err = c.DeleteWantempSystemVirtualWanLinkService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkService resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkService resource: %v", err)
 	}
 
 	d.SetId("")
@@ -370,17 +367,16 @@ func resourceWantempSystemVirtualWanLinkServiceDelete(d *schema.ResourceData, m
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkServiceRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -388,25 +384,28 @@ func resourceWantempSystemVirtualWanLinkServiceRead(d *schema.ResourceData, m in
 	if wanprof == "" {
 		wanprof = importOptionChecking(m.(*FortiClient).Cfg, "wanprof")
 		if err = d.Set("wanprof", wanprof); err != nil {
-			return fmt.Errorf("Error set params wanprof: %v", err)
+			return diag.Errorf("Error set params wanprof: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
 
 	o, err := c.ReadWantempSystemVirtualWanLinkService(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkService resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkService resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkService resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkService(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkService resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkService resource from API: %v", err)
 	}
 	return nil
 }
@@ -9,23 +9,25 @@
 package fortimanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceWantempSystemVirtualWanLinkServiceSla() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWantempSystemVirtualWanLinkServiceSlaCreate,
-		Read:   resourceWantempSystemVirtualWanLinkServiceSlaRead,
-		Update: resourceWantempSystemVirtualWanLinkServiceSlaUpdate,
-		Delete: resourceWantempSystemVirtualWanLinkServiceSlaDelete,
+		CreateContext: resourceWantempSystemVirtualWanLinkServiceSlaCreate,
+		ReadContext:   resourceWantempSystemVirtualWanLinkServiceSlaRead,
+		UpdateContext: resourceWantempSystemVirtualWanLinkServiceSlaUpdate,
+		DeleteContext: resourceWantempSystemVirtualWanLinkServiceSlaDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,15 +69,14 @@ func resourceWantempSystemVirtualWanLinkServiceSla() *schema.Resource {
 	}
 }
 
-func resourceWantempSystemVirtualWanLinkServiceSlaCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+func resourceWantempSystemVirtualWanLinkServiceSlaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -86,30 +87,29 @@ func resourceWantempSystemVirtualWanLinkServiceSlaCreate(d *schema.ResourceData,
 
 	obj, err := getObjectWantempSystemVirtualWanLinkServiceSla(d)
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkServiceSla resource while getting object: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkServiceSla resource while getting object: %v", err)
 	}
 
 	_, err = c.CreateWantempSystemVirtualWanLinkServiceSla(obj, paradict)
 
 	if err != nil {
-		return fmt.Errorf("Error creating WantempSystemVirtualWanLinkServiceSla resource: %v", err)
+		return diag.Errorf("Error creating WantempSystemVirtualWanLinkServiceSla resource: %v", err)
 	}
 
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkServiceSlaRead(d, m)
+	return resourceWantempSystemVirtualWanLinkServiceSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkServiceSlaUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceSlaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -120,32 +120,29 @@ func resourceWantempSystemVirtualWanLinkServiceSlaUpdate(d *schema.ResourceData,
 
 	obj, err := getObjectWantempSystemVirtualWanLinkServiceSla(d)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkServiceSla resource while getting object: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkServiceSla resource while getting object: %v", err)
 	}
 
 	_, err = c.UpdateWantempSystemVirtualWanLinkServiceSla(obj, mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error updating WantempSystemVirtualWanLinkServiceSla resource: %v", err)
+		return diag.Errorf("Error updating WantempSystemVirtualWanLinkServiceSla resource: %v", err)
 	}
 
-	log.Printf(strconv.Itoa(c.Retries))
-
 	d.SetId(getStringKey(d, ""))
 
-	return resourceWantempSystemVirtualWanLinkServiceSlaRead(d, m)
+	return resourceWantempSystemVirtualWanLinkServiceSlaRead(ctx, d, m)
 }
 
-func resourceWantempSystemVirtualWanLinkServiceSlaDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceSlaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -156,7 +153,7 @@ func resourceWantempSystemVirtualWanLinkServiceSlaDelete(d *schema.ResourceData,
 
 	err = c.DeleteWantempSystemVirtualWanLinkServiceSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error deleting WantempSystemVirtualWanLinkServiceSla resource: %v", err)
+		return diag.Errorf("Error deleting WantempSystemVirtualWanLinkServiceSla resource: %v", err)
 	}
 
 	d.SetId("")
@@ -164,17 +161,16 @@ func resourceWantempSystemVirtualWanLinkServiceSlaDelete(d *schema.ResourceData,
 	return nil
 }
 
-func resourceWantempSystemVirtualWanLinkServiceSlaRead(d *schema.ResourceData, m interface{}) error {
+func resourceWantempSystemVirtualWanLinkServiceSlaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	mkey := d.Id()
 
-	c := m.(*FortiClient).Client
-	c.Retries = 1
+	c := m.(*FortiClient).sdk(ctx)
 
 	paradict := make(map[string]string)
 	cfg := m.(*FortiClient).Cfg
 	adomv, err := adomChecking(cfg, d)
 	if err != nil {
-		return fmt.Errorf("Error adom configuration: %v", err)
+		return diag.Errorf("Error adom configuration: %v", err)
 	}
 	paradict["adom"] = adomv
 
@@ -183,7 +179,7 @@ func resourceWantempSystemVirtualWanLinkServiceSlaRead(d *schema.ResourceData, m
 	if service == "" {
 		service = importOptionChecking(m.(*FortiClient).Cfg, "service")
 		if err = d.Set("service", service); err != nil {
-			return fmt.Errorf("Error set params service: %v", err)
+			return diag.Errorf("Error set params service: %v", err)
 		}
 	}
 	paradict["wanprof"] = wanprof
@@ -191,18 +187,21 @@ func resourceWantempSystemVirtualWanLinkServiceSlaRead(d *schema.ResourceData, m
 
 	o, err := c.ReadWantempSystemVirtualWanLinkServiceSla(mkey, paradict)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkServiceSla resource: %v", err)
+		if IsNotFound(sdkError(c, err)) {
+			log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkServiceSla resource: %v", err)
 	}
 
 	if o == nil {
-		log.Printf("[WARN] resource (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkServiceSla resource: no data returned for %s", d.Id())
 	}
 
 	err = refreshObjectWantempSystemVirtualWanLinkServiceSla(d, o)
 	if err != nil {
-		return fmt.Errorf("Error reading WantempSystemVirtualWanLinkServiceSla resource from API: %v", err)
+		return diag.Errorf("Error reading WantempSystemVirtualWanLinkServiceSla resource from API: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,183 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Retry policy for FortiManager requests
+
+package fortimanager
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// retryPolicy decides which failed requests are sent again and how long to
+// wait between attempts
+type retryPolicy struct {
+	maxRetries int
+	backoffMin time.Duration
+	backoffMax time.Duration
+
+	// FortiManager status codes treated as transient in addition to the
+	// messages matched by transientMessages
+	errorCodes map[int]bool
+
+	// exec calls are not idempotent and are only retried when their url
+	// starts with one of these prefixes
+	safeExecURLs []string
+}
+
+// transientMessages are parts of FortiManager status messages that signal a
+// temporary condition. Lock conflicts are waited for separately, see
+// lock_wait_timeout.
+var transientMessages = []string{
+	"busy",
+	"try again",
+}
+
+func defaultRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		maxRetries: 5,
+		backoffMin: time.Second,
+		backoffMax: 30 * time.Second,
+		errorCodes: make(map[int]bool),
+	}
+}
+
+func newRetryPolicy(maxRetries, backoffMin, backoffMax int, errorCodes []int, safeExecURLs []string) (*retryPolicy, error) {
+	if maxRetries < 0 {
+		return nil, fmt.Errorf("max_retries must not be negative")
+	}
+
+	if backoffMin <= 0 || backoffMax < backoffMin {
+		return nil, fmt.Errorf("retry_backoff_min must be positive and not greater than retry_backoff_max")
+	}
+
+	p := &retryPolicy{
+		maxRetries:   maxRetries,
+		backoffMin:   time.Duration(backoffMin) * time.Second,
+		backoffMax:   time.Duration(backoffMax) * time.Second,
+		errorCodes:   make(map[int]bool),
+		safeExecURLs: safeExecURLs,
+	}
+
+	for _, code := range errorCodes {
+		p.errorCodes[code] = true
+	}
+
+	return p, nil
+}
+
+// allowed reports whether rpc may be sent more than once. An add that may
+// have been applied is not, see RoundTrip.
+func (p *retryPolicy) allowed(rpc map[string]interface{}) bool {
+	if fmt.Sprintf("%v", rpc["method"]) != "exec" {
+		return true
+	}
+
+	url := jsonrpcURL(rpc)
+	for _, prefix := range p.safeExecURLs {
+		if prefix != "" && strings.HasPrefix(url, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transientReason returns why the response signals a transient failure worth
+// retrying, or an empty string
+func (p *retryPolicy) transientReason(rsp *http.Response, body []byte) string {
+	if rsp.StatusCode == http.StatusServiceUnavailable || rsp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Sprintf("HTTP %s", rsp.Status)
+	}
+
+	code, message := jsonrpcStatus(body)
+	if code == 0 {
+		return ""
+	}
+
+	if p.errorCodes[code] {
+		return fmt.Sprintf("err %d: %s", code, message)
+	}
+
+	lower := strings.ToLower(message)
+	for _, m := range transientMessages {
+		if strings.Contains(lower, m) {
+			return fmt.Sprintf("err %d: %s", code, message)
+		}
+	}
+
+	return ""
+}
+
+// backoff returns the wait before the retry following attempt: an exponential
+// delay capped at backoffMax, with jitter over its upper half
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	d := p.backoffMin
+	for i := 0; i < attempt && d < p.backoffMax; i++ {
+		d *= 2
+	}
+
+	if d > p.backoffMax {
+		d = p.backoffMax
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryableError reports whether a transport error may be retried.
+// Certificate errors and cancelled requests are final.
+func retryableError(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	return !strings.Contains(err.Error(), "x509: ")
+}
+
+// requestNotSent reports whether a transport error happened before the
+// request reached FortiManager: the connection or the TLS handshake failed
+func requestNotSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var recordErr tls.RecordHeaderError
+	if errors.As(err, &recordErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		// A TLS alert of FortiManager fails the handshake as a remote error
+		return opErr.Op == "dial" || opErr.Op == "remote error"
+	}
+
+	return false
+}
+
+// jsonrpcURL returns the url of the first parameter of rpc
+func jsonrpcURL(rpc map[string]interface{}) string {
+	params, ok := rpc["params"].([]interface{})
+	if !ok || len(params) == 0 {
+		return ""
+	}
+
+	param, ok := params[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	return fortiStringValue(param["url"])
+}
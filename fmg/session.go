@@ -0,0 +1,586 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: FortiManager session lifecycle: login, logout and session cache
+
+package fortimanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// openTransports holds the transports of this provider process, their
+// workspace locks and sessions are released when the plugin shuts down
+var openTransports struct {
+	sync.Mutex
+	transports []*fmgTransport
+}
+
+func registerTransport(t *fmgTransport) {
+	openTransports.Lock()
+	openTransports.transports = append(openTransports.transports, t)
+	openTransports.Unlock()
+}
+
+// CloseSessions commits and unlocks the workspace locks taken by
+// workspace_mode auto, then logs out of the FortiManager sessions held by
+// this provider process. It is called once the plugin has stopped serving,
+// the session is kept for the whole run so that the resources of one apply
+// share it.
+func CloseSessions() {
+	openTransports.Lock()
+	defer openTransports.Unlock()
+
+	for _, t := range openTransports.transports {
+		if t.workspace != nil {
+			t.stopIdleTimer()
+			if err := t.workspace.release(); err != nil {
+				log.Printf("[ERROR] Cannot release the workspace locks of FortiManager %s: %v", t.auth.Hostname, err)
+			}
+		}
+
+		t.releaseSession()
+	}
+	openTransports.transports = nil
+}
+
+// trackOperations wraps the CRUD functions of a resource or data source so
+// that the workspace changes are committed once no operation is in progress,
+// see endOperation
+func trackOperations(r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = schema.CreateContextFunc(trackCRUD(crudFunc(r.CreateContext)))
+	}
+
+	if r.ReadContext != nil {
+		r.ReadContext = schema.ReadContextFunc(trackCRUD(crudFunc(r.ReadContext)))
+	}
+
+	if r.UpdateContext != nil {
+		r.UpdateContext = schema.UpdateContextFunc(trackCRUD(crudFunc(r.UpdateContext)))
+	}
+
+	if r.DeleteContext != nil {
+		r.DeleteContext = schema.DeleteContextFunc(trackCRUD(crudFunc(r.DeleteContext)))
+	}
+}
+
+// trackCRUD reports the workspace errors to the operation that triggers
+// them: a failed commit fails the operation that ended last, and a failed
+// release of the idle locks is a warning of the next operation
+func trackCRUD(f crudFunc) crudFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		c, ok := m.(*FortiClient)
+		if !ok || c.transport == nil {
+			return f(ctx, d, m)
+		}
+
+		if err := c.transport.beginOperation(); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Cannot release the workspace locks",
+				Detail:   err.Error(),
+			})
+		}
+
+		defer func() {
+			if err := c.transport.endOperation(); err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+		}()
+
+		return append(diags, f(ctx, d, m)...)
+	}
+}
+
+// beginOperation registers an operation of a resource or data source and
+// stops the release of the idle workspace locks. It returns the error of the
+// last release, if any, so that it is reported once.
+func (t *fmgTransport) beginOperation() error {
+	t.operationsMu.Lock()
+	defer t.operationsMu.Unlock()
+
+	t.operations++
+	t.stopIdleTimerLocked()
+
+	err := t.releaseErr
+	t.releaseErr = nil
+
+	return err
+}
+
+// endOperation ends an operation. Once the last operation in progress has
+// ended, the changes made under the workspace locks are committed and the
+// locks are kept for the next operations, they are released after the idle
+// timeout of the workspace or when the plugin shuts down, see CloseSessions.
+// The session is kept until the plugin shuts down.
+//
+// The workspace is locked before operationsMu is unlocked, so that the
+// operations starting during the commit wait for it before writing, and the
+// commit runs without operationsMu so that they are not blocked otherwise.
+func (t *fmgTransport) endOperation() error {
+	t.operationsMu.Lock()
+
+	t.operations--
+	if t.operations > 0 || t.workspace == nil {
+		t.operationsMu.Unlock()
+		return nil
+	}
+
+	w := t.workspace
+	w.mu.Lock()
+	t.startIdleTimerLocked()
+	t.operationsMu.Unlock()
+	defer w.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), workspaceReleaseTimeout)
+	defer cancel()
+
+	return w.commitChanges(ctx)
+}
+
+// startIdleTimerLocked releases the workspace locks once the workspace has
+// been idle for its idle timeout, operationsMu must be held. The generation
+// tells a timer that fired while an operation started apart from the
+// current one.
+func (t *fmgTransport) startIdleTimerLocked() {
+	t.idleGen++
+	gen := t.idleGen
+
+	t.idle = time.AfterFunc(t.workspace.idleTimeout, func() {
+		t.operationsMu.Lock()
+		if t.operations > 0 || t.idleGen != gen {
+			t.operationsMu.Unlock()
+			return
+		}
+
+		w := t.workspace
+		w.mu.Lock()
+		t.idle = nil
+		t.operationsMu.Unlock()
+
+		err := w.releaseLocks()
+		w.mu.Unlock()
+		if err == nil {
+			return
+		}
+		log.Printf("[ERROR] Cannot release the workspace locks of FortiManager %s: %v", t.auth.Hostname, err)
+
+		t.operationsMu.Lock()
+		t.releaseErr = err
+		t.operationsMu.Unlock()
+	})
+}
+
+// stopIdleTimerLocked stops the release of the idle workspace locks,
+// operationsMu must be held
+func (t *fmgTransport) stopIdleTimerLocked() {
+	t.idleGen++
+	if t.idle != nil {
+		t.idle.Stop()
+		t.idle = nil
+	}
+}
+
+func (t *fmgTransport) stopIdleTimer() {
+	t.operationsMu.Lock()
+	t.stopIdleTimerLocked()
+	t.operationsMu.Unlock()
+}
+
+// releaseSession logs out of the session opened by the provider. A cached
+// session is logged out by its last user only, a session given by presession
+// is kept.
+func (t *fmgTransport) releaseSession() {
+	t.sessionMu.Lock()
+	defer t.sessionMu.Unlock()
+
+	if t.session == "" {
+		return
+	}
+
+	var err error
+	switch {
+	case t.cache != nil:
+		err = t.releaseCachedSession(t.session)
+	case t.closeSession:
+		err = t.logoutSession(t.session)
+	default:
+		return
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Cannot log out of FortiManager %s: %v", t.auth.Hostname, err)
+	}
+
+	t.session = ""
+	t.closeSession = false
+}
+
+// relogin renews the session under the session lock, or opens one when the
+// previous session has been released. If another request has already renewed
+// the session that failed for us, the new one is reused.
+func (t *fmgTransport) relogin(stale string) error {
+	t.sessionMu.Lock()
+	defer t.sessionMu.Unlock()
+
+	if t.session != stale {
+		return nil
+	}
+
+	var session string
+	var err error
+	if t.cache != nil {
+		session, err = t.acquireCachedSession(stale)
+	} else {
+		session, err = t.login()
+	}
+	if err != nil {
+		return err
+	}
+
+	// The stale session is logged out in case FortiManager still counts it
+	// as an open admin session
+	if stale != "" {
+		if err := t.logoutSession(stale); err != nil {
+			log.Printf("[DEBUG] Cannot log out of stale FortiManager session: %v", err)
+		}
+	}
+
+	t.session = session
+	t.closeSession = t.cache == nil
+
+	return nil
+}
+
+// login runs the sys/login/user flow and returns the new session
+func (t *fmgTransport) login() (string, error) {
+	data := map[string]interface{}{
+		"method": "exec",
+		"params": []map[string]interface{}{
+			{
+				"url": "sys/login/user",
+				"data": []map[string]interface{}{
+					{
+						"user":   t.auth.User,
+						"passwd": t.auth.Passwd,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := t.call(data)
+	if err != nil {
+		return "", fmt.Errorf("login failed: %v", err)
+	}
+
+	code, message := jsonrpcStatus(body)
+	if code != 0 {
+		return "", fmt.Errorf("login failed for user %q, err %d: %s", t.auth.User, code, message)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("login failed for user %q, cannot decode response: %v", t.auth.User, err)
+	}
+
+	session := fortiStringValue(result["session"])
+	if session == "" {
+		return "", fmt.Errorf("login failed for user %q, unexpected response: %s", t.auth.User, bodySnippet(body))
+	}
+
+	return session, nil
+}
+
+// logoutSession closes session with sys/logout
+func (t *fmgTransport) logoutSession(session string) error {
+	data := map[string]interface{}{
+		"method": "exec",
+		"params": []map[string]interface{}{
+			{
+				"url": "/sys/logout",
+			},
+		},
+		"session": session,
+	}
+
+	body, err := t.call(data)
+	if err != nil {
+		return err
+	}
+
+	if code, message := jsonrpcStatus(body); code != 0 {
+		return fmt.Errorf("err %d: %s", code, message)
+	}
+
+	return nil
+}
+
+// checkSession reports whether session is still accepted by FortiManager
+func (t *fmgTransport) checkSession(session string) bool {
+	data := map[string]interface{}{
+		"method": "get",
+		"params": []map[string]interface{}{
+			{
+				"url": "/sys/status",
+			},
+		},
+		"session": session,
+	}
+
+	body, err := t.call(data)
+	if err != nil {
+		return false
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false
+	}
+
+	code, _ := jsonrpcStatus(body)
+	return code == 0 && result["result"] != nil
+}
+
+// openSession sets up the session of the provider: a session given by
+// presession is checked and reused, otherwise the provider logs in and the
+// session is logged out once released, see releaseSession
+func (t *fmgTransport) openSession() error {
+	if t.auth.Session != "" {
+		if t.checkSession(t.auth.Session) {
+			t.setSession(t.auth.Session)
+			return nil
+		}
+
+		if t.auth.User == "" {
+			return fmt.Errorf("presession is not valid and no username is set")
+		}
+		log.Printf("[INFO] presession is no longer valid, logging in to FortiManager %s", t.auth.Hostname)
+	}
+
+	return t.relogin("")
+}
+
+func (t *fmgTransport) cacheKey() string {
+	return t.auth.Hostname + "|" + t.auth.User
+}
+
+// openCachedSession sets up the session of the provider from cache, see
+// acquireCachedSession
+func (t *fmgTransport) openCachedSession(cache *sessionCache) error {
+	t.cache = cache
+
+	return t.relogin("")
+}
+
+// acquireCachedSession returns the session cached for the host and user of t
+// when it is still valid, otherwise it logs in and caches the new session.
+// stale is the expired session t used so far, if any, it is removed from the
+// cache. The cache stays locked for the whole operation so that concurrent
+// provider instances end up sharing one session.
+func (t *fmgTransport) acquireCachedSession(stale string) (string, error) {
+	unlock, err := t.cache.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	key := t.cacheKey()
+	sessions, err := t.cache.load()
+	if err != nil {
+		return "", err
+	}
+
+	cached, ok := sessions[key]
+	if ok && cached.Session != "" && cached.Session != stale {
+		if t.checkSession(cached.Session) {
+			log.Printf("[INFO] Reusing cached FortiManager session for %s", t.auth.Hostname)
+			cached.Users++
+			sessions[key] = cached
+			if err := t.cache.save(sessions); err != nil {
+				return "", err
+			}
+
+			return cached.Session, nil
+		}
+		log.Printf("[INFO] Cached FortiManager session for %s is no longer valid", t.auth.Hostname)
+	}
+
+	session, err := t.login()
+	if err != nil {
+		return "", err
+	}
+
+	sessions[key] = cachedSession{
+		Session: session,
+		Updated: time.Now().Unix(),
+		Users:   1,
+	}
+
+	if err := t.cache.save(sessions); err != nil {
+		// The session is not shared, it is logged out like an uncached one
+		log.Printf("[WARN] Cannot update FortiManager session cache: %v", err)
+	}
+
+	return session, nil
+}
+
+// releaseCachedSession drops the use of session by t. The last user of the
+// cached session logs it out and removes it from the cache. A session that is
+// not the cached one is only used by t and is logged out.
+func (t *fmgTransport) releaseCachedSession(session string) error {
+	unlock, err := t.cache.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	key := t.cacheKey()
+	sessions, err := t.cache.load()
+	if err != nil {
+		return err
+	}
+
+	if cached, ok := sessions[key]; ok && cached.Session == session {
+		if cached.Users--; cached.Users > 0 {
+			sessions[key] = cached
+			return t.cache.save(sessions)
+		}
+
+		delete(sessions, key)
+		if err := t.cache.save(sessions); err != nil {
+			return err
+		}
+	}
+
+	return t.logoutSession(session)
+}
+
+// sessionCache is a JSON file holding FortiManager sessions keyed by host and
+// user, shared by all provider instances that point to the same file. A
+// cached session belongs to the cache: it counts the provider instances using
+// it, and the last one logs it out. The count of a provider process that was
+// killed is never dropped, its session stays cached until FortiManager
+// expires it and the next user replaces it.
+type sessionCache struct {
+	path string
+}
+
+type cachedSession struct {
+	Session string `json:"session"`
+	Updated int64  `json:"updated"`
+	Users   int    `json:"users"`
+}
+
+const (
+	sessionCacheLockWait  = 30 * time.Second
+	sessionCacheLockStale = 2 * time.Minute
+)
+
+func newSessionCache(path string) *sessionCache {
+	return &sessionCache{path: path}
+}
+
+// defaultSessionCacheFile returns the session cache used when the cache is
+// enabled without an explicit file
+func defaultSessionCacheFile() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "terraform-provider-fortimanager", "sessions.json")
+}
+
+// lock takes an exclusive lock on the cache by creating a lock file next to
+// it. A lock file left behind by a crashed process is removed once stale.
+func (c *sessionCache) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return nil, fmt.Errorf("cannot create session cache directory: %v", err)
+	}
+
+	lockPath := c.path + ".lock"
+	deadline := time.Now().Add(sessionCacheLockWait)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() {
+				if err := os.Remove(lockPath); err != nil {
+					log.Printf("[WARN] Cannot remove session cache lock %s: %v", lockPath, err)
+				}
+			}, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("cannot lock session cache: %v", err)
+		}
+
+		if fi, err := os.Stat(lockPath); err == nil && time.Since(fi.ModTime()) > sessionCacheLockStale {
+			log.Printf("[WARN] Removing stale session cache lock %s", lockPath)
+			if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("cannot remove stale session cache lock: %v", err)
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for session cache lock %s", lockPath)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (c *sessionCache) load() (map[string]cachedSession, error) {
+	sessions := make(map[string]cachedSession)
+
+	data, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read session cache: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		log.Printf("[WARN] Ignoring corrupted session cache %s: %v", c.path, err)
+		return make(map[string]cachedSession), nil
+	}
+
+	return sessions, nil
+}
+
+func (c *sessionCache) save(sessions map[string]cachedSession) error {
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("cannot encode session cache: %v", err)
+	}
+
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("cannot write session cache: %v", err)
+	}
+
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("cannot write session cache: %v", err)
+	}
+
+	return nil
+}
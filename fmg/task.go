@@ -0,0 +1,482 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Tracking of asynchronous FortiManager tasks
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Interval between two polls of a running task, it grows from the minimum to
+// the maximum while the task runs
+const (
+	taskPollMin = 2 * time.Second
+	taskPollMax = 10 * time.Second
+)
+
+// taskStates maps the numeric task and line states returned without verbose
+var taskStates = map[int]string{
+	0:  "pending",
+	1:  "running",
+	2:  "cancelling",
+	3:  "cancelled",
+	4:  "done",
+	5:  "error",
+	6:  "aborting",
+	7:  "aborted",
+	8:  "warning",
+	9:  "to_continue",
+	10: "unknown",
+}
+
+// Task is a FortiManager task (/task/task/{id}) started by an exec call such
+// as a package install, a script execution or adding a device
+type Task struct {
+	ID       int
+	Title    string
+	State    string
+	Percent  int
+	NumLines int
+	NumDone  int
+	NumErr   int
+	NumWarn  int
+
+	// Lines holds one entry per target device, see GetTaskLines
+	Lines []TaskLine
+}
+
+// TaskLine is the progress of a task on one device (/task/task/{id}/line)
+type TaskLine struct {
+	OID     int
+	Name    string
+	Vdom    string
+	IP      string
+	State   string
+	Percent int
+	Err     int
+	Detail  string
+}
+
+// finished reports whether the task is no longer running
+func (t *Task) finished() bool {
+	switch t.State {
+	case "done", "error", "cancelled", "aborted", "warning":
+		return true
+	}
+
+	return t.Percent >= 100
+}
+
+// failedLines returns the lines of the devices the task failed on
+func (t *Task) failedLines() []TaskLine {
+	var failed []TaskLine
+	for _, l := range t.Lines {
+		if l.failed() {
+			failed = append(failed, l)
+		}
+	}
+
+	return failed
+}
+
+// failed reports whether the task failed on at least one device
+func (t *Task) failed() bool {
+	switch t.State {
+	case "error", "cancelled", "aborted":
+		return true
+	}
+
+	return t.NumErr > 0 || len(t.failedLines()) > 0
+}
+
+func (l *TaskLine) failed() bool {
+	switch l.State {
+	case "error", "cancelled", "aborted":
+		return true
+	}
+
+	return l.Err != 0
+}
+
+// target returns the device, and vdom if any, of the line
+func (l *TaskLine) target() string {
+	if l.Vdom != "" {
+		return l.Name + "/" + l.Vdom
+	}
+
+	return l.Name
+}
+
+// GetTask returns the state of task id, without its lines
+func (c *FortiClient) GetTask(ctx context.Context, id int) (*Task, error) {
+	data, err := c.jsonrpc(ctx, "get", "/task/task/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	o, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected data for task %d: %v", id, data)
+	}
+
+	return &Task{
+		ID:       id,
+		Title:    fortiStringValue(o["title"]),
+		State:    taskState(o["state"]),
+		Percent:  fortiIntValue(o["percent"]),
+		NumLines: fortiIntValue(o["num_lines"]),
+		NumDone:  fortiIntValue(o["num_done"]),
+		NumErr:   fortiIntValue(o["num_err"]),
+		NumWarn:  fortiIntValue(o["num_warn"]),
+	}, nil
+}
+
+// GetTaskLines returns the per-device progress of task id
+func (c *FortiClient) GetTaskLines(ctx context.Context, id int) ([]TaskLine, error) {
+	data, err := c.jsonrpc(ctx, "get", "/task/task/"+strconv.Itoa(id)+"/line", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l, _ := data.([]interface{})
+	lines := make([]TaskLine, 0, len(l))
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		lines = append(lines, TaskLine{
+			OID:     fortiIntValue(o["oid"]),
+			Name:    fortiStringValue(o["name"]),
+			Vdom:    fortiStringValue(o["vdom"]),
+			IP:      fortiStringValue(o["ip"]),
+			State:   taskState(o["state"]),
+			Percent: fortiIntValue(o["percent"]),
+			Err:     fortiIntValue(o["err"]),
+			Detail:  fortiStringValue(o["detail"]),
+		})
+	}
+
+	return lines, nil
+}
+
+// GetTaskLineHistory returns the log of task id on the device of line oid,
+// e.g. the steps and CLI errors of an install
+func (c *FortiClient) GetTaskLineHistory(ctx context.Context, id, oid int) ([]string, error) {
+	url := fmt.Sprintf("/task/task/%d/line/%d/history", id, oid)
+	data, err := c.jsonrpc(ctx, "get", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l, _ := data.([]interface{})
+	history := make([]string, 0, len(l))
+	for _, v := range l {
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if detail := fortiStringValue(o["detail"]); detail != "" {
+			history = append(history, detail)
+		}
+	}
+
+	return history, nil
+}
+
+// cancelTask cancels the running task id, e.g. a script execution. The
+// context of the interrupted operation is already cancelled, the request is
+// bounded on its own.
+func (c *FortiClient) cancelTask(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err := c.jsonrpc(ctx, "exec", fmt.Sprintf("/task/task/%d/cancel", id), nil)
+	return err
+}
+
+// WaitTask polls task id until it finishes and returns it with its lines.
+// If the task is still running after timeout, or ctx is done, the last known
+// state of the task is returned with an error. A failed poll is retried until
+// timeout, e.g. while the connection or the session is renewed, unless the
+// task does not exist or cannot be read by the administrator.
+func (c *FortiClient) WaitTask(ctx context.Context, id int, timeout time.Duration) (*Task, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var task *Task
+	var lastErr error
+	interval := taskPollMin
+	for {
+		t, err := c.GetTask(waitCtx, id)
+		if err != nil && waitCtx.Err() == nil {
+			if IsNotFound(err) || IsPermissionDenied(err) {
+				return task, fmt.Errorf("cannot get task %d: %v", id, err)
+			}
+
+			log.Printf("[WARN] Cannot get task %d, polling again: %v", id, err)
+			lastErr = err
+		}
+
+		if err == nil {
+			lastErr = nil
+			task = t
+			if task.finished() {
+				break
+			}
+			log.Printf("[INFO] Waiting for task %d (%s): %s, %d%%", id, task.Title, task.State, task.Percent)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-waitCtx.Done():
+		}
+
+		if waitCtx.Err() != nil {
+			if task != nil {
+				// Best effort, the lines tell which devices are done
+				task.Lines, _ = c.GetTaskLines(context.Background(), id)
+			}
+
+			if ctx.Err() == context.Canceled {
+				return task, fmt.Errorf("interrupted while waiting for task %d: %v", id, ctx.Err())
+			}
+			if lastErr != nil {
+				return task, fmt.Errorf("timeout after %v waiting for task %d%s, last error: %v", timeout, id, taskProgress(task), lastErr)
+			}
+			return task, fmt.Errorf("timeout after %v waiting for task %d%s", timeout, id, taskProgress(task))
+		}
+
+		if interval *= 2; interval > taskPollMax {
+			interval = taskPollMax
+		}
+	}
+
+	lines, err := c.GetTaskLines(ctx, id)
+	if err != nil {
+		return task, fmt.Errorf("cannot get lines of task %d: %v", id, err)
+	}
+	task.Lines = lines
+
+	return task, nil
+}
+
+func taskState(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	if n, ok := v.(float64); ok {
+		if s, ok := taskStates[int(n)]; ok {
+			return s
+		}
+	}
+
+	return "unknown"
+}
+
+func taskProgress(task *Task) string {
+	if task == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s, %d%% done)", task.State, task.Percent)
+}
+
+// taskError describes the devices a failed task reported errors for
+func taskError(task *Task) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "task %d (%s) finished with state %s", task.ID, task.Title, task.State)
+
+	for _, l := range task.failedLines() {
+		fmt.Fprintf(&b, "\n  %s: %s", l.target(), l.State)
+		if l.Err != 0 {
+			fmt.Fprintf(&b, " (err %d)", l.Err)
+		}
+		if l.Detail != "" {
+			fmt.Fprintf(&b, ": %s", l.Detail)
+		}
+	}
+
+	return fmt.Errorf("%s", b.String())
+}
+
+// taskIDFromOutput returns the id of the task started by an exec call, or 0
+// if FortiManager returned none
+func taskIDFromOutput(o map[string]interface{}) int {
+	for _, k := range []string{"task", "taskid", "task_id"} {
+		switch v := o[k].(type) {
+		case float64:
+			return int(v)
+		case string:
+			if id, err := strconv.Atoi(v); err == nil {
+				return id
+			}
+		}
+	}
+
+	return 0
+}
+
+// execTimeout returns the timeout of the running operation of d, exec
+// resources run the same function on create and update
+func execTimeout(d *schema.ResourceData) time.Duration {
+	if d.IsNewResource() {
+		return d.Timeout(schema.TimeoutCreate)
+	}
+
+	return d.Timeout(schema.TimeoutUpdate)
+}
+
+// waitExecTask waits for the task started by an exec call whose output is o
+// and sets the task attributes of d. It fails if the task does not finish in
+// time or fails on any device, or if FortiManager returned no task. If ctx is
+// cancelled while waiting, the task is stopped with abort, e.g. cancelTask.
+// The task is returned once it has finished, even if it failed.
+func waitExecTask(ctx context.Context, c *FortiClient, d *schema.ResourceData, o map[string]interface{}, abort func(id int) error) (*Task, error) {
+	id := taskIDFromOutput(o)
+	d.Set("task_id", id)
+	if id == 0 {
+		return nil, fmt.Errorf("FortiManager returned no task, the result of the request is unknown")
+	}
+
+	task, err := c.WaitTask(ctx, id, execTimeout(d))
+	if ctx.Err() == context.Canceled {
+		return nil, interruptTask(c, d, id, abort)
+	}
+
+	if task != nil {
+		setTaskAttributes(d, task)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if task.failed() {
+		return task, taskError(task)
+	}
+
+	return task, nil
+}
+
+// interruptTask stops task id after Terraform has been interrupted and
+// reports the devices that had already received their configuration
+func interruptTask(c *FortiClient, d *schema.ResourceData, id int, abort func(id int) error) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "interrupted while waiting for task %d", id)
+
+	if err := abort(id); err != nil {
+		fmt.Fprintf(&b, ", cannot abort the task, it keeps running on FortiManager: %v", err)
+	} else {
+		b.WriteString(", the task has been aborted on FortiManager")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	task, err := c.GetTask(ctx, id)
+	if err != nil {
+		fmt.Fprintf(&b, "\ncannot get the state of the task: %v", err)
+		return fmt.Errorf("%s", b.String())
+	}
+
+	task.Lines, err = c.GetTaskLines(ctx, id)
+	if err != nil {
+		fmt.Fprintf(&b, "\ncannot get the state of the devices: %v", err)
+	}
+	setTaskAttributes(d, task)
+
+	var done, pending []string
+	for _, l := range task.Lines {
+		if !l.failed() && (l.State == "done" || l.State == "warning" || l.Percent >= 100) {
+			done = append(done, l.target())
+		} else {
+			pending = append(pending, fmt.Sprintf("%s (%s, %d%%)", l.target(), l.State, l.Percent))
+		}
+	}
+
+	if len(done) > 0 {
+		fmt.Fprintf(&b, "\ndevices that received the configuration: %s", strings.Join(done, ", "))
+	}
+	if len(pending) > 0 {
+		fmt.Fprintf(&b, "\ndevices not completed: %s", strings.Join(pending, ", "))
+	}
+
+	return fmt.Errorf("%s", b.String())
+}
+
+func setTaskAttributes(d *schema.ResourceData, task *Task) {
+	results := make([]map[string]interface{}, 0, len(task.Lines))
+	for _, l := range task.Lines {
+		results = append(results, map[string]interface{}{
+			"name":    l.Name,
+			"vdom":    l.Vdom,
+			"ip":      l.IP,
+			"state":   l.State,
+			"percent": l.Percent,
+			"err":     l.Err,
+			"detail":  l.Detail,
+		})
+	}
+
+	d.Set("task_id", task.ID)
+	d.Set("task_state", task.State)
+	d.Set("task_percent", task.Percent)
+	d.Set("task_results", results)
+}
+
+// taskResultsSchema returns the computed per-device results of the task of
+// an exec resource
+func taskResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"vdom": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"ip": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"state": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"percent": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"err": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"detail": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
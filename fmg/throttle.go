@@ -0,0 +1,128 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Concurrency and rate limits for FortiManager requests
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// throttle limits the requests in flight and the request rate of all
+// resources sharing one provider configuration
+type throttle struct {
+	// sem holds one slot per request in flight, nil means no limit
+	sem chan struct{}
+
+	// token bucket, rate is in requests per second and 0 means no limit
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newThrottle(maxConcurrent int, requestsPerSecond float64) (*throttle, error) {
+	if maxConcurrent < 0 {
+		return nil, fmt.Errorf("max_concurrent_requests must not be negative")
+	}
+
+	if requestsPerSecond < 0 {
+		return nil, fmt.Errorf("requests_per_second must not be negative")
+	}
+
+	t := &throttle{
+		rate: requestsPerSecond,
+	}
+
+	if maxConcurrent > 0 {
+		t.sem = make(chan struct{}, maxConcurrent)
+	}
+
+	if requestsPerSecond > 0 {
+		// Allow bursts of up to one second worth of requests
+		t.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		t.tokens = t.burst
+		t.last = time.Now()
+	}
+
+	return t, nil
+}
+
+// acquire waits until a request may be sent and returns the function that
+// releases its slot, or an error if ctx is done first
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	if t == nil {
+		return func() {}, nil
+	}
+
+	if err := t.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if t.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case t.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-t.sem })
+	}, nil
+}
+
+// wait takes a token from the bucket, waiting for it to refill if needed
+func (t *throttle) wait(ctx context.Context) error {
+	if t.rate <= 0 {
+		return nil
+	}
+
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		t.tokens = math.Min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+		t.last = now
+
+		if t.tokens >= 1 {
+			t.tokens--
+			t.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - t.tokens) / t.rate * float64(time.Second))
+		t.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// releaseBody releases the slot of a request once its response body is
+// closed, so a slot is held until the response has been read
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
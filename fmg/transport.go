@@ -0,0 +1,585 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: JSON-RPC transport shared by all FortiManager SDK requests
+
+package fortimanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/romanromanovv/forti-sdk-go/fortimanager2/auth"
+)
+
+// requestTimeout bounds each request to FortiManager, unless the call sets
+// its own bound with withRequestTimeout. The context deadline, e.g. the
+// timeout of the resource operation, bounds the call and its retries.
+const requestTimeout = 250 * time.Second
+
+// Status codes of the JSON-RPC error responses made up by the provider for
+// failures detected on the client side, they never collide with FortiManager
+// status codes
+const (
+	errCodeConnection = -90001
+	errCodeMalformed  = -90002
+	errCodeWorkspace  = -90003
+)
+
+// fmgTransport wraps the http.RoundTripper handed to the FortiManager SDK.
+// Every SDK call (createUpdate, read, readMove, delete, JsonGenericAPI) ends
+// up as a POST to /jsonrpc through this transport, so the provider can manage
+// the session on behalf of the SDK: the session in each request body is
+// replaced with the current one, and an expired session is renewed once and
+// the request replayed. When an API token is configured, it is sent as a
+// bearer token instead and no session is used.
+type fmgTransport struct {
+	base     http.RoundTripper
+	auth     *auth.Auth
+	token    string
+	endpoint *url.URL
+
+	sessionMu sync.Mutex
+	session   string
+	cache     *sessionCache
+
+	retry    *retryPolicy
+	throttle *throttle
+
+	// lockWaitTimeout is how long a request rejected because of a workspace
+	// lock held by another administrator is sent again, see
+	// sendRPCWaitingForLock
+	lockWaitTimeout time.Duration
+
+	// workspace takes the workspace locks of workspace_mode auto, nil
+	// otherwise
+	workspace *workspace
+
+	// closeSession is set when the session was opened by this provider
+	// instance and is logged out once released, see releaseSession
+	closeSession bool
+
+	// operations counts the resource operations in progress, see
+	// endOperation. idle releases the workspace locks once no operation has
+	// started for a while, idleGen is bumped whenever an operation starts or
+	// ends, and releaseErr is the error of the last release.
+	operationsMu sync.Mutex
+	operations   int
+	idle         *time.Timer
+	idleGen      int
+	releaseErr   error
+}
+
+func newFmgTransport(base http.RoundTripper, auth *auth.Auth, token string, endpoint *url.URL) *fmgTransport {
+	return &fmgTransport{
+		base:     base,
+		auth:     auth,
+		token:    token,
+		endpoint: endpoint,
+		retry:    defaultRetryPolicy(),
+	}
+}
+
+func (t *fmgTransport) setSession(session string) {
+	t.sessionMu.Lock()
+	t.session = session
+	t.sessionMu.Unlock()
+}
+
+func (t *fmgTransport) currentSession() string {
+	t.sessionMu.Lock()
+	defer t.sessionMu.Unlock()
+
+	return t.session
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *fmgTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc := decodeJSONRPC(reqBody)
+	if rpc == nil {
+		// Not a JSON-RPC call carrying a session (e.g. the login request)
+		return t.send(req, reqBody)
+	}
+	recordAPIError(req.Context(), nil)
+
+	if t.workspace != nil && !callOptionsFromContext(req.Context()).noWorkspace {
+		if err := t.workspace.prepare(req.Context(), rpc); err != nil {
+			return errorResponse(req, rpc, errCodeWorkspace, err.Error()), nil
+		}
+	}
+
+	retry := t.retry.allowed(rpc)
+	maxRetries := t.retry.maxRetries
+	for attempt := 0; ; attempt++ {
+		rsp, rspBody, err := t.sendRPCWaitingForLock(req, rpc)
+
+		reason := ""
+		if err != nil {
+			// FortiManager may have applied an add whose response was lost,
+			// sending it again would fail because the object exists
+			if !retryableError(req, err) || (fmt.Sprintf("%v", rpc["method"]) == "add" && !requestNotSent(err)) {
+				return errorResponse(req, rpc, errCodeConnection, fmt.Sprintf("cannot send request to FortiManager: %v", err)), nil
+			}
+			reason = err.Error()
+		} else {
+			reason = t.retry.transientReason(rsp, rspBody)
+			if reason == "" {
+				return finalResponse(req, rpc, rsp, rspBody), nil
+			}
+		}
+
+		if !retry || attempt >= maxRetries {
+			if err != nil {
+				return errorResponse(req, rpc, errCodeConnection, fmt.Sprintf("lost connection to FortiManager after %d attempts: %v", attempt+1, err)), nil
+			}
+			return finalResponse(req, rpc, rsp, rspBody), nil
+		}
+
+		wait := t.retry.backoff(attempt)
+		log.Printf("[INFO] Retrying FortiManager request in %v (attempt %d/%d): %s", wait, attempt+1, maxRetries, reason)
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return errorResponse(req, rpc, errCodeConnection, fmt.Sprintf("request cancelled: %v", req.Context().Err())), nil
+		}
+	}
+}
+
+// sendRPC sends rpc with the current session, logging in first if it has been
+// released. If FortiManager reports that the session has expired, it logs in
+// again once and replays the request.
+func (t *fmgTransport) sendRPC(req *http.Request, rpc map[string]interface{}) (*http.Response, []byte, error) {
+	session := t.currentSession()
+	if session == "" && t.token == "" && t.auth.User != "" {
+		// The session has been released, e.g. replaced in the session cache.
+		// A failed login is reported as is, retrying it could lock the account.
+		if err := t.relogin(""); err != nil {
+			rsp := errorResponse(req, rpc, errCodeConnection, fmt.Sprintf("cannot log in to FortiManager: %v", err))
+			rspBody, err := readResponseBody(rsp)
+			return rsp, rspBody, err
+		}
+		session = t.currentSession()
+	}
+
+	rsp, rspBody, err := t.sendWithSession(req, rpc, session)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if t.token != "" || t.auth.User == "" {
+		return rsp, rspBody, nil
+	}
+
+	code, message := jsonrpcStatus(rspBody)
+	expired := isSessionExpired(code, message)
+	if !expired && code == FortiAPIErrorPermission {
+		// FortiManager answers "No permission" both to a real permission
+		// denial and to a request made with an expired session, only the
+		// latter fails the session check
+		expired = !t.checkSession(session)
+	}
+	if !expired {
+		return rsp, rspBody, nil
+	}
+
+	log.Printf("[INFO] FortiManager session expired (err %d: %s), logging in again", code, message)
+	if err = t.relogin(session); err != nil {
+		log.Printf("[WARN] Cannot renew FortiManager session: %v", err)
+		return rsp, rspBody, nil
+	}
+
+	return t.sendWithSession(req, rpc, t.currentSession())
+}
+
+func (t *fmgTransport) sendWithSession(req *http.Request, rpc map[string]interface{}, session string) (*http.Response, []byte, error) {
+	rpc["session"] = session
+
+	reqBody, err := json.Marshal(rpc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot encode request: %v", err)
+	}
+
+	rsp, err := t.send(req, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rspBody, err := readResponseBody(rsp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rsp, rspBody, nil
+}
+
+// contextTransport binds the requests of an SDK client to ctx, the SDK
+// itself creates its requests without a context. errors receives the
+// FortiAPIError of its last request, see sdkError.
+type contextTransport struct {
+	ctx    context.Context
+	base   http.RoundTripper
+	errors *apiErrorRecorder
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// send issues a copy of req carrying body, so the same request can be replayed.
+// The SDK always targets https://host/path, the copy is sent to the
+// configured endpoint, keeping its scheme and path prefix. Each copy is
+// bounded by the request timeout of the call, see requestTimeout.
+func (t *fmgTransport) send(req *http.Request, body []byte) (*http.Response, error) {
+	release, err := t.throttle.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := callOptionsFromContext(req.Context()).timeout
+	if timeout <= 0 {
+		timeout = requestTimeout
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+
+	r := req.Clone(ctx)
+	r.URL.Scheme = t.endpoint.Scheme
+	r.URL.Host = t.endpoint.Host
+	r.URL.Path = t.endpoint.Path + req.URL.Path
+	r.URL.RawPath = ""
+	r.Host = ""
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	if t.token != "" {
+		r.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	rsp, err := t.base.RoundTrip(r)
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+
+	rsp.Body = &releaseBody{ReadCloser: rsp.Body, release: func() {
+		cancel()
+		release()
+	}}
+	return rsp, nil
+}
+
+// call posts a JSON-RPC request built by the provider itself (login, logout,
+// session checks) and returns the response body
+func (t *fmgTransport) call(data map[string]interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode request: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+t.endpoint.Host+"/jsonrpc", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := t.send(req, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("cannot send request: %v", err)
+	}
+
+	body, err := readResponseBody(rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %s: %s", rsp.Status, bodySnippet(body))
+	}
+
+	return body, nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	defer req.Body.Close()
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read request body: %v", err)
+	}
+
+	return body, nil
+}
+
+// readResponseBody reads the whole response body and puts it back, so the
+// response can still be consumed by the SDK
+func readResponseBody(rsp *http.Response) ([]byte, error) {
+	defer rsp.Body.Close()
+	body, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response body: %v", err)
+	}
+
+	rsp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// errorResponse makes up a JSON-RPC error response for rpc. The SDK reports
+// it like any FortiManager error instead of retrying the request on its own.
+func errorResponse(req *http.Request, rpc map[string]interface{}, code int, message string) *http.Response {
+	recordAPIError(req.Context(), newFortiAPIError(rpc, code, message))
+
+	return statusResponse(req, code, statusMessage(rpc, message))
+}
+
+// newFortiAPIError returns the error of the failed status of rpc
+func newFortiAPIError(rpc map[string]interface{}, code int, message string) *FortiAPIError {
+	return &FortiAPIError{
+		Code:    code,
+		Message: message,
+		URL:     jsonrpcURL(rpc),
+		Method:  fmt.Sprintf("%v", rpc["method"]),
+	}
+}
+
+// statusResponse makes up a JSON-RPC response with the given status
+func statusResponse(req *http.Request, code int, message string) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"result": []map[string]interface{}{
+			{
+				"status": map[string]interface{}{
+					"code":    code,
+					"message": message,
+				},
+			},
+		},
+	})
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// finalResponse returns the response handed to the SDK for rpc. Responses the
+// SDK cannot decode are replaced with an error describing them.
+func finalResponse(req *http.Request, rpc map[string]interface{}, rsp *http.Response, body []byte) *http.Response {
+	if problem := malformedResponse(body, sdkDecodesObject(req, rpc)); problem != "" {
+		message := fmt.Sprintf("unexpected response from FortiManager (HTTP %s), %s: %s", rsp.Status, problem, bodySnippet(body))
+		return errorResponse(req, rpc, errCodeMalformed, message)
+	}
+
+	return annotateStatus(req, rsp, body, rpc)
+}
+
+// malformedResponse describes why body is not a JSON-RPC response the SDK
+// can decode safely, or returns an empty string. The SDK expects a JSON
+// object whose result is a non-empty list of objects with a status object.
+// When object is set, the data of the result must be an object or null.
+func malformedResponse(body []byte, object bool) string {
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "response is not a JSON object"
+	}
+
+	l, ok := result["result"].([]interface{})
+	if !ok {
+		return "response has no result list"
+	}
+
+	if len(l) == 0 {
+		return "response has an empty result list"
+	}
+
+	v, ok := l[0].(map[string]interface{})
+	if !ok {
+		return "result is not an object"
+	}
+
+	if _, ok := v["status"].(map[string]interface{}); !ok {
+		return "result has no status"
+	}
+
+	if object && v["data"] != nil {
+		if _, ok := v["data"].(map[string]interface{}); !ok {
+			return "data is not an object"
+		}
+	}
+
+	return ""
+}
+
+// sdkDecodesObject reports whether the SDK decodes the data of the response
+// to rpc as an object. Its create, update, read and exec calls send a data
+// parameter, possibly null, and fail on data of another type, while the
+// calls reading lists send none.
+func sdkDecodesObject(req *http.Request, rpc map[string]interface{}) bool {
+	if !callOptionsFromContext(req.Context()).sdkDecode {
+		return false
+	}
+
+	params, ok := rpc["params"].([]interface{})
+	if !ok || len(params) == 0 {
+		return false
+	}
+
+	param, ok := params[0].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	_, ok = param["data"]
+	return ok
+}
+
+// annotateStatus appends the method and url of rpc to the status message of a
+// failed response, so that the error returned by the SDK tells which request
+// failed, and records the FortiAPIError of the response (see sdkError)
+func annotateStatus(req *http.Request, rsp *http.Response, body []byte, rpc map[string]interface{}) *http.Response {
+	var result map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&result); err != nil {
+		return rsp
+	}
+
+	l, ok := result["result"].([]interface{})
+	if !ok || len(l) == 0 {
+		return rsp
+	}
+
+	v, ok := l[0].(map[string]interface{})
+	if !ok {
+		return rsp
+	}
+
+	status, ok := v["status"].(map[string]interface{})
+	if !ok || fmt.Sprintf("%v", status["code"]) == "0" {
+		return rsp
+	}
+
+	code, message := jsonrpcStatus(body)
+	recordAPIError(req.Context(), newFortiAPIError(rpc, code, message))
+
+	status["message"] = statusMessage(rpc, message)
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return rsp
+	}
+
+	rsp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	rsp.ContentLength = int64(len(body))
+	rsp.Header.Del("Content-Length")
+	return rsp
+}
+
+func statusMessage(rpc map[string]interface{}, message string) string {
+	return fmt.Sprintf("%s [%v %s]", message, rpc["method"], jsonrpcURL(rpc))
+}
+
+// bodySnippet returns the beginning of a response body for error messages
+func bodySnippet(body []byte) string {
+	const max = 256
+
+	s := strings.TrimSpace(string(body))
+	if len(s) > max {
+		s = s[:max] + "..."
+	}
+
+	return s
+}
+
+// decodeJSONRPC returns the decoded request if it is a JSON-RPC call carrying
+// a session, otherwise nil. Numbers are kept as json.Number so that the
+// request is re-encoded without losing precision.
+func decodeJSONRPC(body []byte) map[string]interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var rpc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&rpc); err != nil {
+		return nil
+	}
+
+	if _, ok := rpc["session"]; !ok {
+		return nil
+	}
+
+	return rpc
+}
+
+// jsonrpcStatus returns the status code and message of the first result of
+// a JSON-RPC response. A body that cannot be decoded returns code 0.
+func jsonrpcStatus(body []byte) (int, string) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, ""
+	}
+
+	l, ok := result["result"].([]interface{})
+	if !ok || len(l) == 0 {
+		return 0, ""
+	}
+
+	v, ok := l[0].(map[string]interface{})
+	if !ok {
+		return 0, ""
+	}
+
+	status, ok := v["status"].(map[string]interface{})
+	if !ok {
+		return 0, ""
+	}
+
+	return fortiIntValue(status["code"]), fortiStringValue(status["message"])
+}
+
+// isSessionExpired reports whether FortiManager rejected the request because
+// the session is no longer valid. An expired session may also be reported as
+// a permission error (-11), which sendRPC confirms with checkSession.
+func isSessionExpired(code int, message string) bool {
+	if code == 0 {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(message), "invalid session")
+}
@@ -0,0 +1,191 @@
+package fortimanager
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/romanromanovv/forti-sdk-go/fortimanager2/auth"
+)
+
+// Responses recorded from FortiManager and from the reverse proxies and load
+// balancers in front of it
+const (
+	responseProxyError  = `<html><head><title>502 Bad Gateway</title></head><body><center><h1>502 Bad Gateway</h1></center><hr><center>nginx</center></body></html>`
+	responseTruncated   = `{"id":1,"result":[{"data":{"name":"root","oid":3`
+	responseEmptyResult = `{"id":1,"result":[]}`
+	responseResultMap   = `{"id":1,"result":{"status":{"code":0,"message":"OK"}}}`
+	responseNullResult  = `{"id":1,"result":[null]}`
+	responseNoStatus    = `{"id":1,"result":[{"data":{"name":"root"},"url":"/dvmdb/adom/root"}]}`
+	responseListData    = `{"id":1,"result":[{"data":[{"name":"root"}],"status":{"code":0,"message":"OK"},"url":"/dvmdb/adom"}]}`
+	responseStringData  = `{"id":1,"result":[{"data":"OK","status":{"code":0,"message":"OK"},"url":"/dvmdb/adom/root"}]}`
+	responseObjectData  = `{"id":1,"result":[{"data":{"name":"root","oid":3},"status":{"code":0,"message":"OK"},"url":"/dvmdb/adom/root"}]}`
+	responseNullData    = `{"id":1,"result":[{"status":{"code":0,"message":"OK"},"url":"/dvmdb/adom/root"}]}`
+	responseNotFound    = `{"id":1,"result":[{"status":{"code":-3,"message":"Object does not exist"},"url":"/dvmdb/adom/none"}]}`
+)
+
+func TestMalformedResponse(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		object bool
+		want   string
+	}{
+		{"proxy error page", responseProxyError, false, "response is not a JSON object"},
+		{"empty body", "", false, "response is not a JSON object"},
+		{"truncated", responseTruncated, false, "response is not a JSON object"},
+		{"empty result", responseEmptyResult, false, "response has an empty result list"},
+		{"result is a map", responseResultMap, false, "response has no result list"},
+		{"null result", responseNullResult, false, "result is not an object"},
+		{"no status", responseNoStatus, false, "result has no status"},
+		{"list data read as object", responseListData, true, "data is not an object"},
+		{"string data read as object", responseStringData, true, "data is not an object"},
+		{"list data", responseListData, false, ""},
+		{"string data", responseStringData, false, ""},
+		{"object data", responseObjectData, true, ""},
+		{"null data", responseNullData, true, ""},
+		{"error status", responseNotFound, true, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := malformedResponse([]byte(tc.body), tc.object); got != tc.want {
+				t.Errorf("malformedResponse() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSDKMalformedResponses(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"proxy error page", http.StatusBadGateway, responseProxyError, "502 Bad Gateway"},
+		{"truncated", http.StatusOK, responseTruncated, `"name":"root"`},
+		{"empty result", http.StatusOK, responseEmptyResult, "empty result list"},
+		{"result is a map", http.StatusOK, responseResultMap, "no result list"},
+		{"null result", http.StatusOK, responseNullResult, "result is not an object"},
+		{"no status", http.StatusOK, responseNoStatus, "result has no status"},
+		{"list data", http.StatusOK, responseListData, "data is not an object"},
+		{"string data", http.StatusOK, responseStringData, "data is not an object"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+
+			sdk := c.sdk(context.Background())
+			o, err := sdk.ReadDvmdbAdom("root", map[string]string{"adom": ""})
+			if err == nil {
+				t.Fatalf("ReadDvmdbAdom() = %v, want an error", o)
+			}
+
+			if !strings.Contains(err.Error(), "unexpected response from FortiManager") || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("ReadDvmdbAdom() error = %q, want the problem and %q", err, tc.want)
+			}
+
+			e, ok := AsFortiAPIError(sdkError(sdk, err))
+			if !ok || e.Code != errCodeMalformed {
+				t.Errorf("sdkError() = %v, want error code %d", sdkError(sdk, err), errCodeMalformed)
+			}
+		})
+	}
+}
+
+func TestSDKResponses(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := readRequestBody(r)
+		if strings.Contains(string(body), "/dvmdb/adom/none") {
+			w.Write([]byte(responseNotFound))
+			return
+		}
+		w.Write([]byte(responseListData))
+	})
+
+	sdk := c.sdk(context.Background())
+	_, err := sdk.ReadDvmdbAdom("none", map[string]string{"adom": ""})
+	if !IsNotFound(sdkError(sdk, err)) {
+		t.Errorf("sdkError() = %v, want not found", sdkError(sdk, err))
+	}
+
+	if IsNotFound(err) {
+		t.Errorf("IsNotFound() of the SDK error = true, want the error recorded by the transport only")
+	}
+
+	// JsonGenericAPI returns the response as is, list data is valid
+	res, err := c.sdk(context.Background(), withRawResponse()).JsonGenericAPI(`{"method":"get","params":[{"url":"/dvmdb/adom"}]}`)
+	if err != nil || res != responseListData {
+		t.Errorf("JsonGenericAPI() = %q, %v, want the response as is", res, err)
+	}
+
+	data, err := c.jsonrpc(context.Background(), "get", "/dvmdb/adom", nil)
+	if l, ok := data.([]interface{}); err != nil || !ok || len(l) != 1 {
+		t.Errorf("jsonrpc() = %v, %v, want the list of ADOMs", data, err)
+	}
+}
+
+// newTestClient returns a client authenticated with an API token, whose
+// requests are answered by handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *FortiClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	endpoint, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retry, err := newRetryPolicy(0, 1, 1, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := auth.NewAuth(endpoint.Host, "", "", "", "", false)
+	tr := newFmgTransport(http.DefaultTransport, a, "token", endpoint)
+	tr.retry = retry
+
+	return &FortiClient{
+		Client:    newSDKClient(a, &http.Client{Transport: tr}, endpoint),
+		Cfg:       &Config{},
+		transport: tr,
+	}
+}
+
+func TestRequestNotSent(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}, true},
+		{"unknown host", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "fmg"}}, true},
+		{"tls alert", &net.OpError{Op: "remote error", Err: errors.New("tls: handshake failure")}, true},
+		{"not tls", tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}, true},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("read: connection reset by peer")}, false},
+		{"server closed", io.ErrUnexpectedEOF, false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := requestNotSent(tc.err); got != tc.want {
+				t.Errorf("requestNotSent(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
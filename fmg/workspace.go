@@ -0,0 +1,392 @@
+// Copyright 2020 Fortinet, Inc. All rights reserved.
+// Author: Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01)
+// Documentation:
+// Hongbin Lu (@fgtdev-hblu), Frank Shen (@frankshen01),
+// Xing Li (@lix-fortinet), Yue Wang (@yuew-ftnt)
+
+// Description: Automatic workspace locks of ADOMs and policy packages
+
+package fortimanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Values of the workspace_mode provider argument
+const (
+	workspaceModeDisabled = "disabled"
+	workspaceModeAuto     = "auto"
+)
+
+// workspaceReleaseTimeout bounds the commit of the pending changes once the
+// last operation has ended, and the commit and unlock of all locks
+const workspaceReleaseTimeout = 2 * time.Minute
+
+// workspaceIdleTimeout is how long the locks are kept while no operation is
+// in progress, the operations of one apply run back to back
+const workspaceIdleTimeout = time.Minute
+
+// workspaceWriteMethods are the JSON-RPC methods that change the database of
+// an ADOM and need its lock when FortiManager runs in workspace mode
+var workspaceWriteMethods = map[string]bool{
+	"add":     true,
+	"set":     true,
+	"update":  true,
+	"delete":  true,
+	"move":    true,
+	"clone":   true,
+	"replace": true,
+}
+
+// workspaceTarget is an ADOM, or a policy package or device of an ADOM, that
+// can be locked. The global database uses the ADOM name global.
+type workspaceTarget struct {
+	adom string
+	pkg  string
+	dev  string
+}
+
+func (w workspaceTarget) String() string {
+	if w.pkg != "" {
+		return fmt.Sprintf("policy package %s of ADOM %s", w.pkg, w.adom)
+	}
+
+	if w.dev != "" {
+		return fmt.Sprintf("device %s of ADOM %s", w.dev, w.adom)
+	}
+
+	return "ADOM " + w.adom
+}
+
+// workspaceTargetFor returns the target given by the adom (as returned by
+// adomChecking), target and param arguments of fortimanager_exec_workspace_action
+// and fortimanager_workspace_lock
+func workspaceTargetFor(adomv, target, param string) (workspaceTarget, error) {
+	w := workspaceTarget{
+		adom: strings.TrimPrefix(adomv, "adom/"),
+	}
+
+	switch target {
+	case "":
+	case "pkg":
+		w.pkg = param
+	case "dev":
+		w.dev = param
+	default:
+		return w, fmt.Errorf("lock information is not available for target %q", target)
+	}
+
+	if target != "" && param == "" {
+		return w, fmt.Errorf("param is required for target %q", target)
+	}
+
+	return w, nil
+}
+
+// url returns the url of the workspace action (lock, commit, unlock) on w
+func (w workspaceTarget) url(action string) string {
+	path := "/dvmdb/adom/" + w.adom
+	if w.adom == "global" {
+		path = "/dvmdb/global"
+	}
+
+	path += "/workspace/" + action
+	if w.pkg != "" {
+		path += "/pkg/" + w.pkg
+	} else if w.dev != "" {
+		path += "/dev/" + w.dev
+	}
+
+	return path
+}
+
+// workspaceTargetOf returns the ADOM or policy package a write to url
+// changes. Writes to the policy package settings lock the package only,
+// other writes to an ADOM lock the whole ADOM.
+func workspaceTargetOf(url string) (workspaceTarget, bool) {
+	p := strings.Split(strings.Trim(url, "/"), "/")
+
+	adom := func(i int) (workspaceTarget, bool) {
+		if len(p) <= i || p[i] == "" {
+			return workspaceTarget{}, false
+		}
+		return workspaceTarget{adom: p[i]}, true
+	}
+
+	switch {
+	case len(p) >= 3 && p[0] == "pm" && p[1] == "config" && p[2] == "global":
+		if len(p) >= 5 && p[3] == "pkg" {
+			return workspaceTarget{adom: "global", pkg: p[4]}, true
+		}
+		return workspaceTarget{adom: "global"}, true
+
+	case len(p) >= 4 && p[0] == "pm" && p[1] == "config" && p[2] == "adom":
+		if len(p) >= 6 && p[4] == "pkg" {
+			return workspaceTarget{adom: p[3], pkg: p[5]}, true
+		}
+		return adom(3)
+
+	case len(p) >= 3 && p[0] == "pm" && p[2] == "global":
+		// Policy packages, device and WAN profiles of the global database
+		return workspaceTarget{adom: "global"}, true
+
+	case len(p) >= 4 && p[0] == "pm" && p[2] == "adom":
+		return adom(3)
+
+	case len(p) >= 3 && p[0] == "dvmdb" && p[1] == "global":
+		return workspaceTarget{adom: "global"}, true
+
+	case len(p) >= 4 && p[0] == "dvmdb" && p[1] == "adom" && p[3] != "workspace":
+		// Devices, groups and scripts of the ADOM, not the ADOM itself
+		return adom(2)
+	}
+
+	return workspaceTarget{}, false
+}
+
+// workspace takes the workspace locks of workspace_mode auto. The first
+// write to an ADOM or policy package locks it. The changes are committed
+// when the last operation in progress ends, so that a failed commit fails
+// that operation, and the locks are kept for the next operations. They are
+// released once, after idleTimeout without any operation or when the
+// provider stops, see endOperation and CloseSessions.
+type workspace struct {
+	t       *fmgTransport
+	comment string
+
+	// idleTimeout is how long the locks are kept while no operation is in
+	// progress
+	idleTimeout time.Duration
+
+	// mu serializes the lock decisions, it is held while a lock is taken so
+	// that concurrent writes lock an ADOM only once, and while the changes
+	// are committed or the locks released so that writes wait for them
+	mu sync.Mutex
+
+	// mode is the workspace-mode of FortiManager, empty until detected
+	mode string
+
+	// locks holds the locked targets in lock order
+	locks  []workspaceTarget
+	locked map[workspaceTarget]bool
+
+	// changed holds the locked targets with uncommitted changes
+	changed map[workspaceTarget]bool
+}
+
+func newWorkspace(t *fmgTransport, comment string) *workspace {
+	return &workspace{
+		t:           t,
+		comment:     comment,
+		idleTimeout: workspaceIdleTimeout,
+		locked:      make(map[workspaceTarget]bool),
+		changed:     make(map[workspaceTarget]bool),
+	}
+}
+
+// prepare runs before rpc is sent: writes lock the ADOM or policy package
+// they change, and installs commit the pending changes first because they
+// only see the committed configuration
+func (w *workspace) prepare(ctx context.Context, rpc map[string]interface{}) error {
+	method := fmt.Sprintf("%v", rpc["method"])
+	url := jsonrpcURL(rpc)
+
+	if method == "exec" && strings.HasPrefix(strings.TrimPrefix(url, "/"), "securityconsole/install") {
+		return w.commit(ctx)
+	}
+
+	if !workspaceWriteMethods[method] {
+		return nil
+	}
+
+	target, ok := workspaceTargetOf(url)
+	if !ok {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.detect(ctx); err != nil {
+		return err
+	}
+
+	switch w.mode {
+	case "disabled":
+		return nil
+	case "normal":
+	default:
+		return fmt.Errorf("workspace_mode auto does not support FortiManager workspace-mode %s", w.mode)
+	}
+
+	if adom := (workspaceTarget{adom: target.adom}); w.locked[adom] {
+		w.changed[adom] = true
+		return nil
+	}
+	if w.locked[target] {
+		w.changed[target] = true
+		return nil
+	}
+
+	if _, err := w.t.jsonrpc(ctx, "exec", target.url("lock"), nil, withoutWorkspace()); err != nil {
+		return fmt.Errorf("cannot lock %s: %v", target, err)
+	}
+	log.Printf("[INFO] Locked %s", target)
+
+	w.locks = append(w.locks, target)
+	w.locked[target] = true
+	w.changed[target] = true
+
+	return nil
+}
+
+// detect reads the workspace-mode of FortiManager once
+func (w *workspace) detect(ctx context.Context) error {
+	if w.mode != "" {
+		return nil
+	}
+
+	data, err := w.t.jsonrpc(ctx, "get", "/cli/global/system/global", map[string]interface{}{
+		"fields": []string{"workspace-mode"},
+	}, withoutWorkspace())
+	if err != nil {
+		return fmt.Errorf("cannot read the workspace-mode of FortiManager: %v", err)
+	}
+
+	v, _ := data.(map[string]interface{})
+	w.mode = workspaceModeName(v["workspace-mode"])
+	log.Printf("[INFO] FortiManager workspace-mode is %s", w.mode)
+
+	return nil
+}
+
+// workspaceModeName returns the name of a workspace-mode value, FortiManager
+// returns its number when the request is not verbose
+func workspaceModeName(v interface{}) string {
+	if s := fortiStringValue(v); s != "" {
+		return s
+	}
+
+	switch fortiIntValue(v) {
+	case 1:
+		return "normal"
+	case 2:
+		return "workflow"
+	}
+
+	return "disabled"
+}
+
+// commit commits the changes made under the locks taken so far and keeps
+// the locks
+func (w *workspace) commit(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.commitChanges(ctx)
+}
+
+// commitChanges commits the locked targets with uncommitted changes, w.mu
+// must be held. A target that cannot be committed keeps its changes.
+func (w *workspace) commitChanges(ctx context.Context) error {
+	var errs []string
+	for _, target := range w.locks {
+		if !w.changed[target] {
+			continue
+		}
+
+		if err := w.commitTarget(ctx, target); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		delete(w.changed, target)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func (w *workspace) commitTarget(ctx context.Context, target workspaceTarget) error {
+	var params map[string]interface{}
+	if w.comment != "" {
+		params = map[string]interface{}{
+			"data": map[string]interface{}{
+				"comment": w.comment,
+			},
+		}
+	}
+
+	if _, err := w.t.jsonrpc(ctx, "exec", target.url("commit"), params, withoutWorkspace()); err != nil {
+		return fmt.Errorf("cannot commit %s: %v", target, err)
+	}
+	log.Printf("[INFO] Committed %s", target)
+
+	return nil
+}
+
+// release commits the pending changes and unlocks every target, packages
+// before their ADOM. A target that cannot be committed is still unlocked, so
+// that a failed or interrupted run never leaves locks behind, and its
+// changes are discarded. The errors of all targets are returned. The next
+// write locks its target again.
+func (w *workspace) release() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.releaseLocks()
+}
+
+// releaseLocks implements release, w.mu must be held
+func (w *workspace) releaseLocks() error {
+	if len(w.locks) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), workspaceReleaseTimeout)
+	defer cancel()
+
+	targets := make([]workspaceTarget, 0, len(w.locks))
+	for _, target := range w.locks {
+		if target.pkg != "" {
+			targets = append(targets, target)
+		}
+	}
+	for _, target := range w.locks {
+		if target.pkg == "" {
+			targets = append(targets, target)
+		}
+	}
+
+	var errs []string
+	for _, target := range targets {
+		if w.changed[target] {
+			if err := w.commitTarget(ctx, target); err != nil {
+				errs = append(errs, fmt.Sprintf("%v, the changes are discarded", err))
+			}
+		}
+
+		if _, err := w.t.jsonrpc(ctx, "exec", target.url("unlock"), nil, withoutWorkspace()); err != nil {
+			errs = append(errs, fmt.Sprintf("cannot unlock %s: %v", target, err))
+			continue
+		}
+		log.Printf("[INFO] Unlocked %s", target)
+	}
+
+	w.locks = nil
+	w.locked = make(map[workspaceTarget]bool)
+	w.changed = make(map[workspaceTarget]bool)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
@@ -6,4 +6,5 @@ require (
 	github.com/google/uuid v1.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.26.1
 	github.com/romanromanovv/forti-sdk-go v1.9.8
+	golang.org/x/net v0.8.0
 )
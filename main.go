@@ -8,4 +8,6 @@ import (
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: fortimanager.Provider})
+
+	fortimanager.CloseSessions()
 }